@@ -17,13 +17,20 @@ var (
 	ErrAlertWithInvalidDescription = errors.New("alert description must not have \" or \\")
 
 	ErrAlertWithInvalidMinimumReminderInterval = errors.New("minimum-reminder-interval must be either omitted or be at least 5m")
+
+	// ErrAlertWithTypeAndEscalationPolicy is the error with which Gatus will panic if an alert has both a type and an
+	// escalation policy
+	ErrAlertWithTypeAndEscalationPolicy = errors.New("alert must not have both a type and an escalation-policy")
 )
 
 // Alert is endpoint.Endpoint's alert configuration
 type Alert struct {
-	// Type of alert (required)
+	// Type of alert (required, unless EscalationPolicy is set)
 	Type Type `yaml:"type"`
 
+	// EscalationPolicy is the name of the escalation policy to notify instead of a single provider
+	EscalationPolicy string `yaml:"escalation-policy,omitempty" json:"-"`
+
 	// Enabled defines whether the alert is enabled
 	//
 	// Use Alert.IsEnabled() to retrieve the value of this field.
@@ -53,6 +60,10 @@ type Alert struct {
 	// or not for provider.ParseWithDefaultAlert to work. Use Alert.IsSendingOnResolved() for a non-pointer
 	SendOnResolved *bool `yaml:"send-on-resolved,omitempty"`
 
+	// BurnRate is the optional configuration for triggering the alert based on the rate at which the error budget of
+	// the endpoint's SLO is consumed. If set, FailureThreshold and SuccessThreshold are ignored.
+	BurnRate *BurnRateConfig `yaml:"burn-rate,omitempty" json:"-"`
+
 	// ProviderOverride is an optional field that can be used to override the provider's configuration
 	// It is freeform so that it can be used for any provider-specific configuration.
 	ProviderOverride map[string]any `yaml:"provider-override,omitempty"`
@@ -70,6 +81,14 @@ type Alert struct {
 	// some reason, the alert provider always returns errors when trying to send the resolved notification
 	// (SendOnResolved).
 	Triggered bool `yaml:"-"`
+
+	// TriggeredAt is the time at which the alert was triggered.
+	// Only used for alerts with an EscalationPolicy, to determine which steps of the policy have been reached.
+	TriggeredAt time.Time `yaml:"-" json:"-"`
+
+	// EscalationResolveKeys are the resolve keys of the steps of the EscalationPolicy that have been notified, in order.
+	// The number of keys is the number of steps that have been reached.
+	EscalationResolveKeys []string `yaml:"-" json:"-"`
 }
 
 // ValidateAndSetDefaults validates the alert's configuration and sets the default value of fields that have one
@@ -83,6 +102,17 @@ func (alert *Alert) ValidateAndSetDefaults() error {
 	if alert.MinimumReminderInterval != 0 && alert.MinimumReminderInterval < 5*time.Minute {
 		return ErrAlertWithInvalidMinimumReminderInterval
 	}
+	if len(alert.Type) > 0 && len(alert.EscalationPolicy) > 0 {
+		return ErrAlertWithTypeAndEscalationPolicy
+	}
+	if alert.BurnRate != nil {
+		if len(alert.EscalationPolicy) > 0 {
+			return ErrAlertWithBurnRateAndEscalationPolicy
+		}
+		if err := alert.BurnRate.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
 	if strings.ContainsAny(alert.GetDescription(), "\"\\") {
 		return ErrAlertWithInvalidDescription
 	}
@@ -125,6 +155,12 @@ func (alert *Alert) Checksum() string {
 		strconv.Itoa(alert.FailureThreshold) + "_" +
 		alert.GetDescription()),
 	)
+	if len(alert.EscalationPolicy) > 0 {
+		hash.Write([]byte("_" + alert.EscalationPolicy))
+	}
+	if alert.BurnRate != nil {
+		hash.Write([]byte("_" + strconv.FormatFloat(alert.BurnRate.Threshold, 'f', -1, 64) + "_" + alert.BurnRate.LongWindow.String() + "_" + alert.BurnRate.ShortWindow.String()))
+	}
 	return hex.EncodeToString(hash.Sum(nil))
 }
 
@@ -92,6 +92,65 @@ func TestAlert_ValidateAndSetDefaults(t *testing.T) {
 			expectedFailureThreshold: 10,
 			expectedSuccessThreshold: 5,
 		},
+		{
+			name: "valid-escalation-policy",
+			alert: Alert{
+				EscalationPolicy: "critical",
+			},
+			expectedError:            nil,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name: "invalid-type-and-escalation-policy",
+			alert: Alert{
+				Type:             TypeSlack,
+				EscalationPolicy: "critical",
+			},
+			expectedError:            ErrAlertWithTypeAndEscalationPolicy,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name: "valid-burn-rate",
+			alert: Alert{
+				Type:     TypeSlack,
+				BurnRate: &BurnRateConfig{},
+			},
+			expectedError:            nil,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name: "invalid-burn-rate-and-escalation-policy",
+			alert: Alert{
+				EscalationPolicy: "critical",
+				BurnRate:         &BurnRateConfig{},
+			},
+			expectedError:            ErrAlertWithBurnRateAndEscalationPolicy,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name: "invalid-burn-rate-threshold",
+			alert: Alert{
+				Type:     TypeSlack,
+				BurnRate: &BurnRateConfig{Threshold: -1},
+			},
+			expectedError:            ErrAlertWithInvalidBurnRateThreshold,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name: "invalid-burn-rate-windows",
+			alert: Alert{
+				Type:     TypeSlack,
+				BurnRate: &BurnRateConfig{LongWindow: time.Hour, ShortWindow: 2 * time.Hour},
+			},
+			expectedError:            ErrAlertWithInvalidBurnRateWindows,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -236,6 +295,13 @@ func TestAlert_Checksum(t *testing.T) {
 			},
 			expected: "5c28963b3a76104cfa4a0d79c89dd29ec596c8cfa4b1af210ec83d6d41587b5f",
 		},
+		{
+			name: "with-escalation-policy",
+			alert: Alert{
+				EscalationPolicy: "critical",
+			},
+			expected: "15c2160b922890c44d04fbdebb0dc1f13c896909184fb2ba5524a4e8524c6c64",
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -246,3 +312,25 @@ func TestAlert_Checksum(t *testing.T) {
 		})
 	}
 }
+
+func TestBurnRateConfig_ValidateAndSetDefaults(t *testing.T) {
+	burnRate := &BurnRateConfig{}
+	if err := burnRate.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if burnRate.Threshold != DefaultBurnRateThreshold || burnRate.LongWindow != DefaultBurnRateLongWindow || burnRate.ShortWindow != DefaultBurnRateShortWindow {
+		t.Errorf("expected default burn rate configuration, got %+v", burnRate)
+	}
+	burnRate = &BurnRateConfig{Threshold: 3, LongWindow: 24 * time.Hour, ShortWindow: 2 * time.Hour}
+	if err := burnRate.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if burnRate.Threshold != 3 || burnRate.LongWindow != 24*time.Hour || burnRate.ShortWindow != 2*time.Hour {
+		t.Errorf("expected configured values to be kept, got %+v", burnRate)
+	}
+	// Uptime statistics are stored by hour, so a window shorter than an hour is rejected
+	burnRate = &BurnRateConfig{LongWindow: time.Hour, ShortWindow: 5 * time.Minute}
+	if err := burnRate.ValidateAndSetDefaults(); !errors.Is(err, ErrAlertWithInvalidBurnRateWindows) {
+		t.Errorf("expected error %v, got %v", ErrAlertWithInvalidBurnRateWindows, err)
+	}
+}
@@ -0,0 +1,71 @@
+package alert
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+)
+
+const (
+	// DefaultBurnRateThreshold is the default burn rate above which a burn-rate alert is triggered.
+	// At this rate, 5% of the error budget of a 30-day window is consumed in 6 hours.
+	DefaultBurnRateThreshold = 6
+
+	// DefaultBurnRateLongWindow is the default long window over which the burn rate is evaluated
+	DefaultBurnRateLongWindow = 6 * time.Hour
+
+	// DefaultBurnRateShortWindow is the default short window over which the burn rate is evaluated
+	DefaultBurnRateShortWindow = time.Hour
+)
+
+var (
+	// ErrAlertWithInvalidBurnRateThreshold is the error with which Gatus will panic if a burn-rate alert has a negative
+	// threshold
+	ErrAlertWithInvalidBurnRateThreshold = errors.New("burn-rate.threshold must be greater than 0")
+
+	// ErrAlertWithInvalidBurnRateWindows is the error with which Gatus will panic if the short window of a burn-rate
+	// alert is shorter than slo.MinimumWindow or isn't shorter than its long window
+	ErrAlertWithInvalidBurnRateWindows = errors.New("burn-rate.short-window must be at least 1h and shorter than burn-rate.long-window")
+
+	// ErrAlertWithBurnRateAndEscalationPolicy is the error with which Gatus will panic if a burn-rate alert has an
+	// escalation policy
+	ErrAlertWithBurnRateAndEscalationPolicy = errors.New("alert must not have both a burn-rate and an escalation-policy")
+)
+
+// BurnRateConfig is the configuration of an alert that is triggered based on the rate at which the error budget of
+// the endpoint's SLO is consumed, rather than on a number of failures in a row.
+//
+// The alert is triggered when the burn rate exceeds the threshold over both the long window and the short window, and
+// resolved as soon as it no longer does. The long window prevents short bursts of errors from triggering the alert,
+// whereas the short window allows the alert to be resolved quickly once the errors stop.
+type BurnRateConfig struct {
+	// Threshold is the burn rate above which the alert is triggered
+	Threshold float64 `yaml:"threshold,omitempty"`
+
+	// LongWindow is the long window over which the burn rate is evaluated
+	LongWindow time.Duration `yaml:"long-window,omitempty"`
+
+	// ShortWindow is the short window over which the burn rate is evaluated.
+	// Because uptime statistics are stored by hour, it must be at least slo.MinimumWindow.
+	ShortWindow time.Duration `yaml:"short-window,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the burn rate configuration and sets the default values if necessary
+func (c *BurnRateConfig) ValidateAndSetDefaults() error {
+	if c.Threshold == 0 {
+		c.Threshold = DefaultBurnRateThreshold
+	} else if c.Threshold < 0 {
+		return ErrAlertWithInvalidBurnRateThreshold
+	}
+	if c.LongWindow == 0 {
+		c.LongWindow = DefaultBurnRateLongWindow
+	}
+	if c.ShortWindow == 0 {
+		c.ShortWindow = DefaultBurnRateShortWindow
+	}
+	if c.ShortWindow < slo.MinimumWindow || c.ShortWindow >= c.LongWindow {
+		return ErrAlertWithInvalidBurnRateWindows
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/escalation"
+	"github.com/TwiN/gatus/v5/alerting/grouping"
 	"github.com/TwiN/gatus/v5/alerting/provider"
 	"github.com/TwiN/gatus/v5/alerting/provider/awsses"
 	"github.com/TwiN/gatus/v5/alerting/provider/clickup"
@@ -52,6 +54,14 @@ import (
 
 // Config is the configuration for alerting providers
 type Config struct {
+	// Grouping is the configuration for grouping the alerts of multiple endpoints into a single notification.
+	// If nil, each alert is sent as soon as it's triggered or resolved.
+	Grouping *grouping.Config `yaml:"grouping,omitempty"`
+
+	// EscalationPolicies are the escalation policies that endpoint alerts may reference by name through their
+	// escalation-policy parameter
+	EscalationPolicies map[string]*escalation.Policy `yaml:"escalation-policies,omitempty"`
+
 	// AWSSimpleEmailService is the configuration for the aws-ses alerting provider
 	AWSSimpleEmailService *awsses.AlertProvider `yaml:"aws-ses,omitempty"`
 
@@ -187,7 +197,8 @@ func (config *Config) GetAlertingProviderByAlertType(alertType alert.Type) provi
 			if fieldValue.IsNil() {
 				return nil
 			}
-			return fieldValue.Interface().(provider.AlertProvider)
+			alertProvider, _ := fieldValue.Interface().(provider.AlertProvider)
+			return alertProvider
 		}
 	}
 	logr.Infof("[alerting.GetAlertingProviderByAlertType] No alerting provider found for alert type %s", alertType)
@@ -0,0 +1,81 @@
+package escalation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+var (
+	// ErrNoSteps is the error returned when an escalation policy has no steps
+	ErrNoSteps = errors.New("invalid escalation policy: must have at least one step")
+
+	// ErrStepWithNoType is the error returned when a step of an escalation policy has no type
+	ErrStepWithNoType = errors.New("invalid escalation policy: every step must have a type")
+
+	// ErrStepWithInvalidAfter is the error returned when the after parameter of a step is negative
+	ErrStepWithInvalidAfter = errors.New("invalid escalation policy: after must not be negative")
+
+	// ErrStepsNotInOrder is the error returned when a step is set to be reached before the step preceding it
+	ErrStepsNotInOrder = errors.New("invalid escalation policy: steps must be ordered by after")
+)
+
+// Policy is a named chain of steps through which a triggered alert is escalated for as long as it remains triggered
+type Policy struct {
+	// Steps are the steps of the policy, ordered by the duration after which they are reached
+	Steps []*Step `yaml:"steps"`
+}
+
+// Step is a single step of an escalation policy
+type Step struct {
+	// Type of alert to send once the step is reached
+	Type alert.Type `yaml:"type"`
+
+	// After is the duration during which the alert must have been triggered for the step to be reached
+	After time.Duration `yaml:"after,omitempty"`
+
+	// ProviderOverride is an optional field that can be used to override the provider's configuration for this step
+	ProviderOverride map[string]any `yaml:"provider-override,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the escalation policy
+func (policy *Policy) ValidateAndSetDefaults() error {
+	if len(policy.Steps) == 0 {
+		return ErrNoSteps
+	}
+	for i, step := range policy.Steps {
+		if len(step.Type) == 0 {
+			return ErrStepWithNoType
+		}
+		if step.After < 0 {
+			return ErrStepWithInvalidAfter
+		}
+		if i > 0 && step.After < policy.Steps[i-1].After {
+			return ErrStepsNotInOrder
+		}
+	}
+	return nil
+}
+
+// NumberOfStepsDue returns the number of steps that have been reached by an alert triggered at the given time
+func (policy *Policy) NumberOfStepsDue(triggeredAt time.Time) int {
+	elapsed := time.Since(triggeredAt)
+	for i, step := range policy.Steps {
+		if step.After > elapsed {
+			return i
+		}
+	}
+	return len(policy.Steps)
+}
+
+// ToAlert returns a copy of the endpoint alert that can be sent to the provider of the step
+func (step *Step) ToAlert(endpointAlert *alert.Alert, resolveKey string) *alert.Alert {
+	stepAlert := *endpointAlert
+	stepAlert.Type = step.Type
+	stepAlert.EscalationPolicy = ""
+	stepAlert.EscalationResolveKeys = nil
+	stepAlert.ProviderOverride = step.ProviderOverride
+	stepAlert.ResolveKey = resolveKey
+	return &stepAlert
+}
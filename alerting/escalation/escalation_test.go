@@ -0,0 +1,112 @@
+package escalation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+func TestPolicy_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		policy      *Policy
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			policy: &Policy{Steps: []*Step{
+				{Type: alert.TypeSlack},
+				{Type: alert.TypePagerDuty, After: 15 * time.Minute},
+				{Type: alert.TypeEmail, After: time.Hour},
+			}},
+		},
+		{
+			name:        "no-steps",
+			policy:      &Policy{},
+			expectedErr: ErrNoSteps,
+		},
+		{
+			name:        "step-with-no-type",
+			policy:      &Policy{Steps: []*Step{{After: time.Minute}}},
+			expectedErr: ErrStepWithNoType,
+		},
+		{
+			name:        "step-with-negative-after",
+			policy:      &Policy{Steps: []*Step{{Type: alert.TypeSlack, After: -time.Minute}}},
+			expectedErr: ErrStepWithInvalidAfter,
+		},
+		{
+			name: "steps-not-in-order",
+			policy: &Policy{Steps: []*Step{
+				{Type: alert.TypeSlack, After: time.Hour},
+				{Type: alert.TypePagerDuty, After: 15 * time.Minute},
+			}},
+			expectedErr: ErrStepsNotInOrder,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.policy.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPolicy_NumberOfStepsDue(t *testing.T) {
+	policy := &Policy{Steps: []*Step{
+		{Type: alert.TypeSlack},
+		{Type: alert.TypePagerDuty, After: 15 * time.Minute},
+		{Type: alert.TypeEmail, After: time.Hour},
+	}}
+	scenarios := []struct {
+		name     string
+		elapsed  time.Duration
+		expected int
+	}{
+		{name: "just-triggered", elapsed: 0, expected: 1},
+		{name: "before-second-step", elapsed: 14 * time.Minute, expected: 1},
+		{name: "after-second-step", elapsed: 16 * time.Minute, expected: 2},
+		{name: "after-last-step", elapsed: 2 * time.Hour, expected: 3},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if numberOfStepsDue := policy.NumberOfStepsDue(time.Now().Add(-scenario.elapsed)); numberOfStepsDue != scenario.expected {
+				t.Errorf("expected %d steps to be due, got %d", scenario.expected, numberOfStepsDue)
+			}
+		})
+	}
+}
+
+func TestStep_ToAlert(t *testing.T) {
+	description := "description"
+	endpointAlert := &alert.Alert{
+		EscalationPolicy:      "critical",
+		Description:           &description,
+		FailureThreshold:      5,
+		ResolveKey:            "endpoint-resolve-key",
+		EscalationResolveKeys: []string{"step-resolve-key"},
+	}
+	step := &Step{Type: alert.TypePagerDuty, ProviderOverride: map[string]any{"integration-key": "00000000000000000000000000000000"}}
+	stepAlert := step.ToAlert(endpointAlert, "step-resolve-key")
+	if stepAlert == endpointAlert {
+		t.Fatal("expected step alert to be a copy of the endpoint alert")
+	}
+	if stepAlert.Type != alert.TypePagerDuty {
+		t.Errorf("expected type %s, got %s", alert.TypePagerDuty, stepAlert.Type)
+	}
+	if len(stepAlert.EscalationPolicy) != 0 || stepAlert.EscalationResolveKeys != nil {
+		t.Error("expected step alert to not have an escalation policy")
+	}
+	if stepAlert.ResolveKey != "step-resolve-key" {
+		t.Errorf("expected resolve key step-resolve-key, got %s", stepAlert.ResolveKey)
+	}
+	if stepAlert.GetDescription() != description || stepAlert.FailureThreshold != 5 {
+		t.Error("expected step alert to keep the description and thresholds of the endpoint alert")
+	}
+	if stepAlert.ProviderOverride["integration-key"] != "00000000000000000000000000000000" {
+		t.Error("expected step alert to have the provider override of the step")
+	}
+}
@@ -0,0 +1,90 @@
+package grouping
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const (
+	// ByGroup groups alerts by the group of their endpoint
+	ByGroup = "group"
+
+	// ByLabel groups alerts by the value of one of the extra labels of their endpoint
+	ByLabel = "label"
+
+	// DefaultWindow is the default duration during which triggered and resolved alerts are buffered before being sent
+	DefaultWindow = 30 * time.Second
+)
+
+var (
+	// ErrInvalidBy is the error returned when the by parameter is neither ByGroup nor ByLabel
+	ErrInvalidBy = errors.New("invalid alert grouping: by must be one of group or label")
+
+	// ErrLabelNotSet is the error returned when alerts are grouped by label, but no label is specified
+	ErrLabelNotSet = errors.New("invalid alert grouping: label must be set when grouping by label")
+
+	// ErrInvalidWindow is the error returned when the window is negative
+	ErrInvalidWindow = errors.New("invalid alert grouping: window must not be negative")
+)
+
+// Config is the configuration for grouping alerts of multiple endpoints into a single notification
+type Config struct {
+	// Window is the duration during which alerts are buffered before being sent as a single notification
+	Window time.Duration `yaml:"window,omitempty"`
+
+	// By is what alerts are grouped by. Must be one of ByGroup or ByLabel
+	By string `yaml:"by,omitempty"`
+
+	// Label is the name of the extra label whose value alerts are grouped by. Only used when By is ByLabel
+	Label string `yaml:"label,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the grouping configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.Window < 0 {
+		return ErrInvalidWindow
+	}
+	if c.Window == 0 {
+		c.Window = DefaultWindow
+	}
+	if len(c.By) == 0 {
+		c.By = ByGroup
+	}
+	switch c.By {
+	case ByGroup:
+	case ByLabel:
+		if len(c.Label) == 0 {
+			return ErrLabelNotSet
+		}
+	default:
+		return ErrInvalidBy
+	}
+	return nil
+}
+
+// GetKey returns the value that alerts of the given endpoint are grouped by
+func (c *Config) GetKey(ep *endpoint.Endpoint) string {
+	if c.By == ByLabel {
+		return ep.ExtraLabels[c.Label]
+	}
+	return ep.Group
+}
+
+// Entry is the alert of a single endpoint within a Batch
+type Entry struct {
+	Endpoint *endpoint.Endpoint
+	Alert    *alert.Alert
+	Result   *endpoint.Result
+}
+
+// Batch is a set of alerts of the same type that are sent as a single notification
+type Batch struct {
+	// Key is the value the alerts were grouped by (e.g. the name of the group)
+	Key string
+
+	// Entries are the alerts that are part of the batch, one per endpoint
+	Entries []*Entry
+}
@@ -0,0 +1,77 @@
+package grouping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		cfg            *Config
+		expectedErr    error
+		expectedWindow time.Duration
+		expectedBy     string
+	}{
+		{
+			name:           "empty",
+			cfg:            &Config{},
+			expectedWindow: DefaultWindow,
+			expectedBy:     ByGroup,
+		},
+		{
+			name:           "by-label",
+			cfg:            &Config{Window: time.Minute, By: ByLabel, Label: "team"},
+			expectedWindow: time.Minute,
+			expectedBy:     ByLabel,
+		},
+		{
+			name:        "by-label-without-label",
+			cfg:         &Config{By: ByLabel},
+			expectedErr: ErrLabelNotSet,
+		},
+		{
+			name:        "invalid-by",
+			cfg:         &Config{By: "name"},
+			expectedErr: ErrInvalidBy,
+		},
+		{
+			name:        "negative-window",
+			cfg:         &Config{Window: -time.Second},
+			expectedErr: ErrInvalidWindow,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.cfg.Window != scenario.expectedWindow {
+				t.Errorf("expected window %s, got %s", scenario.expectedWindow, scenario.cfg.Window)
+			}
+			if scenario.cfg.By != scenario.expectedBy {
+				t.Errorf("expected by %s, got %s", scenario.expectedBy, scenario.cfg.By)
+			}
+		})
+	}
+}
+
+func TestConfig_GetKey(t *testing.T) {
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core", ExtraLabels: map[string]string{"team": "web"}}
+	if key := (&Config{By: ByGroup}).GetKey(ep); key != "core" {
+		t.Errorf("expected key to be core, got %s", key)
+	}
+	if key := (&Config{By: ByLabel, Label: "team"}).GetKey(ep); key != "web" {
+		t.Errorf("expected key to be web, got %s", key)
+	}
+	if key := (&Config{By: ByLabel, Label: "region"}).GetKey(ep); key != "" {
+		t.Errorf("expected key to be empty, got %s", key)
+	}
+}
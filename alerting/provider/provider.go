@@ -1,7 +1,11 @@
 package provider
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/grouping"
 	"github.com/TwiN/gatus/v5/alerting/provider/awsses"
 	"github.com/TwiN/gatus/v5/alerting/provider/clickup"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
@@ -60,6 +64,75 @@ type AlertProvider interface {
 	ValidateOverrides(group string, alert *alert.Alert) error
 }
 
+// BatchAlertProvider is the interface implemented by providers that have their own way of sending multiple alerts as a
+// single notification. Providers that don't implement it send the alerts of a batch as a single combined alert.
+type BatchAlertProvider interface {
+	// SendBatch sends the alerts of a batch as a single notification
+	SendBatch(batch *grouping.Batch, resolved bool) error
+}
+
+// SendBatch sends a batch of alerts using the provider's batch-aware send path, or through Send if the batch only has
+// a single entry, in which case the alert is sent as if it hadn't been grouped.
+//
+// Providers that don't implement BatchAlertProvider are sent a single alert combining every alert of the batch through
+// Send instead. See combineBatch.
+func SendBatch(provider AlertProvider, batch *grouping.Batch, resolved bool) error {
+	if len(batch.Entries) == 0 {
+		return nil
+	}
+	if len(batch.Entries) == 1 {
+		entry := batch.Entries[0]
+		return provider.Send(entry.Endpoint, entry.Alert, entry.Result, resolved)
+	}
+	if batchProvider, ok := provider.(BatchAlertProvider); ok {
+		return batchProvider.SendBatch(batch, resolved)
+	}
+	ep, combinedAlert, result := combineBatch(batch)
+	return provider.Send(ep, combinedAlert, result, resolved)
+}
+
+// combineBatch returns an endpoint, an alert and a result describing every alert of a batch, so that the batch can be
+// sent as a single alert by providers that don't implement BatchAlertProvider.
+//
+// The endpoint is named after the number of endpoints affected, the description of the alert lists each of them and
+// the conditions and errors of the result are prefixed by the name of the endpoint they belong to. Since every alert
+// of a batch is of the same type, has the same provider override and belongs to an endpoint of the same group, the
+// provider configuration used is the same as if the alerts had been sent separately.
+func combineBatch(batch *grouping.Batch) (*endpoint.Endpoint, *alert.Alert, *endpoint.Result) {
+	first := batch.Entries[0]
+	ep := &endpoint.Endpoint{
+		Name:  fmt.Sprintf("%d endpoints", len(batch.Entries)),
+		Group: first.Endpoint.Group,
+	}
+	combinedAlert := *first.Alert
+	result := &endpoint.Result{Success: true}
+	descriptions := make([]string, 0, len(batch.Entries))
+	for _, entry := range batch.Entries {
+		name := entry.Endpoint.Name
+		if description := entry.Alert.GetDescription(); len(description) > 0 {
+			descriptions = append(descriptions, name+": "+description)
+		} else {
+			descriptions = append(descriptions, name)
+		}
+		if entry.Result.Timestamp.After(result.Timestamp) {
+			result.Timestamp = entry.Result.Timestamp
+		}
+		result.Success = result.Success && entry.Result.Success
+		for _, conditionResult := range entry.Result.ConditionResults {
+			result.ConditionResults = append(result.ConditionResults, &endpoint.ConditionResult{
+				Condition: name + ": " + conditionResult.Condition,
+				Success:   conditionResult.Success,
+			})
+		}
+		for _, err := range entry.Result.Errors {
+			result.Errors = append(result.Errors, name+": "+err)
+		}
+	}
+	description := strings.Join(descriptions, ", ")
+	combinedAlert.Description = &description
+	return ep, &combinedAlert, result
+}
+
 type Config[T any] interface {
 	Validate() error
 	Merge(override *T)
@@ -133,6 +206,9 @@ var (
 	_ AlertProvider = (*zapier.AlertProvider)(nil)
 	_ AlertProvider = (*zulip.AlertProvider)(nil)
 
+	// Validate batch provider interface implementation on compile
+	_ BatchAlertProvider = (*slack.AlertProvider)(nil)
+
 	// Validate config interface implementation on compile
 	_ Config[awsses.Config]         = (*awsses.Config)(nil)
 	_ Config[clickup.Config]        = (*clickup.Config)(nil)
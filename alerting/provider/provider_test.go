@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/grouping"
+	"github.com/TwiN/gatus/v5/config/endpoint"
 )
 
 func TestParseWithDefaultAlert(t *testing.T) {
@@ -157,3 +159,84 @@ func TestParseWithDefaultAlert(t *testing.T) {
 		})
 	}
 }
+
+type mockAlertProvider struct {
+	sentEndpoints []*endpoint.Endpoint
+	sentAlerts    []*alert.Alert
+	sentResults   []*endpoint.Result
+}
+
+func (p *mockAlertProvider) Validate() error { return nil }
+
+func (p *mockAlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	p.sentEndpoints = append(p.sentEndpoints, ep)
+	p.sentAlerts = append(p.sentAlerts, alert)
+	p.sentResults = append(p.sentResults, result)
+	return nil
+}
+
+func (p *mockAlertProvider) GetDefaultAlert() *alert.Alert { return nil }
+
+func (p *mockAlertProvider) ValidateOverrides(group string, alert *alert.Alert) error { return nil }
+
+type mockBatchAlertProvider struct {
+	mockAlertProvider
+	sentBatches []*grouping.Batch
+}
+
+func (p *mockBatchAlertProvider) SendBatch(batch *grouping.Batch, resolved bool) error {
+	p.sentBatches = append(p.sentBatches, batch)
+	return nil
+}
+
+func TestSendBatch(t *testing.T) {
+	description := "failing"
+	newBatch := func(numberOfEntries int) *grouping.Batch {
+		batch := &grouping.Batch{Key: "core"}
+		for i := 0; i < numberOfEntries; i++ {
+			batch.Entries = append(batch.Entries, &grouping.Entry{
+				Endpoint: &endpoint.Endpoint{Name: "endpoint-" + string(rune('a'+i)), Group: "core"},
+				Alert:    &alert.Alert{Type: alert.TypeCustom, Description: &description},
+				Result:   &endpoint.Result{ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}},
+			})
+		}
+		return batch
+	}
+	// Providers without a batch-aware send path should receive a single alert combining every alert of the batch
+	p := &mockAlertProvider{}
+	if err := SendBatch(p, newBatch(3), false); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(p.sentEndpoints) != 1 {
+		t.Fatalf("expected a single combined alert to be sent, got %d", len(p.sentEndpoints))
+	}
+	if p.sentEndpoints[0].Name != "3 endpoints" || p.sentEndpoints[0].Group != "core" {
+		t.Errorf("expected the combined alert to be sent for core/3 endpoints, got %s", p.sentEndpoints[0].DisplayName())
+	}
+	if description := p.sentAlerts[0].GetDescription(); description != "endpoint-a: failing, endpoint-b: failing, endpoint-c: failing" {
+		t.Errorf("expected the description of the combined alert to list every endpoint, got %s", description)
+	}
+	if len(p.sentResults[0].ConditionResults) != 3 || p.sentResults[0].ConditionResults[1].Condition != "endpoint-b: [STATUS] == 200" || p.sentResults[0].Success {
+		t.Error("expected the result of the combined alert to have the failed condition of every endpoint")
+	}
+	p = &mockAlertProvider{}
+	// Providers with a batch-aware send path should receive the batch
+	bp := &mockBatchAlertProvider{}
+	if err := SendBatch(bp, newBatch(3), false); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(bp.sentBatches) != 1 || len(bp.sentEndpoints) != 0 {
+		t.Errorf("expected the batch to be sent through SendBatch, got %d batches and %d alerts", len(bp.sentBatches), len(bp.sentEndpoints))
+	}
+	// Batches with a single entry should be sent as a regular alert
+	if err := SendBatch(bp, newBatch(1), false); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(bp.sentEndpoints) != 1 || bp.sentEndpoints[0].Name != "endpoint-a" {
+		t.Error("expected a batch with a single entry to be sent through Send")
+	}
+	// Empty batches should not be sent
+	if err := SendBatch(p, newBatch(0), false); err != nil || len(p.sentEndpoints) != 0 {
+		t.Error("expected an empty batch to not be sent")
+	}
+}
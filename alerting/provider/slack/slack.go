@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/grouping"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"gopkg.in/yaml.v3"
@@ -77,8 +78,21 @@ func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, r
 	if err != nil {
 		return err
 	}
-	buffer := bytes.NewBuffer(provider.buildRequestBody(cfg, ep, alert, result, resolved))
-	request, err := http.NewRequest(http.MethodPost, cfg.WebhookURL, buffer)
+	return provider.post(cfg, provider.buildRequestBody(cfg, ep, alert, result, resolved))
+}
+
+// SendBatch sends the alerts of multiple endpoints as a single message listing every affected endpoint
+func (provider *AlertProvider) SendBatch(batch *grouping.Batch, resolved bool) error {
+	first := batch.Entries[0]
+	cfg, err := provider.GetConfig(first.Endpoint.Group, first.Alert)
+	if err != nil {
+		return err
+	}
+	return provider.post(cfg, provider.buildBatchRequestBody(cfg, batch, resolved))
+}
+
+func (provider *AlertProvider) post(cfg *Config, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, cfg.WebhookURL, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -163,6 +177,50 @@ func (provider *AlertProvider) buildRequestBody(cfg *Config, ep *endpoint.Endpoi
 	return bodyAsJSON
 }
 
+// buildBatchRequestBody builds the request body for a batch of alerts, with one field per affected endpoint
+func (provider *AlertProvider) buildBatchRequestBody(cfg *Config, batch *grouping.Batch, resolved bool) []byte {
+	var message, color string
+	if resolved {
+		message = fmt.Sprintf("Alerts for *%d endpoints* have been resolved", len(batch.Entries))
+		color = "#36A64F"
+	} else {
+		message = fmt.Sprintf("Alerts for *%d endpoints* have been triggered", len(batch.Entries))
+		color = "#DD0000"
+	}
+	if len(batch.Key) > 0 {
+		message += fmt.Sprintf(" in *%s*", batch.Key)
+	}
+	attachment := Attachment{
+		Title: cfg.Title,
+		Text:  message,
+		Short: false,
+		Color: color,
+	}
+	if len(attachment.Title) == 0 {
+		attachment.Title = ":helmet_with_white_cross: Gatus"
+	}
+	for _, entry := range batch.Entries {
+		var value string
+		if description := entry.Alert.GetDescription(); len(description) > 0 {
+			value = "> " + description + "\n"
+		}
+		for _, conditionResult := range entry.Result.ConditionResults {
+			prefix := ":x:"
+			if conditionResult.Success {
+				prefix = ":white_check_mark:"
+			}
+			value += fmt.Sprintf("%s - `%s`\n", prefix, conditionResult.Condition)
+		}
+		attachment.Fields = append(attachment.Fields, Field{
+			Title: entry.Endpoint.DisplayName(),
+			Value: value,
+			Short: false,
+		})
+	}
+	bodyAsJSON, _ := json.Marshal(Body{Text: "", Attachments: []Attachment{attachment}})
+	return bodyAsJSON
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
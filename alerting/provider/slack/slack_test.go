@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/grouping"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/test"
@@ -231,6 +232,54 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildBatchRequestBody(t *testing.T) {
+	description := "description-1"
+	provider := AlertProvider{DefaultConfig: Config{WebhookURL: "http://example.com"}}
+	batch := &grouping.Batch{
+		Key: "core",
+		Entries: []*grouping.Entry{
+			{
+				Endpoint: &endpoint.Endpoint{Name: "frontend", Group: "core"},
+				Alert:    &alert.Alert{Description: &description},
+				Result:   &endpoint.Result{ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}},
+			},
+			{
+				Endpoint: &endpoint.Endpoint{Name: "backend", Group: "core"},
+				Alert:    &alert.Alert{},
+				Result:   &endpoint.Result{ConditionResults: []*endpoint.ConditionResult{{Condition: "[CONNECTED] == true", Success: true}}},
+			},
+		},
+	}
+	scenarios := []struct {
+		Name         string
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Resolved:     false,
+			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"Alerts for *2 endpoints* have been triggered in *core*\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"core/frontend\",\"value\":\"\\u003e description-1\\n:x: - `[STATUS] == 200`\\n\",\"short\":false},{\"title\":\"core/backend\",\"value\":\":white_check_mark: - `[CONNECTED] == true`\\n\",\"short\":false}]}]}",
+		},
+		{
+			Name:         "resolved",
+			Resolved:     true,
+			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"Alerts for *2 endpoints* have been resolved in *core*\",\"short\":false,\"color\":\"#36A64F\",\"fields\":[{\"title\":\"core/frontend\",\"value\":\"\\u003e description-1\\n:x: - `[STATUS] == 200`\\n\",\"short\":false},{\"title\":\"core/backend\",\"value\":\":white_check_mark: - `[CONNECTED] == true`\\n\",\"short\":false}]}]}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := provider.buildBatchRequestBody(&provider.DefaultConfig, batch, scenario.Resolved)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
 func TestAlertProvider_GetDefaultAlert(t *testing.T) {
 	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
 		t.Error("expected default alert to be not nil")
@@ -0,0 +1,133 @@
+package silence
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/pattern"
+)
+
+var (
+	// ErrEmptyMatcher is the error returned when a silence has a matcher that would match every alert
+	ErrEmptyMatcher = errors.New("matcher must have at least one of endpointKey, group or alertType")
+
+	// ErrInvalidEndpointKeyPattern is the error returned when the endpoint key of a matcher is not a valid pattern
+	ErrInvalidEndpointKeyPattern = errors.New("matcher has an invalid endpointKey pattern")
+
+	// ErrInvalidDuration is the error returned when the duration of a silence is not strictly positive
+	ErrInvalidDuration = errors.New("duration must be greater than 0")
+
+	// ErrEmptyComment is the error returned when a silence has no comment
+	ErrEmptyComment = errors.New("comment must not be empty")
+)
+
+// Matcher determines which alerts a Silence applies to.
+// Every field that is set must match for an alert to be matched.
+type Matcher struct {
+	// EndpointKey is a pattern that the key of the endpoint must match (e.g. core_*)
+	EndpointKey string `json:"endpointKey,omitempty"`
+
+	// Group is the group the endpoint must be part of
+	Group string `json:"group,omitempty"`
+
+	// AlertType is the type the alert must be of
+	AlertType alert.Type `json:"alertType,omitempty"`
+}
+
+// Validate validates the matcher
+func (matcher *Matcher) Validate() error {
+	if len(matcher.EndpointKey) == 0 && len(matcher.Group) == 0 && len(matcher.AlertType) == 0 {
+		return ErrEmptyMatcher
+	}
+	if _, err := filepath.Match(matcher.EndpointKey, ""); err != nil {
+		return ErrInvalidEndpointKeyPattern
+	}
+	return nil
+}
+
+// Matches returns whether the alert of the given type of the endpoint with the given key and group is matched
+func (matcher *Matcher) Matches(endpointKey, group string, alertType alert.Type) bool {
+	if len(matcher.AlertType) > 0 && matcher.AlertType != alertType {
+		return false
+	}
+	return matcher.MatchesEndpoint(endpointKey, group)
+}
+
+// MatchesEndpoint returns whether at least some alerts of the endpoint with the given key and group are matched
+func (matcher *Matcher) MatchesEndpoint(endpointKey, group string) bool {
+	if len(matcher.EndpointKey) > 0 && !pattern.Match(matcher.EndpointKey, endpointKey) {
+		return false
+	}
+	if len(matcher.Group) > 0 && matcher.Group != group {
+		return false
+	}
+	return true
+}
+
+// Silence prevents the alerts it matches from being sent until it expires
+type Silence struct {
+	// ID is the unique identifier of the silence, set by the store
+	ID int64 `json:"id"`
+
+	// Matcher determines which alerts the silence applies to
+	Matcher Matcher `json:"matcher"`
+
+	// Comment explains why the alerts were silenced
+	Comment string `json:"comment"`
+
+	// CreatedBy is who created the silence
+	CreatedBy string `json:"createdBy,omitempty"`
+
+	// CreatedAt is when the silence was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt is when the silence stops applying
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewSilence creates a new silence that expires after the given duration
+func NewSilence(matcher Matcher, duration time.Duration, comment, createdBy string) (*Silence, error) {
+	if err := matcher.Validate(); err != nil {
+		return nil, err
+	}
+	if duration <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	if len(comment) == 0 {
+		return nil, ErrEmptyComment
+	}
+	now := time.Now()
+	return &Silence{
+		Matcher:   matcher,
+		Comment:   comment,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: now.Add(duration),
+	}, nil
+}
+
+// IsActive returns whether the silence hasn't expired yet
+func (silence *Silence) IsActive() bool {
+	return time.Now().Before(silence.ExpiresAt)
+}
+
+// Acknowledgement prevents a triggered alert from being sent again, whether as a reminder or as an escalation, until
+// the alert is resolved
+type Acknowledgement struct {
+	// EndpointKey is the key of the endpoint the acknowledged alert belongs to
+	EndpointKey string `json:"endpointKey"`
+
+	// AlertChecksum is the checksum of the acknowledged alert, as returned by alert.Alert.Checksum
+	AlertChecksum string `json:"alertChecksum"`
+
+	// Comment is an optional comment left by whoever acknowledged the alert
+	Comment string `json:"comment,omitempty"`
+
+	// AcknowledgedBy is who acknowledged the alert
+	AcknowledgedBy string `json:"acknowledgedBy,omitempty"`
+
+	// AcknowledgedAt is when the alert was acknowledged
+	AcknowledgedAt time.Time `json:"acknowledgedAt"`
+}
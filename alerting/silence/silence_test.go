@@ -0,0 +1,220 @@
+package silence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+func TestMatcher_Validate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		matcher     Matcher
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			matcher:     Matcher{},
+			expectedErr: ErrEmptyMatcher,
+		},
+		{
+			name:        "invalid-endpoint-key-pattern",
+			matcher:     Matcher{EndpointKey: "core_[frontend"},
+			expectedErr: ErrInvalidEndpointKeyPattern,
+		},
+		{
+			name:    "endpoint-key",
+			matcher: Matcher{EndpointKey: "core_*"},
+		},
+		{
+			name:    "group",
+			matcher: Matcher{Group: "core"},
+		},
+		{
+			name:    "alert-type",
+			matcher: Matcher{AlertType: alert.TypeSlack},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.matcher.Validate(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestMatcher_Matches(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		matcher     Matcher
+		endpointKey string
+		group       string
+		alertType   alert.Type
+		expected    bool
+	}{
+		{
+			name:        "endpoint-key-exact-match",
+			matcher:     Matcher{EndpointKey: "core_frontend"},
+			endpointKey: "core_frontend",
+			group:       "core",
+			alertType:   alert.TypeSlack,
+			expected:    true,
+		},
+		{
+			name:        "endpoint-key-glob-match",
+			matcher:     Matcher{EndpointKey: "core_*"},
+			endpointKey: "core_frontend",
+			group:       "core",
+			alertType:   alert.TypeSlack,
+			expected:    true,
+		},
+		{
+			name:        "endpoint-key-no-match",
+			matcher:     Matcher{EndpointKey: "core_*"},
+			endpointKey: "internal_frontend",
+			group:       "internal",
+			alertType:   alert.TypeSlack,
+			expected:    false,
+		},
+		{
+			name:        "group-match",
+			matcher:     Matcher{Group: "core"},
+			endpointKey: "core_frontend",
+			group:       "core",
+			alertType:   alert.TypeSlack,
+			expected:    true,
+		},
+		{
+			name:        "group-no-match",
+			matcher:     Matcher{Group: "core"},
+			endpointKey: "internal_frontend",
+			group:       "internal",
+			alertType:   alert.TypeSlack,
+			expected:    false,
+		},
+		{
+			name:        "alert-type-match",
+			matcher:     Matcher{AlertType: alert.TypeSlack},
+			endpointKey: "core_frontend",
+			group:       "core",
+			alertType:   alert.TypeSlack,
+			expected:    true,
+		},
+		{
+			name:        "alert-type-no-match",
+			matcher:     Matcher{AlertType: alert.TypeSlack},
+			endpointKey: "core_frontend",
+			group:       "core",
+			alertType:   alert.TypePagerDuty,
+			expected:    false,
+		},
+		{
+			name:        "all-match",
+			matcher:     Matcher{EndpointKey: "*frontend", Group: "core", AlertType: alert.TypeSlack},
+			endpointKey: "core_frontend",
+			group:       "core",
+			alertType:   alert.TypeSlack,
+			expected:    true,
+		},
+		{
+			name:        "all-but-one-match",
+			matcher:     Matcher{EndpointKey: "*frontend", Group: "core", AlertType: alert.TypeSlack},
+			endpointKey: "core_frontend",
+			group:       "core",
+			alertType:   alert.TypeDiscord,
+			expected:    false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := scenario.matcher.Matches(scenario.endpointKey, scenario.group, scenario.alertType); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatcher_MatchesEndpoint(t *testing.T) {
+	matcher := Matcher{EndpointKey: "core_*", AlertType: alert.TypeSlack}
+	if !matcher.MatchesEndpoint("core_frontend", "core") {
+		t.Error("expected matcher to match endpoint regardless of the alert type")
+	}
+	if matcher.MatchesEndpoint("internal_frontend", "internal") {
+		t.Error("expected matcher to not match endpoint with a key that doesn't match the pattern")
+	}
+}
+
+func TestNewSilence(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		matcher     Matcher
+		duration    time.Duration
+		comment     string
+		expectedErr error
+	}{
+		{
+			name:        "invalid-matcher",
+			matcher:     Matcher{},
+			duration:    time.Hour,
+			comment:     "Maintenance",
+			expectedErr: ErrEmptyMatcher,
+		},
+		{
+			name:        "zero-duration",
+			matcher:     Matcher{Group: "core"},
+			duration:    0,
+			comment:     "Maintenance",
+			expectedErr: ErrInvalidDuration,
+		},
+		{
+			name:        "negative-duration",
+			matcher:     Matcher{Group: "core"},
+			duration:    -time.Hour,
+			comment:     "Maintenance",
+			expectedErr: ErrInvalidDuration,
+		},
+		{
+			name:        "no-comment",
+			matcher:     Matcher{Group: "core"},
+			duration:    time.Hour,
+			expectedErr: ErrEmptyComment,
+		},
+		{
+			name:     "valid",
+			matcher:  Matcher{Group: "core"},
+			duration: time.Hour,
+			comment:  "Maintenance",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			sil, err := NewSilence(scenario.matcher, scenario.duration, scenario.comment, "john.doe")
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if sil.ExpiresAt.Sub(sil.CreatedAt) != scenario.duration {
+				t.Errorf("expected silence to expire %s after its creation, got %s", scenario.duration, sil.ExpiresAt.Sub(sil.CreatedAt))
+			}
+			if !sil.IsActive() {
+				t.Error("expected silence to be active")
+			}
+		})
+	}
+}
+
+func TestSilence_IsActive(t *testing.T) {
+	sil := &Silence{ExpiresAt: time.Now().Add(-time.Second)}
+	if sil.IsActive() {
+		t.Error("expected expired silence to not be active")
+	}
+	sil.ExpiresAt = time.Now().Add(time.Minute)
+	if !sil.IsActive() {
+		t.Error("expected silence that hasn't expired yet to be active")
+	}
+}
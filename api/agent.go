@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AgentEndpoints returns the configuration of the endpoints assigned to the location of the agent making the request
+func AgentEndpoints(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		location, err := getLocationFromAuthorizationHeader(c, cfg)
+		if err != nil {
+			return c.Status(401).SendString(err.Error())
+		}
+		body, err := agent.MarshalAssignment(location.Name, cfg.GetEndpointsByLocation(location.Name))
+		if err != nil {
+			logr.Errorf("[api.AgentEndpoints] Failed to marshal endpoints assigned to location=%s: %s", location.Name, err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		c.Set("Content-Type", "application/yaml")
+		return c.Status(200).Send(body)
+	}
+}
+
+// CreateAgentEndpointResult persists the result of an endpoint reported by the agent of one of its locations
+func CreateAgentEndpointResult(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		location, err := getLocationFromAuthorizationHeader(c, cfg)
+		if err != nil {
+			return c.Status(401).SendString(err.Error())
+		}
+		key := c.Params("key")
+		ep := cfg.GetEndpointByKey(key)
+		if ep == nil {
+			logr.Errorf("[api.CreateAgentEndpointResult] Endpoint with key=%s not found", key)
+			return c.Status(404).SendString("not found")
+		}
+		if !slices.Contains(ep.Locations, location.Name) {
+			logr.Errorf("[api.CreateAgentEndpointResult] Endpoint with key=%s is not monitored from location=%s", key, location.Name)
+			return c.Status(403).SendString("endpoint is not monitored from this location")
+		}
+		var report agent.Report
+		if err := json.Unmarshal(c.Body(), &report); err != nil {
+			return c.Status(400).SendString("invalid report: " + err.Error())
+		}
+		result := report.ToResult()
+		if result == nil {
+			return c.Status(400).SendString("report must have a result")
+		}
+		if result.Timestamp.IsZero() {
+			result.Timestamp = time.Now()
+		}
+		watchdog.HandleLocationResult(ep, location.Name, result)
+		logr.Debugf("[api.CreateAgentEndpointResult] Received result for endpoint with key=%s from location=%s with success=%v", key, location.Name, result.Success)
+		return c.Status(200).SendString("")
+	}
+}
+
+// getLocationFromAuthorizationHeader returns the location whose token is the bearer token of the request
+func getLocationFromAuthorizationHeader(c *fiber.Ctx, cfg *config.Config) (*agent.Location, error) {
+	authorizationHeader := string(c.Request().Header.Peek("Authorization"))
+	if !strings.HasPrefix(authorizationHeader, "Bearer ") {
+		return nil, errors.New("invalid Authorization header")
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(authorizationHeader, "Bearer "))
+	if len(token) == 0 {
+		return nil, errors.New("bearer token must not be empty")
+	}
+	location := cfg.GetLocationByToken(token)
+	if location == nil {
+		logr.Errorf("[api.getLocationFromAuthorizationHeader] Invalid token from %s", c.IP())
+		return nil, errors.New("invalid token")
+	}
+	return location, nil
+}
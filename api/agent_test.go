@@ -0,0 +1,195 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAgentEndpoints(t *testing.T) {
+	cfg := &config.Config{
+		Locations: []*agent.Location{{Name: "eu-west", Token: "potato"}, {Name: "us-east", Token: "tomato"}},
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "website", Group: "core", URL: "https://example.org", Conditions: []endpoint.Condition{"[STATUS] == 200"}, Locations: []string{"eu-west", "us-east"}},
+			{Name: "api", Group: "core", URL: "https://example.org", Conditions: []endpoint.Condition{"[STATUS] == 200"}, Locations: []string{"us-east"}},
+			{Name: "local", Group: "core", URL: "https://example.org", Conditions: []endpoint.Condition{"[STATUS] == 200"}},
+		},
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name              string
+		Authorization     string
+		ExpectedCode      int
+		ExpectedLocation  string
+		ExpectedEndpoints []string
+	}{
+		{
+			Name:          "no-token",
+			Authorization: "",
+			ExpectedCode:  401,
+		},
+		{
+			Name:          "bad-token",
+			Authorization: "Bearer bad-token",
+			ExpectedCode:  401,
+		},
+		{
+			Name:              "eu-west",
+			Authorization:     "Bearer potato",
+			ExpectedCode:      200,
+			ExpectedLocation:  "eu-west",
+			ExpectedEndpoints: []string{"website"},
+		},
+		{
+			Name:              "us-east",
+			Authorization:     "Bearer tomato",
+			ExpectedCode:      200,
+			ExpectedLocation:  "us-east",
+			ExpectedEndpoints: []string{"website", "api"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/v1/agents/endpoints", http.NoBody)
+			if len(scenario.Authorization) > 0 {
+				request.Header.Set("Authorization", scenario.Authorization)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode != 200 {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var assignment agent.Assignment
+			if err := yaml.Unmarshal(body, &assignment); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if assignment.Location != scenario.ExpectedLocation {
+				t.Errorf("expected location %s, got %s", scenario.ExpectedLocation, assignment.Location)
+			}
+			if len(assignment.Endpoints) != len(scenario.ExpectedEndpoints) {
+				t.Fatalf("expected %d endpoints, got %d", len(scenario.ExpectedEndpoints), len(assignment.Endpoints))
+			}
+			for i, ep := range assignment.Endpoints {
+				if ep.Name != scenario.ExpectedEndpoints[i] {
+					t.Errorf("expected endpoint %s, got %s", scenario.ExpectedEndpoints[i], ep.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateAgentEndpointResult(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Locations: []*agent.Location{{Name: "eu-west", Token: "potato"}, {Name: "us-east", Token: "tomato"}},
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "website", Group: "core", URL: "https://example.org", Conditions: []endpoint.Condition{"[STATUS] == 200"}, Locations: []string{"eu-west"}},
+		},
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name          string
+		Path          string
+		Authorization string
+		Body          string
+		ExpectedCode  int
+	}{
+		{
+			Name:          "no-token",
+			Path:          "/api/v1/agents/endpoints/core_website/results",
+			Authorization: "",
+			Body:          `{"result":{"success":true}}`,
+			ExpectedCode:  401,
+		},
+		{
+			Name:          "bad-token",
+			Path:          "/api/v1/agents/endpoints/core_website/results",
+			Authorization: "Bearer bad-token",
+			Body:          `{"result":{"success":true}}`,
+			ExpectedCode:  401,
+		},
+		{
+			Name:          "unknown-endpoint",
+			Path:          "/api/v1/agents/endpoints/core_unknown/results",
+			Authorization: "Bearer potato",
+			Body:          `{"result":{"success":true}}`,
+			ExpectedCode:  404,
+		},
+		{
+			Name:          "endpoint-not-monitored-from-location",
+			Path:          "/api/v1/agents/endpoints/core_website/results",
+			Authorization: "Bearer tomato",
+			Body:          `{"result":{"success":true}}`,
+			ExpectedCode:  403,
+		},
+		{
+			Name:          "invalid-body",
+			Path:          "/api/v1/agents/endpoints/core_website/results",
+			Authorization: "Bearer potato",
+			Body:          `potato`,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "no-result",
+			Path:          "/api/v1/agents/endpoints/core_website/results",
+			Authorization: "Bearer potato",
+			Body:          `{}`,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "good",
+			Path:          "/api/v1/agents/endpoints/core_website/results",
+			Authorization: "Bearer potato",
+			Body:          `{"result":{"success":false,"duration":150000000,"errors":["timeout"]},"connected":true}`,
+			ExpectedCode:  200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			if len(scenario.Authorization) > 0 {
+				request.Header.Set("Authorization", scenario.Authorization)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-location-result", func(t *testing.T) {
+		latestResults, err := store.Get().GetLatestLocationResults(cfg.Endpoints[0].Key())
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		result, exists := latestResults["eu-west"]
+		if !exists {
+			t.Fatal("expected the result to be stored for the location")
+		}
+		if result.Success || result.Duration.Milliseconds() != 150 || len(result.Errors) != 1 || result.Timestamp.IsZero() {
+			t.Errorf("expected the pushed result to be stored, got %+v", result)
+		}
+	})
+}
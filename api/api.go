@@ -8,6 +8,7 @@ import (
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/ui"
 	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/security"
 	static "github.com/TwiN/gatus/v5/web"
 	"github.com/TwiN/health"
 	"github.com/TwiN/logr"
@@ -76,7 +77,9 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	// UNPROTECTED ROUTES //
 	////////////////////////
 	unprotectedAPIRouter := apiRouter.Group("/")
+	// The incidents and maintenance windows returned by this route depend on the principal of the request, if any
 	unprotectedAPIRouter.Get("/v1/config", ConfigHandler{securityConfig: cfg.Security, config: cfg}.GetConfig)
+	// Note that these routes aren't restricted to the endpoint groups of the principal, since they're not authenticated
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/health/badge.svg", HealthBadge)
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/health/badge.shields", HealthBadgeShields)
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/uptimes/:duration", UptimeRaw)
@@ -87,6 +90,9 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/history", ResponseTimeHistory)
 	// This endpoint requires authz with bearer token, so technically it is protected
 	unprotectedAPIRouter.Post("/v1/endpoints/:key/external", CreateExternalEndpointResult(cfg))
+	// These endpoints require authz with the bearer token of a location, so technically they are protected
+	unprotectedAPIRouter.Get("/v1/agents/endpoints", AgentEndpoints(cfg))
+	unprotectedAPIRouter.Post("/v1/agents/endpoints/:key/results", CreateAgentEndpointResult(cfg))
 	// SPA
 	app.Get("/", SinglePageApplication(cfg.UI))
 	app.Get("/endpoints/:key", SinglePageApplication(cfg.UI))
@@ -128,9 +134,48 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 			panic(err)
 		}
 	}
-	protectedAPIRouter.Get("/v1/endpoints/statuses", EndpointStatuses(cfg))
-	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", EndpointStatus(cfg))
-	protectedAPIRouter.Get("/v1/suites/statuses", SuiteStatuses(cfg))
-	protectedAPIRouter.Get("/v1/suites/:key/statuses", SuiteStatus(cfg))
+	// API tokens can only call the routes covered by their scopes
+	requireReadStatuses := security.RequireScope(security.APITokenScopeReadStatuses)
+	protectedAPIRouter.Get("/v1/endpoints/statuses", requireReadStatuses, EndpointStatuses(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", requireReadStatuses, EndpointStatus(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/:key/results", requireReadStatuses, EndpointResults)
+	protectedAPIRouter.Get("/v1/endpoints/:key/events", requireReadStatuses, EndpointEvents)
+	protectedAPIRouter.Get("/v1/endpoints/:key/slo", requireReadStatuses, EndpointSLO(cfg))
+	protectedAPIRouter.Get("/v1/suites/statuses", requireReadStatuses, SuiteStatuses(cfg))
+	protectedAPIRouter.Get("/v1/suites/:key/statuses", requireReadStatuses, SuiteStatus(cfg))
+	protectedAPIRouter.Get("/v1/incidents", requireReadStatuses, Incidents(cfg))
+	protectedAPIRouter.Get("/v1/incidents/:id", requireReadStatuses, Incident(cfg))
+	protectedAPIRouter.Get("/v1/silences", requireReadStatuses, Silences)
+	protectedAPIRouter.Get("/v1/maintenance-windows", requireReadStatuses, MaintenanceWindows(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/:key/alerts", requireReadStatuses, EndpointAlerts(cfg))
+	// Managing incidents is only possible if security is configured, as they are displayed on the status page
+	requireOperator := security.RequireRole(security.RoleOperator)
+	if cfg.Security != nil {
+		requireManageIncidents := security.RequireScope(security.APITokenScopeManageIncidents)
+		protectedAPIRouter.Post("/v1/incidents", requireOperator, requireManageIncidents, CreateIncident(cfg))
+		protectedAPIRouter.Put("/v1/incidents/:id", requireOperator, requireManageIncidents, UpdateIncident(cfg))
+		protectedAPIRouter.Post("/v1/incidents/:id/resolve", requireOperator, requireManageIncidents, ResolveIncident(cfg))
+	}
+	// Muting alerts is only possible if security is configured, as it affects which alerts are sent
+	if cfg.Security != nil {
+		requireManageSilences := security.RequireScope(security.APITokenScopeManageSilences)
+		requireManageMaintenanceWindows := security.RequireScope(security.APITokenScopeManageMaintenanceWindows)
+		protectedAPIRouter.Post("/v1/silences", requireOperator, requireManageSilences, CreateSilence)
+		protectedAPIRouter.Delete("/v1/silences/:id", requireOperator, requireManageSilences, DeleteSilence)
+		protectedAPIRouter.Post("/v1/endpoints/:key/alerts/:checksum/ack", requireOperator, requireManageSilences, AcknowledgeAlert(cfg))
+		protectedAPIRouter.Delete("/v1/endpoints/:key/alerts/:checksum/ack", requireOperator, requireManageSilences, UnacknowledgeAlert(cfg))
+		protectedAPIRouter.Post("/v1/maintenance-windows", requireOperator, requireManageMaintenanceWindows, CreateMaintenanceWindow(cfg))
+		protectedAPIRouter.Delete("/v1/maintenance-windows/:id", requireOperator, requireManageMaintenanceWindows, DeleteMaintenanceWindow(cfg))
+	}
+	// Listing API tokens is only possible if security is configured, as that's where they're configured
+	requireAdmin := security.RequireRole(security.RoleAdmin)
+	if cfg.Security != nil {
+		protectedAPIRouter.Get("/v1/api-tokens", requireAdmin, APITokens(cfg))
+	}
+	// Sessions are only created by logging in through OIDC
+	if cfg.Security != nil && cfg.Security.OIDC != nil {
+		protectedAPIRouter.Get("/v1/sessions", requireAdmin, Sessions)
+		protectedAPIRouter.Delete("/v1/sessions/:id", requireAdmin, DeleteSession)
+	}
 	return app
 }
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIToken is the representation of an API token returned by the API, which never includes the token or its hash
+type APIToken struct {
+	Name       string                   `json:"name"`
+	Scopes     []security.APITokenScope `json:"scopes"`
+	ExpiresAt  *time.Time               `json:"expiresAt,omitempty"`
+	Expired    bool                     `json:"expired"`
+	LastUsedAt *time.Time               `json:"lastUsedAt,omitempty"`
+}
+
+// APITokens handles requests to retrieve the API tokens configured, along with the last time each of them was used
+func APITokens(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		lastUsedTimes, err := store.Get().GetAllAPITokenLastUsedTimes()
+		if err != nil {
+			logr.Errorf("[api.APITokens] Failed to retrieve last used times of API tokens: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		apiTokens := make([]*APIToken, 0, len(cfg.Security.APITokens))
+		for _, apiToken := range cfg.Security.APITokens {
+			response := &APIToken{Name: apiToken.Name, Scopes: apiToken.Scopes}
+			if !apiToken.ExpiresAt.IsZero() {
+				expiresAt := apiToken.ExpiresAt
+				response.ExpiresAt = &expiresAt
+				response.Expired = apiToken.HasExpired()
+			}
+			if lastUsedAt, exists := lastUsedTimes[apiToken.Name]; exists {
+				response.LastUsedAt = &lastUsedAt
+			}
+			apiTokens = append(apiTokens, response)
+		}
+		return sendJSON(c, 200, apiTokens)
+	}
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestAPITokens(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{Name: "backup", Group: "core", Token: "endpoint-token"},
+			{Name: "backup", Group: "team-a", Token: "other-endpoint-token"},
+		},
+		Storage: &storage.Config{
+			MaximumNumberOfResults: storage.DefaultMaximumNumberOfResults,
+			MaximumNumberOfEvents:  storage.DefaultMaximumNumberOfEvents,
+		},
+		Maintenance: &maintenance.Config{},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+			APITokens: []*security.APIToken{
+				// sha256("reader")
+				{Name: "reader", TokenSHA256: "3d0941964aa3ebdcb00ccef58b1bb399f9f898465e9886d5aec7f31090a0fb30", Scopes: []security.APITokenScope{security.APITokenScopeReadStatuses}},
+				// sha256("pusher")
+				{Name: "pusher", TokenSHA256: "c1ca19857709b36ccdcc93f218f86e4dc8235a65a46ca06f9b53886f9b66ad2f", Scopes: []security.APITokenScope{security.APITokenScopePushExternalResults}, ExpiresAt: time.Now().Add(time.Hour)},
+				// sha256("expired")
+				{Name: "expired", TokenSHA256: "fa64ea1e82e1206f828ab2a02917c7e92accb98e3b95881a1b4ad52b914b66e3", Scopes: []security.APITokenScope{security.APITokenScopeReadStatuses}, ExpiresAt: time.Now().Add(-time.Hour)},
+				// sha256("team-pusher")
+				{Name: "team-pusher", TokenSHA256: "1db2a8ec182b1da75dffef0477a81ae08dd9b51566c9131636929a8d84d568f3", Scopes: []security.APITokenScope{security.APITokenScopePushExternalResults}, EndpointGroups: []string{"team-*"}},
+			},
+		},
+	}
+	if !cfg.Security.ValidateAndSetDefaults() {
+		t.Fatal("expected security config to be valid")
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name          string
+		Method        string
+		Path          string
+		Body          string
+		Authorization string
+		BasicAuth     bool
+		ExpectedCode  int
+	}{
+		{
+			Name:          "read-statuses-with-api-token",
+			Method:        "GET",
+			Path:          "/api/v1/endpoints/statuses",
+			Authorization: "Bearer reader",
+			ExpectedCode:  200,
+		},
+		{
+			Name:          "read-statuses-with-expired-api-token",
+			Method:        "GET",
+			Path:          "/api/v1/endpoints/statuses",
+			Authorization: "Bearer expired",
+			ExpectedCode:  401,
+		},
+		{
+			Name:          "read-statuses-with-api-token-without-scope",
+			Method:        "GET",
+			Path:          "/api/v1/endpoints/statuses",
+			Authorization: "Bearer pusher",
+			ExpectedCode:  403,
+		},
+		{
+			Name:          "create-silence-with-api-token-without-scope",
+			Method:        "POST",
+			Path:          "/api/v1/silences",
+			Body:          `{"matcher":{"group":"core"},"duration":"2h","comment":"Maintenance"}`,
+			Authorization: "Bearer reader",
+			ExpectedCode:  403,
+		},
+		{
+			Name:          "push-external-result-with-api-token",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/core_backup/external?success=true",
+			Authorization: "Bearer pusher",
+			ExpectedCode:  200,
+		},
+		{
+			Name:          "push-external-result-with-api-token-without-scope",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/core_backup/external?success=true",
+			Authorization: "Bearer reader",
+			ExpectedCode:  401,
+		},
+		{
+			Name:          "push-external-result-with-api-token-restricted-to-its-groups",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/team-a_backup/external?success=true",
+			Authorization: "Bearer team-pusher",
+			ExpectedCode:  200,
+		},
+		{
+			Name:          "push-external-result-with-api-token-restricted-to-other-groups",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/core_backup/external?success=true",
+			Authorization: "Bearer team-pusher",
+			ExpectedCode:  401,
+		},
+		{
+			Name:          "push-external-result-with-endpoint-token",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/core_backup/external?success=true",
+			Authorization: "Bearer endpoint-token",
+			ExpectedCode:  200,
+		},
+		{
+			Name:          "list-api-tokens-with-api-token",
+			Method:        "GET",
+			Path:          "/api/v1/api-tokens",
+			Authorization: "Bearer reader",
+			ExpectedCode:  403,
+		},
+		{
+			Name:         "list-api-tokens-as-admin",
+			Method:       "GET",
+			Path:         "/api/v1/api-tokens",
+			BasicAuth:    true,
+			ExpectedCode: 200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			if len(scenario.Authorization) > 0 {
+				request.Header.Set("Authorization", scenario.Authorization)
+			}
+			if scenario.BasicAuth {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-api-tokens", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/api/v1/api-tokens", http.NoBody)
+		request.SetBasicAuth("john.doe", "hunter2")
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		var apiTokens []*APIToken
+		if err = json.NewDecoder(response.Body).Decode(&apiTokens); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if len(apiTokens) != 4 {
+			t.Fatalf("expected 4 API tokens, got %d", len(apiTokens))
+		}
+		if reader := apiTokens[0]; reader.Name != "reader" || reader.LastUsedAt == nil || reader.ExpiresAt != nil || reader.Expired {
+			t.Errorf("expected reader to have been used and to never expire, got %+v", reader)
+		}
+		if pusher := apiTokens[1]; pusher.LastUsedAt == nil || pusher.ExpiresAt == nil || pusher.Expired {
+			t.Errorf("expected pusher to have been used and to not have expired yet, got %+v", pusher)
+		}
+		if expired := apiTokens[2]; expired.LastUsedAt != nil || !expired.Expired {
+			t.Errorf("expected expired token to have expired and to have never been used, got %+v", expired)
+		}
+	})
+}
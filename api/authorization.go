@@ -0,0 +1,79 @@
+package api
+
+import (
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+// checkEndpointVisibility returns common.ErrEndpointNotFound if the principal of the request can't see the endpoint
+// with the given key, so that the existence of the endpoints of other groups isn't disclosed
+func checkEndpointVisibility(c *fiber.Ctx, key string) error {
+	principal := security.GetPrincipal(c)
+	if principal.CanSeeAllGroups() {
+		return nil
+	}
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(key, paging.NewEndpointStatusParams())
+	if err != nil {
+		return err
+	}
+	if !principal.CanSeeGroup(endpointStatus.Group) {
+		return common.ErrEndpointNotFound
+	}
+	return nil
+}
+
+// canAccessGroups returns whether the principal of the request can see and manage what applies to the endpoints and
+// suites of the given groups, such as silences, maintenance windows and incidents.
+//
+// Principals that can only see some groups can only access what applies exclusively to these groups, because anything
+// else would also affect, and disclose the existence of, the endpoints of other groups.
+func canAccessGroups(c *fiber.Ctx, groups []string) bool {
+	return canPrincipalAccessGroups(security.GetPrincipal(c), groups)
+}
+
+// canPrincipalAccessGroups returns whether the principal passed can see and manage what applies to the endpoints and
+// suites of the given groups. See canAccessGroups.
+func canPrincipalAccessGroups(principal *security.Principal, groups []string) bool {
+	if principal.CanSeeAllGroups() {
+		return true
+	}
+	if len(groups) == 0 {
+		return false
+	}
+	for _, group := range groups {
+		if !principal.CanSeeGroup(group) {
+			return false
+		}
+	}
+	return true
+}
+
+// getGroupsOfKeys returns the groups of the endpoints, external endpoints and suites with the given keys.
+// The keys that don't reference anything in the configuration are given a blank group.
+func getGroupsOfKeys(cfg *config.Config, endpointKeys, suiteKeys []string) []string {
+	groups := make([]string, 0, len(endpointKeys)+len(suiteKeys))
+	for _, key := range endpointKeys {
+		if ep := cfg.GetEndpointByKey(key); ep != nil {
+			groups = append(groups, ep.Group)
+		} else if ee := cfg.GetExternalEndpointByKey(key); ee != nil {
+			groups = append(groups, ee.Group)
+		} else {
+			groups = append(groups, "")
+		}
+	}
+	for _, key := range suiteKeys {
+		group := ""
+		for _, s := range cfg.Suites {
+			if s.Key() == key {
+				group = s.Group
+				break
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
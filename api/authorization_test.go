@@ -0,0 +1,391 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestAuthorization(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	passwordBcryptHashBase64Encoded := "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "team-a", Alerts: []*alert.Alert{{Type: alert.TypeSlack, Triggered: true}}},
+			{Name: "backend", Group: "team-b", Alerts: []*alert.Alert{{Type: alert.TypeSlack, Triggered: true}}},
+		},
+		Suites: []*suite.Suite{
+			{Name: "checkout", Group: "team-a"},
+			{Name: "login", Group: "team-b"},
+		},
+		Storage: &storage.Config{
+			MaximumNumberOfResults: storage.DefaultMaximumNumberOfResults,
+			MaximumNumberOfEvents:  storage.DefaultMaximumNumberOfEvents,
+		},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{Users: []*security.BasicUser{
+				{Username: "admin", PasswordBcryptHashBase64Encoded: passwordBcryptHashBase64Encoded},
+				{Username: "viewer", PasswordBcryptHashBase64Encoded: passwordBcryptHashBase64Encoded},
+				{Username: "team-a-operator", PasswordBcryptHashBase64Encoded: passwordBcryptHashBase64Encoded},
+				{Username: "nobody", PasswordBcryptHashBase64Encoded: passwordBcryptHashBase64Encoded},
+			}},
+			Authorization: &security.AuthorizationConfig{Rules: []*security.AuthorizationRule{
+				{Role: security.RoleAdmin, Subjects: []string{"admin"}},
+				{Role: security.RoleViewer, Subjects: []string{"viewer"}},
+				{Role: security.RoleOperator, Subjects: []string{"team-a-operator"}, EndpointGroups: []string{"team-a"}},
+			}},
+		},
+	}
+	for _, ep := range cfg.Endpoints {
+		watchdog.UpdateEndpointStatus(ep, &endpoint.Result{Success: false, Timestamp: time.Now()})
+	}
+	api := New(cfg)
+	router := api.Router()
+	soon, later := time.Now().Add(time.Hour).UTC().Format(time.RFC3339), time.Now().Add(2*time.Hour).UTC().Format(time.RFC3339)
+	scenarios := []struct {
+		Name         string
+		Username     string
+		Method       string
+		Path         string
+		Body         string
+		ExpectedCode int
+		ExpectedBody []string
+		Unexpected   []string
+	}{
+		{
+			Name:         "user-without-role",
+			Username:     "nobody",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "admin-sees-every-endpoint",
+			Username:     "admin",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			ExpectedCode: 200,
+			ExpectedBody: []string{"team-a_frontend", "team-b_backend"},
+		},
+		{
+			Name:         "restricted-user-only-sees-endpoints-of-its-groups",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			ExpectedCode: 200,
+			ExpectedBody: []string{"team-a_frontend"},
+			Unexpected:   []string{"team-b_backend"},
+		},
+		{
+			Name:         "restricted-user-cannot-see-endpoint-of-other-group",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/team-b_backend/statuses",
+			ExpectedCode: 404,
+		},
+		{
+			Name:         "restricted-user-cannot-export-results-of-endpoint-of-other-group",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/team-b_backend/results",
+			ExpectedCode: 404,
+		},
+		{
+			Name:         "restricted-user-can-export-results-of-endpoint-of-its-groups",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/team-a_frontend/results",
+			ExpectedCode: 200,
+		},
+		{
+			Name:         "restricted-user-cannot-see-alerts-of-endpoint-of-other-group",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/team-b_backend/alerts",
+			ExpectedCode: 404,
+		},
+		{
+			Name:         "restricted-user-only-sees-suites-of-its-groups",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/suites/statuses",
+			ExpectedCode: 200,
+			ExpectedBody: []string{"team-a_checkout"},
+			Unexpected:   []string{"team-b_login"},
+		},
+		{
+			Name:         "restricted-user-cannot-see-suite-of-other-group",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/suites/team-b_login/statuses",
+			ExpectedCode: 404,
+		},
+		{
+			Name:         "viewer-cannot-create-silence",
+			Username:     "viewer",
+			Method:       "POST",
+			Path:         "/api/v1/silences",
+			Body:         `{"matcher":{"group":"team-a"},"duration":"2h","comment":"Maintenance"}`,
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "viewer-cannot-create-maintenance-window",
+			Username:     "viewer",
+			Method:       "POST",
+			Path:         "/api/v1/maintenance-windows",
+			Body:         `{"groups":["team-a"],"from":"2050-01-01T00:00:00Z","until":"2050-01-01T02:00:00Z"}`,
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "viewer-cannot-create-incident",
+			Username:     "viewer",
+			Method:       "POST",
+			Path:         "/api/v1/incidents",
+			Body:         `{"title":"Outage","severity":"major","message":"Investigating"}`,
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "restricted-operator-cannot-create-silence-for-every-group",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/silences",
+			Body:         `{"matcher":{"alertType":"slack"},"duration":"2h","comment":"Maintenance"}`,
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "restricted-operator-cannot-create-silence-for-other-group",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/silences",
+			Body:         `{"matcher":{"group":"team-b"},"duration":"2h","comment":"Maintenance"}`,
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "restricted-operator-can-create-silence-for-its-groups",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/silences",
+			Body:         `{"matcher":{"group":"team-a"},"duration":"2h","comment":"Maintenance"}`,
+			ExpectedCode: 201,
+			ExpectedBody: []string{`"createdBy":"team-a-operator"`},
+		},
+		{
+			Name:         "restricted-operator-cannot-create-maintenance-window-for-every-endpoint",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/maintenance-windows",
+			Body:         `{"from":"2050-01-01T00:00:00Z","until":"2050-01-01T02:00:00Z"}`,
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "restricted-operator-cannot-create-maintenance-window-for-endpoint-of-other-group",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/maintenance-windows",
+			Body:         `{"endpointKeys":["team-b_backend"],"from":"2050-01-01T00:00:00Z","until":"2050-01-01T02:00:00Z"}`,
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "restricted-operator-can-create-maintenance-window-for-its-groups",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/maintenance-windows",
+			Body:         `{"description":"Upgrade","endpointKeys":["team-a_frontend"],"groups":["team-a"],"suiteKeys":["team-a_checkout"],"from":"2050-01-01T00:00:00Z","until":"2050-01-01T02:00:00Z"}`,
+			ExpectedCode: 201,
+		},
+		{
+			Name:         "restricted-operator-cannot-create-incident-for-suite-of-other-group",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/incidents",
+			Body:         `{"title":"Outage","severity":"major","suiteKeys":["team-b_login"],"message":"Investigating"}`,
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "restricted-operator-can-create-incident-for-its-groups",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/incidents",
+			Body:         `{"title":"Outage","severity":"major","endpointKeys":["team-a_frontend"],"message":"Investigating"}`,
+			ExpectedCode: 201,
+		},
+		{
+			Name:         "admin-can-create-silence-for-every-group",
+			Username:     "admin",
+			Method:       "POST",
+			Path:         "/api/v1/silences",
+			Body:         `{"matcher":{"alertType":"slack"},"duration":"2h","comment":"Maintenance"}`,
+			ExpectedCode: 201,
+		},
+		{
+			Name:         "restricted-operator-only-sees-silences-of-its-groups",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/silences",
+			ExpectedCode: 200,
+			ExpectedBody: []string{`"group":"team-a"`},
+			Unexpected:   []string{`"alertType":"slack"`},
+		},
+		{
+			Name:         "admin-can-create-incident-for-other-group",
+			Username:     "admin",
+			Method:       "POST",
+			Path:         "/api/v1/incidents",
+			Body:         `{"title":"Degraded performance","severity":"minor","endpointKeys":["team-b_backend"],"message":"Investigating"}`,
+			ExpectedCode: 201,
+		},
+		{
+			Name:         "restricted-user-only-sees-incidents-of-its-groups",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/incidents",
+			ExpectedCode: 200,
+			ExpectedBody: []string{"team-a_frontend"},
+			Unexpected:   []string{"team-b_backend"},
+		},
+		{
+			Name:         "restricted-user-can-see-incident-of-its-groups",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/incidents/1",
+			ExpectedCode: 200,
+		},
+		{
+			Name:         "restricted-user-cannot-see-incident-of-other-group",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/incidents/2",
+			ExpectedCode: 404,
+		},
+		{
+			Name:         "admin-can-create-maintenance-window-for-other-group",
+			Username:     "admin",
+			Method:       "POST",
+			Path:         "/api/v1/maintenance-windows",
+			Body:         `{"description":"Migration","groups":["team-b"],"from":"2050-01-01T00:00:00Z","until":"2050-01-01T02:00:00Z"}`,
+			ExpectedCode: 201,
+		},
+		{
+			Name:         "restricted-user-only-sees-maintenance-windows-of-its-groups",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/maintenance-windows",
+			ExpectedCode: 200,
+			ExpectedBody: []string{"Upgrade"},
+			Unexpected:   []string{"Migration"},
+		},
+		{
+			Name:         "restricted-operator-can-create-upcoming-maintenance-window-for-its-groups",
+			Username:     "team-a-operator",
+			Method:       "POST",
+			Path:         "/api/v1/maintenance-windows",
+			Body:         fmt.Sprintf(`{"description":"Hotfix","groups":["team-a"],"from":"%s","until":"%s"}`, soon, later),
+			ExpectedCode: 201,
+		},
+		{
+			Name:         "admin-can-create-upcoming-maintenance-window-for-other-group",
+			Username:     "admin",
+			Method:       "POST",
+			Path:         "/api/v1/maintenance-windows",
+			Body:         fmt.Sprintf(`{"description":"Failover","groups":["team-b"],"from":"%s","until":"%s"}`, soon, later),
+			ExpectedCode: 201,
+		},
+		{
+			Name:         "restricted-user-only-sees-incidents-and-maintenance-windows-of-its-groups-on-status-page",
+			Username:     "team-a-operator",
+			Method:       "GET",
+			Path:         "/api/v1/config",
+			ExpectedCode: 200,
+			ExpectedBody: []string{"team-a_frontend", "Hotfix"},
+			Unexpected:   []string{"team-b_backend", "Failover"},
+		},
+		{
+			Name:         "admin-sees-every-incident-and-maintenance-window-on-status-page",
+			Username:     "admin",
+			Method:       "GET",
+			Path:         "/api/v1/config",
+			ExpectedCode: 200,
+			ExpectedBody: []string{"team-a_frontend", "team-b_backend", "Hotfix", "Failover"},
+		},
+		{
+			Name:         "unauthenticated-user-sees-no-incident-nor-maintenance-window-on-status-page",
+			Username:     "unknown",
+			Method:       "GET",
+			Path:         "/api/v1/config",
+			ExpectedCode: 200,
+			ExpectedBody: []string{`"incidents":[]`, `"maintenanceWindows":[]`},
+		},
+		{
+			Name:         "restricted-operator-cannot-delete-silence-for-every-group",
+			Username:     "team-a-operator",
+			Method:       "DELETE",
+			Path:         "/api/v1/silences/2",
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "restricted-operator-can-delete-silence-for-its-groups",
+			Username:     "team-a-operator",
+			Method:       "DELETE",
+			Path:         "/api/v1/silences/1",
+			ExpectedCode: 204,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			cache.Clear()
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			request.SetBasicAuth(scenario.Username, "hunter2")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			for _, expected := range scenario.ExpectedBody {
+				if !strings.Contains(string(body), expected) {
+					t.Errorf("expected body to contain %s, got %s", expected, body)
+				}
+			}
+			for _, unexpected := range scenario.Unexpected {
+				if strings.Contains(string(body), unexpected) {
+					t.Errorf("expected body to not contain %s, got %s", unexpected, body)
+				}
+			}
+		})
+	}
+	t.Run("verify-silences", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/api/v1/silences", http.NoBody)
+		request.SetBasicAuth("admin", "hunter2")
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		var silences []map[string]any
+		if err = json.NewDecoder(response.Body).Decode(&silences); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if len(silences) != 1 {
+			t.Errorf("expected only the silence created by the admin to remain, got %d silences", len(silences))
+		}
+	})
+}
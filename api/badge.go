@@ -96,7 +96,11 @@ func ResponseTimeBadge(cfg *config.Config) fiber.Handler {
 		if err != nil {
 			return c.Status(400).SendString("invalid key encoding")
 		}
-		averageResponseTime, err := store.Get().GetAverageResponseTimeByKey(key, from, time.Now())
+		percentile, percentileName, err := parsePercentile(c.Query("percentile"))
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		responseTime, err := getResponseTimeByKey(key, from, time.Now(), percentile)
 		if err != nil {
 			if errors.Is(err, common.ErrEndpointNotFound) {
 				return c.Status(404).SendString(err.Error())
@@ -108,7 +112,7 @@ func ResponseTimeBadge(cfg *config.Config) fiber.Handler {
 		c.Set("Content-Type", "image/svg+xml")
 		c.Set("Cache-Control", "no-cache, no-store, must-revalidate")
 		c.Set("Expires", "0")
-		return c.Status(200).Send(generateResponseTimeBadgeSVG(duration, averageResponseTime, key, cfg))
+		return c.Status(200).Send(generateResponseTimeBadgeSVG(duration, percentileName, responseTime, key, cfg))
 	}
 }
 
@@ -243,7 +247,9 @@ func getBadgeColorFromUptime(uptime float64) string {
 	return badgeColorHexVeryBad
 }
 
-func generateResponseTimeBadgeSVG(duration string, averageResponseTime int, key string, cfg *config.Config) []byte {
+// generateResponseTimeBadgeSVG generates the badge of the average response time, or of the given percentile of the
+// response times if percentileName isn't empty
+func generateResponseTimeBadgeSVG(duration, percentileName string, responseTime int, key string, cfg *config.Config) []byte {
 	var labelWidth, valueWidth int
 	switch duration {
 	case "30d":
@@ -256,8 +262,13 @@ func generateResponseTimeBadgeSVG(duration string, averageResponseTime int, key
 		labelWidth = 105
 	default:
 	}
-	color := getBadgeColorFromResponseTime(averageResponseTime, key, cfg)
-	sanitizedValue := strconv.Itoa(averageResponseTime) + "ms"
+	label := "response time " + duration
+	if len(percentileName) > 0 {
+		label = percentileName + " " + label
+		labelWidth += (len(percentileName) + 1) * 7
+	}
+	color := getBadgeColorFromResponseTime(responseTime, key, cfg)
+	sanitizedValue := strconv.Itoa(responseTime) + "ms"
 	valueWidth = len(sanitizedValue) * 11
 	width := labelWidth + valueWidth
 	labelX := labelWidth / 2
@@ -277,10 +288,10 @@ func generateResponseTimeBadgeSVG(duration string, averageResponseTime int, key
   </g>
   <g fill="#fff" text-anchor="middle" font-family="DejaVu Sans,Verdana,Geneva,sans-serif" font-size="11">
     <text x="%d" y="15" fill="#010101" fill-opacity=".3">
-      response time %s
+      %s
     </text>
     <text x="%d" y="14">
-      response time %s
+      %s
     </text>
     <text x="%d" y="15" fill="#010101" fill-opacity=".3">
       %s
@@ -289,7 +300,7 @@ func generateResponseTimeBadgeSVG(duration string, averageResponseTime int, key
       %s
     </text>
   </g>
-</svg>`, width, width, labelWidth, color, labelWidth, valueWidth, labelWidth, width, labelX, duration, labelX, duration, valueX, sanitizedValue, valueX, sanitizedValue))
+</svg>`, width, width, labelWidth, color, labelWidth, valueWidth, labelWidth, width, labelX, label, labelX, label, valueX, sanitizedValue, valueX, sanitizedValue))
 	return svg
 }
 
@@ -95,6 +95,16 @@ func TestBadge(t *testing.T) {
 			Path:         "/api/v1/endpoints/invalid_key/response-times/7d/badge.svg",
 			ExpectedCode: http.StatusNotFound,
 		},
+		{
+			Name:         "badge-response-time-p99",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/24h/badge.svg?percentile=p99",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "badge-response-time-with-invalid-percentile",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/24h/badge.svg?percentile=95",
+			ExpectedCode: http.StatusBadRequest,
+		},
 		{
 			Name:         "badge-health-up",
 			Path:         "/api/v1/endpoints/core_frontend/health/badge.svg",
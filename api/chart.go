@@ -6,9 +6,9 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 	"time"
 
-	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/logr"
 	"github.com/gofiber/fiber/v2"
@@ -50,7 +50,11 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(400).SendString("invalid key encoding")
 	}
-	hourlyAverageResponseTime, err := store.Get().GetHourlyAverageResponseTimeByKey(key, from, time.Now())
+	percentile, percentileName, err := parsePercentile(c.Query("percentile"))
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	hourlyResponseTime, err := getHourlyResponseTimesByKey(key, from, time.Now(), percentile)
 	if err != nil {
 		if errors.Is(err, common.ErrEndpointNotFound) {
 			return c.Status(404).SendString(err.Error())
@@ -59,19 +63,23 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 		}
 		return c.Status(500).SendString(err.Error())
 	}
-	if len(hourlyAverageResponseTime) == 0 {
+	if len(hourlyResponseTime) == 0 {
 		return c.Status(204).SendString("")
 	}
+	yAxisName := "Average response time"
+	if len(percentileName) > 0 {
+		yAxisName = strings.ToUpper(percentileName[:1]) + percentileName[1:] + " response time"
+	}
 	series := chart.TimeSeries{
-		Name: "Average response time per hour",
+		Name: yAxisName + " per hour",
 		Style: chart.Style{
 			StrokeWidth: 1.5,
 			DotWidth:    2.0,
 		},
 	}
-	keys := make([]int, 0, len(hourlyAverageResponseTime))
+	keys := make([]int, 0, len(hourlyResponseTime))
 	earliestTimestamp := int64(0)
-	for hourlyTimestamp := range hourlyAverageResponseTime {
+	for hourlyTimestamp := range hourlyResponseTime {
 		keys = append(keys, int(hourlyTimestamp))
 		if earliestTimestamp == 0 || hourlyTimestamp < earliestTimestamp {
 			earliestTimestamp = hourlyTimestamp
@@ -82,14 +90,14 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 		keys = append(keys, int(earliestTimestamp))
 	}
 	sort.Ints(keys)
-	var maxAverageResponseTime float64
+	var maxResponseTime float64
 	for _, key := range keys {
-		averageResponseTime := float64(hourlyAverageResponseTime[int64(key)])
-		if maxAverageResponseTime < averageResponseTime {
-			maxAverageResponseTime = averageResponseTime
+		responseTime := float64(hourlyResponseTime[int64(key)])
+		if maxResponseTime < responseTime {
+			maxResponseTime = responseTime
 		}
 		series.XValues = append(series.XValues, time.Unix(int64(key), 0))
-		series.YValues = append(series.YValues, averageResponseTime)
+		series.YValues = append(series.YValues, responseTime)
 	}
 	graph := chart.Chart{
 		Canvas:     transparentStyle,
@@ -104,14 +112,14 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 			NameStyle:      axisStyle,
 		},
 		YAxis: chart.YAxis{
-			Name:           "Average response time",
+			Name:           yAxisName,
 			GridMajorStyle: gridStyle,
 			GridMinorStyle: gridStyle,
 			Style:          axisStyle,
 			NameStyle:      axisStyle,
 			Range: &chart.ContinuousRange{
 				Min: 0,
-				Max: math.Ceil(maxAverageResponseTime * 1.25),
+				Max: math.Ceil(maxResponseTime * 1.25),
 			},
 		},
 		Series: []chart.Series{series},
@@ -144,7 +152,11 @@ func ResponseTimeHistory(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(400).SendString("invalid key encoding")
 	}
-	hourlyAverageResponseTime, err := store.Get().GetHourlyAverageResponseTimeByKey(endpointKey, from, time.Now())
+	percentile, _, err := parsePercentile(c.Query("percentile"))
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	hourlyResponseTime, err := getHourlyResponseTimesByKey(endpointKey, from, time.Now(), percentile)
 	if err != nil {
 		if errors.Is(err, common.ErrEndpointNotFound) {
 			return c.Status(404).SendString(err.Error())
@@ -154,15 +166,15 @@ func ResponseTimeHistory(c *fiber.Ctx) error {
 		}
 		return c.Status(500).SendString(err.Error())
 	}
-	if len(hourlyAverageResponseTime) == 0 {
+	if len(hourlyResponseTime) == 0 {
 		return c.Status(200).JSON(map[string]interface{}{
 			"timestamps": []int64{},
 			"values":     []int{},
 		})
 	}
-	hourlyTimestamps := make([]int, 0, len(hourlyAverageResponseTime))
+	hourlyTimestamps := make([]int, 0, len(hourlyResponseTime))
 	earliestTimestamp := int64(0)
-	for hourlyTimestamp := range hourlyAverageResponseTime {
+	for hourlyTimestamp := range hourlyResponseTime {
 		hourlyTimestamps = append(hourlyTimestamps, int(hourlyTimestamp))
 		if earliestTimestamp == 0 || hourlyTimestamp < earliestTimestamp {
 			earliestTimestamp = hourlyTimestamp
@@ -177,9 +189,8 @@ func ResponseTimeHistory(c *fiber.Ctx) error {
 	values := make([]int, 0, len(hourlyTimestamps))
 	for _, hourlyTimestamp := range hourlyTimestamps {
 		timestamp := int64(hourlyTimestamp)
-		averageResponseTime := hourlyAverageResponseTime[timestamp]
 		timestamps = append(timestamps, timestamp*1000)
-		values = append(values, averageResponseTime)
+		values = append(values, hourlyResponseTime[timestamp])
 	}
 	return c.Status(http.StatusOK).JSON(map[string]interface{}{
 		"timestamps": timestamps,
@@ -64,6 +64,16 @@ func TestResponseTimeChart(t *testing.T) {
 			Path:         "/api/v1/endpoints/invalid_key/response-times/7d/chart.svg",
 			ExpectedCode: http.StatusNotFound,
 		},
+		{
+			Name:         "chart-response-time-p90",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/24h/chart.svg?percentile=p90",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "chart-response-time-with-invalid-percentile",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/24h/chart.svg?percentile=p101",
+			ExpectedCode: http.StatusBadRequest,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
@@ -133,6 +143,16 @@ func TestResponseTimeHistory(t *testing.T) {
 			Path:         "/api/v1/endpoints/invalid_key/response-times/7d/history",
 			ExpectedCode: http.StatusNotFound,
 		},
+		{
+			Name:         "history-response-time-p50",
+			Path:         "/api/v1/endpoints/core_backend/response-times/24h/history?percentile=p50",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "history-response-time-with-invalid-percentile",
+			Path:         "/api/v1/endpoints/core_backend/response-times/24h/history?percentile=median",
+			ExpectedCode: http.StatusBadRequest,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
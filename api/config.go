@@ -3,9 +3,12 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/incident"
 	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/logr"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -33,6 +36,42 @@ func (handler ConfigHandler) GetConfig(c *fiber.Ctx) error {
 	} else {
 		response["announcements"] = []interface{}{}
 	}
+	// Incidents and maintenance windows are only added if the request is authorized to see them, since this route isn't
+	// behind the security middleware
+	principal, authorized := (*security.Principal)(nil), true
+	if handler.securityConfig != nil {
+		principal, authorized = handler.securityConfig.ResolvePrincipal(c)
+	}
+	// Add incidents that are unresolved or that have been resolved recently
+	response["incidents"] = []*incident.Incident{}
+	if authorized {
+		incidents, err := getVisibleIncidents()
+		if err != nil {
+			logr.Errorf("[api.GetConfig] Failed to retrieve incidents: %s", err.Error())
+			incidents = []*incident.Incident{}
+		}
+		if !principal.CanSeeAllGroups() {
+			incidents = slices.DeleteFunc(incidents, func(inc *incident.Incident) bool {
+				return !canPrincipalAccessGroups(principal, getGroupsOfKeys(handler.config, inc.EndpointKeys, inc.SuiteKeys))
+			})
+		}
+		response["incidents"] = incidents
+	}
+	// Add maintenance windows that are ongoing or that are starting soon
+	response["maintenanceWindows"] = []*VisibleMaintenanceWindow{}
+	if handler.config != nil && authorized {
+		maintenanceWindows, err := getVisibleMaintenanceWindows(handler.config)
+		if err != nil {
+			logr.Errorf("[api.GetConfig] Failed to retrieve maintenance windows: %s", err.Error())
+			maintenanceWindows = []*VisibleMaintenanceWindow{}
+		}
+		if !principal.CanSeeAllGroups() {
+			maintenanceWindows = slices.DeleteFunc(maintenanceWindows, func(window *VisibleMaintenanceWindow) bool {
+				return !canPrincipalAccessGroups(principal, append(getGroupsOfKeys(handler.config, window.EndpointKeys, window.SuiteKeys), window.Groups...))
+			})
+		}
+		response["maintenanceWindows"] = maintenanceWindows
+	}
 
 	// Return the config as JSON
 	c.Set("Content-Type", "application/json")
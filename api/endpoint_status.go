@@ -10,6 +10,7 @@ import (
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/remote"
+	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
@@ -23,28 +24,43 @@ func EndpointStatuses(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		page, pageSize := extractPageAndPageSizeFromRequest(c, cfg.Storage.MaximumNumberOfResults)
 		value, exists := cache.Get(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize))
-		var data []byte
+		var endpointStatuses []*endpoint.Status
 		if !exists {
-			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
+			var err error
+			endpointStatuses, err = store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
 			if err != nil {
 				logr.Errorf("[api.EndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
 				return c.Status(500).SendString(err.Error())
 			}
+			if endpointStatuses, err = populateEndpointStatusesMutes(endpointStatuses); err != nil {
+				logr.Errorf("[api.EndpointStatuses] Failed to retrieve silences and acknowledgements: %s", err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
 			// ALPHA: Retrieve endpoint statuses from remote instances
 			if endpointStatusesFromRemote, err := getEndpointStatusesFromRemoteInstances(cfg.Remote); err != nil {
 				logr.Errorf("[handler.EndpointStatuses] Silently failed to retrieve endpoint statuses from remote: %s", err.Error())
 			} else if endpointStatusesFromRemote != nil {
 				endpointStatuses = append(endpointStatuses, endpointStatusesFromRemote...)
 			}
-			// Marshal endpoint statuses to JSON
-			data, err = json.Marshal(endpointStatuses)
-			if err != nil {
-				logr.Errorf("[api.EndpointStatuses] Unable to marshal object to JSON: %s", err.Error())
-				return c.Status(500).SendString("unable to marshal object to JSON")
-			}
-			cache.SetWithTTL(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize), data, cacheTTL)
+			cache.SetWithTTL(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize), endpointStatuses, cacheTTL)
 		} else {
-			data = value.([]byte)
+			endpointStatuses = value.([]*endpoint.Status)
+		}
+		// Only keep the endpoint statuses of the groups the requester can see
+		if principal := security.GetPrincipal(c); !principal.CanSeeAllGroups() {
+			visibleEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
+			for _, endpointStatus := range endpointStatuses {
+				if principal.CanSeeGroup(endpointStatus.Group) {
+					visibleEndpointStatuses = append(visibleEndpointStatuses, endpointStatus)
+				}
+			}
+			endpointStatuses = visibleEndpointStatuses
+		}
+		// Marshal endpoint statuses to JSON
+		data, err := json.Marshal(endpointStatuses)
+		if err != nil {
+			logr.Errorf("[api.EndpointStatuses] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
 		}
 		c.Set("Content-Type", "application/json")
 		return c.Status(200).Send(data)
@@ -104,7 +120,15 @@ func EndpointStatus(cfg *config.Config) fiber.Handler {
 			logr.Errorf("[api.EndpointStatus] Endpoint with key=%s not found", key)
 			return c.Status(404).SendString("not found")
 		}
-		output, err := json.Marshal(endpointStatus)
+		if !security.GetPrincipal(c).CanSeeGroup(endpointStatus.Group) {
+			return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+		}
+		endpointStatuses, err := populateEndpointStatusesMutes([]*endpoint.Status{endpointStatus})
+		if err != nil {
+			logr.Errorf("[api.EndpointStatus] Failed to retrieve silences and acknowledgements: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		output, err := json.Marshal(endpointStatuses[0])
 		if err != nil {
 			logr.Errorf("[api.EndpointStatus] Unable to marshal object to JSON: %s", err.Error())
 			return c.Status(500).SendString("unable to marshal object to JSON")
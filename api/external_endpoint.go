@@ -8,6 +8,7 @@ import (
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/watchdog"
@@ -38,7 +39,7 @@ func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 			logr.Errorf("[api.CreateExternalEndpointResult] External endpoint with key=%s not found", key)
 			return c.Status(404).SendString("not found")
 		}
-		if externalEndpoint.Token != token {
+		if externalEndpoint.Token != token && (cfg.Security == nil || !cfg.Security.AuthorizeAPIToken(token, security.APITokenScopePushExternalResults, externalEndpoint.Group)) {
 			logr.Errorf("[api.CreateExternalEndpointResult] Invalid token for external endpoint with key=%s", key)
 			return c.Status(401).SendString("invalid token")
 		}
@@ -76,10 +77,8 @@ func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 			}
 		}
 		// Check if an alert should be triggered or resolved
-		if !cfg.Maintenance.IsUnderMaintenance() && !inEndpointMaintenanceWindow {
-			watchdog.HandleAlerting(convertedEndpoint, result, cfg.Alerting)
-			externalEndpoint.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
-			externalEndpoint.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
+		if !cfg.Maintenance.IsUnderMaintenance() && !inEndpointMaintenanceWindow && !watchdog.IsUnderRuntimeMaintenance(externalEndpoint.Key(), externalEndpoint.Group, "") {
+			watchdog.HandleExternalEndpointAlerting(externalEndpoint, result, cfg.Alerting)
 		} else {
 			logr.Debug("[api.CreateExternalEndpointResult] Not handling alerting because currently in the maintenance window")
 		}
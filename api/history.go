@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// DefaultHistoryLimit is the default number of results or events returned per request by the history endpoints
+	DefaultHistoryLimit = 1000
+
+	// MaximumHistoryLimit is the maximum number of results or events that can be returned per request by the history
+	// endpoints
+	MaximumHistoryLimit = 10000
+
+	// NextCursorHeader is the header containing the cursor to pass to retrieve the next page of results or events.
+	// It is omitted if there's no next page.
+	NextCursorHeader = "X-Next-Cursor"
+
+	historyFormatJSONLines = "jsonl"
+	historyFormatCSV       = "csv"
+)
+
+var resultsCSVHeader = []string{"timestamp", "success", "status", "hostname", "durationMs", "errors", "conditionResults", "suppressedBy"}
+
+// EndpointResults handles requests to export the stored results of an endpoint, from the oldest to the most recent,
+// as JSON Lines or CSV
+func EndpointResults(c *fiber.Ctx) error {
+	key, params, format, err := extractEndpointHistoryParametersFromRequest(c)
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	if err = checkEndpointVisibility(c, key); err != nil {
+		return sendEndpointHistoryError(c, key, err)
+	}
+	results, nextCursor, err := store.Get().GetEndpointResultsByKey(key, params)
+	if err != nil {
+		return sendEndpointHistoryError(c, key, err)
+	}
+	if format == historyFormatCSV {
+		return streamCSV(c, nextCursor, resultsCSVHeader, len(results), func(i int) []string {
+			result := results[i]
+			conditionResults, _ := json.Marshal(result.ConditionResults)
+			return []string{
+				result.Timestamp.Format(time.RFC3339Nano),
+				strconv.FormatBool(result.Success),
+				strconv.Itoa(result.HTTPStatus),
+				result.Hostname,
+				strconv.FormatInt(result.Duration.Milliseconds(), 10),
+				strings.Join(result.Errors, "\n"),
+				string(conditionResults),
+				result.SuppressedBy,
+			}
+		})
+	}
+	return streamJSONLines(c, nextCursor, len(results), func(i int) any { return results[i] })
+}
+
+// EndpointEvents handles requests to export the stored events of an endpoint, from the oldest to the most recent,
+// as JSON Lines or CSV
+func EndpointEvents(c *fiber.Ctx) error {
+	key, params, format, err := extractEndpointHistoryParametersFromRequest(c)
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	if params.Success != nil {
+		return c.Status(400).SendString("success is not supported for events")
+	}
+	if err = checkEndpointVisibility(c, key); err != nil {
+		return sendEndpointHistoryError(c, key, err)
+	}
+	events, nextCursor, err := store.Get().GetEndpointEventsByKey(key, params)
+	if err != nil {
+		return sendEndpointHistoryError(c, key, err)
+	}
+	if format == historyFormatCSV {
+		return streamCSV(c, nextCursor, []string{"timestamp", "type"}, len(events), func(i int) []string {
+			return []string{events[i].Timestamp.Format(time.RFC3339Nano), string(events[i].Type)}
+		})
+	}
+	return streamJSONLines(c, nextCursor, len(events), func(i int) any { return events[i] })
+}
+
+// extractEndpointHistoryParametersFromRequest extracts the endpoint key, the paging parameters and the format from a
+// request to one of the history endpoints
+func extractEndpointHistoryParametersFromRequest(c *fiber.Ctx) (key string, params *paging.EndpointHistoryParams, format string, err error) {
+	if key, err = url.QueryUnescape(c.Params("key")); err != nil {
+		return "", nil, "", errors.New("invalid key encoding")
+	}
+	limit := DefaultHistoryLimit
+	if limitParameter := c.Query("limit"); len(limitParameter) != 0 {
+		if limit, err = strconv.Atoi(limitParameter); err != nil || limit < 1 || limit > MaximumHistoryLimit {
+			return "", nil, "", errors.New("limit must be between 1 and " + strconv.Itoa(MaximumHistoryLimit))
+		}
+	}
+	params = paging.NewEndpointHistoryParams(limit).WithCursor(c.Query("cursor"))
+	var from, to time.Time
+	if fromParameter := c.Query("from"); len(fromParameter) != 0 {
+		if from, err = time.Parse(time.RFC3339, fromParameter); err != nil {
+			return "", nil, "", errors.New("from must be in RFC3339 format")
+		}
+	}
+	if toParameter := c.Query("to"); len(toParameter) != 0 {
+		if to, err = time.Parse(time.RFC3339, toParameter); err != nil {
+			return "", nil, "", errors.New("to must be in RFC3339 format")
+		}
+	}
+	params.WithTimeRange(from, to)
+	if successParameter := c.Query("success"); len(successParameter) != 0 {
+		success, err := strconv.ParseBool(successParameter)
+		if err != nil {
+			return "", nil, "", errors.New("success must be either true or false")
+		}
+		params.WithSuccess(success)
+	}
+	switch format = c.Query("format"); format {
+	case historyFormatJSONLines, historyFormatCSV:
+	case "":
+		format = historyFormatJSONLines
+		if strings.Contains(c.Get("Accept"), "text/csv") {
+			format = historyFormatCSV
+		}
+	default:
+		return "", nil, "", errors.New("formats supported: " + historyFormatJSONLines + ", " + historyFormatCSV)
+	}
+	return key, params, format, nil
+}
+
+func sendEndpointHistoryError(c *fiber.Ctx, key string, err error) error {
+	if errors.Is(err, common.ErrEndpointNotFound) {
+		return c.Status(404).SendString(err.Error())
+	} else if errors.Is(err, common.ErrInvalidTimeRange) || errors.Is(err, common.ErrInvalidCursor) {
+		return c.Status(400).SendString(err.Error())
+	}
+	logr.Errorf("[api.sendEndpointHistoryError] Failed to retrieve history of endpoint with key=%s: %s", key, err.Error())
+	return c.Status(500).SendString(err.Error())
+}
+
+// streamJSONLines streams n entries as JSON Lines, one JSON object per line
+func streamJSONLines(c *fiber.Ctx, nextCursor string, n int, entryAt func(i int) any) error {
+	setEndpointHistoryHeaders(c, "application/x-ndjson", nextCursor)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		encoder := json.NewEncoder(w)
+		for i := 0; i < n; i++ {
+			if err := encoder.Encode(entryAt(i)); err != nil {
+				logr.Errorf("[api.streamJSONLines] Failed to stream entry: %s", err.Error())
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// streamCSV streams n entries as CSV, preceded by a header row
+func streamCSV(c *fiber.Ctx, nextCursor string, header []string, n int, recordAt func(i int) []string) error {
+	setEndpointHistoryHeaders(c, "text/csv", nextCursor)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writer := csv.NewWriter(w)
+		_ = writer.Write(header)
+		for i := 0; i < n; i++ {
+			if err := writer.Write(recordAt(i)); err != nil {
+				logr.Errorf("[api.streamCSV] Failed to stream entry: %s", err.Error())
+				return
+			}
+		}
+		writer.Flush()
+	})
+	return nil
+}
+
+func setEndpointHistoryHeaders(c *fiber.Ctx, contentType, nextCursor string) {
+	c.Set("Content-Type", contentType)
+	c.Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if len(nextCursor) != 0 {
+		c.Set(NextCursorHeader, nextCursor)
+	}
+	c.Status(200)
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestEndpointResultsAndEvents(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	baseTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		result := testSuccessfulResult
+		if i == 1 {
+			result = testUnsuccessfulResult
+		}
+		result.Timestamp = baseTime.Add(time.Duration(i) * time.Minute)
+		if err := store.Get().InsertEndpointResult(&testEndpoint, &result); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	router := New(&config.Config{
+		Metrics: true,
+		Storage: &storage.Config{
+			MaximumNumberOfResults: storage.DefaultMaximumNumberOfResults,
+			MaximumNumberOfEvents:  storage.DefaultMaximumNumberOfEvents,
+		},
+	}).Router()
+	type Scenario struct {
+		Name                string
+		Path                string
+		Accept              string
+		ExpectedCode        int
+		ExpectedContentType string
+		ExpectedNumberLines int
+		ExpectedBodyPrefix  string
+		ExpectedNextCursor  bool
+	}
+	scenarios := []Scenario{
+		{
+			Name:                "results",
+			Path:                "/api/v1/endpoints/group_name/results",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "application/x-ndjson",
+			ExpectedNumberLines: 3,
+			ExpectedBodyPrefix:  `{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true}`,
+		},
+		{
+			Name:                "results-with-limit",
+			Path:                "/api/v1/endpoints/group_name/results?limit=2",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "application/x-ndjson",
+			ExpectedNumberLines: 2,
+			ExpectedNextCursor:  true,
+		},
+		{
+			Name:                "results-unsuccessful",
+			Path:                "/api/v1/endpoints/group_name/results?success=false",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "application/x-ndjson",
+			ExpectedNumberLines: 1,
+			ExpectedBodyPrefix:  `{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"]`,
+		},
+		{
+			Name:                "results-within-time-range",
+			Path:                "/api/v1/endpoints/group_name/results?from=2025-01-01T00:00:30Z&to=2025-01-01T00:05:00Z",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "application/x-ndjson",
+			ExpectedNumberLines: 2,
+		},
+		{
+			Name:                "results-as-csv",
+			Path:                "/api/v1/endpoints/group_name/results?format=csv&success=true",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "text/csv",
+			ExpectedNumberLines: 3,
+			ExpectedBodyPrefix:  "timestamp,success,status,hostname,durationMs,errors,conditionResults,suppressedBy\n2025-01-01T00:00:00Z,true,200,example.org,150,,",
+		},
+		{
+			Name:                "results-as-csv-through-accept-header",
+			Path:                "/api/v1/endpoints/group_name/results",
+			Accept:              "text/csv",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "text/csv",
+		},
+		{
+			Name:         "results-with-invalid-format",
+			Path:         "/api/v1/endpoints/group_name/results?format=xml",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "results-with-invalid-limit",
+			Path:         "/api/v1/endpoints/group_name/results?limit=0",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "results-with-invalid-from",
+			Path:         "/api/v1/endpoints/group_name/results?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "results-with-invalid-time-range",
+			Path:         "/api/v1/endpoints/group_name/results?from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "results-with-invalid-cursor",
+			Path:         "/api/v1/endpoints/group_name/results?cursor=invalid",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "results-for-invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/results",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:                "events",
+			Path:                "/api/v1/endpoints/group_name/events",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "application/x-ndjson",
+			ExpectedNumberLines: 4,
+			ExpectedBodyPrefix:  `{"type":"START",`,
+		},
+		{
+			Name:                "events-as-csv",
+			Path:                "/api/v1/endpoints/group_name/events?format=csv&from=2025-01-01T00:01:00Z&to=2025-01-01T00:01:00Z",
+			ExpectedCode:        http.StatusOK,
+			ExpectedContentType: "text/csv",
+			ExpectedNumberLines: 2,
+			ExpectedBodyPrefix:  "timestamp,type\n2025-01-01T00:01:00Z,UNHEALTHY\n",
+		},
+		{
+			Name:         "events-with-success",
+			Path:         "/api/v1/endpoints/group_name/events?success=true",
+			ExpectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			if len(scenario.Accept) != 0 {
+				request.Header.Set("Accept", scenario.Accept)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			if contentType := response.Header.Get("Content-Type"); contentType != scenario.ExpectedContentType {
+				t.Errorf("expected Content-Type %s, got %s", scenario.ExpectedContentType, contentType)
+			}
+			if hasNextCursor := len(response.Header.Get(NextCursorHeader)) != 0; hasNextCursor != scenario.ExpectedNextCursor {
+				t.Errorf("expected next cursor to be present=%v, got %v", scenario.ExpectedNextCursor, hasNextCursor)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if scenario.ExpectedNumberLines != 0 && strings.Count(string(body), "\n") != scenario.ExpectedNumberLines {
+				t.Errorf("expected %d lines, got body:\n%s", scenario.ExpectedNumberLines, body)
+			}
+			if !strings.HasPrefix(string(body), scenario.ExpectedBodyPrefix) {
+				t.Errorf("expected body to start with:\n%s\ngot:\n%s", scenario.ExpectedBodyPrefix, body)
+			}
+		})
+	}
+}
+
+func TestEndpointResults_FollowingNextCursor(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	for i := 0; i < 5; i++ {
+		result := testSuccessfulResult
+		result.Timestamp = time.Now().Add(time.Duration(i-5) * time.Minute)
+		if err := store.Get().InsertEndpointResult(&testEndpoint, &result); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	router := New(&config.Config{Metrics: true}).Router()
+	var numberOfResults, numberOfRequests int
+	path := "/api/v1/endpoints/group_name/results?limit=2"
+	for {
+		response, err := router.Test(httptest.NewRequest("GET", path, http.NoBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		numberOfResults += strings.Count(string(body), "\n")
+		numberOfRequests++
+		nextCursor := response.Header.Get(NextCursorHeader)
+		if len(nextCursor) == 0 || numberOfRequests > 5 {
+			break
+		}
+		path = "/api/v1/endpoints/group_name/results?limit=2&cursor=" + nextCursor
+	}
+	if numberOfResults != 5 || numberOfRequests != 3 {
+		t.Errorf("expected 5 results over 3 requests, got %d results over %d requests", numberOfResults, numberOfRequests)
+	}
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/incident"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// resolvedIncidentVisibilityDuration is how long a resolved incident keeps being returned by /api/v1/config
+	resolvedIncidentVisibilityDuration = 7 * 24 * time.Hour
+)
+
+// CreateIncidentRequest is the body of a request to create an incident
+type CreateIncidentRequest struct {
+	Title        string            `json:"title"`
+	Severity     incident.Severity `json:"severity"`
+	EndpointKeys []string          `json:"endpointKeys"`
+	SuiteKeys    []string          `json:"suiteKeys"`
+	Status       incident.Status   `json:"status"` // Defaults to incident.StatusInvestigating
+	Message      string            `json:"message"`
+}
+
+// UpdateIncidentRequest is the body of a request to update an incident.
+// Every field is optional, but if Status is set, Message must be set as well, and vice versa.
+type UpdateIncidentRequest struct {
+	Title        *string            `json:"title"`
+	Severity     *incident.Severity `json:"severity"`
+	EndpointKeys *[]string          `json:"endpointKeys"`
+	SuiteKeys    *[]string          `json:"suiteKeys"`
+	Status       incident.Status    `json:"status"`
+	Message      string             `json:"message"`
+}
+
+// ResolveIncidentRequest is the body of a request to resolve an incident
+type ResolveIncidentRequest struct {
+	Message string `json:"message"` // Defaults to incident.DefaultResolveMessage
+}
+
+// Incidents handles requests to retrieve all incidents that apply exclusively to groups the requester can see
+func Incidents(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		incidents, err := store.Get().GetAllIncidents()
+		if err != nil {
+			logr.Errorf("[api.Incidents] Failed to retrieve incidents: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		accessibleIncidents := make([]*incident.Incident, 0, len(incidents))
+		for _, inc := range incidents {
+			if canAccessGroups(c, getGroupsOfKeys(cfg, inc.EndpointKeys, inc.SuiteKeys)) {
+				accessibleIncidents = append(accessibleIncidents, inc)
+			}
+		}
+		return sendJSON(c, 200, accessibleIncidents)
+	}
+}
+
+// Incident handles requests to retrieve a single incident that applies exclusively to groups the requester can see
+func Incident(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		inc, statusCode, err := getIncidentFromRequest(c)
+		if err != nil {
+			return c.Status(statusCode).SendString(err.Error())
+		}
+		if !canAccessGroups(c, getGroupsOfKeys(cfg, inc.EndpointKeys, inc.SuiteKeys)) {
+			// The existence of incidents applying to endpoints or suites the principal cannot see isn't disclosed
+			return c.Status(404).SendString(common.ErrIncidentNotFound.Error())
+		}
+		return sendJSON(c, 200, inc)
+	}
+}
+
+// CreateIncident handles requests to create an incident
+func CreateIncident(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request CreateIncidentRequest
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(400).SendString("invalid request body: " + err.Error())
+		}
+		inc, err := incident.NewIncident(request.Title, request.Severity, request.Status, request.Message)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		if err = validateIncidentKeys(cfg, request.EndpointKeys, request.SuiteKeys); err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		if !canAccessGroups(c, getGroupsOfKeys(cfg, request.EndpointKeys, request.SuiteKeys)) {
+			return c.Status(403).SendString("incident must be restricted to endpoints and suites you can see")
+		}
+		inc.EndpointKeys = request.EndpointKeys
+		inc.SuiteKeys = request.SuiteKeys
+		if err = store.Get().InsertIncident(inc); err != nil {
+			logr.Errorf("[api.CreateIncident] Failed to insert incident: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		logr.Infof("[api.CreateIncident] Created incident with id=%d", inc.ID)
+		return sendJSON(c, 201, inc)
+	}
+}
+
+// UpdateIncident handles requests to update an incident and/or add an update to its timeline
+func UpdateIncident(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		inc, statusCode, err := getIncidentFromRequest(c)
+		if err != nil {
+			return c.Status(statusCode).SendString(err.Error())
+		}
+		var request UpdateIncidentRequest
+		if err = json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(400).SendString("invalid request body: " + err.Error())
+		}
+		if !canAccessGroups(c, getGroupsOfKeys(cfg, inc.EndpointKeys, inc.SuiteKeys)) {
+			return c.Status(403).SendString("incident applies to endpoints or suites you cannot see")
+		}
+		if inc.IsResolved() {
+			return c.Status(409).SendString(incident.ErrAlreadyResolved.Error())
+		}
+		if request.Title != nil {
+			inc.Title = *request.Title
+		}
+		if request.Severity != nil {
+			inc.Severity = *request.Severity
+		}
+		if request.EndpointKeys != nil {
+			inc.EndpointKeys = *request.EndpointKeys
+		}
+		if request.SuiteKeys != nil {
+			inc.SuiteKeys = *request.SuiteKeys
+		}
+		if len(request.Status) > 0 || len(request.Message) > 0 {
+			if err = inc.AddUpdate(request.Status, request.Message); err != nil {
+				return c.Status(400).SendString(err.Error())
+			}
+		} else {
+			inc.UpdatedAt = time.Now()
+		}
+		if err = inc.Validate(); err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		if err = validateIncidentKeys(cfg, inc.EndpointKeys, inc.SuiteKeys); err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		if !canAccessGroups(c, getGroupsOfKeys(cfg, inc.EndpointKeys, inc.SuiteKeys)) {
+			return c.Status(403).SendString("incident must be restricted to endpoints and suites you can see")
+		}
+		return persistIncidentUpdate(c, inc)
+	}
+}
+
+// ResolveIncident handles requests to resolve an incident
+func ResolveIncident(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		inc, statusCode, err := getIncidentFromRequest(c)
+		if err != nil {
+			return c.Status(statusCode).SendString(err.Error())
+		}
+		if !canAccessGroups(c, getGroupsOfKeys(cfg, inc.EndpointKeys, inc.SuiteKeys)) {
+			return c.Status(403).SendString("incident applies to endpoints or suites you cannot see")
+		}
+		var request ResolveIncidentRequest
+		if len(c.Body()) > 0 {
+			if err = json.Unmarshal(c.Body(), &request); err != nil {
+				return c.Status(400).SendString("invalid request body: " + err.Error())
+			}
+		}
+		if err = inc.Resolve(request.Message); err != nil {
+			if errors.Is(err, incident.ErrAlreadyResolved) {
+				return c.Status(409).SendString(err.Error())
+			}
+			return c.Status(400).SendString(err.Error())
+		}
+		return persistIncidentUpdate(c, inc)
+	}
+}
+
+// getIncidentFromRequest retrieves the incident matching the id route parameter.
+// If the incident cannot be retrieved, the status code to respond with is returned alongside the error.
+func getIncidentFromRequest(c *fiber.Ctx) (*incident.Incident, int, error) {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return nil, 400, errors.New("invalid incident id")
+	}
+	inc, err := store.Get().GetIncidentByID(id)
+	if err != nil {
+		if errors.Is(err, common.ErrIncidentNotFound) {
+			return nil, 404, err
+		}
+		logr.Errorf("[api.getIncidentFromRequest] Failed to retrieve incident with id=%d: %s", id, err.Error())
+		return nil, 500, err
+	}
+	return inc, 200, nil
+}
+
+func persistIncidentUpdate(c *fiber.Ctx, inc *incident.Incident) error {
+	if err := store.Get().UpdateIncident(inc); err != nil {
+		if errors.Is(err, common.ErrIncidentNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		logr.Errorf("[api.persistIncidentUpdate] Failed to update incident with id=%d: %s", inc.ID, err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	logr.Infof("[api.persistIncidentUpdate] Updated incident with id=%d to status=%s", inc.ID, inc.Status)
+	return sendJSON(c, 200, inc)
+}
+
+// validateIncidentKeys makes sure that every key references a configured endpoint, external endpoint or suite
+func validateIncidentKeys(cfg *config.Config, endpointKeys, suiteKeys []string) error {
+	for _, key := range endpointKeys {
+		if cfg.GetEndpointByKey(key) == nil && cfg.GetExternalEndpointByKey(key) == nil {
+			return fmt.Errorf("endpoint with key=%s not found", key)
+		}
+	}
+	for _, key := range suiteKeys {
+		found := false
+		for _, s := range cfg.Suites {
+			if s.Key() == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("suite with key=%s not found", key)
+		}
+	}
+	return nil
+}
+
+// getVisibleIncidents returns the incidents that should be displayed on the status page, which are the unresolved
+// incidents as well as the incidents that have been resolved recently
+func getVisibleIncidents() ([]*incident.Incident, error) {
+	incidents, err := store.Get().GetAllIncidents()
+	if err != nil {
+		return nil, err
+	}
+	visibleIncidents := make([]*incident.Incident, 0, len(incidents))
+	for _, inc := range incidents {
+		if !inc.IsResolved() || time.Since(inc.UpdatedAt) < resolvedIncidentVisibilityDuration {
+			visibleIncidents = append(visibleIncidents, inc)
+		}
+	}
+	return visibleIncidents, nil
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/incident"
+	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestIncidents(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core"},
+		},
+		Suites: []*suite.Suite{
+			{Name: "checkout", Group: "flows"},
+		},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name           string
+		Method         string
+		Path           string
+		Body           string
+		Authenticated  bool
+		ExpectedCode   int
+		ExpectedStatus incident.Status
+	}{
+		{
+			Name:         "create-unauthenticated",
+			Method:       "POST",
+			Path:         "/api/v1/incidents",
+			Body:         `{"title":"Frontend is down","severity":"major","endpointKeys":["core_frontend"],"message":"We are investigating"}`,
+			ExpectedCode: 401,
+		},
+		{
+			Name:          "create-with-invalid-severity",
+			Method:        "POST",
+			Path:          "/api/v1/incidents",
+			Body:          `{"title":"Frontend is down","severity":"catastrophic","message":"We are investigating"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-with-unknown-endpoint-key",
+			Method:        "POST",
+			Path:          "/api/v1/incidents",
+			Body:          `{"title":"Frontend is down","severity":"major","endpointKeys":["core_unknown"],"message":"We are investigating"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-with-unknown-suite-key",
+			Method:        "POST",
+			Path:          "/api/v1/incidents",
+			Body:          `{"title":"Checkout is down","severity":"major","suiteKeys":["flows_unknown"],"message":"We are investigating"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:           "create",
+			Method:         "POST",
+			Path:           "/api/v1/incidents",
+			Body:           `{"title":"Frontend is down","severity":"major","endpointKeys":["core_frontend"],"suiteKeys":["flows_checkout"],"message":"We are investigating"}`,
+			Authenticated:  true,
+			ExpectedCode:   201,
+			ExpectedStatus: incident.StatusInvestigating,
+		},
+		{
+			Name:          "get-unauthenticated",
+			Method:        "GET",
+			Path:          "/api/v1/incidents/1",
+			ExpectedCode:  401,
+			Authenticated: false,
+		},
+		{
+			Name:           "get",
+			Method:         "GET",
+			Path:           "/api/v1/incidents/1",
+			Authenticated:  true,
+			ExpectedCode:   200,
+			ExpectedStatus: incident.StatusInvestigating,
+		},
+		{
+			Name:          "get-with-invalid-id",
+			Method:        "GET",
+			Path:          "/api/v1/incidents/invalid",
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "get-nonexistent",
+			Method:        "GET",
+			Path:          "/api/v1/incidents/999",
+			Authenticated: true,
+			ExpectedCode:  404,
+		},
+		{
+			Name:          "update-with-status-but-no-message",
+			Method:        "PUT",
+			Path:          "/api/v1/incidents/1",
+			Body:          `{"status":"identified"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:           "update",
+			Method:         "PUT",
+			Path:           "/api/v1/incidents/1",
+			Body:           `{"severity":"critical","endpointKeys":["core_frontend","core_backend"],"status":"identified","message":"The database is unreachable"}`,
+			Authenticated:  true,
+			ExpectedCode:   200,
+			ExpectedStatus: incident.StatusIdentified,
+		},
+		{
+			Name:          "update-nonexistent",
+			Method:        "PUT",
+			Path:          "/api/v1/incidents/999",
+			Body:          `{"status":"identified","message":"The database is unreachable"}`,
+			Authenticated: true,
+			ExpectedCode:  404,
+		},
+		{
+			Name:           "resolve",
+			Method:         "POST",
+			Path:           "/api/v1/incidents/1/resolve",
+			Authenticated:  true,
+			ExpectedCode:   200,
+			ExpectedStatus: incident.StatusResolved,
+		},
+		{
+			Name:          "resolve-already-resolved",
+			Method:        "POST",
+			Path:          "/api/v1/incidents/1/resolve",
+			Body:          `{"message":"Resolved again"}`,
+			Authenticated: true,
+			ExpectedCode:  409,
+		},
+		{
+			Name:          "update-resolved",
+			Method:        "PUT",
+			Path:          "/api/v1/incidents/1",
+			Body:          `{"status":"monitoring","message":"Monitoring"}`,
+			Authenticated: true,
+			ExpectedCode:  409,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", scenario.Method, scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+			if len(scenario.ExpectedStatus) > 0 {
+				inc := &incident.Incident{}
+				if err = json.NewDecoder(response.Body).Decode(inc); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+				if inc.Status != scenario.ExpectedStatus {
+					t.Errorf("expected status to be %s, got %s", scenario.ExpectedStatus, inc.Status)
+				}
+			}
+		})
+	}
+	// Make sure that the resolved incident has the complete timeline
+	inc, err := store.Get().GetIncidentByID(1)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if inc.Severity != incident.SeverityCritical {
+		t.Errorf("expected severity to be %s, got %s", incident.SeverityCritical, inc.Severity)
+	}
+	if len(inc.EndpointKeys) != 2 || len(inc.SuiteKeys) != 1 {
+		t.Errorf("expected 2 endpoint keys and 1 suite key, got %v and %v", inc.EndpointKeys, inc.SuiteKeys)
+	}
+	if len(inc.Updates) != 3 {
+		t.Fatalf("expected 3 updates, got %d", len(inc.Updates))
+	}
+	if inc.Updates[2].Message != incident.DefaultResolveMessage {
+		t.Errorf("expected last update to have the default resolve message, got %s", inc.Updates[2].Message)
+	}
+}
+
+func TestIncidents_WithoutSecurity(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	api := New(&config.Config{})
+	router := api.Router()
+	request := httptest.NewRequest("POST", "/api/v1/incidents", strings.NewReader(`{"title":"Down","severity":"major","message":"Investigating"}`))
+	request.Header.Set("Content-Type", "application/json")
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == 201 {
+		t.Error("expected incidents to not be manageable without security configured")
+	}
+	// Reading incidents should still work
+	request = httptest.NewRequest("GET", "/api/v1/incidents", http.NoBody)
+	response, err = router.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != 200 {
+		t.Errorf("expected code to be 200, got %d", response.StatusCode)
+	}
+}
+
+func TestConfigHandler_GetConfigWithIncidents(t *testing.T) {
+	defer store.Get().Clear()
+	ongoingIncident, _ := incident.NewIncident("Ongoing", incident.SeverityMinor, "", "Investigating")
+	recentlyResolvedIncident, _ := incident.NewIncident("Recently resolved", incident.SeverityMajor, "", "Investigating")
+	_ = recentlyResolvedIncident.Resolve("")
+	oldIncident, _ := incident.NewIncident("Old", incident.SeverityCritical, "", "Investigating")
+	_ = oldIncident.Resolve("")
+	oldIncident.UpdatedAt = time.Now().Add(-(resolvedIncidentVisibilityDuration + time.Hour))
+	for _, inc := range []*incident.Incident{oldIncident, recentlyResolvedIncident, ongoingIncident} {
+		if err := store.Get().InsertIncident(inc); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	app := New(&config.Config{}).Router()
+	request := httptest.NewRequest("GET", "/api/v1/config", http.NoBody)
+	response, err := app.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	var output struct {
+		Incidents []*incident.Incident `json:"incidents"`
+	}
+	if err = json.Unmarshal(body, &output); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(output.Incidents) != 2 {
+		t.Fatalf("expected 2 incidents, got %d", len(output.Incidents))
+	}
+	if output.Incidents[0].Title != "Ongoing" || output.Incidents[1].Title != "Recently resolved" {
+		t.Errorf("expected the ongoing and recently resolved incidents, got %s and %s", output.Incidents[0].Title, output.Incidents[1].Title)
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// upcomingMaintenanceWindowVisibilityDuration is how long before it starts a maintenance window is returned by
+	// /api/v1/config
+	upcomingMaintenanceWindowVisibilityDuration = 7 * 24 * time.Hour
+)
+
+// CreateMaintenanceWindowRequest is the body of a request to create a maintenance window.
+// Either From and Until, or Cron and Duration must be set.
+type CreateMaintenanceWindowRequest struct {
+	Description  string   `json:"description"`
+	EndpointKeys []string `json:"endpointKeys"`
+	Groups       []string `json:"groups"`
+	SuiteKeys    []string `json:"suiteKeys"`
+	From         string   `json:"from"`      // e.g. 2025-01-31T22:00:00Z
+	Until        string   `json:"until"`     // e.g. 2025-02-01T02:00:00Z
+	Cron         string   `json:"cron"`      // e.g. 0 2 * * SAT
+	Duration     string   `json:"duration"`  // e.g. 2h
+	Timezone     string   `json:"timezone"`  // Defaults to UTC
+	CreatedBy    string   `json:"createdBy"` // Defaults to the username of the requester, if available
+}
+
+// VisibleMaintenanceWindow is an ongoing or upcoming occurrence of a maintenance window, as returned by /api/v1/config.
+// If it has no endpoint keys, groups or suite keys, it applies to every endpoint.
+type VisibleMaintenanceWindow struct {
+	ID           int64     `json:"id,omitempty"` // Only set for maintenance windows managed through the API
+	Description  string    `json:"description,omitempty"`
+	EndpointKeys []string  `json:"endpointKeys,omitempty"`
+	Groups       []string  `json:"groups,omitempty"`
+	SuiteKeys    []string  `json:"suiteKeys,omitempty"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Active       bool      `json:"active"`
+}
+
+// MaintenanceWindows handles requests to retrieve all maintenance windows managed through the API that haven't
+// expired yet and that apply exclusively to groups the requester can see
+func MaintenanceWindows(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		windows, err := store.Get().GetAllMaintenanceWindows()
+		if err != nil {
+			logr.Errorf("[api.MaintenanceWindows] Failed to retrieve maintenance windows: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		accessibleWindows := make([]*maintenance.Window, 0, len(windows))
+		for _, window := range windows {
+			if canAccessGroups(c, append(getGroupsOfKeys(cfg, window.EndpointKeys, window.SuiteKeys), window.Groups...)) {
+				accessibleWindows = append(accessibleWindows, window)
+			}
+		}
+		return sendJSON(c, 200, accessibleWindows)
+	}
+}
+
+// CreateMaintenanceWindow handles requests to create a maintenance window
+func CreateMaintenanceWindow(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request CreateMaintenanceWindowRequest
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(400).SendString("invalid request body: " + err.Error())
+		}
+		if err := validateIncidentKeys(cfg, request.EndpointKeys, request.SuiteKeys); err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		if !canAccessGroups(c, append(getGroupsOfKeys(cfg, request.EndpointKeys, request.SuiteKeys), request.Groups...)) {
+			return c.Status(403).SendString("maintenance window must be restricted to endpoints, groups and suites you can see")
+		}
+		if len(request.CreatedBy) == 0 {
+			request.CreatedBy = getRequesterUsername(c)
+		}
+		window := &maintenance.Window{
+			Description:  request.Description,
+			EndpointKeys: request.EndpointKeys,
+			Groups:       request.Groups,
+			SuiteKeys:    request.SuiteKeys,
+			From:         request.From,
+			Until:        request.Until,
+			Cron:         request.Cron,
+			Duration:     request.Duration,
+			Timezone:     request.Timezone,
+			CreatedBy:    request.CreatedBy,
+		}
+		if err := window.ValidateAndSetDefaults(); err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		if window.HasExpired() {
+			return c.Status(400).SendString("maintenance window has already ended")
+		}
+		if err := store.Get().InsertMaintenanceWindow(window); err != nil {
+			logr.Errorf("[api.CreateMaintenanceWindow] Failed to insert maintenance window: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		logr.Infof("[api.CreateMaintenanceWindow] Created maintenance window with id=%d", window.ID)
+		return sendJSON(c, 201, window)
+	}
+}
+
+// DeleteMaintenanceWindow handles requests to cancel a maintenance window
+func DeleteMaintenanceWindow(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			return c.Status(400).SendString("invalid maintenance window id")
+		}
+		if !security.GetPrincipal(c).CanSeeAllGroups() {
+			windows, err := store.Get().GetAllMaintenanceWindows()
+			if err != nil {
+				logr.Errorf("[api.DeleteMaintenanceWindow] Failed to retrieve maintenance windows: %s", err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+			for _, window := range windows {
+				if window.ID == id && !canAccessGroups(c, append(getGroupsOfKeys(cfg, window.EndpointKeys, window.SuiteKeys), window.Groups...)) {
+					return c.Status(403).SendString("maintenance window applies to endpoints, groups or suites you cannot see")
+				}
+			}
+		}
+		if err = store.Get().DeleteMaintenanceWindow(id); err != nil {
+			if errors.Is(err, common.ErrMaintenanceWindowNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			logr.Errorf("[api.DeleteMaintenanceWindow] Failed to delete maintenance window with id=%d: %s", id, err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		logr.Infof("[api.DeleteMaintenanceWindow] Deleted maintenance window with id=%d", id)
+		return c.SendStatus(204)
+	}
+}
+
+// getVisibleMaintenanceWindows returns the ongoing and upcoming maintenance windows that should be displayed on the
+// status page, whether they're defined in the configuration file or managed through the API, sorted by start time
+func getVisibleMaintenanceWindows(cfg *config.Config) ([]*VisibleMaintenanceWindow, error) {
+	now := time.Now()
+	visibleWindows := make([]*VisibleMaintenanceWindow, 0)
+	addIfVisible := func(nextWindow func(time.Time) (time.Time, time.Time, bool), visibleWindow *VisibleMaintenanceWindow) {
+		start, end, ok := nextWindow(now)
+		if !ok || start.Sub(now) > upcomingMaintenanceWindowVisibilityDuration {
+			return
+		}
+		visibleWindow.Start, visibleWindow.End, visibleWindow.Active = start, end, !now.Before(start)
+		visibleWindows = append(visibleWindows, visibleWindow)
+	}
+	if cfg.Maintenance != nil {
+		addIfVisible(cfg.Maintenance.NextWindow, &VisibleMaintenanceWindow{})
+	}
+	for _, ep := range cfg.Endpoints {
+		for _, maintenanceWindow := range ep.MaintenanceWindows {
+			addIfVisible(maintenanceWindow.NextWindow, &VisibleMaintenanceWindow{EndpointKeys: []string{ep.Key()}})
+		}
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		for _, maintenanceWindow := range ee.MaintenanceWindows {
+			addIfVisible(maintenanceWindow.NextWindow, &VisibleMaintenanceWindow{EndpointKeys: []string{ee.Key()}})
+		}
+	}
+	windows, err := store.Get().GetAllMaintenanceWindows()
+	if err != nil {
+		return nil, err
+	}
+	for _, window := range windows {
+		addIfVisible(window.NextWindow, &VisibleMaintenanceWindow{
+			ID:           window.ID,
+			Description:  window.Description,
+			EndpointKeys: window.EndpointKeys,
+			Groups:       window.Groups,
+			SuiteKeys:    window.SuiteKeys,
+		})
+	}
+	sort.SliceStable(visibleWindows, func(i, j int) bool {
+		return visibleWindows[i].Start.Before(visibleWindows[j].Start)
+	})
+	return visibleWindows, nil
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+		},
+		Suites: []*suite.Suite{
+			{Name: "checkout", Group: "flows"},
+		},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	api := New(cfg)
+	router := api.Router()
+	now := time.Now().UTC()
+	scenarios := []struct {
+		Name          string
+		Method        string
+		Path          string
+		Body          string
+		Authenticated bool
+		ExpectedCode  int
+	}{
+		{
+			Name:         "create-unauthenticated",
+			Method:       "POST",
+			Path:         "/api/v1/maintenance-windows",
+			Body:         `{"description":"Weekly backup","groups":["core"],"cron":"0 2 * * SAT","duration":"2h"}`,
+			ExpectedCode: 401,
+		},
+		{
+			Name:          "create-without-scope",
+			Method:        "POST",
+			Path:          "/api/v1/maintenance-windows",
+			Body:          `{"description":"Weekly backup","cron":"0 2 * * SAT","duration":"2h"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-with-unknown-endpoint-key",
+			Method:        "POST",
+			Path:          "/api/v1/maintenance-windows",
+			Body:          `{"description":"Weekly backup","endpointKeys":["core_unknown"],"cron":"0 2 * * SAT","duration":"2h"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-with-invalid-cron",
+			Method:        "POST",
+			Path:          "/api/v1/maintenance-windows",
+			Body:          `{"description":"Weekly backup","groups":["core"],"cron":"0 2 * *","duration":"2h"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-that-already-ended",
+			Method:        "POST",
+			Path:          "/api/v1/maintenance-windows",
+			Body:          `{"description":"Database migration","groups":["core"],"from":"` + now.Add(-2*time.Hour).Format(time.RFC3339) + `","until":"` + now.Add(-time.Hour).Format(time.RFC3339) + `"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-cron",
+			Method:        "POST",
+			Path:          "/api/v1/maintenance-windows",
+			Body:          `{"description":"Weekly backup","suiteKeys":["flows_checkout"],"cron":"0 2 * * SAT","duration":"2h"}`,
+			Authenticated: true,
+			ExpectedCode:  201,
+		},
+		{
+			Name:          "create-one-off",
+			Method:        "POST",
+			Path:          "/api/v1/maintenance-windows",
+			Body:          `{"description":"Database migration","endpointKeys":["core_frontend"],"from":"` + now.Add(-time.Hour).Format(time.RFC3339) + `","until":"` + now.Add(time.Hour).Format(time.RFC3339) + `"}`,
+			Authenticated: true,
+			ExpectedCode:  201,
+		},
+		{
+			Name:          "get",
+			Method:        "GET",
+			Path:          "/api/v1/maintenance-windows",
+			Authenticated: true,
+			ExpectedCode:  200,
+		},
+		{
+			Name:          "delete-with-invalid-id",
+			Method:        "DELETE",
+			Path:          "/api/v1/maintenance-windows/invalid",
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "delete-nonexistent",
+			Method:        "DELETE",
+			Path:          "/api/v1/maintenance-windows/999",
+			Authenticated: true,
+			ExpectedCode:  404,
+		},
+		{
+			Name:          "delete-cron",
+			Method:        "DELETE",
+			Path:          "/api/v1/maintenance-windows/1",
+			Authenticated: true,
+			ExpectedCode:  204,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", scenario.Method, scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	windows, err := store.Get().GetAllMaintenanceWindows()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(windows) != 1 {
+		t.Fatalf("expected 1 maintenance window, got %d", len(windows))
+	}
+	if windows[0].CreatedBy != "john.doe" || !windows[0].IsActive() {
+		t.Errorf("expected an active maintenance window created by john.doe, got createdBy=%s and active=%v", windows[0].CreatedBy, windows[0].IsActive())
+	}
+}
+
+func TestConfigHandler_GetConfigWithMaintenanceWindows(t *testing.T) {
+	defer store.Get().Clear()
+	now := time.Now().UTC()
+	activeWindow := &maintenance.Window{Description: "Database migration", Groups: []string{"core"}, From: now.Add(-time.Hour).Format(time.RFC3339), Until: now.Add(time.Hour).Format(time.RFC3339)}
+	upcomingWindow := &maintenance.Window{Description: "Network upgrade", Groups: []string{"core"}, From: now.Add(24 * time.Hour).Format(time.RFC3339), Until: now.Add(25 * time.Hour).Format(time.RFC3339)}
+	distantWindow := &maintenance.Window{Description: "Data center move", Groups: []string{"core"}, From: now.Add(30 * 24 * time.Hour).Format(time.RFC3339), Until: now.Add(31 * 24 * time.Hour).Format(time.RFC3339)}
+	for _, window := range []*maintenance.Window{distantWindow, upcomingWindow, activeWindow} {
+		if err := window.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if err := store.Get().InsertMaintenanceWindow(window); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	endpointMaintenance := &maintenance.Config{Start: "00:00", Duration: 24 * time.Hour}
+	if err := endpointMaintenance.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	cfg := &config.Config{
+		Maintenance: maintenance.GetDefaultConfig(),
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core", MaintenanceWindows: []*maintenance.Config{endpointMaintenance}},
+		},
+	}
+	router := New(cfg).Router()
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/config", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var body struct {
+		MaintenanceWindows []*VisibleMaintenanceWindow `json:"maintenanceWindows"`
+	}
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(body.MaintenanceWindows) != 3 {
+		t.Fatalf("expected the disabled global maintenance and the distant window to be excluded, got %d windows", len(body.MaintenanceWindows))
+	}
+	for _, window := range body.MaintenanceWindows {
+		if window.ID == upcomingWindow.ID && window.Active {
+			t.Error("expected the upcoming window to not be active")
+		} else if window.ID != upcomingWindow.ID && !window.Active {
+			t.Errorf("expected window with id=%d and endpoint keys %v to be active", window.ID, window.EndpointKeys)
+		}
+	}
+	if body.MaintenanceWindows[2].ID != upcomingWindow.ID {
+		t.Error("expected maintenance windows to be sorted by start time")
+	}
+}
@@ -15,6 +15,10 @@ func UptimeRaw(c *fiber.Ctx) error {
 	duration := c.Params("duration")
 	var from time.Time
 	switch duration {
+	case "1y":
+		from = time.Now().Add(-365 * 24 * time.Hour)
+	case "90d":
+		from = time.Now().Add(-90 * 24 * time.Hour)
 	case "30d":
 		from = time.Now().Add(-30 * 24 * time.Hour)
 	case "7d":
@@ -24,7 +28,7 @@ func UptimeRaw(c *fiber.Ctx) error {
 	case "1h":
 		from = time.Now().Add(-2 * time.Hour) // Because uptime metrics are stored by hour, we have to cheat a little
 	default:
-		return c.Status(400).SendString("Durations supported: 30d, 7d, 24h, 1h")
+		return c.Status(400).SendString("Durations supported: 1y, 90d, 30d, 7d, 24h, 1h")
 	}
 	key, err := url.QueryUnescape(c.Params("key"))
 	if err != nil {
@@ -50,6 +54,10 @@ func ResponseTimeRaw(c *fiber.Ctx) error {
 	duration := c.Params("duration")
 	var from time.Time
 	switch duration {
+	case "1y":
+		from = time.Now().Add(-365 * 24 * time.Hour)
+	case "90d":
+		from = time.Now().Add(-90 * 24 * time.Hour)
 	case "30d":
 		from = time.Now().Add(-30 * 24 * time.Hour)
 	case "7d":
@@ -59,13 +67,17 @@ func ResponseTimeRaw(c *fiber.Ctx) error {
 	case "1h":
 		from = time.Now().Add(-2 * time.Hour) // Because uptime metrics are stored by hour, we have to cheat a little
 	default:
-		return c.Status(400).SendString("Durations supported: 30d, 7d, 24h, 1h")
+		return c.Status(400).SendString("Durations supported: 1y, 90d, 30d, 7d, 24h, 1h")
 	}
 	key, err := url.QueryUnescape(c.Params("key"))
 	if err != nil {
 		return c.Status(400).SendString("invalid key encoding")
 	}
-	responseTime, err := store.Get().GetAverageResponseTimeByKey(key, from, time.Now())
+	percentile, _, err := parsePercentile(c.Query("percentile"))
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	responseTime, err := getResponseTimeByKey(key, from, time.Now(), percentile)
 	if err != nil {
 		if errors.Is(err, common.ErrEndpointNotFound) {
 			return c.Status(404).SendString(err.Error())
@@ -64,6 +64,16 @@ func TestRawDataEndpoint(t *testing.T) {
 			Path:         "/api/v1/endpoints/core_frontend/uptimes/30d",
 			ExpectedCode: http.StatusOK,
 		},
+		{
+			Name:         "raw-uptime-90d",
+			Path:         "/api/v1/endpoints/core_frontend/uptimes/90d",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "raw-uptime-1y",
+			Path:         "/api/v1/endpoints/core_backend/uptimes/1y",
+			ExpectedCode: http.StatusOK,
+		},
 		{
 			Name:         "raw-uptime-with-invalid-duration",
 			Path:         "/api/v1/endpoints/core_backend/uptimes/3d",
@@ -94,6 +104,16 @@ func TestRawDataEndpoint(t *testing.T) {
 			Path:         "/api/v1/endpoints/core_frontend/response-times/30d",
 			ExpectedCode: http.StatusOK,
 		},
+		{
+			Name:         "raw-response-times-90d",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/90d",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "raw-response-times-1y",
+			Path:         "/api/v1/endpoints/core_backend/response-times/1y",
+			ExpectedCode: http.StatusOK,
+		},
 		{
 			Name:         "raw-response-times-with-invalid-duration",
 			Path:         "/api/v1/endpoints/core_backend/response-times/3d",
@@ -104,6 +124,26 @@ func TestRawDataEndpoint(t *testing.T) {
 			Path:         "/api/v1/endpoints/invalid_key/response-times/7d",
 			ExpectedCode: http.StatusNotFound,
 		},
+		{
+			Name:         "raw-response-times-p95",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/24h?percentile=p95",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "raw-response-times-max",
+			Path:         "/api/v1/endpoints/core_backend/response-times/7d?percentile=max",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "raw-response-times-with-invalid-percentile",
+			Path:         "/api/v1/endpoints/core_frontend/response-times/24h?percentile=p0",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "raw-response-times-with-percentile-for-invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/response-times/7d?percentile=p99",
+			ExpectedCode: http.StatusNotFound,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+// ErrInvalidPercentile is the error returned when the percentile query parameter is invalid
+var ErrInvalidPercentile = errors.New("percentile must be between p0 and p100 (e.g. p50, p95, p99.9) or max")
+
+// parsePercentile parses the percentile query parameter (e.g. p95 or max) of response time requests.
+//
+// Returns the percentile as well as its normalized name, or 0 and an empty name if the query parameter is absent, in
+// which case the average response time should be used.
+func parsePercentile(percentileParameter string) (percentile float64, name string, err error) {
+	if len(percentileParameter) == 0 {
+		return 0, "", nil
+	}
+	percentileParameter = strings.ToLower(percentileParameter)
+	if percentileParameter == "max" {
+		return 100, percentileParameter, nil
+	}
+	value, found := strings.CutPrefix(percentileParameter, "p")
+	if !found {
+		return 0, "", ErrInvalidPercentile
+	}
+	percentile, err = strconv.ParseFloat(value, 64)
+	if err != nil || percentile <= 0 || percentile > 100 {
+		return 0, "", ErrInvalidPercentile
+	}
+	return percentile, percentileParameter, nil
+}
+
+// getResponseTimeByKey returns the given percentile of the response times of an endpoint in milliseconds during a
+// time range, or the average response time if percentile is 0
+func getResponseTimeByKey(key string, from, to time.Time, percentile float64) (int, error) {
+	if percentile == 0 {
+		return store.Get().GetAverageResponseTimeByKey(key, from, to)
+	}
+	statistics, err := store.Get().GetUptimeStatisticsByKey(key, from, to)
+	if err != nil {
+		return 0, err
+	}
+	return int(statistics.ResponseTimePercentile(percentile)), nil
+}
+
+// getHourlyResponseTimesByKey returns a map of hourly (key) percentile of the response times in milliseconds (value)
+// during a time range, or of hourly average response times if percentile is 0
+func getHourlyResponseTimesByKey(key string, from, to time.Time, percentile float64) (map[int64]int, error) {
+	if percentile == 0 {
+		return store.Get().GetHourlyAverageResponseTimeByKey(key, from, to)
+	}
+	hourlyStatistics, err := store.Get().GetHourlyUptimeStatisticsByKey(key, from, to)
+	if err != nil {
+		return nil, err
+	}
+	hourlyResponseTimes := make(map[int64]int, len(hourlyStatistics))
+	for unixTimestamp, statistics := range hourlyStatistics {
+		if statistics.TotalExecutions > 0 {
+			hourlyResponseTimes[unixTimestamp] = int(statistics.ResponseTimePercentile(percentile))
+		}
+	}
+	return hourlyResponseTimes, nil
+}
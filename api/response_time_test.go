@@ -0,0 +1,37 @@
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePercentile(t *testing.T) {
+	scenarios := []struct {
+		parameter          string
+		expectedPercentile float64
+		expectedName       string
+		expectedErr        error
+	}{
+		{parameter: "", expectedPercentile: 0, expectedName: ""},
+		{parameter: "p50", expectedPercentile: 50, expectedName: "p50"},
+		{parameter: "P95", expectedPercentile: 95, expectedName: "p95"},
+		{parameter: "p99.9", expectedPercentile: 99.9, expectedName: "p99.9"},
+		{parameter: "p100", expectedPercentile: 100, expectedName: "p100"},
+		{parameter: "max", expectedPercentile: 100, expectedName: "max"},
+		{parameter: "p0", expectedErr: ErrInvalidPercentile},
+		{parameter: "p101", expectedErr: ErrInvalidPercentile},
+		{parameter: "95", expectedErr: ErrInvalidPercentile},
+		{parameter: "pfoo", expectedErr: ErrInvalidPercentile},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.parameter, func(t *testing.T) {
+			percentile, name, err := parsePercentile(scenario.parameter)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if percentile != scenario.expectedPercentile || name != scenario.expectedName {
+				t.Errorf("expected percentile %v with name %q, got %v with name %q", scenario.expectedPercentile, scenario.expectedName, percentile, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Sessions handles requests to retrieve all sessions that haven't expired yet
+func Sessions(c *fiber.Ctx) error {
+	sessions, err := store.Get().GetAllSessions()
+	if err != nil {
+		logr.Errorf("[api.Sessions] Failed to retrieve sessions: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	return sendJSON(c, 200, sessions)
+}
+
+// DeleteSession handles requests to revoke a session, which logs out the user it belongs to
+func DeleteSession(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if err := store.Get().DeleteSession(id); err != nil {
+		if errors.Is(err, common.ErrSessionNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		logr.Errorf("[api.DeleteSession] Failed to delete session with id=%s: %s", id, err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	logr.Infof("[api.DeleteSession] Revoked session with id=%s", id)
+	return c.SendStatus(204)
+}
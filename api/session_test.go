@@ -0,0 +1,160 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestSessions(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	// The OIDC provider is only needed for its discovery document, which is retrieved when the API is created
+	var identityProvider *httptest.Server
+	identityProvider = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issuer":"` + identityProvider.URL + `","authorization_endpoint":"` + identityProvider.URL + `/authorize","token_endpoint":"` + identityProvider.URL + `/token","jwks_uri":"` + identityProvider.URL + `/keys"}`))
+	}))
+	defer identityProvider.Close()
+	cfg := &config.Config{
+		Storage: &storage.Config{
+			MaximumNumberOfResults: storage.DefaultMaximumNumberOfResults,
+			MaximumNumberOfEvents:  storage.DefaultMaximumNumberOfEvents,
+		},
+		Security: &security.Config{
+			OIDC: &security.OIDCConfig{
+				IssuerURL:    identityProvider.URL,
+				RedirectURL:  "http://localhost:8080/authorization-code/callback",
+				ClientID:     "client-id",
+				ClientSecret: "client-secret",
+				Scopes:       []string{"openid"},
+			},
+			Authorization: &security.AuthorizationConfig{Rules: []*security.AuthorizationRule{
+				{Role: security.RoleAdmin, Subjects: []string{"admin"}},
+				{Role: security.RoleViewer, Subjects: []string{"viewer"}},
+			}},
+		},
+	}
+	if !cfg.Security.ValidateAndSetDefaults() {
+		t.Fatal("expected security config to be valid")
+	}
+	adminSession := session.New("admin-token", "admin", nil, "", time.Hour)
+	viewerSession := session.New("viewer-token", "viewer", []string{"team-a"}, "secret-refresh-token", time.Hour)
+	for _, sess := range []*session.Session{adminSession, viewerSession} {
+		if err := store.Get().UpsertSession(sess); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name         string
+		Method       string
+		Path         string
+		SessionToken string
+		ExpectedCode int
+		ExpectedBody []string
+		Unexpected   []string
+	}{
+		{
+			Name:         "list-sessions-without-session",
+			Method:       "GET",
+			Path:         "/api/v1/sessions",
+			ExpectedCode: 401,
+		},
+		{
+			Name:         "list-sessions-as-viewer",
+			Method:       "GET",
+			Path:         "/api/v1/sessions",
+			SessionToken: "viewer-token",
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "list-sessions-as-admin",
+			Method:       "GET",
+			Path:         "/api/v1/sessions",
+			SessionToken: "admin-token",
+			ExpectedCode: 200,
+			ExpectedBody: []string{adminSession.ID, viewerSession.ID, `"subject":"viewer"`, `"groups":["team-a"]`},
+			Unexpected:   []string{"admin-token", "viewer-token", "secret-refresh-token"},
+		},
+		{
+			Name:         "revoke-session-as-viewer",
+			Method:       "DELETE",
+			Path:         "/api/v1/sessions/" + adminSession.ID,
+			SessionToken: "viewer-token",
+			ExpectedCode: 403,
+		},
+		{
+			Name:         "revoke-session-as-admin",
+			Method:       "DELETE",
+			Path:         "/api/v1/sessions/" + viewerSession.ID,
+			SessionToken: "admin-token",
+			ExpectedCode: 204,
+		},
+		{
+			Name:         "revoked-session-is-rejected",
+			Method:       "GET",
+			Path:         "/api/v1/endpoints/statuses",
+			SessionToken: "viewer-token",
+			ExpectedCode: 401,
+		},
+		{
+			Name:         "revoke-session-that-does-not-exist",
+			Method:       "DELETE",
+			Path:         "/api/v1/sessions/" + viewerSession.ID,
+			SessionToken: "admin-token",
+			ExpectedCode: 404,
+		},
+		{
+			Name:         "logout",
+			Method:       "POST",
+			Path:         "/oidc/logout",
+			SessionToken: "admin-token",
+			ExpectedCode: 302,
+		},
+		{
+			Name:         "session-is-rejected-after-logout",
+			Method:       "GET",
+			Path:         "/api/v1/sessions",
+			SessionToken: "admin-token",
+			ExpectedCode: 401,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			if len(scenario.SessionToken) > 0 {
+				request.AddCookie(&http.Cookie{Name: "gatus_session", Value: scenario.SessionToken})
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			for _, expected := range scenario.ExpectedBody {
+				if !strings.Contains(string(body), expected) {
+					t.Errorf("expected body to contain %s, got %s", expected, body)
+				}
+			}
+			for _, unexpected := range scenario.Unexpected {
+				if strings.Contains(string(body), unexpected) {
+					t.Errorf("expected body to not contain %s, got %s", unexpected, body)
+				}
+			}
+		})
+	}
+}
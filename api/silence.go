@@ -0,0 +1,285 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateSilenceRequest is the body of a request to create a silence
+type CreateSilenceRequest struct {
+	Matcher   silence.Matcher `json:"matcher"`
+	Duration  string          `json:"duration"` // e.g. 2h
+	Comment   string          `json:"comment"`
+	CreatedBy string          `json:"createdBy"` // Ignored if the request was authenticated with a username
+}
+
+// AcknowledgeAlertRequest is the body of a request to acknowledge a triggered alert
+type AcknowledgeAlertRequest struct {
+	Comment        string `json:"comment"`
+	AcknowledgedBy string `json:"acknowledgedBy"` // Ignored if the request was authenticated with a username
+}
+
+// EndpointAlert is the representation of an endpoint's alert returned by the API
+type EndpointAlert struct {
+	Type             alert.Type               `json:"type,omitempty"`
+	EscalationPolicy string                   `json:"escalationPolicy,omitempty"`
+	Description      string                   `json:"description,omitempty"`
+	Checksum         string                   `json:"checksum"`
+	Triggered        bool                     `json:"triggered"`
+	Acknowledgement  *silence.Acknowledgement `json:"acknowledgement,omitempty"`
+}
+
+// Silences handles requests to retrieve all silences that haven't expired yet and that apply exclusively to a group the
+// requester can see
+func Silences(c *fiber.Ctx) error {
+	silences, err := store.Get().GetAllSilences()
+	if err != nil {
+		logr.Errorf("[api.Silences] Failed to retrieve silences: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	accessibleSilences := make([]*silence.Silence, 0, len(silences))
+	for _, sil := range silences {
+		if canAccessGroups(c, getGroupsOfSilence(sil)) {
+			accessibleSilences = append(accessibleSilences, sil)
+		}
+	}
+	return sendJSON(c, 200, accessibleSilences)
+}
+
+// CreateSilence handles requests to create a silence
+func CreateSilence(c *fiber.Ctx) error {
+	var request CreateSilenceRequest
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return c.Status(400).SendString("invalid request body: " + err.Error())
+	}
+	duration, err := time.ParseDuration(request.Duration)
+	if err != nil {
+		return c.Status(400).SendString("invalid duration: " + err.Error())
+	}
+	if username := getRequesterUsername(c); len(username) > 0 {
+		request.CreatedBy = username
+	}
+	sil, err := silence.NewSilence(request.Matcher, duration, request.Comment, request.CreatedBy)
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	if !canAccessGroups(c, getGroupsOfSilence(sil)) {
+		return c.Status(403).SendString("silence must be restricted to a group you can see")
+	}
+	if err = store.Get().InsertSilence(sil); err != nil {
+		logr.Errorf("[api.CreateSilence] Failed to insert silence: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	logr.Infof("[api.CreateSilence] Created silence with id=%d expiring at %s", sil.ID, sil.ExpiresAt.Format(time.RFC3339))
+	return sendJSON(c, 201, sil)
+}
+
+// DeleteSilence handles requests to delete a silence before it expires
+func DeleteSilence(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).SendString("invalid silence id")
+	}
+	if !security.GetPrincipal(c).CanSeeAllGroups() {
+		silences, err := store.Get().GetAllSilences()
+		if err != nil {
+			logr.Errorf("[api.DeleteSilence] Failed to retrieve silences: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		for _, sil := range silences {
+			if sil.ID == id && !canAccessGroups(c, getGroupsOfSilence(sil)) {
+				return c.Status(403).SendString("silence applies to groups you cannot see")
+			}
+		}
+	}
+	if err = store.Get().DeleteSilence(id); err != nil {
+		if errors.Is(err, common.ErrSilenceNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		logr.Errorf("[api.DeleteSilence] Failed to delete silence with id=%d: %s", id, err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	logr.Infof("[api.DeleteSilence] Deleted silence with id=%d", id)
+	return c.SendStatus(204)
+}
+
+// EndpointAlerts handles requests to retrieve the alerts of an endpoint, including the checksum needed to
+// acknowledge them
+func EndpointAlerts(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, alerts, statusCode, err := getEndpointAlertsFromRequest(cfg, c)
+		if err != nil {
+			return c.Status(statusCode).SendString(err.Error())
+		}
+		acknowledgements, err := store.Get().GetAllAlertAcknowledgements()
+		if err != nil {
+			logr.Errorf("[api.EndpointAlerts] Failed to retrieve alert acknowledgements: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		endpointAlerts := make([]*EndpointAlert, 0, len(alerts))
+		for _, endpointAlert := range alerts {
+			checksum := endpointAlert.Checksum()
+			response := &EndpointAlert{
+				Type:             endpointAlert.Type,
+				EscalationPolicy: endpointAlert.EscalationPolicy,
+				Description:      endpointAlert.GetDescription(),
+				Checksum:         checksum,
+				Triggered:        endpointAlert.Triggered,
+			}
+			for _, ack := range acknowledgements {
+				if ack.EndpointKey == key && ack.AlertChecksum == checksum {
+					response.Acknowledgement = ack
+					break
+				}
+			}
+			endpointAlerts = append(endpointAlerts, response)
+		}
+		return sendJSON(c, 200, endpointAlerts)
+	}
+}
+
+// AcknowledgeAlert handles requests to acknowledge a triggered alert, which prevents it from being sent again until
+// it's resolved
+func AcknowledgeAlert(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, endpointAlert, statusCode, err := getEndpointAlertFromRequest(cfg, c)
+		if err != nil {
+			return c.Status(statusCode).SendString(err.Error())
+		}
+		if !endpointAlert.Triggered {
+			return c.Status(409).SendString("alert is not triggered")
+		}
+		var request AcknowledgeAlertRequest
+		if len(c.Body()) > 0 {
+			if err = json.Unmarshal(c.Body(), &request); err != nil {
+				return c.Status(400).SendString("invalid request body: " + err.Error())
+			}
+		}
+		if username := getRequesterUsername(c); len(username) > 0 {
+			request.AcknowledgedBy = username
+		}
+		ack := &silence.Acknowledgement{
+			EndpointKey:    key,
+			AlertChecksum:  endpointAlert.Checksum(),
+			Comment:        request.Comment,
+			AcknowledgedBy: request.AcknowledgedBy,
+			AcknowledgedAt: time.Now(),
+		}
+		if err = store.Get().UpsertAlertAcknowledgement(ack); err != nil {
+			return c.Status(500).SendString(err.Error())
+		}
+		logr.Infof("[api.AcknowledgeAlert] Acknowledged alert of type=%s for endpoint with key=%s", endpointAlert.Type, key)
+		return sendJSON(c, 201, ack)
+	}
+}
+
+// UnacknowledgeAlert handles requests to remove the acknowledgement of a triggered alert
+func UnacknowledgeAlert(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, endpointAlert, statusCode, err := getEndpointAlertFromRequest(cfg, c)
+		if err != nil {
+			return c.Status(statusCode).SendString(err.Error())
+		}
+		if err = store.Get().DeleteAlertAcknowledgement(key, endpointAlert.Checksum()); err != nil {
+			if errors.Is(err, common.ErrAcknowledgementNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			logr.Errorf("[api.UnacknowledgeAlert] Failed to delete acknowledgement of alert for endpoint with key=%s: %s", key, err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		return c.SendStatus(204)
+	}
+}
+
+// getEndpointAlertsFromRequest retrieves the alerts of the endpoint or external endpoint matching the key route
+// parameter. If the endpoint cannot be found, the status code to respond with is returned alongside the error.
+func getEndpointAlertsFromRequest(cfg *config.Config, c *fiber.Ctx) (string, []*alert.Alert, int, error) {
+	key, err := url.QueryUnescape(c.Params("key"))
+	if err != nil {
+		return "", nil, 400, errors.New("invalid key encoding")
+	}
+	principal := security.GetPrincipal(c)
+	if ep := cfg.GetEndpointByKey(key); ep != nil && principal.CanSeeGroup(ep.Group) {
+		return key, ep.Alerts, 200, nil
+	}
+	if ee := cfg.GetExternalEndpointByKey(key); ee != nil && principal.CanSeeGroup(ee.Group) {
+		return key, ee.Alerts, 200, nil
+	}
+	return "", nil, 404, common.ErrEndpointNotFound
+}
+
+// getEndpointAlertFromRequest retrieves the alert matching the checksum route parameter of the endpoint or external
+// endpoint matching the key route parameter.
+// If the alert cannot be found, the status code to respond with is returned alongside the error.
+func getEndpointAlertFromRequest(cfg *config.Config, c *fiber.Ctx) (string, *alert.Alert, int, error) {
+	key, alerts, statusCode, err := getEndpointAlertsFromRequest(cfg, c)
+	if err != nil {
+		return "", nil, statusCode, err
+	}
+	for _, endpointAlert := range alerts {
+		if endpointAlert.Checksum() == c.Params("checksum") {
+			return key, endpointAlert, 200, nil
+		}
+	}
+	return "", nil, 404, errors.New("alert not found")
+}
+
+// getGroupsOfSilence returns the group the silence is restricted to, if any
+func getGroupsOfSilence(sil *silence.Silence) []string {
+	if len(sil.Matcher.Group) == 0 {
+		return nil
+	}
+	return []string{sil.Matcher.Group}
+}
+
+// getRequesterUsername returns the username the request was authenticated with, if any
+func getRequesterUsername(c *fiber.Ctx) string {
+	if username, ok := c.Locals("username").(string); ok {
+		return username
+	}
+	return ""
+}
+
+// populateEndpointStatusesMutes returns a copy of the endpoint statuses passed with the silences and acknowledgements
+// that apply to each of them
+func populateEndpointStatusesMutes(endpointStatuses []*endpoint.Status) ([]*endpoint.Status, error) {
+	silences, err := store.Get().GetAllSilences()
+	if err != nil {
+		return nil, err
+	}
+	acknowledgements, err := store.Get().GetAllAlertAcknowledgements()
+	if err != nil {
+		return nil, err
+	}
+	populatedEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
+	for _, endpointStatus := range endpointStatuses {
+		// The store may cache statuses, so they must not be modified
+		endpointStatusCopy := *endpointStatus
+		endpointStatusCopy.Silences, endpointStatusCopy.Acknowledgements = nil, nil
+		for _, sil := range silences {
+			if sil.Matcher.MatchesEndpoint(endpointStatus.Key, endpointStatus.Group) {
+				endpointStatusCopy.Silences = append(endpointStatusCopy.Silences, sil)
+			}
+		}
+		for _, ack := range acknowledgements {
+			if ack.EndpointKey == endpointStatus.Key {
+				endpointStatusCopy.Acknowledgements = append(endpointStatusCopy.Acknowledgements, ack)
+			}
+		}
+		populatedEndpointStatuses = append(populatedEndpointStatuses, &endpointStatusCopy)
+	}
+	return populatedEndpointStatuses, nil
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestSilencesAndAcknowledgements(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	triggeredAlert := &alert.Alert{Type: alert.TypeSlack, Triggered: true}
+	notTriggeredAlert := &alert.Alert{Type: alert.TypePagerDuty}
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core", Alerts: []*alert.Alert{triggeredAlert, notTriggeredAlert}},
+			{Name: "backend", Group: "core"},
+		},
+		Storage: &storage.Config{
+			MaximumNumberOfResults: storage.DefaultMaximumNumberOfResults,
+			MaximumNumberOfEvents:  storage.DefaultMaximumNumberOfEvents,
+		},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	watchdog.UpdateEndpointStatus(cfg.Endpoints[0], &endpoint.Result{Success: false, Timestamp: time.Now()})
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name          string
+		Method        string
+		Path          string
+		Body          string
+		Authenticated bool
+		ExpectedCode  int
+	}{
+		{
+			Name:         "create-silence-unauthenticated",
+			Method:       "POST",
+			Path:         "/api/v1/silences",
+			Body:         `{"matcher":{"group":"core"},"duration":"2h","comment":"Maintenance"}`,
+			ExpectedCode: 401,
+		},
+		{
+			Name:          "create-silence-with-empty-matcher",
+			Method:        "POST",
+			Path:          "/api/v1/silences",
+			Body:          `{"matcher":{},"duration":"2h","comment":"Maintenance"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-silence-with-invalid-duration",
+			Method:        "POST",
+			Path:          "/api/v1/silences",
+			Body:          `{"matcher":{"group":"core"},"duration":"forever","comment":"Maintenance"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-silence-without-comment",
+			Method:        "POST",
+			Path:          "/api/v1/silences",
+			Body:          `{"matcher":{"group":"core"},"duration":"2h"}`,
+			Authenticated: true,
+			ExpectedCode:  400,
+		},
+		{
+			Name:          "create-silence",
+			Method:        "POST",
+			Path:          "/api/v1/silences",
+			Body:          `{"matcher":{"endpointKey":"core_*","alertType":"slack"},"duration":"2h","comment":"Maintenance","createdBy":"jane.doe"}`,
+			Authenticated: true,
+			ExpectedCode:  201,
+		},
+		{
+			Name:          "get-silences",
+			Method:        "GET",
+			Path:          "/api/v1/silences",
+			Authenticated: true,
+			ExpectedCode:  200,
+		},
+		{
+			Name:          "get-endpoint-alerts",
+			Method:        "GET",
+			Path:          "/api/v1/endpoints/core_frontend/alerts",
+			Authenticated: true,
+			ExpectedCode:  200,
+		},
+		{
+			Name:          "get-nonexistent-endpoint-alerts",
+			Method:        "GET",
+			Path:          "/api/v1/endpoints/core_nonexistent/alerts",
+			Authenticated: true,
+			ExpectedCode:  404,
+		},
+		{
+			Name:         "acknowledge-unauthenticated",
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/core_frontend/alerts/" + triggeredAlert.Checksum() + "/ack",
+			ExpectedCode: 401,
+		},
+		{
+			Name:          "acknowledge-nonexistent-alert",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/core_frontend/alerts/nonexistent/ack",
+			Authenticated: true,
+			ExpectedCode:  404,
+		},
+		{
+			Name:          "acknowledge-alert-that-is-not-triggered",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/core_frontend/alerts/" + notTriggeredAlert.Checksum() + "/ack",
+			Authenticated: true,
+			ExpectedCode:  409,
+		},
+		{
+			Name:          "acknowledge",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/core_frontend/alerts/" + triggeredAlert.Checksum() + "/ack",
+			Body:          `{"comment":"Looking into it","acknowledgedBy":"jane.doe"}`,
+			Authenticated: true,
+			ExpectedCode:  201,
+		},
+		{
+			Name:          "get-endpoint-status-with-silences-and-acknowledgements",
+			Method:        "GET",
+			Path:          "/api/v1/endpoints/core_frontend/statuses",
+			Authenticated: true,
+			ExpectedCode:  200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", scenario.Method, scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	silences, _ := store.Get().GetAllSilences()
+	if len(silences) != 1 {
+		t.Fatalf("expected 1 silence, got %d", len(silences))
+	}
+	if silences[0].CreatedBy != "john.doe" {
+		t.Errorf("expected silence to have been created by john.doe, got %s", silences[0].CreatedBy)
+	}
+	acknowledgements, _ := store.Get().GetAllAlertAcknowledgements()
+	if len(acknowledgements) != 1 {
+		t.Fatalf("expected 1 acknowledgement, got %d", len(acknowledgements))
+	}
+	if acknowledgements[0].AcknowledgedBy != "john.doe" || acknowledgements[0].Comment != "Looking into it" {
+		t.Errorf("expected acknowledgement by john.doe with comment 'Looking into it', got %s and %s", acknowledgements[0].AcknowledgedBy, acknowledgements[0].Comment)
+	}
+	// The endpoint status should list the silences and acknowledgements that apply to the endpoint
+	request := httptest.NewRequest("GET", "/api/v1/endpoints/core_frontend/statuses", http.NoBody)
+	request.SetBasicAuth("john.doe", "hunter2")
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var endpointStatus endpoint.Status
+	if err = json.NewDecoder(response.Body).Decode(&endpointStatus); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(endpointStatus.Silences) != 1 || len(endpointStatus.Acknowledgements) != 1 {
+		t.Errorf("expected 1 silence and 1 acknowledgement, got %d and %d", len(endpointStatus.Silences), len(endpointStatus.Acknowledgements))
+	}
+	// Delete the silence and the acknowledgement
+	for _, path := range []string{"/api/v1/silences/" + strconv.FormatInt(silences[0].ID, 10), "/api/v1/endpoints/core_frontend/alerts/" + triggeredAlert.Checksum() + "/ack"} {
+		for _, expectedCode := range []int{204, 404} {
+			request = httptest.NewRequest("DELETE", path, http.NoBody)
+			request.SetBasicAuth("john.doe", "hunter2")
+			response, err = router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response.Body.Close()
+			if response.StatusCode != expectedCode {
+				t.Errorf("DELETE %s should have returned %d, but returned %d instead", path, expectedCode, response.StatusCode)
+			}
+		}
+	}
+}
+
+func TestSilences_WithoutSecurity(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	api := New(&config.Config{})
+	router := api.Router()
+	request := httptest.NewRequest("POST", "/api/v1/silences", strings.NewReader(`{"matcher":{"group":"core"},"duration":"2h","comment":"Maintenance"}`))
+	request.Header.Set("Content-Type", "application/json")
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == 201 {
+		t.Error("expected silences to not be manageable without security configured")
+	}
+	if silences, _ := store.Get().GetAllSilences(); len(silences) != 0 {
+		t.Errorf("expected no silences, got %d", len(silences))
+	}
+}
+
+func TestPopulateEndpointStatusesMutes(t *testing.T) {
+	defer store.Get().Clear()
+	sil, _ := silence.NewSilence(silence.Matcher{Group: "core"}, time.Hour, "Maintenance", "")
+	_ = store.Get().InsertSilence(sil)
+	original := &endpoint.Status{Name: "frontend", Group: "core", Key: "core_frontend"}
+	populated, err := populateEndpointStatusesMutes([]*endpoint.Status{original, {Name: "backend", Group: "other", Key: "other_backend"}})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(populated[0].Silences) != 1 {
+		t.Errorf("expected the silence to apply to core_frontend, got %d silences", len(populated[0].Silences))
+	}
+	if len(populated[1].Silences) != 0 {
+		t.Errorf("expected the silence to not apply to other_backend, got %d silences", len(populated[1].Silences))
+	}
+	if len(original.Silences) != 0 {
+		t.Error("expected the original endpoint status to not have been modified")
+	}
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrEndpointWithoutSLO is the error returned when the SLO of an endpoint that doesn't have one is requested
+var ErrEndpointWithoutSLO = errors.New("endpoint has no slo")
+
+// EndpointSLO handles requests to retrieve the state of the service level objectives of an endpoint, including the
+// remaining error budget
+func EndpointSLO(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, err := url.QueryUnescape(c.Params("key"))
+		if err != nil {
+			return c.Status(400).SendString("invalid key encoding")
+		}
+		ep := cfg.GetEndpointByKey(key)
+		if ep == nil || !security.GetPrincipal(c).CanSeeGroup(ep.Group) {
+			return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+		}
+		if ep.SLO == nil {
+			return c.Status(404).SendString(ErrEndpointWithoutSLO.Error())
+		}
+		status, err := watchdog.GetSLOStatus(ep)
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				// The endpoint hasn't been monitored yet, so none of the error budget has been consumed
+				status = ep.SLO.NewStatus(0, 0, 0)
+			} else {
+				logr.Errorf("[api.EndpointSLO] Failed to compute SLO status for endpoint with key=%s: %s", key, err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+		}
+		return sendJSON(c, 200, status)
+	}
+}
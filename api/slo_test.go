@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestEndpointSLO(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	endpointWithSLO := &endpoint.Endpoint{Name: "frontend", Group: "core", SLO: &slo.Config{Target: 75, Latency: &slo.LatencyConfig{Threshold: 200 * time.Millisecond, Target: 50}}}
+	if err := endpointWithSLO.SLO.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			endpointWithSLO,
+			{Name: "backend", Group: "core"},
+			{Name: "unmonitored", Group: "core", SLO: &slo.Config{Target: 99.9, Window: "7d"}},
+		},
+	}
+	now := time.Now()
+	for _, result := range []endpoint.Result{testSuccessfulResult, testSuccessfulResult, testSuccessfulResult, testUnsuccessfulResult} {
+		result.Timestamp = now
+		if err := store.Get().InsertEndpointResult(endpointWithSLO, &result); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                         string
+		Path                         string
+		ExpectedCode                 int
+		ExpectedErrorBudgetRemaining float64
+	}{
+		{
+			Name:                         "endpoint-with-slo",
+			Path:                         "/api/v1/endpoints/core_frontend/slo",
+			ExpectedCode:                 http.StatusOK,
+			ExpectedErrorBudgetRemaining: 0,
+		},
+		{
+			Name:                         "endpoint-with-slo-that-has-not-been-monitored",
+			Path:                         "/api/v1/endpoints/core_unmonitored/slo",
+			ExpectedCode:                 http.StatusOK,
+			ExpectedErrorBudgetRemaining: 1,
+		},
+		{
+			Name:         "endpoint-without-slo",
+			Path:         "/api/v1/endpoints/core_backend/slo",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "nonexistent-endpoint",
+			Path:         "/api/v1/endpoints/core_nonexistent/slo",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s should have returned %d, but returned %d instead", scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			var status slo.Status
+			if err = json.NewDecoder(response.Body).Decode(&status); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if status.ErrorBudgetRemaining != scenario.ExpectedErrorBudgetRemaining {
+				t.Errorf("expected an error budget remaining of %f, got %f", scenario.ExpectedErrorBudgetRemaining, status.ErrorBudgetRemaining)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/gofiber/fiber/v2"
@@ -29,6 +30,16 @@ func SuiteStatuses(cfg *config.Config) fiber.Handler {
 				}
 			}
 		}
+		// Only keep the suite statuses of the groups the requester can see
+		if principal := security.GetPrincipal(c); !principal.CanSeeAllGroups() {
+			visibleSuiteStatuses := make([]*suite.Status, 0, len(suiteStatuses))
+			for _, suiteStatus := range suiteStatuses {
+				if principal.CanSeeGroup(suiteStatus.Group) {
+					visibleSuiteStatuses = append(visibleSuiteStatuses, suiteStatus)
+				}
+			}
+			suiteStatuses = visibleSuiteStatuses
+		}
 		return c.Status(fiber.StatusOK).JSON(suiteStatuses)
 	}
 }
@@ -48,11 +59,11 @@ func SuiteStatus(cfg *config.Config) fiber.Handler {
 					break
 				}
 			}
-			if status == nil {
-				return c.Status(404).JSON(fiber.Map{
-					"error": fmt.Sprintf("Suite with key '%s' not found", key),
-				})
-			}
+		}
+		if status == nil || !security.GetPrincipal(c).CanSeeGroup(status.Group) {
+			return c.Status(404).JSON(fiber.Map{
+				"error": fmt.Sprintf("Suite with key '%s' not found", key),
+			})
 		}
 		return c.Status(fiber.StatusOK).JSON(status)
 	}
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"encoding/json"
 	"strconv"
 
+	"github.com/TwiN/logr"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -44,3 +46,14 @@ func extractPageAndPageSizeFromRequest(c *fiber.Ctx, maximumNumberOfResults int)
 	}
 	return
 }
+
+// sendJSON responds with the JSON representation of the value passed as parameter
+func sendJSON(c *fiber.Ctx, statusCode int, v any) error {
+	output, err := json.Marshal(v)
+	if err != nil {
+		logr.Errorf("[api.sendJSON] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(statusCode).Send(output)
+}
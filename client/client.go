@@ -5,7 +5,6 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,17 +22,12 @@ import (
 	"github.com/TwiN/whois"
 	"github.com/gorilla/websocket"
 	"github.com/ishidawataru/sctp"
-	"github.com/miekg/dns"
 	ping "github.com/prometheus-community/pro-bing"
 	"github.com/registrobr/rdap"
 	"github.com/registrobr/rdap/protocol"
 	"golang.org/x/crypto/ssh"
 )
 
-const (
-	dnsPort = 53
-)
-
 var (
 	// injectedHTTPClient is used for testing purposes
 	injectedHTTPClient *http.Client
@@ -393,39 +387,16 @@ func ShouldRunPingerAsPrivileged() bool {
 
 // QueryWebSocket opens a websocket connection, write `body` and return a message from the server
 func QueryWebSocket(address, body string, headers map[string]string, config *Config) (bool, []byte, error) {
-	const (
-		Origin = "http://localhost/"
-	)
-	var (
-		dialer = websocket.Dialer{
-			EnableCompression: true,
-		}
-		wsHeaders = make(http.Header)
-	)
-
-	wsHeaders.Set("Origin", Origin)
-	for name, value := range headers {
-		wsHeaders.Set(name, value)
-	}
-
 	ctx := context.Background()
-	if config != nil {
-		if config.Timeout > 0 {
-			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(ctx, config.Timeout)
-			defer cancel()
-		}
-		dialer.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: config.Insecure,
-		}
-		if config.HasTLSConfig() && config.TLS.isValid() == nil {
-			dialer.TLSClientConfig = configureTLS(dialer.TLSClientConfig, *config.TLS)
-		}
+	if config != nil && config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
 	}
 	// Dial URL
-	ws, _, err := dialer.DialContext(ctx, address, wsHeaders)
+	ws, err := dialWebSocket(ctx, address, headers, config)
 	if err != nil {
-		return false, nil, fmt.Errorf("error dialing websocket: %w", err)
+		return false, nil, err
 	}
 	defer ws.Close()
 	body = parseLocalAddressPlaceholder(body, ws.LocalAddr())
@@ -443,76 +414,6 @@ func QueryWebSocket(address, body string, headers map[string]string, config *Con
 	return true, msg, nil
 }
 
-func QueryDNS(queryType, queryName, url string) (connected bool, dnsRcode string, body []byte, err error) {
-	if !strings.Contains(url, ":") {
-		url = fmt.Sprintf("%s:%d", url, dnsPort)
-	}
-	queryTypeAsUint16 := dns.StringToType[queryType]
-	// Special handling: if this is a PTR query and queryName looks like a plain IP,
-	// convert it to the proper reverse lookup domain automatically.
-	if queryTypeAsUint16 == dns.TypePTR &&
-		!strings.HasSuffix(queryName, ".in-addr.arpa.") &&
-		!strings.HasSuffix(queryName, ".ip6.arpa.") {
-		if rev, convErr := reverseNameForIP(queryName); convErr == nil {
-			queryName = rev
-		} else {
-			return false, "", nil, convErr
-		}
-	}
-	c := new(dns.Client)
-	m := new(dns.Msg)
-	m.SetQuestion(queryName, queryTypeAsUint16)
-	r, _, err := c.Exchange(m, url)
-	if err != nil {
-		logr.Infof("[client.QueryDNS] Error exchanging DNS message: %v", err)
-		return false, "", nil, err
-	}
-	connected = true
-	dnsRcode = dns.RcodeToString[r.Rcode]
-	for _, rr := range r.Answer {
-		switch rr.Header().Rrtype {
-		case dns.TypeA:
-			if a, ok := rr.(*dns.A); ok {
-				body = []byte(a.A.String())
-			}
-		case dns.TypeAAAA:
-			if aaaa, ok := rr.(*dns.AAAA); ok {
-				body = []byte(aaaa.AAAA.String())
-			}
-		case dns.TypeCNAME:
-			if cname, ok := rr.(*dns.CNAME); ok {
-				body = []byte(cname.Target)
-			}
-		case dns.TypeMX:
-			if mx, ok := rr.(*dns.MX); ok {
-				body = []byte(mx.Mx)
-			}
-		case dns.TypeNS:
-			if ns, ok := rr.(*dns.NS); ok {
-				body = []byte(ns.Ns)
-			}
-		case dns.TypePTR:
-			if ptr, ok := rr.(*dns.PTR); ok {
-				body = []byte(ptr.Ptr)
-			}
-		case dns.TypeSRV:
-			if srv, ok := rr.(*dns.SRV); ok {
-				body = []byte(fmt.Sprintf("%s:%d", srv.Target, srv.Port))
-			}
-		case dns.TypeTXT:
-			if txt, ok := rr.(*dns.TXT); ok {
-				if len(body) > 0 {
-					body = append(body, '\n')
-				}
-				body = append(body, []byte(strings.Join(txt.Txt, ""))...)
-			}
-		default:
-			body = []byte("query type is not supported yet")
-		}
-	}
-	return connected, dnsRcode, body, nil
-}
-
 // InjectHTTPClient is used to inject a custom HTTP client for testing purposes
 func InjectHTTPClient(httpClient *http.Client) {
 	injectedHTTPClient = httpClient
@@ -540,27 +441,3 @@ func rdapQuery(hostname string) (*whois.Response, error) {
 	}
 	return &response, nil
 }
-
-// helper to reverse IP and add in-addr.arpa. IPv4 and IPv6
-func reverseNameForIP(ipStr string) (string, error) {
-	ip := net.ParseIP(ipStr)
-	if ip == nil {
-		return "", fmt.Errorf("invalid IP: %s", ipStr)
-	}
-
-	if ipv4 := ip.To4(); ipv4 != nil {
-		parts := strings.Split(ipv4.String(), ".")
-		for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
-			parts[i], parts[j] = parts[j], parts[i]
-		}
-		return strings.Join(parts, ".") + ".in-addr.arpa.", nil
-	}
-
-	ip = ip.To16()
-	hexStr := hex.EncodeToString(ip)
-	nibbles := strings.Split(hexStr, "")
-	for i, j := 0, len(nibbles)-1; i < j; i, j = i+1, j-1 {
-		nibbles[i], nibbles[j] = nibbles[j], nibbles[i]
-	}
-	return strings.Join(nibbles, ".") + ".ip6.arpa.", nil
-}
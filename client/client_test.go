@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/netip"
@@ -547,39 +548,45 @@ func TestQueryDNS(t *testing.T) {
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
-			_, dnsRCode, body, err := QueryDNS(scenario.inputDNS.QueryType, scenario.inputDNS.QueryName, scenario.inputURL)
+			_, dnsRCode, body, _, err := QueryDNS(scenario.inputDNS.QueryType, scenario.inputDNS.QueryName, scenario.inputURL, false, nil)
 			if scenario.isErrExpected && err == nil {
 				t.Errorf("there should be an error")
 			}
 			if dnsRCode != scenario.expectedDNSCode {
 				t.Errorf("expected DNSRCode to be %s, got %s", scenario.expectedDNSCode, dnsRCode)
 			}
-			if scenario.inputDNS.QueryType == "NS" || scenario.inputDNS.QueryType == "TXT" {
-				// Some record types can have multiple valid answers, so wildcard matching is used in those scenarios
-				if !pattern.Match(scenario.expectedBody, string(body)) {
-					t.Errorf("got %s, expected result %s,", string(body), scenario.expectedBody)
+			if scenario.isErrExpected {
+				return
+			}
+			var answers []DNSAnswer
+			if err := json.Unmarshal(body, &answers); err != nil {
+				t.Fatalf("expected body to be a JSON array of answers, got %s", string(body))
+			}
+			if len(answers) == 0 {
+				t.Fatalf("expected at least one answer, got %s", string(body))
+			}
+			for _, answer := range answers {
+				if answer.Type != scenario.inputDNS.QueryType {
+					continue
 				}
-			} else {
-				if string(body) != scenario.expectedBody {
-					// little hack to validate arbitrary ipv4/ipv6
-					switch scenario.expectedBody {
-					case "__IPV4__":
-						if addr, err := netip.ParseAddr(string(body)); err != nil {
-							t.Errorf("got %s, expected result %s", string(body), scenario.expectedBody)
-						} else if !addr.Is4() {
-							t.Errorf("got %s, expected valid IPv4", string(body))
-						}
-					case "__IPV6__":
-						if addr, err := netip.ParseAddr(string(body)); err != nil {
-							t.Errorf("got %s, expected result %s", string(body), scenario.expectedBody)
-						} else if !addr.Is6() {
-							t.Errorf("got %s, expected valid IPv6", string(body))
-						}
-					default:
-						t.Errorf("got %s, expected result %s", string(body), scenario.expectedBody)
+				switch scenario.expectedBody {
+				// little hack to validate arbitrary ipv4/ipv6
+				case "__IPV4__":
+					if addr, err := netip.ParseAddr(answer.Value); err == nil && addr.Is4() {
+						return
+					}
+				case "__IPV6__":
+					if addr, err := netip.ParseAddr(answer.Value); err == nil && addr.Is6() {
+						return
+					}
+				default:
+					// Some record types can have multiple valid answers, so wildcard matching is used
+					if pattern.Match(scenario.expectedBody, answer.Value) {
+						return
 					}
 				}
 			}
+			t.Errorf("got %s, expected an answer matching %s", string(body), scenario.expectedBody)
 		})
 		time.Sleep(10 * time.Millisecond)
 	}
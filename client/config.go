@@ -87,6 +87,14 @@ type Config struct {
 	// StoreCookies determines whether cookies are stored and included across requests.
 	StoreCookies bool `yaml:"store-cookies,omitempty"`
 
+	// HTTP2 determines whether HTTP/2 is negotiated with HTTPS endpoints that support it (true) or whether HTTP/1.1 is
+	// always used (false, default)
+	HTTP2 bool `yaml:"http2,omitempty"`
+
+	// DisableKeepAlives determines whether a new connection is established for every request rather than reusing an
+	// idle connection, which means that the DNS lookup, TCP connection and TLS handshake of every request are timed
+	DisableKeepAlives bool `yaml:"disable-keep-alives,omitempty"`
+
 	httpClient *http.Client
 }
 
@@ -231,6 +239,9 @@ func (c *Config) getHTTPClient() *http.Client {
 				MaxIdleConnsPerHost: 20,
 				Proxy:               http.ProxyFromEnvironment,
 				TLSClientConfig:     tlsConfig,
+				// A custom TLS configuration disables HTTP/2 unless explicitly requested
+				ForceAttemptHTTP2: c.HTTP2,
+				DisableKeepAlives: c.DisableKeepAlives,
 			},
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				if c.IgnoreRedirect {
@@ -52,6 +52,18 @@ func TestConfig_getHTTPClient_withCookieJar(t *testing.T) {
 	}
 }
 
+func TestConfig_getHTTPClient_withDisableKeepAlives(t *testing.T) {
+	config := &Config{DisableKeepAlives: true}
+	config.ValidateAndSetDefaults()
+	client := config.getHTTPClient()
+	if !client.Transport.(*http.Transport).DisableKeepAlives {
+		t.Error("expected Config.DisableKeepAlives to cause the HTTP client to establish a new connection for every request")
+	}
+	if (&Config{}).getHTTPClient().Transport.(*http.Transport).DisableKeepAlives {
+		t.Error("expected the HTTP client to reuse idle connections by default")
+	}
+}
+
 func TestConfig_ValidateAndSetDefaults_withCustomDNSResolver(t *testing.T) {
 	type args struct {
 		dnsResolver string
@@ -0,0 +1,272 @@
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TwiN/logr"
+	"github.com/miekg/dns"
+)
+
+const (
+	dnsPort          = 53
+	dnsOverTLSPort   = 853
+	dnsOverTLSScheme = "tls://"
+
+	// dnsMessageContentType is the media type of the DNS messages sent to and received from DNS over HTTPS resolvers
+	dnsMessageContentType = "application/dns-message"
+
+	// dnsUDPSize is the size of the largest UDP response advertised to resolvers through EDNS0, which is large enough
+	// for most responses, including those with DNSSEC signatures
+	dnsUDPSize = 4096
+)
+
+var (
+	// ErrUnsupportedDNSResolverScheme is the error returned when the URL of a DNS resolver has a scheme that isn't
+	// supported
+	ErrUnsupportedDNSResolverScheme = errors.New("unsupported DNS resolver scheme: must be tls:// or https://, or no scheme at all")
+)
+
+// DNSAnswer is a record of the answer section of a DNS response
+type DNSAnswer struct {
+	// Name is the name of the record (e.g. example.org.)
+	Name string `json:"name"`
+
+	// Type is the type of the record (e.g. A)
+	Type string `json:"type"`
+
+	// TTL is the time to live of the record, in seconds
+	TTL uint32 `json:"ttl"`
+
+	// Value is the data of the record (e.g. 93.184.215.14)
+	Value string `json:"value"`
+}
+
+// newDNSAnswer creates a DNSAnswer from a resource record
+func newDNSAnswer(rr dns.RR) *DNSAnswer {
+	answer := &DNSAnswer{Name: rr.Header().Name, Type: dns.TypeToString[rr.Header().Rrtype], TTL: rr.Header().Ttl}
+	switch record := rr.(type) {
+	case *dns.A:
+		answer.Value = record.A.String()
+	case *dns.AAAA:
+		answer.Value = record.AAAA.String()
+	case *dns.CNAME:
+		answer.Value = record.Target
+	case *dns.MX:
+		answer.Value = record.Mx
+	case *dns.NS:
+		answer.Value = record.Ns
+	case *dns.PTR:
+		answer.Value = record.Ptr
+	case *dns.SRV:
+		answer.Value = fmt.Sprintf("%s:%d", record.Target, record.Port)
+	case *dns.TXT:
+		answer.Value = strings.Join(record.Txt, "")
+	default:
+		// For every other type, the value is the data of the record in its presentation format
+		answer.Value = strings.TrimSpace(strings.TrimPrefix(rr.String(), rr.Header().String()))
+	}
+	return answer
+}
+
+// QueryDNS sends a query to the DNS resolver at the given URL, and returns the response code as well as the answers,
+// marshaled as a JSON array of DNSAnswer.
+//
+// The URL may be an address (e.g. 8.8.8.8 or 8.8.8.8:53) to use plain DNS, a tls:// URL (e.g. tls://1.1.1.1) to use DNS
+// over TLS or an https:// URL (e.g. https://dns.google/dns-query) to use DNS over HTTPS.
+// If dnssec is true, the answers are validated from the root zone down to the zone of the query name, and dnssecValid
+// is whether the validation succeeded.
+func QueryDNS(queryType, queryName, url string, dnssec bool, config *Config) (connected bool, dnsRcode string, body []byte, dnssecValid bool, err error) {
+	if config == nil {
+		config = GetDefaultConfig()
+	}
+	queryTypeAsUint16, ok := dns.StringToType[queryType]
+	if !ok {
+		return false, "", nil, false, fmt.Errorf("invalid query type: %s", queryType)
+	}
+	// Special handling: if this is a PTR query and queryName looks like a plain IP,
+	// convert it to the proper reverse lookup domain automatically.
+	if queryTypeAsUint16 == dns.TypePTR &&
+		!strings.HasSuffix(queryName, ".in-addr.arpa.") &&
+		!strings.HasSuffix(queryName, ".ip6.arpa.") {
+		if rev, convErr := reverseNameForIP(queryName); convErr == nil {
+			queryName = rev
+		} else {
+			return false, "", nil, false, convErr
+		}
+	}
+	exchange, err := newDNSExchangeFunc(url, config)
+	if err != nil {
+		return false, "", nil, false, err
+	}
+	m := new(dns.Msg)
+	m.SetQuestion(queryName, queryTypeAsUint16)
+	m.SetEdns0(dnsUDPSize, dnssec)
+	r, err := exchange(m)
+	if err != nil {
+		logr.Infof("[client.QueryDNS] Error exchanging DNS message: %v", err)
+		return false, "", nil, false, err
+	}
+	connected = true
+	dnsRcode = dns.RcodeToString[r.Rcode]
+	answers := make([]*DNSAnswer, 0, len(r.Answer))
+	for _, rr := range r.Answer {
+		// Signatures are only relevant to the DNSSEC validation, unless they're what was queried
+		if rr.Header().Rrtype == dns.TypeRRSIG && queryTypeAsUint16 != dns.TypeRRSIG {
+			continue
+		}
+		answers = append(answers, newDNSAnswer(rr))
+	}
+	if body, err = json.Marshal(answers); err != nil {
+		return connected, dnsRcode, nil, false, err
+	}
+	if dnssec && r.Rcode == dns.RcodeSuccess {
+		validator := newDNSSECValidator(exchange, rootTrustAnchors)
+		if validationErr := validator.validateAnswer(r.Answer); validationErr != nil {
+			logr.Debugf("[client.QueryDNS] DNSSEC validation of %s %s failed: %s", queryType, queryName, validationErr.Error())
+		} else {
+			dnssecValid = true
+		}
+	}
+	return connected, dnsRcode, body, dnssecValid, nil
+}
+
+// dnsExchangeFunc sends a DNS query to a resolver and returns its response
+type dnsExchangeFunc func(m *dns.Msg) (*dns.Msg, error)
+
+// newDNSExchangeFunc returns the function that sends DNS queries to the resolver at the given URL
+func newDNSExchangeFunc(url string, config *Config) (dnsExchangeFunc, error) {
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		return func(m *dns.Msg) (*dns.Msg, error) {
+			return exchangeDNSOverHTTPS(url, m, config)
+		}, nil
+	case strings.HasPrefix(url, dnsOverTLSScheme):
+		address := withDefaultPort(strings.TrimPrefix(url, dnsOverTLSScheme), dnsOverTLSPort)
+		return func(m *dns.Msg) (*dns.Msg, error) {
+			return exchangeDNSOverConnection("tcp-tls", address, m, config)
+		}, nil
+	case strings.Contains(url, "://"):
+		return nil, ErrUnsupportedDNSResolverScheme
+	}
+	address := withDefaultPort(url, dnsPort)
+	return func(m *dns.Msg) (*dns.Msg, error) {
+		// SSH tunnels can only forward TCP connections
+		if config.ResolvedTunnel != nil {
+			return exchangeDNSOverConnection("tcp", address, m, config)
+		}
+		r, err := exchangeDNSOverConnection("udp", address, m, config)
+		if err == nil && r.Truncated {
+			// The response didn't fit in a UDP packet, so the query must be sent again over TCP
+			return exchangeDNSOverConnection("tcp", address, m, config)
+		}
+		return r, err
+	}, nil
+}
+
+// exchangeDNSOverConnection sends a DNS query to the resolver at the given address over udp, tcp or tcp-tls
+func exchangeDNSOverConnection(network, address string, m *dns.Msg, config *Config) (*dns.Msg, error) {
+	dialNetwork := network
+	if network == "tcp-tls" {
+		dialNetwork = "tcp"
+	}
+	var connection net.Conn
+	var err error
+	if config.ResolvedTunnel != nil {
+		connection, err = config.ResolvedTunnel.Dial(dialNetwork, address)
+	} else {
+		connection, err = net.DialTimeout(dialNetwork, address, config.Timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if network == "tcp-tls" {
+		host, _, _ := net.SplitHostPort(address)
+		tlsConfig := &tls.Config{ServerName: host, InsecureSkipVerify: config.Insecure}
+		if config.HasTLSConfig() && config.TLS.isValid() == nil {
+			tlsConfig = configureTLS(tlsConfig, *config.TLS)
+		}
+		connection = tls.Client(connection, tlsConfig)
+	}
+	defer connection.Close()
+	_ = connection.SetDeadline(time.Now().Add(config.Timeout))
+	client := &dns.Client{Net: network, Timeout: config.Timeout}
+	r, _, err := client.ExchangeWithConn(m, &dns.Conn{Conn: connection})
+	return r, err
+}
+
+// exchangeDNSOverHTTPS sends a DNS query to the DNS over HTTPS resolver at the given URL, as described in RFC 8484
+func exchangeDNSOverHTTPS(url string, m *dns.Msg, config *Config) (*dns.Msg, error) {
+	// The ID of queries sent over HTTPS should be 0, so that the responses can be cached
+	query := m.Copy()
+	query.Id = 0
+	packedQuery, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(packedQuery))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", dnsMessageContentType)
+	request.Header.Set("Accept", dnsMessageContentType)
+	response, err := GetHTTPClient(config).Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DNS over HTTPS resolver returned status %d", response.StatusCode)
+	}
+	packedResponse, err := io.ReadAll(io.LimitReader(response.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, err
+	}
+	r := new(dns.Msg)
+	if err = r.Unpack(packedResponse); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// withDefaultPort returns the address with the given port if it doesn't already have one
+func withDefaultPort(address string, port int) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+	return net.JoinHostPort(strings.Trim(address, "[]"), strconv.Itoa(port))
+}
+
+// helper to reverse IP and add in-addr.arpa. IPv4 and IPv6
+func reverseNameForIP(ipStr string) (string, error) {
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP: %s", ipStr)
+	}
+
+	if ipv4 := ip.To4(); ipv4 != nil {
+		parts := strings.Split(ipv4.String(), ".")
+		for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+			parts[i], parts[j] = parts[j], parts[i]
+		}
+		return strings.Join(parts, ".") + ".in-addr.arpa.", nil
+	}
+
+	ip = ip.To16()
+	hexStr := hex.EncodeToString(ip)
+	nibbles := strings.Split(hexStr, "")
+	for i, j := 0, len(nibbles)-1; i < j; i, j = i+1, j-1 {
+		nibbles[i], nibbles[j] = nibbles[j], nibbles[i]
+	}
+	return strings.Join(nibbles, ".") + ".ip6.arpa.", nil
+}
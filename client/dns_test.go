@@ -0,0 +1,238 @@
+package client
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// newTestDNSHandler returns a handler that answers every query for example.org. with two A records, and every other
+// query with NXDOMAIN
+func newTestDNSHandler() dns.HandlerFunc {
+	return func(w dns.ResponseWriter, r *dns.Msg) {
+		_ = w.WriteMsg(newTestDNSResponse(r))
+	}
+}
+
+func newTestDNSResponse(r *dns.Msg) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetReply(r)
+	if r.Question[0].Name != "example.org." {
+		m.Rcode = dns.RcodeNameError
+		return m
+	}
+	for _, ip := range []string{"192.0.2.1", "192.0.2.2"} {
+		m.Answer = append(m.Answer, &dns.A{
+			Hdr: dns.RR_Header{Name: "example.org.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 300},
+			A:   net.ParseIP(ip),
+		})
+	}
+	return m
+}
+
+func startTestDNSServer(t *testing.T, server *dns.Server) {
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	<-started
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+}
+
+func TestQueryDNS_WithLocalResolver(t *testing.T) {
+	packetConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	startTestDNSServer(t, &dns.Server{PacketConn: packetConn, Handler: newTestDNSHandler()})
+	certificate, err := tls.LoadX509KeyPair("../testdata/cert.pem", "../testdata/cert.key")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	tlsListener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{certificate}})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	startTestDNSServer(t, &dns.Server{Listener: tlsListener, Net: "tcp-tls", Handler: newTestDNSHandler()})
+	dohServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dnsMessageContentType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		packedQuery, _ := io.ReadAll(r.Body)
+		query := new(dns.Msg)
+		if err := query.Unpack(packedQuery); err != nil || query.Id != 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		packedResponse, _ := newTestDNSResponse(query).Pack()
+		w.Header().Set("Content-Type", dnsMessageContentType)
+		_, _ = w.Write(packedResponse)
+	}))
+	defer dohServer.Close()
+	cfg := &Config{Insecure: true, Timeout: 5 * time.Second}
+	scenarios := []struct {
+		name            string
+		url             string
+		queryName       string
+		expectedDNSCode string
+		expectedAnswers []DNSAnswer
+	}{
+		{
+			name:            "udp",
+			url:             packetConn.LocalAddr().String(),
+			queryName:       "example.org.",
+			expectedDNSCode: "NOERROR",
+			expectedAnswers: []DNSAnswer{
+				{Name: "example.org.", Type: "A", TTL: 300, Value: "192.0.2.1"},
+				{Name: "example.org.", Type: "A", TTL: 300, Value: "192.0.2.2"},
+			},
+		},
+		{
+			name:            "udp-nxdomain",
+			url:             packetConn.LocalAddr().String(),
+			queryName:       "example.com.",
+			expectedDNSCode: "NXDOMAIN",
+			expectedAnswers: []DNSAnswer{},
+		},
+		{
+			name:            "dns-over-tls",
+			url:             "tls://" + tlsListener.Addr().String(),
+			queryName:       "example.org.",
+			expectedDNSCode: "NOERROR",
+			expectedAnswers: []DNSAnswer{
+				{Name: "example.org.", Type: "A", TTL: 300, Value: "192.0.2.1"},
+				{Name: "example.org.", Type: "A", TTL: 300, Value: "192.0.2.2"},
+			},
+		},
+		{
+			name:            "dns-over-https",
+			url:             dohServer.URL + "/dns-query",
+			queryName:       "example.org.",
+			expectedDNSCode: "NOERROR",
+			expectedAnswers: []DNSAnswer{
+				{Name: "example.org.", Type: "A", TTL: 300, Value: "192.0.2.1"},
+				{Name: "example.org.", Type: "A", TTL: 300, Value: "192.0.2.2"},
+			},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, dnsRCode, body, dnssecValid, err := QueryDNS("A", scenario.queryName, scenario.url, false, cfg)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if !connected {
+				t.Error("expected to be connected")
+			}
+			if dnssecValid {
+				t.Error("expected dnssecValid to be false, since DNSSEC is disabled")
+			}
+			if dnsRCode != scenario.expectedDNSCode {
+				t.Errorf("expected DNSRCode to be %s, got %s", scenario.expectedDNSCode, dnsRCode)
+			}
+			var answers []DNSAnswer
+			if err := json.Unmarshal(body, &answers); err != nil {
+				t.Fatalf("expected body to be a JSON array of answers, got %s", string(body))
+			}
+			if len(answers) != len(scenario.expectedAnswers) {
+				t.Fatalf("expected %d answers, got %s", len(scenario.expectedAnswers), string(body))
+			}
+			for i, answer := range answers {
+				if answer != scenario.expectedAnswers[i] {
+					t.Errorf("expected answer %d to be %v, got %v", i, scenario.expectedAnswers[i], answer)
+				}
+			}
+		})
+	}
+	t.Run("dnssec-with-unsigned-answers", func(t *testing.T) {
+		_, dnsRCode, _, dnssecValid, err := QueryDNS("A", "example.org.", packetConn.LocalAddr().String(), true, cfg)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if dnsRCode != "NOERROR" {
+			t.Errorf("expected DNSRCode to be NOERROR, got %s", dnsRCode)
+		}
+		if dnssecValid {
+			t.Error("expected dnssecValid to be false, since the answers are not signed")
+		}
+	})
+}
+
+func TestQueryDNS_WithUnsupportedResolverScheme(t *testing.T) {
+	connected, _, _, _, err := QueryDNS("A", "example.org.", "quic://1.1.1.1", false, nil)
+	if !errors.Is(err, ErrUnsupportedDNSResolverScheme) {
+		t.Errorf("expected error to be %v, got %v", ErrUnsupportedDNSResolverScheme, err)
+	}
+	if connected {
+		t.Error("expected to not be connected")
+	}
+}
+
+func TestNewDNSAnswer(t *testing.T) {
+	scenarios := []struct {
+		record        string
+		expectedType  string
+		expectedValue string
+	}{
+		{record: "example.org. 300 IN A 192.0.2.1", expectedType: "A", expectedValue: "192.0.2.1"},
+		{record: "example.org. 300 IN AAAA 2001:db8::1", expectedType: "AAAA", expectedValue: "2001:db8::1"},
+		{record: "www.example.org. 300 IN CNAME example.org.", expectedType: "CNAME", expectedValue: "example.org."},
+		{record: "example.org. 300 IN MX 10 mail.example.org.", expectedType: "MX", expectedValue: "mail.example.org."},
+		{record: "example.org. 300 IN NS ns1.example.org.", expectedType: "NS", expectedValue: "ns1.example.org."},
+		{record: "1.2.0.192.in-addr.arpa. 300 IN PTR example.org.", expectedType: "PTR", expectedValue: "example.org."},
+		{record: "_sip._tcp.example.org. 300 IN SRV 10 60 5060 sip.example.org.", expectedType: "SRV", expectedValue: "sip.example.org.:5060"},
+		{record: `example.org. 300 IN TXT "v=spf1 " "-all"`, expectedType: "TXT", expectedValue: "v=spf1 -all"},
+		{record: `example.org. 300 IN CAA 0 issue "letsencrypt.org"`, expectedType: "CAA", expectedValue: `0 issue "letsencrypt.org"`},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.expectedType, func(t *testing.T) {
+			rr, err := dns.NewRR(scenario.record)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			answer := newDNSAnswer(rr)
+			if answer.Type != scenario.expectedType {
+				t.Errorf("expected type to be %s, got %s", scenario.expectedType, answer.Type)
+			}
+			if answer.Value != scenario.expectedValue {
+				t.Errorf("expected value to be %s, got %s", scenario.expectedValue, answer.Value)
+			}
+			if answer.TTL != 300 {
+				t.Errorf("expected TTL to be 300, got %d", answer.TTL)
+			}
+		})
+	}
+}
+
+func TestWithDefaultPort(t *testing.T) {
+	scenarios := []struct {
+		address  string
+		expected string
+	}{
+		{address: "8.8.8.8", expected: "8.8.8.8:53"},
+		{address: "8.8.8.8:5353", expected: "8.8.8.8:5353"},
+		{address: "dns.google", expected: "dns.google:53"},
+		{address: "2001:4860:4860::8888", expected: "[2001:4860:4860::8888]:53"},
+		{address: "[2001:4860:4860::8888]", expected: "[2001:4860:4860::8888]:53"},
+		{address: "[2001:4860:4860::8888]:5353", expected: "[2001:4860:4860::8888]:5353"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.address, func(t *testing.T) {
+			if actual := withDefaultPort(scenario.address, dnsPort); actual != scenario.expected {
+				t.Errorf("expected %s, got %s", scenario.expected, actual)
+			}
+		})
+	}
+}
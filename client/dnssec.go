@@ -0,0 +1,210 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+var (
+	// rootTrustAnchors are the DS records of the key signing keys of the root zone, as published by IANA at
+	// https://data.iana.org/root-anchors/root-anchors.xml
+	rootTrustAnchors = mustParseDS(
+		". IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+		". IN DS 38696 8 2 683D2D0ACB8C9B712A1948B27F741219298D0A450D612C483AF444A4C0FB2B16",
+	)
+
+	errDNSSECNoAnswer         = errors.New("there is no answer to validate")
+	errDNSSECNoValidSignature = errors.New("no valid signature")
+)
+
+// dnssecValidator validates DNS answers by verifying their signatures, as well as the chain of trust from the zone that
+// signed them up to the root zone, whose keys must match the trust anchors
+type dnssecValidator struct {
+	exchange     dnsExchangeFunc
+	trustAnchors []*dns.DS
+
+	validatedKeys map[string][]*dns.DNSKEY // Keys of the zones that have already been validated, keyed by zone
+	pendingZones  map[string]bool          // Zones whose keys are being validated, used to detect loops
+}
+
+func newDNSSECValidator(exchange dnsExchangeFunc, trustAnchors []*dns.DS) *dnssecValidator {
+	return &dnssecValidator{
+		exchange:      exchange,
+		trustAnchors:  trustAnchors,
+		validatedKeys: make(map[string][]*dns.DNSKEY),
+		pendingZones:  make(map[string]bool),
+	}
+}
+
+// validateAnswer validates every record set of the answer section of a DNS response.
+//
+// Note that only answers can be validated, so the absence of records (e.g. NXDOMAIN) can't be validated.
+func (v *dnssecValidator) validateAnswer(answer []dns.RR) error {
+	rrsets, signatures := groupRRsets(answer)
+	if len(rrsets) == 0 {
+		return errDNSSECNoAnswer
+	}
+	for key, rrset := range rrsets {
+		if err := v.validateRRset(rrset, signatures[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRRset validates a record set using the signatures passed, at least one of which must be valid and made by a
+// validated key of the zone that the record set is part of
+func (v *dnssecValidator) validateRRset(rrset []dns.RR, signatures []*dns.RRSIG) error {
+	header := rrset[0].Header()
+	err := fmt.Errorf("%s %s: %w", header.Name, dns.TypeToString[header.Rrtype], errDNSSECNoValidSignature)
+	for _, signature := range signatures {
+		// A record set can only be signed by the zone it's part of, which is either its name or one of its ancestors
+		if !dns.IsSubDomain(signature.SignerName, header.Name) {
+			continue
+		}
+		keys, keysErr := v.getValidatedKeys(signature.SignerName)
+		if keysErr != nil {
+			err = keysErr
+			continue
+		}
+		if verifySignature(signature, keys, rrset) {
+			return nil
+		}
+	}
+	return err
+}
+
+// getValidatedKeys retrieves the keys of a zone, and returns them if they're signed by one of the key signing keys of
+// the zone, and if that key signing key is referenced by a validated DS record of the parent zone (or by a trust anchor
+// for the root zone)
+func (v *dnssecValidator) getValidatedKeys(zone string) ([]*dns.DNSKEY, error) {
+	zone = dns.CanonicalName(zone)
+	if keys, ok := v.validatedKeys[zone]; ok {
+		return keys, nil
+	}
+	if v.pendingZones[zone] {
+		return nil, fmt.Errorf("%s: loop in the chain of trust", zone)
+	}
+	v.pendingZones[zone] = true
+	defer delete(v.pendingZones, zone)
+	keyRRset, keySignatures, err := v.query(zone, dns.TypeDNSKEY)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*dns.DNSKEY, 0, len(keyRRset))
+	for _, rr := range keyRRset {
+		keys = append(keys, rr.(*dns.DNSKEY))
+	}
+	dsRecords := v.trustAnchors
+	if zone != "." {
+		dsRRset, dsSignatures, err := v.query(zone, dns.TypeDS)
+		if err != nil {
+			return nil, err
+		}
+		if len(dsRRset) == 0 {
+			return nil, fmt.Errorf("%s: zone is not signed, or its delegation is not secure", zone)
+		}
+		// The DS records of a zone are part of its parent zone, which must not be the zone itself
+		for _, signature := range dsSignatures {
+			if dns.CanonicalName(signature.SignerName) == zone {
+				return nil, fmt.Errorf("%s: DS records must be signed by the parent zone", zone)
+			}
+		}
+		if err = v.validateRRset(dsRRset, dsSignatures); err != nil {
+			return nil, err
+		}
+		dsRecords = make([]*dns.DS, 0, len(dsRRset))
+		for _, rr := range dsRRset {
+			dsRecords = append(dsRecords, rr.(*dns.DS))
+		}
+	}
+	var keySigningKeys []*dns.DNSKEY
+	for _, key := range keys {
+		for _, ds := range dsRecords {
+			if expectedDS := key.ToDS(ds.DigestType); expectedDS != nil && expectedDS.KeyTag == ds.KeyTag && expectedDS.Algorithm == ds.Algorithm && strings.EqualFold(expectedDS.Digest, ds.Digest) {
+				keySigningKeys = append(keySigningKeys, key)
+				break
+			}
+		}
+	}
+	if len(keySigningKeys) == 0 {
+		return nil, fmt.Errorf("%s: no key matches the DS records of the zone", zone)
+	}
+	for _, signature := range keySignatures {
+		if verifySignature(signature, keySigningKeys, keyRRset) {
+			v.validatedKeys[zone] = keys
+			return keys, nil
+		}
+	}
+	return nil, fmt.Errorf("%s DNSKEY: %w", zone, errDNSSECNoValidSignature)
+}
+
+// query retrieves the record set of the given type at the given name, along with its signatures
+func (v *dnssecValidator) query(name string, queryType uint16) ([]dns.RR, []*dns.RRSIG, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(name, queryType)
+	m.SetEdns0(dnsUDPSize, true)
+	r, err := v.exchange(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.Rcode != dns.RcodeSuccess {
+		return nil, nil, fmt.Errorf("%s %s: unexpected response code %s", name, dns.TypeToString[queryType], dns.RcodeToString[r.Rcode])
+	}
+	rrsets, signatures := groupRRsets(r.Answer)
+	key := rrsetKey{name: dns.CanonicalName(name), rrtype: queryType}
+	return rrsets[key], signatures[key], nil
+}
+
+// rrsetKey identifies a record set
+type rrsetKey struct {
+	name   string
+	rrtype uint16
+}
+
+// groupRRsets groups resource records by record set, and returns them along with the signatures of each record set
+func groupRRsets(records []dns.RR) (map[rrsetKey][]dns.RR, map[rrsetKey][]*dns.RRSIG) {
+	rrsets := make(map[rrsetKey][]dns.RR)
+	signatures := make(map[rrsetKey][]*dns.RRSIG)
+	for _, rr := range records {
+		name := dns.CanonicalName(rr.Header().Name)
+		if signature, ok := rr.(*dns.RRSIG); ok {
+			key := rrsetKey{name: name, rrtype: signature.TypeCovered}
+			signatures[key] = append(signatures[key], signature)
+			continue
+		}
+		key := rrsetKey{name: name, rrtype: rr.Header().Rrtype}
+		rrsets[key] = append(rrsets[key], rr)
+	}
+	return rrsets, signatures
+}
+
+// verifySignature returns whether the signature of the record set is currently valid and was made by one of the keys
+func verifySignature(signature *dns.RRSIG, keys []*dns.DNSKEY, rrset []dns.RR) bool {
+	if !signature.ValidityPeriod(time.Now()) {
+		return false
+	}
+	for _, key := range keys {
+		if key.KeyTag() == signature.KeyTag && key.Algorithm == signature.Algorithm && signature.Verify(key, rrset) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// mustParseDS parses DS records in their presentation format, and panics if one of them is invalid
+func mustParseDS(records ...string) []*dns.DS {
+	dsRecords := make([]*dns.DS, 0, len(records))
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			panic(err)
+		}
+		dsRecords = append(dsRecords, rr.(*dns.DS))
+	}
+	return dsRecords
+}
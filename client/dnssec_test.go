@@ -0,0 +1,241 @@
+package client
+
+import (
+	"crypto"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// testSignedZone is a zone signed with a single key, which is used both as key signing key and as zone signing key
+type testSignedZone struct {
+	name       string
+	key        *dns.DNSKEY
+	privateKey crypto.Signer
+}
+
+func newTestSignedZone(t *testing.T, name string) *testSignedZone {
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: name, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     dns.ZONE | dns.SEP,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	privateKey, err := key.Generate(256)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	return &testSignedZone{name: name, key: key, privateKey: privateKey.(crypto.Signer)}
+}
+
+func (z *testSignedZone) ds() *dns.DS {
+	return z.key.ToDS(dns.SHA256)
+}
+
+func (z *testSignedZone) sign(t *testing.T, rrset []dns.RR, inception, expiration time.Time) *dns.RRSIG {
+	signature := &dns.RRSIG{
+		Hdr:        dns.RR_Header{Name: rrset[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: rrset[0].Header().Ttl},
+		Algorithm:  z.key.Algorithm,
+		Inception:  uint32(inception.Unix()),
+		Expiration: uint32(expiration.Unix()),
+		KeyTag:     z.key.KeyTag(),
+		SignerName: z.name,
+	}
+	if err := signature.Sign(z.privateKey, rrset); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	return signature
+}
+
+// testSignedRecords holds the record sets, along with their signatures, that the fake resolver answers with
+type testSignedRecords map[rrsetKey][]dns.RR
+
+func (records testSignedRecords) add(t *testing.T, signer *testSignedZone, rrset ...dns.RR) {
+	records.addWithValidity(t, signer, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), rrset...)
+}
+
+func (records testSignedRecords) addWithValidity(t *testing.T, signer *testSignedZone, inception, expiration time.Time, rrset ...dns.RR) {
+	key := rrsetKey{name: rrset[0].Header().Name, rrtype: rrset[0].Header().Rrtype}
+	records[key] = append(records[key], rrset...)
+	records[key] = append(records[key], signer.sign(t, rrset, inception, expiration))
+}
+
+func (records testSignedRecords) exchange(m *dns.Msg) (*dns.Msg, error) {
+	r := new(dns.Msg)
+	r.SetReply(m)
+	r.Answer = records[rrsetKey{name: m.Question[0].Name, rrtype: m.Question[0].Qtype}]
+	return r, nil
+}
+
+func TestDNSSECValidator_validateAnswer(t *testing.T) {
+	root := newTestSignedZone(t, ".")
+	org := newTestSignedZone(t, "org.")
+	exampleOrg := newTestSignedZone(t, "example.org.")
+	attacker := newTestSignedZone(t, "example.org.")
+	newA := func(name, ip string) dns.RR {
+		return &dns.A{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 300}, A: net.ParseIP(ip)}
+	}
+	// newChainOfTrust returns the records of a chain of trust from the root zone down to example.org.
+	newChainOfTrust := func() testSignedRecords {
+		records := make(testSignedRecords)
+		records.add(t, root, root.key)
+		records.add(t, root, org.ds())
+		records.add(t, org, org.key)
+		records.add(t, org, exampleOrg.ds())
+		records.add(t, exampleOrg, exampleOrg.key)
+		return records
+	}
+	scenarios := []struct {
+		name          string
+		records       testSignedRecords
+		answer        func(records testSignedRecords) []dns.RR
+		trustAnchors  []*dns.DS
+		expectedValid bool
+	}{
+		{
+			name:    "valid",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.add(t, exampleOrg, newA("example.org.", "192.0.2.1"), newA("example.org.", "192.0.2.2"))
+				return records[rrsetKey{name: "example.org.", rrtype: dns.TypeA}]
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: true,
+		},
+		{
+			name:    "valid-subdomain-signed-by-its-zone",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.add(t, exampleOrg, newA("www.example.org.", "192.0.2.1"))
+				return records[rrsetKey{name: "www.example.org.", rrtype: dns.TypeA}]
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: true,
+		},
+		{
+			name:    "tampered-answer",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.add(t, exampleOrg, newA("example.org.", "192.0.2.1"))
+				answer := records[rrsetKey{name: "example.org.", rrtype: dns.TypeA}]
+				answer[0].(*dns.A).A = net.ParseIP("198.51.100.1")
+				return answer
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: false,
+		},
+		{
+			name:    "unsigned-answer",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				return []dns.RR{newA("example.org.", "192.0.2.1")}
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: false,
+		},
+		{
+			name:    "expired-signature",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.addWithValidity(t, exampleOrg, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour), newA("example.org.", "192.0.2.1"))
+				return records[rrsetKey{name: "example.org.", rrtype: dns.TypeA}]
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: false,
+		},
+		{
+			name: "missing-ds",
+			records: func() testSignedRecords {
+				records := make(testSignedRecords)
+				records.add(t, root, root.key)
+				records.add(t, root, org.ds())
+				records.add(t, org, org.key)
+				records.add(t, exampleOrg, exampleOrg.key)
+				return records
+			}(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.add(t, exampleOrg, newA("example.org.", "192.0.2.1"))
+				return records[rrsetKey{name: "example.org.", rrtype: dns.TypeA}]
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: false,
+		},
+		{
+			name: "ds-signed-by-the-zone-itself",
+			records: func() testSignedRecords {
+				records := make(testSignedRecords)
+				records.add(t, root, root.key)
+				records.add(t, root, org.ds())
+				records.add(t, org, org.key)
+				records.add(t, attacker, attacker.ds())
+				records.add(t, attacker, attacker.key)
+				return records
+			}(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.add(t, attacker, newA("example.org.", "192.0.2.1"))
+				return records[rrsetKey{name: "example.org.", rrtype: dns.TypeA}]
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: false,
+		},
+		{
+			name:    "signed-by-key-not-referenced-by-ds",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.add(t, attacker, newA("example.org.", "192.0.2.1"))
+				return records[rrsetKey{name: "example.org.", rrtype: dns.TypeA}]
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: false,
+		},
+		{
+			name:    "signed-by-zone-that-is-not-an-ancestor",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.add(t, exampleOrg, newA("example.com.", "192.0.2.1"))
+				return records[rrsetKey{name: "example.com.", rrtype: dns.TypeA}]
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: false,
+		},
+		{
+			name:    "untrusted-root",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				records.add(t, exampleOrg, newA("example.org.", "192.0.2.1"))
+				return records[rrsetKey{name: "example.org.", rrtype: dns.TypeA}]
+			},
+			trustAnchors:  rootTrustAnchors,
+			expectedValid: false,
+		},
+		{
+			name:    "no-answer",
+			records: newChainOfTrust(),
+			answer: func(records testSignedRecords) []dns.RR {
+				return nil
+			},
+			trustAnchors:  []*dns.DS{root.ds()},
+			expectedValid: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			answer := scenario.answer(scenario.records)
+			err := newDNSSECValidator(scenario.records.exchange, scenario.trustAnchors).validateAnswer(answer)
+			if scenario.expectedValid && err != nil {
+				t.Errorf("expected answer to be valid, got %v", err)
+			}
+			if !scenario.expectedValid && err == nil {
+				t.Error("expected answer to be invalid")
+			}
+		})
+	}
+	t.Run("no-answer-error", func(t *testing.T) {
+		if err := newDNSSECValidator(nil, nil).validateAnswer(nil); !errors.Is(err, errDNSSECNoAnswer) {
+			t.Errorf("expected error to be %v, got %v", errDNSSECNoAnswer, err)
+		}
+	})
+}
@@ -3,14 +3,52 @@ package client
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/TwiN/logr"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	// grpcStatusCodeNames are the canonical names of the gRPC status codes, as defined in
+	// https://github.com/grpc/grpc/blob/master/doc/statuscodes.md
+	grpcStatusCodeNames = map[codes.Code]string{
+		codes.OK:                 "OK",
+		codes.Canceled:           "CANCELLED",
+		codes.Unknown:            "UNKNOWN",
+		codes.InvalidArgument:    "INVALID_ARGUMENT",
+		codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+		codes.NotFound:           "NOT_FOUND",
+		codes.AlreadyExists:      "ALREADY_EXISTS",
+		codes.PermissionDenied:   "PERMISSION_DENIED",
+		codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+		codes.FailedPrecondition: "FAILED_PRECONDITION",
+		codes.Aborted:            "ABORTED",
+		codes.OutOfRange:         "OUT_OF_RANGE",
+		codes.Unimplemented:      "UNIMPLEMENTED",
+		codes.Internal:           "INTERNAL",
+		codes.Unavailable:        "UNAVAILABLE",
+		codes.DataLoss:           "DATA_LOSS",
+		codes.Unauthenticated:    "UNAUTHENTICATED",
+	}
+
+	// ErrGRPCStreamingMethod is the error returned when the gRPC method to call is not unary
+	ErrGRPCStreamingMethod = errors.New("only unary gRPC methods can be called")
 )
 
 // PerformGRPCHealthCheck dials a gRPC target and performs the standard Health/Check RPC.
@@ -22,6 +60,89 @@ func PerformGRPCHealthCheck(address string, useTLS bool, cfg *Config) (bool, str
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
 	defer cancel()
 
+	start := time.Now()
+	conn, err := grpc.DialContext(ctx, address, newGRPCDialOptions(useTLS, cfg)...)
+	if err != nil {
+		return false, "", err, time.Since(start)
+	}
+	defer conn.Close()
+
+	client := health.NewHealthClient(conn)
+	resp, err := client.Check(ctx, &health.HealthCheckRequest{Service: ""})
+	if err != nil {
+		return false, "", err, time.Since(start)
+	}
+	return true, resp.GetStatus().String(), nil, time.Since(start)
+}
+
+// PerformGRPCUnaryCall dials a gRPC target and calls a unary method (e.g. helloworld.Greeter/SayHello) with a request
+// decoded from the JSON body passed, and with the headers passed as metadata.
+// The types of the request and of the response are resolved through the descriptors passed, or through server
+// reflection if descriptors is nil.
+// Returns whether a connection was established, the status code of the call (e.g. OK, NOT_FOUND), the response encoded
+// as JSON, an error (if any), and the elapsed duration.
+func PerformGRPCUnaryCall(address string, useTLS bool, method, body string, headers map[string]string, descriptors *protoregistry.Files, cfg *Config) (bool, string, []byte, error, time.Duration) {
+	if cfg == nil {
+		cfg = GetDefaultConfig()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	opts := newGRPCDialOptions(useTLS, cfg)
+	md := metadata.MD{}
+	for name, value := range headers {
+		// The user agent can't be passed as metadata, as it's reserved by the transport
+		if strings.EqualFold(name, "User-Agent") {
+			opts = append(opts, grpc.WithUserAgent(value))
+			continue
+		}
+		md.Append(name, value)
+	}
+	start := time.Now()
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return false, "", nil, err, time.Since(start)
+	}
+	defer conn.Close()
+
+	service, methodName, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if descriptors == nil {
+		if descriptors, err = describeGRPCService(ctx, conn, service); err != nil {
+			return isGRPCConnected(conn, err), "", nil, fmt.Errorf("failed to describe %s through server reflection: %w", service, err), time.Since(start)
+		}
+	}
+	methodDescriptor, err := findGRPCMethod(descriptors, service, methodName)
+	if err != nil {
+		return false, "", nil, err, time.Since(start)
+	}
+	types := dynamicpb.NewTypes(descriptors)
+	request := dynamicpb.NewMessage(methodDescriptor.Input())
+	if len(strings.TrimSpace(body)) > 0 {
+		if err = (protojson.UnmarshalOptions{Resolver: types}).Unmarshal([]byte(body), request); err != nil {
+			return false, "", nil, fmt.Errorf("invalid request body for %s: %w", method, err), time.Since(start)
+		}
+	}
+	response := dynamicpb.NewMessage(methodDescriptor.Output())
+	err = conn.Invoke(metadata.NewOutgoingContext(ctx, md), "/"+service+"/"+methodName, request, response)
+	duration := time.Since(start)
+	if err != nil {
+		grpcStatus, ok := status.FromError(err)
+		if !ok || !isGRPCConnected(conn, err) {
+			return false, "", nil, err, duration
+		}
+		// The method was called but failed, which is reflected by the status code rather than by an error, so that it
+		// can be used in conditions
+		return true, grpcStatusCodeNames[grpcStatus.Code()], nil, nil, duration
+	}
+	responseBody, err := protojson.MarshalOptions{Resolver: types, EmitUnpopulated: true}.Marshal(response)
+	if err != nil {
+		return true, grpcStatusCodeNames[codes.OK], nil, err, duration
+	}
+	return true, grpcStatusCodeNames[codes.OK], responseBody, nil, duration
+}
+
+// newGRPCDialOptions returns the options to dial a gRPC target with, based on the client configuration
+func newGRPCDialOptions(useTLS bool, cfg *Config) []grpc.DialOption {
 	var opts []grpc.DialOption
 	// Transport credentials
 	if useTLS {
@@ -42,7 +163,7 @@ func PerformGRPCHealthCheck(address string, useTLS bool, cfg *Config) (bool, str
 			resolverCfg, err := cfg.parseDNSResolver()
 			if err != nil {
 				// Shouldn't happen because already validated; log and fall back
-				logr.Errorf("[client.PerformGRPCHealthCheck] invalid DNS resolver: %v", err)
+				logr.Errorf("[client.newGRPCDialOptions] invalid DNS resolver: %v", err)
 			} else {
 				d := &net.Dialer{Resolver: &net.Resolver{PreferGo: true, Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
 					d := net.Dialer{}
@@ -54,18 +175,35 @@ func PerformGRPCHealthCheck(address string, useTLS bool, cfg *Config) (bool, str
 		var d net.Dialer
 		return d.DialContext(ctx, "tcp", addr)
 	}))
+	return opts
+}
 
-	start := time.Now()
-	conn, err := grpc.DialContext(ctx, address, opts...)
-	if err != nil {
-		return false, "", err, time.Since(start)
+// isGRPCConnected returns whether the error of a call was returned by the server, as opposed to being caused by the
+// connection to the server failing
+func isGRPCConnected(conn *grpc.ClientConn, err error) bool {
+	if conn.GetState() == connectivity.Ready {
+		return true
 	}
-	defer conn.Close()
+	code := status.Code(err)
+	return code != codes.Unavailable && code != codes.DeadlineExceeded && code != codes.Unknown
+}
 
-	client := health.NewHealthClient(conn)
-	resp, err := client.Check(ctx, &health.HealthCheckRequest{Service: ""})
+// findGRPCMethod returns the descriptor of a unary method of a service
+func findGRPCMethod(descriptors *protoregistry.Files, service, method string) (protoreflect.MethodDescriptor, error) {
+	descriptor, err := descriptors.FindDescriptorByName(protoreflect.FullName(service))
 	if err != nil {
-		return false, "", err, time.Since(start)
+		return nil, fmt.Errorf("service %s not found: %w", service, err)
 	}
-	return true, resp.GetStatus().String(), nil, time.Since(start)
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(method))
+	if methodDescriptor == nil {
+		return nil, fmt.Errorf("method %s not found in service %s", method, service)
+	}
+	if methodDescriptor.IsStreamingClient() || methodDescriptor.IsStreamingServer() {
+		return nil, fmt.Errorf("%s/%s: %w", service, method, ErrGRPCStreamingMethod)
+	}
+	return methodDescriptor, nil
 }
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionalphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// grpcReflectionStream is a stream of the server reflection protocol
+type grpcReflectionStream interface {
+	Send(request *reflectionpb.ServerReflectionRequest) error
+	Recv() (*reflectionpb.ServerReflectionResponse, error)
+	CloseSend() error
+}
+
+// describeGRPCService retrieves the descriptors of the files that describe a service, along with their dependencies,
+// through server reflection
+func describeGRPCService(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	files, err := describeGRPCServiceWithStream(ctx, conn, service, newGRPCReflectionStream)
+	if status.Code(err) == codes.Unimplemented {
+		// Servers that predate v1 of the server reflection protocol only implement v1alpha
+		files, err = describeGRPCServiceWithStream(ctx, conn, service, newGRPCReflectionV1AlphaStream)
+	}
+	return files, err
+}
+
+func describeGRPCServiceWithStream(ctx context.Context, conn *grpc.ClientConn, service string, newStream func(context.Context, *grpc.ClientConn) (grpcReflectionStream, error)) (*protoregistry.Files, error) {
+	stream, err := newStream(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+	fileDescriptors := make(map[string]*descriptorpb.FileDescriptorProto)
+	err = requestGRPCFileDescriptors(stream, &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}, fileDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	// Servers may not return every dependency of the file that describes the service, in which case the missing
+	// dependencies are requested one by one
+	for missingDependencies := getMissingDependencies(fileDescriptors); len(missingDependencies) > 0; missingDependencies = getMissingDependencies(fileDescriptors) {
+		for _, dependency := range missingDependencies {
+			// Well-known types may not be served, but they're part of the global registry
+			if fileDescriptor, err := protoregistry.GlobalFiles.FindFileByPath(dependency); err == nil {
+				fileDescriptors[dependency] = protodesc.ToFileDescriptorProto(fileDescriptor)
+				continue
+			}
+			err = requestGRPCFileDescriptors(stream, &reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dependency},
+			}, fileDescriptors)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := fileDescriptors[dependency]; !ok {
+				return nil, fmt.Errorf("server did not return file %s", dependency)
+			}
+		}
+	}
+	fileDescriptorSet := &descriptorpb.FileDescriptorSet{}
+	for _, fileDescriptor := range fileDescriptors {
+		fileDescriptorSet.File = append(fileDescriptorSet.File, fileDescriptor)
+	}
+	return protodesc.NewFiles(fileDescriptorSet)
+}
+
+// requestGRPCFileDescriptors sends a request for file descriptors through a server reflection stream, and adds the
+// file descriptors of the response to the map passed
+func requestGRPCFileDescriptors(stream grpcReflectionStream, request *reflectionpb.ServerReflectionRequest, fileDescriptors map[string]*descriptorpb.FileDescriptorProto) error {
+	if err := stream.Send(request); err != nil {
+		return err
+	}
+	response, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if errorResponse := response.GetErrorResponse(); errorResponse != nil {
+		return status.Error(codes.Code(errorResponse.GetErrorCode()), errorResponse.GetErrorMessage())
+	}
+	for _, serializedFileDescriptor := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		fileDescriptor := new(descriptorpb.FileDescriptorProto)
+		if err = proto.Unmarshal(serializedFileDescriptor, fileDescriptor); err != nil {
+			return err
+		}
+		fileDescriptors[fileDescriptor.GetName()] = fileDescriptor
+	}
+	return nil
+}
+
+// getMissingDependencies returns the dependencies of the file descriptors that are not part of the file descriptors
+func getMissingDependencies(fileDescriptors map[string]*descriptorpb.FileDescriptorProto) []string {
+	var missingDependencies []string
+	for _, fileDescriptor := range fileDescriptors {
+		for _, dependency := range fileDescriptor.GetDependency() {
+			if _, ok := fileDescriptors[dependency]; !ok {
+				missingDependencies = append(missingDependencies, dependency)
+			}
+		}
+	}
+	return missingDependencies
+}
+
+func newGRPCReflectionStream(ctx context.Context, conn *grpc.ClientConn) (grpcReflectionStream, error) {
+	return reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+}
+
+func newGRPCReflectionV1AlphaStream(ctx context.Context, conn *grpc.ClientConn) (grpcReflectionStream, error) {
+	stream, err := reflectionalphapb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcReflectionV1AlphaStream{stream: stream}, nil
+}
+
+// grpcReflectionV1AlphaStream is a stream of v1alpha of the server reflection protocol, whose messages are converted
+// from and to the messages of v1, which are identical on the wire
+type grpcReflectionV1AlphaStream struct {
+	stream reflectionalphapb.ServerReflection_ServerReflectionInfoClient
+}
+
+func (s *grpcReflectionV1AlphaStream) Send(request *reflectionpb.ServerReflectionRequest) error {
+	alphaRequest := new(reflectionalphapb.ServerReflectionRequest)
+	if err := convertProtoMessage(request, alphaRequest); err != nil {
+		return err
+	}
+	return s.stream.Send(alphaRequest)
+}
+
+func (s *grpcReflectionV1AlphaStream) Recv() (*reflectionpb.ServerReflectionResponse, error) {
+	alphaResponse, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	response := new(reflectionpb.ServerReflectionResponse)
+	if err = convertProtoMessage(alphaResponse, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *grpcReflectionV1AlphaStream) CloseSend() error {
+	return s.stream.CloseSend()
+}
+
+// convertProtoMessage converts a message into another message with the same wire format
+func convertProtoMessage(from, to proto.Message) error {
+	data, err := proto.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, to)
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionalphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// startTestGRPCServer starts a gRPC server implementing the health service, and returns its address along with a
+// function returning the metadata of the last call received
+func startTestGRPCServer(t *testing.T, register func(server *grpc.Server)) (string, func() metadata.MD) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	var lastMetadata metadata.MD
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		lastMetadata, _ = metadata.FromIncomingContext(ctx)
+		return handler(ctx, req)
+	}))
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("down", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("starting", healthpb.HealthCheckResponse_UNKNOWN)
+	healthpb.RegisterHealthServer(server, healthServer)
+	if register != nil {
+		register(server)
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), func() metadata.MD { return lastMetadata }
+}
+
+func TestPerformGRPCUnaryCall(t *testing.T) {
+	addressWithReflection, getMetadata := startTestGRPCServer(t, func(server *grpc.Server) { reflection.Register(server) })
+	addressWithV1AlphaReflection, _ := startTestGRPCServer(t, func(server *grpc.Server) {
+		reflectionalphapb.RegisterServerReflectionServer(server, reflection.NewServer(reflection.ServerOptions{Services: server}))
+	})
+	addressWithoutReflection, _ := startTestGRPCServer(t, nil)
+	descriptors, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto),
+	}})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	cfg := &Config{Timeout: 5 * time.Second}
+	scenarios := []struct {
+		name              string
+		address           string
+		method            string
+		body              string
+		descriptors       *protoregistry.Files
+		expectedConnected bool
+		expectedStatus    string
+		expectedBody      string
+		expectedErr       string
+	}{
+		{
+			name:              "reflection",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Check",
+			body:              `{"service":""}`,
+			expectedConnected: true,
+			expectedStatus:    "OK",
+			expectedBody:      `{"status":"SERVING"}`,
+		},
+		{
+			name:              "reflection-with-empty-body",
+			address:           addressWithReflection,
+			method:            "/grpc.health.v1.Health/Check",
+			expectedConnected: true,
+			expectedStatus:    "OK",
+			expectedBody:      `{"status":"SERVING"}`,
+		},
+		{
+			name:              "reflection-with-unpopulated-fields",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Check",
+			body:              `{"service":"starting"}`,
+			expectedConnected: true,
+			expectedStatus:    "OK",
+			expectedBody:      `{"status":"UNKNOWN"}`,
+		},
+		{
+			name:              "reflection-with-map-in-response",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/List",
+			expectedConnected: true,
+			expectedStatus:    "OK",
+			expectedBody:      `"down":{"status":"NOT_SERVING"}`,
+		},
+		{
+			name:              "reflection-v1alpha",
+			address:           addressWithV1AlphaReflection,
+			method:            "grpc.health.v1.Health/Check",
+			body:              `{"service":"down"}`,
+			expectedConnected: true,
+			expectedStatus:    "OK",
+			expectedBody:      `{"status":"NOT_SERVING"}`,
+		},
+		{
+			name:              "descriptors",
+			address:           addressWithoutReflection,
+			method:            "grpc.health.v1.Health/Check",
+			body:              `{"service":""}`,
+			descriptors:       descriptors,
+			expectedConnected: true,
+			expectedStatus:    "OK",
+			expectedBody:      `{"status":"SERVING"}`,
+		},
+		{
+			name:              "error-status",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Health/Check",
+			body:              `{"service":"unknown"}`,
+			expectedConnected: true,
+			expectedStatus:    "NOT_FOUND",
+		},
+		{
+			name:              "no-reflection",
+			address:           addressWithoutReflection,
+			method:            "grpc.health.v1.Health/Check",
+			expectedConnected: true,
+			expectedErr:       "server reflection",
+		},
+		{
+			name:              "unknown-service",
+			address:           addressWithReflection,
+			method:            "grpc.health.v1.Unknown/Check",
+			expectedConnected: true,
+			expectedErr:       "server reflection",
+		},
+		{
+			name:        "unknown-method",
+			address:     addressWithReflection,
+			method:      "grpc.health.v1.Health/Unknown",
+			expectedErr: "method Unknown not found",
+		},
+		{
+			name:        "streaming-method",
+			address:     addressWithReflection,
+			method:      "grpc.health.v1.Health/Watch",
+			expectedErr: ErrGRPCStreamingMethod.Error(),
+		},
+		{
+			name:        "invalid-body",
+			address:     addressWithReflection,
+			method:      "grpc.health.v1.Health/Check",
+			body:        `{"unknown":true}`,
+			expectedErr: "invalid request body",
+		},
+		{
+			name:        "connection-refused",
+			address:     "127.0.0.1:1",
+			method:      "grpc.health.v1.Health/Check",
+			descriptors: descriptors,
+			expectedErr: "connection refused",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, grpcStatus, body, err, _ := PerformGRPCUnaryCall(scenario.address, false, scenario.method, scenario.body, nil, scenario.descriptors, cfg)
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if grpcStatus != scenario.expectedStatus {
+				t.Errorf("expected status to be %s, got %s", scenario.expectedStatus, grpcStatus)
+			}
+			if len(scenario.expectedErr) == 0 && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if len(scenario.expectedErr) > 0 && (err == nil || !strings.Contains(err.Error(), scenario.expectedErr)) {
+				t.Errorf("expected error to contain %s, got %v", scenario.expectedErr, err)
+			}
+			if !strings.Contains(string(body), scenario.expectedBody) || (len(scenario.expectedBody) == 0 && len(body) > 0) {
+				t.Errorf("expected body to contain %s, got %s", scenario.expectedBody, string(body))
+			}
+		})
+	}
+	t.Run("metadata", func(t *testing.T) {
+		headers := map[string]string{"User-Agent": "Gatus/1.0", "Authorization": "Bearer token", "X-Request-Id": "1234"}
+		_, _, _, err, _ := PerformGRPCUnaryCall(addressWithReflection, false, "grpc.health.v1.Health/Check", "", headers, descriptors, cfg)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		md := getMetadata()
+		if values := md.Get("authorization"); len(values) != 1 || values[0] != "Bearer token" {
+			t.Errorf("expected authorization metadata to be sent, got %v", values)
+		}
+		if values := md.Get("x-request-id"); len(values) != 1 || values[0] != "1234" {
+			t.Errorf("expected x-request-id metadata to be sent, got %v", values)
+		}
+		if values := md.Get("user-agent"); len(values) != 1 || !strings.HasPrefix(values[0], "Gatus/1.0") {
+			t.Errorf("expected user agent to be Gatus/1.0, got %v", values)
+		}
+	})
+}
+
+func TestFindGRPCMethod_WithNonServiceName(t *testing.T) {
+	descriptors, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto),
+	}})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if _, err = findGRPCMethod(descriptors, "grpc.health.v1.HealthCheckRequest", "Check"); err == nil {
+		t.Error("expected an error, since HealthCheckRequest is a message")
+	}
+	if _, err = findGRPCMethod(descriptors, "grpc.health.v1.Health", "Watch"); !errors.Is(err, ErrGRPCStreamingMethod) {
+		t.Errorf("expected error to be %v, got %v", ErrGRPCStreamingMethod, err)
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// HTTPTimings are the durations of the phases of an HTTP request.
+//
+// If redirects were followed, they are the durations of the phases of the last request. The durations of the phases
+// that didn't happen, such as the DNS lookup, TCP connection and TLS handshake when an idle connection is reused, are 0.
+//
+// Since the hooks recording the durations may be called concurrently, the durations must be read through Snapshot.
+type HTTPTimings struct {
+	// DNSLookup is the time it took to resolve the host
+	DNSLookup time.Duration
+
+	// TCPConnect is the time it took to establish the TCP connection
+	TCPConnect time.Duration
+
+	// TLSHandshake is the time it took to complete the TLS handshake
+	TLSHandshake time.Duration
+
+	// TimeToFirstByte is the time between the moment the request was written and the moment the first byte of the
+	// response was received, which is the time the server took to start responding
+	TimeToFirstByte time.Duration
+
+	mutex             sync.Mutex
+	dnsStart          time.Time
+	connectStart      time.Time
+	tlsHandshakeStart time.Time
+	wroteRequest      time.Time
+}
+
+// WithHTTPTimings returns a copy of the request that records the duration of each of its phases in the timings passed
+func WithHTTPTimings(request *http.Request, timings *HTTPTimings) *http.Request {
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			timings.update(func() {
+				// Each request, including those made when following redirects, starts by getting a connection
+				timings.DNSLookup, timings.TCPConnect, timings.TLSHandshake, timings.TimeToFirstByte = 0, 0, 0, 0
+			})
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			timings.update(func() { timings.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			timings.update(func() { timings.DNSLookup = time.Since(timings.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			timings.update(func() {
+				// When a host resolves to several addresses, connections may be attempted in parallel
+				if timings.connectStart.IsZero() {
+					timings.connectStart = time.Now()
+				}
+			})
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				timings.update(func() {
+					timings.TCPConnect = time.Since(timings.connectStart)
+					timings.connectStart = time.Time{}
+				})
+			}
+		},
+		TLSHandshakeStart: func() {
+			timings.update(func() { timings.tlsHandshakeStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			timings.update(func() { timings.TLSHandshake = time.Since(timings.tlsHandshakeStart) })
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			timings.update(func() { timings.wroteRequest = time.Now() })
+		},
+		GotFirstResponseByte: func() {
+			timings.update(func() { timings.TimeToFirstByte = time.Since(timings.wroteRequest) })
+		},
+	}
+	return request.WithContext(httptrace.WithClientTrace(request.Context(), trace))
+}
+
+// Snapshot returns a copy of the durations recorded so far, which is safe to read even if the hooks of the trace are
+// still being called, e.g. by a connection attempt that was abandoned in favor of another
+func (t *HTTPTimings) Snapshot() *HTTPTimings {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return &HTTPTimings{DNSLookup: t.DNSLookup, TCPConnect: t.TCPConnect, TLSHandshake: t.TLSHandshake, TimeToFirstByte: t.TimeToFirstByte}
+}
+
+// update applies a change to the timings while holding the lock, since the hooks of a trace may be called concurrently
+func (t *HTTPTimings) update(change func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	change()
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithHTTPTimings(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/slow", http.StatusFound)
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+	scenarios := []struct {
+		name                 string
+		url                  string
+		expectedTCPConnect   bool
+		expectedTLSHandshake bool
+	}{
+		{name: "http", url: server.URL + "/slow", expectedTCPConnect: true},
+		{name: "https", url: tlsServer.URL + "/slow", expectedTCPConnect: true, expectedTLSHandshake: true},
+		// The timings are those of the last request, which reuses the connection of the request that was redirected
+		{name: "redirect", url: server.URL + "/redirect"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsServer.Client().Transport.(*http.Transport).TLSClientConfig}}
+			defer httpClient.CloseIdleConnections()
+			request, _ := http.NewRequest(http.MethodGet, scenario.url, nil)
+			timings := &HTTPTimings{}
+			response, err := httpClient.Do(WithHTTPTimings(request, timings))
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			_, _ = io.ReadAll(response.Body)
+			_ = response.Body.Close()
+			recorded := timings.Snapshot()
+			if recorded.DNSLookup != 0 {
+				t.Errorf("expected no DNS lookup, since the host is an IP, got %s", recorded.DNSLookup)
+			}
+			if scenario.expectedTCPConnect && recorded.TCPConnect <= 0 {
+				t.Errorf("expected the TCP connection to have been timed, got %s", recorded.TCPConnect)
+			}
+			if scenario.expectedTLSHandshake && recorded.TLSHandshake <= 0 {
+				t.Errorf("expected the TLS handshake to have been timed, got %s", recorded.TLSHandshake)
+			}
+			if !scenario.expectedTLSHandshake && recorded.TLSHandshake != 0 {
+				t.Errorf("expected no TLS handshake, got %s", recorded.TLSHandshake)
+			}
+			if recorded.TimeToFirstByte < 50*time.Millisecond {
+				t.Errorf("expected the time to first byte to include the time the server took to respond, got %s", recorded.TimeToFirstByte)
+			}
+			// The connection is now idle, so a second request must reuse it
+			request, _ = http.NewRequest(http.MethodGet, scenario.url, nil)
+			timings = &HTTPTimings{}
+			response, err = httpClient.Do(WithHTTPTimings(request, timings))
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			_ = response.Body.Close()
+			recorded = timings.Snapshot()
+			if recorded.TCPConnect != 0 || recorded.TLSHandshake != 0 {
+				t.Errorf("expected no TCP connection nor TLS handshake when reusing a connection, got %s and %s", recorded.TCPConnect, recorded.TLSHandshake)
+			}
+			if recorded.TimeToFirstByte < 50*time.Millisecond {
+				t.Errorf("expected the time to first byte to include the time the server took to respond, got %s", recorded.TimeToFirstByte)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	wsconfig "github.com/TwiN/gatus/v5/config/endpoint/websocket"
+	"github.com/TwiN/gatus/v5/pattern"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// webSocketOrigin is the origin sent when opening a websocket connection
+	webSocketOrigin = "http://localhost/"
+
+	// webSocketPingPayload is the payload of the pings sent during a websocket conversation
+	webSocketPingPayload = "gatus"
+)
+
+// WebSocketConversation is the outcome of a scripted conversation with a websocket server
+type WebSocketConversation struct {
+	// Messages are the data messages received from the server, in the order in which they were received
+	Messages [][]byte
+
+	// NamedMessages are the messages that completed the expect steps with a name, keyed by the name of the step
+	NamedMessages map[string][]byte
+
+	// CloseCode is the code of the close frame received from the server, or 0 if the server didn't close the connection
+	CloseCode int
+}
+
+// webSocketEvent is something received from a websocket server: a data message, a pong or an error, which is a
+// *websocket.CloseError if the connection was closed
+type webSocketEvent struct {
+	message []byte
+	pong    bool
+	err     error
+}
+
+// dialWebSocket opens a websocket connection
+func dialWebSocket(ctx context.Context, address string, headers map[string]string, config *Config) (*websocket.Conn, error) {
+	dialer := websocket.Dialer{
+		EnableCompression: true,
+	}
+	wsHeaders := make(http.Header)
+	wsHeaders.Set("Origin", webSocketOrigin)
+	for name, value := range headers {
+		wsHeaders.Set(name, value)
+	}
+	if config != nil {
+		dialer.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: config.Insecure,
+		}
+		if config.HasTLSConfig() && config.TLS.isValid() == nil {
+			dialer.TLSClientConfig = configureTLS(dialer.TLSClientConfig, *config.TLS)
+		}
+	}
+	ws, _, err := dialer.DialContext(ctx, address, wsHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing websocket: %w", err)
+	}
+	return ws, nil
+}
+
+// PerformWebSocketConversation opens a websocket connection and executes each step in order: sending a message,
+// waiting for a message matching a pattern, sending a ping and waiting for the pong, or closing the connection.
+// If the steps don't close the connection, it is closed with a normal closure once every step has been executed.
+//
+// The conversation is returned even if a step failed, in which case it only includes what happened until then.
+func PerformWebSocketConversation(address string, headers map[string]string, steps []*wsconfig.Step, config *Config) (bool, *WebSocketConversation, error) {
+	if config == nil {
+		config = GetDefaultConfig()
+	}
+	conversation := &WebSocketConversation{NamedMessages: make(map[string][]byte)}
+	dialCtx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	ws, err := dialWebSocket(dialCtx, address, headers, config)
+	if err != nil {
+		return false, conversation, err
+	}
+	defer ws.Close()
+	// Messages can only be read by a single reader, and pongs are only handled while reading, so everything received
+	// is read continuously and passed to the steps through a channel
+	events := make(chan webSocketEvent)
+	done := make(chan struct{})
+	defer close(done)
+	ws.SetPongHandler(func(string) error {
+		select {
+		case events <- webSocketEvent{pong: true}:
+		case <-done:
+		}
+		return nil
+	})
+	go func() {
+		for {
+			_, message, err := ws.ReadMessage()
+			select {
+			case events <- webSocketEvent{message: message, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	// waitFor consumes the events until one of them completes the step, the connection is closed or the timeout expires
+	waitFor := func(timeout time.Duration, description string, isComplete func(event webSocketEvent) bool) error {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		for {
+			select {
+			case event := <-events:
+				if event.err != nil {
+					var closeErr *websocket.CloseError
+					if errors.As(event.err, &closeErr) {
+						conversation.CloseCode = closeErr.Code
+						return fmt.Errorf("connection closed by server with code %d while waiting for %s", closeErr.Code, description)
+					}
+					return fmt.Errorf("error reading websocket message while waiting for %s: %w", description, event.err)
+				}
+				if !event.pong {
+					conversation.Messages = append(conversation.Messages, event.message)
+				}
+				if isComplete(event) {
+					return nil
+				}
+			case <-timer.C:
+				return fmt.Errorf("timed out after %s waiting for %s", timeout, description)
+			}
+		}
+	}
+	for i, step := range steps {
+		timeout := step.Timeout
+		if timeout == 0 {
+			timeout = config.Timeout
+		}
+		switch {
+		case len(step.Send) > 0:
+			_ = ws.SetWriteDeadline(time.Now().Add(timeout))
+			if err = ws.WriteMessage(websocket.TextMessage, []byte(parseLocalAddressPlaceholder(step.Send, ws.LocalAddr()))); err != nil {
+				return true, conversation, fmt.Errorf("step %d: error writing websocket message: %w", i+1, err)
+			}
+		case len(step.Expect) > 0:
+			err = waitFor(timeout, fmt.Sprintf("a message matching %s", step.Expect), func(event webSocketEvent) bool {
+				if event.pong || !pattern.Match(step.Expect, string(event.message)) {
+					return false
+				}
+				if len(step.Name) > 0 {
+					conversation.NamedMessages[step.Name] = event.message
+				}
+				return true
+			})
+			if err != nil {
+				return true, conversation, fmt.Errorf("step %d: %w", i+1, err)
+			}
+		case step.Ping:
+			if err = ws.WriteControl(websocket.PingMessage, []byte(webSocketPingPayload), time.Now().Add(timeout)); err != nil {
+				return true, conversation, fmt.Errorf("step %d: error writing websocket ping: %w", i+1, err)
+			}
+			if err = waitFor(timeout, "a pong", func(event webSocketEvent) bool { return event.pong }); err != nil {
+				return true, conversation, fmt.Errorf("step %d: %w", i+1, err)
+			}
+		case step.Close != 0:
+			if err = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(step.Close, ""), time.Now().Add(timeout)); err != nil {
+				return true, conversation, fmt.Errorf("step %d: error writing websocket close frame: %w", i+1, err)
+			}
+			// The server is expected to reply with a close frame, which ends the conversation
+			if err = waitFor(timeout, "the connection to be closed", func(webSocketEvent) bool { return false }); conversation.CloseCode == 0 {
+				return true, conversation, fmt.Errorf("step %d: %w", i+1, err)
+			}
+			return true, conversation, nil
+		}
+	}
+	_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	return true, conversation, nil
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	wsconfig "github.com/TwiN/gatus/v5/config/endpoint/websocket"
+	"github.com/gorilla/websocket"
+)
+
+// newTestWebSocketServer returns a websocket server that greets clients, only accepts subscriptions from clients that
+// authenticated first, and pushes a heartbeat followed by two updates to subscribers
+func newTestWebSocketServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("expected no error, got", err)
+			return
+		}
+		defer ws.Close()
+		_ = ws.WriteMessage(websocket.TextMessage, []byte(`{"type":"welcome"}`))
+		authenticated := false
+		for {
+			_, message, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			switch string(message) {
+			case "auth secret":
+				authenticated = true
+				_ = ws.WriteMessage(websocket.TextMessage, []byte(`{"type":"authenticated"}`))
+			case "subscribe":
+				if !authenticated {
+					_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "unauthenticated"), time.Now().Add(time.Second))
+					return
+				}
+				_ = ws.WriteMessage(websocket.TextMessage, []byte(`{"type":"heartbeat"}`))
+				_ = ws.WriteMessage(websocket.TextMessage, []byte(`{"type":"update","value":1}`))
+				_ = ws.WriteMessage(websocket.TextMessage, []byte(`{"type":"update","value":2}`))
+			default:
+				_ = ws.WriteMessage(websocket.TextMessage, []byte(`{"type":"echo","message":"`+string(message)+`"}`))
+			}
+		}
+	}))
+}
+
+func TestPerformWebSocketConversation(t *testing.T) {
+	server := newTestWebSocketServer(t)
+	defer server.Close()
+	address := "ws" + strings.TrimPrefix(server.URL, "http")
+	cfg := &Config{Timeout: 5 * time.Second}
+	scenarios := []struct {
+		name                  string
+		address               string
+		steps                 []*wsconfig.Step
+		expectedConnected     bool
+		expectedMessages      []string
+		expectedNamedMessages map[string]string
+		expectedCloseCode     int
+		expectedErr           string
+	}{
+		{
+			name:    "conversation",
+			address: address,
+			steps: []*wsconfig.Step{
+				{Send: "auth secret"},
+				{Expect: `*"authenticated"*`},
+				{Send: "subscribe"},
+				{Name: "first-update", Expect: `*"update"*`},
+				{Expect: `*"value":2*`},
+				{Ping: true},
+				{Close: websocket.CloseNormalClosure},
+			},
+			expectedConnected: true,
+			expectedMessages: []string{
+				`{"type":"welcome"}`,
+				`{"type":"authenticated"}`,
+				`{"type":"heartbeat"}`,
+				`{"type":"update","value":1}`,
+				`{"type":"update","value":2}`,
+			},
+			expectedNamedMessages: map[string]string{"first-update": `{"type":"update","value":1}`},
+			expectedCloseCode:     websocket.CloseNormalClosure,
+		},
+		{
+			name:              "server-pushed-message",
+			address:           address,
+			steps:             []*wsconfig.Step{{Name: "greeting", Expect: "*welcome*"}},
+			expectedConnected: true,
+			expectedMessages:  []string{`{"type":"welcome"}`},
+			expectedNamedMessages: map[string]string{
+				"greeting": `{"type":"welcome"}`,
+			},
+		},
+		{
+			name:    "closed-by-server",
+			address: address,
+			steps: []*wsconfig.Step{
+				{Send: "subscribe"},
+				{Expect: `*"update"*`},
+			},
+			expectedConnected: true,
+			expectedMessages:  []string{`{"type":"welcome"}`},
+			expectedCloseCode: websocket.ClosePolicyViolation,
+			expectedErr:       "step 2: connection closed by server with code 1008",
+		},
+		{
+			name:    "timeout",
+			address: address,
+			steps: []*wsconfig.Step{
+				{Send: "hello"},
+				{Expect: `*"never"*`, Timeout: 100 * time.Millisecond},
+			},
+			expectedConnected: true,
+			expectedMessages:  []string{`{"type":"welcome"}`, `{"type":"echo","message":"hello"}`},
+			expectedErr:       "step 2: timed out after 100ms",
+		},
+		{
+			name:        "invalid-address",
+			address:     "ws://127.0.0.1:1",
+			steps:       []*wsconfig.Step{{Ping: true}},
+			expectedErr: "error dialing websocket",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			connected, conversation, err := PerformWebSocketConversation(scenario.address, nil, scenario.steps, cfg)
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if len(scenario.expectedErr) == 0 && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if len(scenario.expectedErr) > 0 && (err == nil || !strings.Contains(err.Error(), scenario.expectedErr)) {
+				t.Errorf("expected error to contain %s, got %v", scenario.expectedErr, err)
+			}
+			if len(conversation.Messages) != len(scenario.expectedMessages) {
+				t.Fatalf("expected %d messages, got %d", len(scenario.expectedMessages), len(conversation.Messages))
+			}
+			for i, message := range conversation.Messages {
+				if string(message) != scenario.expectedMessages[i] {
+					t.Errorf("expected message %d to be %s, got %s", i, scenario.expectedMessages[i], message)
+				}
+			}
+			for name, expectedMessage := range scenario.expectedNamedMessages {
+				if string(conversation.NamedMessages[name]) != expectedMessage {
+					t.Errorf("expected message of step %s to be %s, got %s", name, expectedMessage, conversation.NamedMessages[name])
+				}
+			}
+			if conversation.CloseCode != scenario.expectedCloseCode {
+				t.Errorf("expected close code to be %d, got %d", scenario.expectedCloseCode, conversation.CloseCode)
+			}
+		})
+	}
+}
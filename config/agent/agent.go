@@ -0,0 +1,226 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/logr"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultSynchronizationInterval is the default interval at which an agent retrieves the endpoints assigned to its
+	// location from the central instance
+	DefaultSynchronizationInterval = time.Minute
+)
+
+var (
+	// ErrAgentWithNoURL is the error with which Gatus will panic if the agent configuration has no url
+	ErrAgentWithNoURL = errors.New("agent must have the url of the central instance")
+
+	// ErrAgentWithNoToken is the error with which Gatus will panic if the agent configuration has no token
+	ErrAgentWithNoToken = errors.New("agent must have the token of its location")
+
+	// ErrLocationWithNoName is the error with which Gatus will panic if a location has no name
+	ErrLocationWithNoName = errors.New("location must have a name")
+
+	// ErrLocationWithNoToken is the error with which Gatus will panic if a location has no token
+	ErrLocationWithNoToken = errors.New("location must have a token")
+
+	// centralOnlyEndpointFields are the fields of the configuration of an endpoint that are only relevant to the
+	// central instance, and which are therefore not sent to agents
+	centralOnlyEndpointFields = []string{"alerts", "depends-on", "enabled", "location-quorum", "locations", "maintenance-windows", "slo"}
+)
+
+// Config is the configuration for running Gatus as an agent, which monitors the endpoints assigned to its location by
+// a central Gatus instance and reports their results back to it
+type Config struct {
+	// URL is the base URL of the central Gatus instance (e.g. https://status.example.org)
+	URL string `yaml:"url"`
+
+	// Token is the bearer token of the agent's location, as configured in the locations of the central instance
+	Token string `yaml:"token"`
+
+	// SynchronizationInterval is the interval at which the endpoints assigned to the agent's location are retrieved
+	// from the central instance
+	SynchronizationInterval time.Duration `yaml:"synchronization-interval,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the central instance
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the agent configuration and sets the default value of args that have one
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.URL) == 0 {
+		return ErrAgentWithNoURL
+	}
+	c.URL = strings.TrimSuffix(c.URL, "/")
+	if len(c.Token) == 0 {
+		return ErrAgentWithNoToken
+	}
+	if c.SynchronizationInterval == 0 {
+		c.SynchronizationInterval = DefaultSynchronizationInterval
+	}
+	if c.ClientConfig == nil {
+		c.ClientConfig = client.GetDefaultConfig()
+	} else if err := c.ClientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FetchAssignment retrieves the endpoints assigned to the agent's location from the central instance.
+//
+// Endpoints whose configuration is invalid are left out of the assignment.
+func (c *Config) FetchAssignment() (*Assignment, error) {
+	request, err := http.NewRequest(http.MethodGet, c.URL+"/api/v1/agents/endpoints", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.send(request)
+	if err != nil {
+		return nil, err
+	}
+	assignment := &Assignment{}
+	if err = yaml.Unmarshal(body, assignment); err != nil {
+		return nil, err
+	}
+	validEndpoints := make([]*endpoint.Endpoint, 0, len(assignment.Endpoints))
+	for _, ep := range assignment.Endpoints {
+		if err := ep.ValidateAndSetDefaults(); err != nil {
+			logr.Errorf("[agent.FetchAssignment] Ignoring invalid endpoint with key=%s assigned to location=%s: %s", ep.Key(), assignment.Location, err.Error())
+			continue
+		}
+		validEndpoints = append(validEndpoints, ep)
+	}
+	assignment.Endpoints = validEndpoints
+	return assignment, nil
+}
+
+// PushResult reports the result of an endpoint assigned to the agent's location to the central instance
+func (c *Config) PushResult(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	body, err := json.Marshal(NewReport(result))
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, c.URL+"/api/v1/agents/endpoints/"+url.PathEscape(ep.Key())+"/results", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	_, err = c.send(request)
+	return err
+}
+
+// send sends a request authenticated with the agent's token to the central instance and returns the response body
+func (c *Config) send(request *http.Request) ([]byte, error) {
+	request.Header.Set("Authorization", "Bearer "+c.Token)
+	response, err := client.GetHTTPClient(c.ClientConfig).Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("central instance returned status code %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// Location is a location from which endpoints can be monitored by an agent
+type Location struct {
+	// Name of the location (e.g. eu-west)
+	Name string `yaml:"name"`
+
+	// Token is the bearer token that the agent of the location must use to communicate with the central instance
+	Token string `yaml:"token"`
+}
+
+// ValidateAndSetDefaults validates the location
+func (l *Location) ValidateAndSetDefaults() error {
+	if len(l.Name) == 0 {
+		return ErrLocationWithNoName
+	}
+	if len(l.Token) == 0 {
+		return ErrLocationWithNoToken
+	}
+	return nil
+}
+
+// Assignment is the list of endpoints assigned to a location, as sent by the central instance to the agent of that
+// location
+type Assignment struct {
+	// Location is the name of the location that the endpoints are assigned to
+	Location string `yaml:"location"`
+
+	// Endpoints are the endpoints that the agent of the location must monitor
+	Endpoints []*endpoint.Endpoint `yaml:"endpoints"`
+}
+
+// MarshalAssignment returns the YAML representation of the assignment of the given endpoints to a location.
+//
+// The configuration of the endpoints that is only relevant to the central instance, such as alerts and locations, is
+// left out.
+func MarshalAssignment(location string, endpoints []*endpoint.Endpoint) ([]byte, error) {
+	marshalledEndpoints := make([]map[string]any, 0, len(endpoints))
+	for _, ep := range endpoints {
+		marshalledEndpoint, err := yaml.Marshal(ep)
+		if err != nil {
+			return nil, err
+		}
+		fields := make(map[string]any)
+		if err = yaml.Unmarshal(marshalledEndpoint, &fields); err != nil {
+			return nil, err
+		}
+		for _, field := range centralOnlyEndpointFields {
+			delete(fields, field)
+		}
+		marshalledEndpoints = append(marshalledEndpoints, fields)
+	}
+	return yaml.Marshal(map[string]any{"location": location, "endpoints": marshalledEndpoints})
+}
+
+// Report is the result of an endpoint reported by an agent to the central instance
+type Report struct {
+	// Result is the result of the evaluation of the endpoint's health by the agent
+	Result *endpoint.Result `json:"result"`
+
+	// Connected is whether the agent could establish a connection to the endpoint.
+	//
+	// Reported separately because it is not part of the JSON representation of a result.
+	Connected bool `json:"connected"`
+
+	// CertificateExpiration is the duration before the certificate of the endpoint expires, if applicable
+	CertificateExpiration time.Duration `json:"certificateExpiration,omitempty"`
+}
+
+// NewReport creates a Report from the result of an endpoint
+func NewReport(result *endpoint.Result) *Report {
+	return &Report{
+		Result:                result,
+		Connected:             result.Connected,
+		CertificateExpiration: result.CertificateExpiration,
+	}
+}
+
+// ToResult returns the result of the report, or nil if the report has no result
+func (r *Report) ToResult() *endpoint.Result {
+	if r.Result == nil {
+		return nil
+	}
+	r.Result.Connected = r.Connected
+	r.Result.CertificateExpiration = r.CertificateExpiration
+	return r.Result
+}
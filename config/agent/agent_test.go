@@ -0,0 +1,193 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      *Config
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			config:      &Config{URL: "https://status.example.org/", Token: "potato"},
+			expectedErr: nil,
+		},
+		{
+			name:        "no-url",
+			config:      &Config{Token: "potato"},
+			expectedErr: ErrAgentWithNoURL,
+		},
+		{
+			name:        "no-token",
+			config:      &Config{URL: "https://status.example.org"},
+			expectedErr: ErrAgentWithNoToken,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.config.URL != "https://status.example.org" {
+				t.Errorf("expected trailing slash to be removed from url, got %s", scenario.config.URL)
+			}
+			if scenario.config.SynchronizationInterval != DefaultSynchronizationInterval {
+				t.Errorf("expected synchronization interval to default to %s, got %s", DefaultSynchronizationInterval, scenario.config.SynchronizationInterval)
+			}
+			if scenario.config.ClientConfig == nil {
+				t.Error("expected client config to be set to the default client config")
+			}
+		})
+	}
+}
+
+func TestLocation_ValidateAndSetDefaults(t *testing.T) {
+	if err := (&Location{Name: "eu-west", Token: "potato"}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if err := (&Location{Token: "potato"}).ValidateAndSetDefaults(); !errors.Is(err, ErrLocationWithNoName) {
+		t.Errorf("expected error %v, got %v", ErrLocationWithNoName, err)
+	}
+	if err := (&Location{Name: "eu-west"}).ValidateAndSetDefaults(); !errors.Is(err, ErrLocationWithNoToken) {
+		t.Errorf("expected error %v, got %v", ErrLocationWithNoToken, err)
+	}
+}
+
+func TestConfig_FetchAssignment(t *testing.T) {
+	ep := &endpoint.Endpoint{
+		Name:           "website",
+		Group:          "core",
+		URL:            "https://example.org",
+		Interval:       30 * time.Second,
+		Conditions:     []endpoint.Condition{"[STATUS] == 200"},
+		Alerts:         []*alert.Alert{{Type: alert.TypeSlack}},
+		DependsOn:      []string{"core_database"},
+		Locations:      []string{"eu-west", "us-east"},
+		LocationQuorum: 2,
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	invalidEndpoint := &endpoint.Endpoint{Name: "invalid", URL: "https://example.org"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/agents/endpoints" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer potato" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("invalid token"))
+			return
+		}
+		body, err := MarshalAssignment("eu-west", []*endpoint.Endpoint{ep, invalidEndpoint})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+	config := &Config{URL: server.URL, Token: "potato"}
+	if err := config.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	assignment, err := config.FetchAssignment()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if assignment.Location != "eu-west" {
+		t.Errorf("expected location to be eu-west, got %s", assignment.Location)
+	}
+	if len(assignment.Endpoints) != 1 {
+		t.Fatalf("expected the invalid endpoint to be left out of the assignment, got %d endpoints", len(assignment.Endpoints))
+	}
+	assignedEndpoint := assignment.Endpoints[0]
+	if assignedEndpoint.Key() != ep.Key() || assignedEndpoint.URL != ep.URL || assignedEndpoint.Interval != ep.Interval {
+		t.Errorf("expected assigned endpoint to have the same configuration as the endpoint, got %+v", assignedEndpoint)
+	}
+	if len(assignedEndpoint.Conditions) != 1 || assignedEndpoint.Conditions[0] != ep.Conditions[0] {
+		t.Errorf("expected assigned endpoint to have the same conditions as the endpoint, got %v", assignedEndpoint.Conditions)
+	}
+	if len(assignedEndpoint.Alerts) != 0 || len(assignedEndpoint.DependsOn) != 0 || len(assignedEndpoint.Locations) != 0 || assignedEndpoint.LocationQuorum != 0 {
+		t.Errorf("expected the configuration only relevant to the central instance to be left out, got %+v", assignedEndpoint)
+	}
+	config.Token = "invalid"
+	if _, err = config.FetchAssignment(); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected an error with the status code returned by the central instance, got %v", err)
+	}
+}
+
+func TestConfig_PushResult(t *testing.T) {
+	var receivedReport Report
+	var receivedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer potato" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		receivedPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &receivedReport); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}))
+	defer server.Close()
+	config := &Config{URL: server.URL, Token: "potato"}
+	if err := config.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	ep := &endpoint.Endpoint{Name: "website", Group: "core"}
+	result := &endpoint.Result{
+		Success:               false,
+		Connected:             true,
+		HTTPStatus:            500,
+		Duration:              150 * time.Millisecond,
+		CertificateExpiration: 48 * time.Hour,
+		Errors:                []string{"error"},
+		Timestamp:             time.Now(),
+	}
+	if err := config.PushResult(ep, result); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if receivedPath != "/api/v1/agents/endpoints/core_website/results" {
+		t.Errorf("expected result to be pushed to the results of the endpoint, got %s", receivedPath)
+	}
+	receivedResult := receivedReport.ToResult()
+	if receivedResult == nil {
+		t.Fatal("expected report to have a result")
+	}
+	if receivedResult.Success || !receivedResult.Connected || receivedResult.HTTPStatus != 500 || receivedResult.Duration != result.Duration || receivedResult.CertificateExpiration != result.CertificateExpiration {
+		t.Errorf("expected received result to match the pushed result, got %+v", receivedResult)
+	}
+	if len(receivedResult.Errors) != 1 || receivedResult.Errors[0] != "error" {
+		t.Errorf("expected errors to be pushed, got %v", receivedResult.Errors)
+	}
+	config.Token = "invalid"
+	if err := config.PushResult(ep, result); err == nil {
+		t.Error("expected an error because the token is invalid")
+	}
+}
+
+func TestReport_ToResult(t *testing.T) {
+	if (&Report{}).ToResult() != nil {
+		t.Error("expected report without result to return nil")
+	}
+}
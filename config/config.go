@@ -1,6 +1,7 @@
 package config
 
 import (
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -14,11 +15,14 @@ import (
 	"github.com/TwiN/deepmerge"
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/escalation"
 	"github.com/TwiN/gatus/v5/alerting/provider"
 	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/agent"
 	"github.com/TwiN/gatus/v5/config/announcement"
 	"github.com/TwiN/gatus/v5/config/connectivity"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/ha"
 	"github.com/TwiN/gatus/v5/config/key"
 	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/config/remote"
@@ -55,6 +59,33 @@ var (
 	// ErrInvalidSecurityConfig is an error returned when the security configuration is invalid
 	ErrInvalidSecurityConfig = errors.New("invalid security configuration")
 
+	// ErrUnknownEndpointDependency is an error returned when an endpoint depends on an endpoint that doesn't exist
+	ErrUnknownEndpointDependency = errors.New("depends on unknown endpoint")
+
+	// ErrEndpointDependencyCycle is an error returned when endpoints depend on each other, directly or indirectly
+	ErrEndpointDependencyCycle = errors.New("endpoint dependency cycle detected")
+
+	// ErrUnknownEscalationPolicy is an error returned when an alert references an escalation policy that doesn't exist
+	ErrUnknownEscalationPolicy = errors.New("unknown escalation policy")
+
+	// ErrUnknownLocation is an error returned when an endpoint references a location that doesn't exist
+	ErrUnknownLocation = errors.New("unknown location")
+
+	// ErrDuplicateLocation is an error returned when multiple locations have the same name or the same token
+	ErrDuplicateLocation = errors.New("locations must have unique names and tokens")
+
+	// ErrSuiteEndpointWithLocations is an error returned when an endpoint that is part of a suite has locations, since
+	// suites can only be monitored by the instance they're configured on
+	ErrSuiteEndpointWithLocations = errors.New("endpoints that are part of a suite cannot have locations")
+
+	// ErrHighAvailabilityWithMemoryStorage is an error returned when high-availability is configured without a storage
+	// that can be shared by multiple instances
+	ErrHighAvailabilityWithMemoryStorage = errors.New("high-availability requires storage.type to be sqlite or postgres")
+
+	// ErrHighAvailabilityWithStorageCaching is an error returned when high-availability is configured with caching,
+	// since the cache of an instance wouldn't be invalidated when the other instances write to the storage
+	ErrHighAvailabilityWithStorageCaching = errors.New("high-availability cannot be used with storage.caching")
+
 	// errEarlyReturn is returned to break out of a loop from a callback early
 	errEarlyReturn = errors.New("early escape")
 )
@@ -123,6 +154,17 @@ type Config struct {
 	// Announcements is the list of system-wide announcements
 	Announcements []*announcement.Announcement `yaml:"announcements,omitempty"`
 
+	// Locations is the list of locations from which endpoints can be monitored by agents
+	Locations []*agent.Location `yaml:"locations,omitempty"`
+
+	// Agent is the configuration for running as an agent, which monitors the endpoints assigned to its location by a
+	// central instance instead of the endpoints in its own configuration
+	Agent *agent.Config `yaml:"agent,omitempty"`
+
+	// HighAvailability is the configuration for running multiple instances against the same storage, in which case
+	// only the instance elected as the leader monitors the endpoints and handles alerting
+	HighAvailability *ha.Config `yaml:"high-availability,omitempty"`
+
 	configPath      string    // path to the file or directory from which config was loaded
 	lastFileModTime time.Time // last modification time
 }
@@ -159,6 +201,27 @@ func (config *Config) GetEndpointByKey(key string) *endpoint.Endpoint {
 	return nil
 }
 
+// GetLocationByToken returns the location whose agent authenticates with the given token, or nil if there is none
+func (config *Config) GetLocationByToken(token string) *agent.Location {
+	for _, location := range config.Locations {
+		if subtle.ConstantTimeCompare([]byte(location.Token), []byte(token)) == 1 {
+			return location
+		}
+	}
+	return nil
+}
+
+// GetEndpointsByLocation returns the enabled endpoints that are monitored from the given location
+func (config *Config) GetEndpointsByLocation(location string) []*endpoint.Endpoint {
+	var endpoints []*endpoint.Endpoint
+	for _, ep := range config.Endpoints {
+		if ep.IsEnabled() && slices.Contains(ep.Locations, location) {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints
+}
+
 func (config *Config) GetExternalEndpointByKey(key string) *endpoint.ExternalEndpoint {
 	for i := 0; i < len(config.ExternalEndpoints); i++ {
 		ee := config.ExternalEndpoints[i]
@@ -291,8 +354,9 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 	if err = yaml.Unmarshal(yamlBytes, &config); err != nil {
 		return
 	}
-	// Check if the configuration file at least has endpoints configured
-	if config == nil || (len(config.Endpoints) == 0 && len(config.Suites) == 0) {
+	// Check if the configuration file at least has endpoints configured, unless it's the configuration of an agent,
+	// in which case the endpoints are retrieved from the central instance
+	if config == nil || (len(config.Endpoints) == 0 && len(config.Suites) == 0 && config.Agent == nil) {
 		err = ErrNoEndpointOrSuiteInConfig
 	} else {
 		// XXX: Remove this in v6.0.0
@@ -302,12 +366,24 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 		}
 		// XXX: End of v6.0.0 removals
 		ValidateAlertingConfig(config.Alerting, config.Endpoints, config.ExternalEndpoints)
+		if err := ValidateAlertingGroupingConfig(config); err != nil {
+			return nil, err
+		}
+		if err := ValidateAlertingEscalationPoliciesConfig(config); err != nil {
+			return nil, err
+		}
 		if err := ValidateSecurityConfig(config); err != nil {
 			return nil, err
 		}
 		if err := ValidateEndpointsConfig(config); err != nil {
 			return nil, err
 		}
+		if err := ValidateLocationsConfig(config); err != nil {
+			return nil, err
+		}
+		if err := ValidateAgentConfig(config); err != nil {
+			return nil, err
+		}
 		if err := ValidateWebConfig(config); err != nil {
 			return nil, err
 		}
@@ -320,6 +396,9 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 		if err := ValidateStorageConfig(config); err != nil {
 			return nil, err
 		}
+		if err := ValidateHighAvailabilityConfig(config); err != nil {
+			return nil, err
+		}
 		if err := ValidateRemoteConfig(config); err != nil {
 			return nil, err
 		}
@@ -416,6 +495,44 @@ func ValidateAnnouncementsConfig(config *Config) error {
 	return nil
 }
 
+// ValidateLocationsConfig validates the locations and makes sure that every location referenced by an endpoint exists
+// NOTE: This must be called after ValidateEndpointsConfig
+func ValidateLocationsConfig(config *Config) error {
+	locationNames := make(map[string]bool, len(config.Locations))
+	locationTokens := make(map[string]bool, len(config.Locations))
+	for _, location := range config.Locations {
+		if err := location.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if locationNames[location.Name] || locationTokens[location.Token] {
+			return fmt.Errorf("%w: %s", ErrDuplicateLocation, location.Name)
+		}
+		locationNames[location.Name], locationTokens[location.Token] = true, true
+	}
+	for _, ep := range config.Endpoints {
+		for _, location := range ep.Locations {
+			if !locationNames[location] {
+				return fmt.Errorf("invalid endpoint %s: %w: %s", ep.Key(), ErrUnknownLocation, location)
+			}
+		}
+	}
+	for _, s := range config.Suites {
+		for _, ep := range s.Endpoints {
+			if len(ep.Locations) > 0 {
+				return fmt.Errorf("invalid suite %s: %w", s.Key(), ErrSuiteEndpointWithLocations)
+			}
+		}
+	}
+	return nil
+}
+
+func ValidateAgentConfig(config *Config) error {
+	if config.Agent != nil {
+		return config.Agent.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
 func ValidateRemoteConfig(config *Config) error {
 	if config.Remote != nil {
 		if err := config.Remote.ValidateAndSetDefaults(); err != nil {
@@ -440,6 +557,21 @@ func ValidateStorageConfig(config *Config) error {
 	return nil
 }
 
+// ValidateHighAvailabilityConfig validates the high-availability configuration, if any
+// NOTE: This must be called after ValidateStorageConfig, because leader election relies on the storage
+func ValidateHighAvailabilityConfig(config *Config) error {
+	if config.HighAvailability == nil {
+		return nil
+	}
+	if config.Storage.Type == storage.TypeMemory {
+		return ErrHighAvailabilityWithMemoryStorage
+	}
+	if config.Storage.Caching {
+		return ErrHighAvailabilityWithStorageCaching
+	}
+	return config.HighAvailability.ValidateAndSetDefaults()
+}
+
 func ValidateMaintenanceConfig(config *Config) error {
 	if config.Maintenance == nil {
 		config.Maintenance = maintenance.GetDefaultConfig()
@@ -499,6 +631,53 @@ func ValidateEndpointsConfig(config *Config) error {
 		}
 	}
 	logr.Infof("[config.ValidateEndpointsConfig] Validated %d external endpoints", len(config.ExternalEndpoints))
+	return validateEndpointDependencies(config)
+}
+
+// validateEndpointDependencies makes sure that every endpoint depended on exists and that there are no dependency cycles
+func validateEndpointDependencies(config *Config) error {
+	dependenciesByKey := make(map[string][]string, len(config.Endpoints)+len(config.ExternalEndpoints))
+	for _, ep := range config.Endpoints {
+		dependenciesByKey[ep.Key()] = ep.DependsOn
+	}
+	for _, ee := range config.ExternalEndpoints {
+		dependenciesByKey[ee.Key()] = nil
+	}
+	for _, ep := range config.Endpoints {
+		for _, dependency := range ep.DependsOn {
+			if _, exists := dependenciesByKey[dependency]; !exists {
+				return fmt.Errorf("invalid endpoint %s: %w: %s", ep.Key(), ErrUnknownEndpointDependency, dependency)
+			}
+		}
+	}
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[string]int, len(dependenciesByKey))
+	var visit func(key string, path []string) error
+	visit = func(key string, path []string) error {
+		switch states[key] {
+		case visiting:
+			// Only keep the part of the path that is part of the cycle
+			return fmt.Errorf("%w: %s", ErrEndpointDependencyCycle, strings.Join(append(path[slices.Index(path, key):], key), " -> "))
+		case visited:
+			return nil
+		}
+		states[key] = visiting
+		for _, dependency := range dependenciesByKey[key] {
+			if err := visit(dependency, append(path, key)); err != nil {
+				return err
+			}
+		}
+		states[key] = visited
+		return nil
+	}
+	for _, ep := range config.Endpoints {
+		if err := visit(ep.Key(), nil); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -584,6 +763,73 @@ func ValidateSecurityConfig(config *Config) error {
 	return nil
 }
 
+// ValidateAlertingGroupingConfig validates the alert grouping configuration, if alerting and alert grouping are configured.
+//
+// Alerts using an escalation policy and burn-rate alerts are never grouped.
+func ValidateAlertingGroupingConfig(config *Config) error {
+	if config.Alerting == nil || config.Alerting.Grouping == nil {
+		return nil
+	}
+	if err := config.Alerting.Grouping.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	logr.Infof("[config.ValidateAlertingGroupingConfig] Alerts will be grouped by %s with a window of %s", config.Alerting.Grouping.By, config.Alerting.Grouping.Window)
+	return nil
+}
+
+// ValidateAlertingEscalationPoliciesConfig validates the escalation policies as well as the references to them
+func ValidateAlertingEscalationPoliciesConfig(config *Config) error {
+	var policies map[string]*escalation.Policy
+	if config.Alerting != nil {
+		policies = config.Alerting.EscalationPolicies
+	}
+	for name, policy := range policies {
+		if policy == nil {
+			return fmt.Errorf("%w: %s", escalation.ErrNoSteps, name)
+		}
+		if err := policy.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+		for _, step := range policy.Steps {
+			if config.Alerting.GetAlertingProviderByAlertType(step.Type) == nil {
+				logr.Warnf("[config.ValidateAlertingEscalationPoliciesConfig] Escalation policy %s has a step of type=%s, but the provider isn't configured properly", name, step.Type)
+			}
+		}
+	}
+	validateReferences := func(key string, alerts []*alert.Alert) error {
+		for _, endpointAlert := range alerts {
+			if len(endpointAlert.EscalationPolicy) == 0 {
+				continue
+			}
+			if _, exists := policies[endpointAlert.EscalationPolicy]; !exists {
+				return fmt.Errorf("%w: endpoint with key=%s references %s", ErrUnknownEscalationPolicy, key, endpointAlert.EscalationPolicy)
+			}
+		}
+		return nil
+	}
+	for _, ep := range config.Endpoints {
+		if err := validateReferences(ep.Key(), ep.Alerts); err != nil {
+			return err
+		}
+	}
+	for _, ee := range config.ExternalEndpoints {
+		if err := validateReferences(ee.Key(), ee.Alerts); err != nil {
+			return err
+		}
+	}
+	for _, s := range config.Suites {
+		for _, ep := range s.Endpoints {
+			if err := validateReferences(ep.Key(), ep.Alerts); err != nil {
+				return err
+			}
+		}
+	}
+	if len(policies) > 0 {
+		logr.Infof("[config.ValidateAlertingEscalationPoliciesConfig] Validated %d escalation policies", len(policies))
+	}
+	return nil
+}
+
 // ValidateAlertingConfig validates the alerting configuration
 // Note that the alerting configuration has to be validated before the endpoint configuration, because the default alert
 // returned by provider.AlertProvider.GetDefaultAlert() must be parsed before endpoint.Endpoint.ValidateAndSetDefaults()
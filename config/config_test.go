@@ -6,11 +6,14 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/escalation"
+	"github.com/TwiN/gatus/v5/alerting/grouping"
 	"github.com/TwiN/gatus/v5/alerting/provider"
 	"github.com/TwiN/gatus/v5/alerting/provider/awsses"
 	"github.com/TwiN/gatus/v5/alerting/provider/clickup"
@@ -53,7 +56,9 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/zapier"
 	"github.com/TwiN/gatus/v5/alerting/provider/zulip"
 	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/agent"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/ha"
 	"github.com/TwiN/gatus/v5/config/suite"
 	"github.com/TwiN/gatus/v5/config/tunneling"
 	"github.com/TwiN/gatus/v5/config/tunneling/sshtunnel"
@@ -1642,6 +1647,57 @@ endpoints:
 	}
 }
 
+func TestValidateEndpointsConfigWithDependencies(t *testing.T) {
+	newEndpoint := func(name string, dependsOn ...string) *endpoint.Endpoint {
+		return &endpoint.Endpoint{Name: name, Group: "core", URL: "https://example.org", Conditions: []endpoint.Condition{"[STATUS] == 200"}, DependsOn: dependsOn}
+	}
+	scenarios := []struct {
+		name              string
+		endpoints         []*endpoint.Endpoint
+		externalEndpoints []*endpoint.ExternalEndpoint
+		expectedErr       error
+		expectedErrSuffix string
+	}{
+		{
+			name:      "valid-chain",
+			endpoints: []*endpoint.Endpoint{newEndpoint("gateway"), newEndpoint("api", "core_gateway"), newEndpoint("frontend", "core_api", "core_gateway")},
+		},
+		{
+			name:              "depends-on-external-endpoint",
+			endpoints:         []*endpoint.Endpoint{newEndpoint("api", "core_vpn")},
+			externalEndpoints: []*endpoint.ExternalEndpoint{{Name: "vpn", Group: "core", Token: "token"}},
+		},
+		{
+			name:        "unknown-dependency",
+			endpoints:   []*endpoint.Endpoint{newEndpoint("api", "core_unknown")},
+			expectedErr: ErrUnknownEndpointDependency,
+		},
+		{
+			name:              "self-dependency",
+			endpoints:         []*endpoint.Endpoint{newEndpoint("api", "core_api")},
+			expectedErr:       ErrEndpointDependencyCycle,
+			expectedErrSuffix: "core_api -> core_api",
+		},
+		{
+			name:              "indirect-cycle",
+			endpoints:         []*endpoint.Endpoint{newEndpoint("frontend", "core_api"), newEndpoint("api", "core_database"), newEndpoint("database", "core_api")},
+			expectedErr:       ErrEndpointDependencyCycle,
+			expectedErrSuffix: "core_api -> core_database -> core_api",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := ValidateEndpointsConfig(&Config{Endpoints: scenario.endpoints, ExternalEndpoints: scenario.externalEndpoints})
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil && !strings.HasSuffix(err.Error(), scenario.expectedErrSuffix) {
+				t.Errorf("expected error to end with %s, got %s", scenario.expectedErrSuffix, err.Error())
+			}
+		})
+	}
+}
+
 func TestParseAndValidateConfigBytesWithDuplicateEndpointName(t *testing.T) {
 	scenarios := []struct {
 		name        string
@@ -1765,6 +1821,290 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithAlertGrouping(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  grouping:
+    by: label
+    label: team
+  slack:
+    webhook-url: "http://example.com"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - type: slack
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if config.Alerting.Grouping.Window != grouping.DefaultWindow {
+		t.Errorf("expected window to default to %s, got %s", grouping.DefaultWindow, config.Alerting.Grouping.Window)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+alerting:
+  grouping:
+    by: label
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, grouping.ErrLabelNotSet) {
+		t.Errorf("expected error %v, got %v", grouping.ErrLabelNotSet, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+alerting:
+  grouping:
+    by: group
+  pagerduty:
+    integration-key: "00000000000000000000000000000000"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - type: pagerduty
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Error("expected no error, because providers without a batch-aware send path send a combined alert, got", err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithEscalationPolicies(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  escalation-policies:
+    critical:
+      steps:
+        - type: slack
+        - type: pagerduty
+          after: 15m
+  slack:
+    webhook-url: "http://example.com"
+  pagerduty:
+    integration-key: "00000000000000000000000000000000"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - escalation-policy: critical
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if policy := config.Alerting.EscalationPolicies["critical"]; len(policy.Steps) != 2 || policy.Steps[1].Type != alert.TypePagerDuty || policy.Steps[1].After != 15*time.Minute {
+		t.Errorf("unexpected escalation policy: %v", policy)
+	}
+	if config.Endpoints[0].Alerts[0].EscalationPolicy != "critical" {
+		t.Errorf("expected alert to reference escalation policy critical, got %s", config.Endpoints[0].Alerts[0].EscalationPolicy)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+alerting:
+  slack:
+    webhook-url: "http://example.com"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - escalation-policy: critical
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrUnknownEscalationPolicy) {
+		t.Errorf("expected error %v, got %v", ErrUnknownEscalationPolicy, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+alerting:
+  escalation-policies:
+    critical:
+      steps:
+        - type: slack
+          after: 1h
+        - type: pagerduty
+          after: 15m
+  slack:
+    webhook-url: "http://example.com"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, escalation.ErrStepsNotInOrder) {
+		t.Errorf("expected error %v, got %v", escalation.ErrStepsNotInOrder, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithLocations(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+locations:
+  - name: eu-west
+    token: potato
+  - name: us-east
+    token: tomato
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    locations: [eu-west, us-east]
+    conditions:
+      - "[STATUS] == 200"
+  - name: local
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if location := config.GetLocationByToken("tomato"); location == nil || location.Name != "us-east" {
+		t.Errorf("expected token to belong to location us-east, got %v", location)
+	}
+	if location := config.GetLocationByToken("invalid"); location != nil {
+		t.Errorf("expected no location for invalid token, got %v", location)
+	}
+	if endpoints := config.GetEndpointsByLocation("eu-west"); len(endpoints) != 1 || endpoints[0].Name != "website" {
+		t.Errorf("expected only the website endpoint to be monitored from eu-west, got %v", endpoints)
+	}
+	if config.Endpoints[0].LocationQuorum != 2 {
+		t.Errorf("expected location quorum to default to a majority of the locations, got %d", config.Endpoints[0].LocationQuorum)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+locations:
+  - name: eu-west
+    token: potato
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    locations: [eu-west, us-east]
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrUnknownLocation) {
+		t.Errorf("expected error %v, got %v", ErrUnknownLocation, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+locations:
+  - name: eu-west
+    token: potato
+  - name: us-east
+    token: potato
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrDuplicateLocation) {
+		t.Errorf("expected error %v, got %v", ErrDuplicateLocation, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+locations:
+  - name: eu-west
+    token: potato
+suites:
+  - name: checkout
+    endpoints:
+      - name: step-1
+        url: https://twin.sh/health
+        locations: [eu-west]
+        conditions:
+          - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrSuiteEndpointWithLocations) {
+		t.Errorf("expected error %v, got %v", ErrSuiteEndpointWithLocations, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithAgent(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+agent:
+  url: https://status.example.org
+  token: potato
+`))
+	if err != nil {
+		t.Fatal("expected an agent to not require endpoints, got", err)
+	}
+	if config.Agent == nil || config.Agent.SynchronizationInterval != agent.DefaultSynchronizationInterval {
+		t.Error("expected agent configuration to have been validated")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+agent:
+  url: https://status.example.org
+`))
+	if !errors.Is(err, agent.ErrAgentWithNoToken) {
+		t.Errorf("expected error %v, got %v", agent.ErrAgentWithNoToken, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithHighAvailability(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+storage:
+  type: sqlite
+  path: /tmp/gatus.db
+high-availability:
+  lease-duration: 30s
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if config.HighAvailability == nil || config.HighAvailability.LeaseDuration != 30*time.Second || len(config.HighAvailability.InstanceID) == 0 {
+		t.Errorf("expected high-availability configuration to have been validated, got %+v", config.HighAvailability)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+high-availability: {}
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrHighAvailabilityWithMemoryStorage) {
+		t.Errorf("expected error %v, got %v", ErrHighAvailabilityWithMemoryStorage, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+storage:
+  type: sqlite
+  path: /tmp/gatus.db
+  caching: true
+high-availability: {}
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrHighAvailabilityWithStorageCaching) {
+		t.Errorf("expected error %v, got %v", ErrHighAvailabilityWithStorageCaching, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+storage:
+  type: sqlite
+  path: /tmp/gatus.db
+high-availability:
+  lease-duration: 1s
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ha.ErrLeaseDurationTooShort) {
+		t.Errorf("expected error %v, got %v", ha.ErrLeaseDurationTooShort, err)
+	}
+}
+
 func TestParseAndValidateConfigBytesWithInvalidSecurityConfig(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(`
 security:
@@ -1819,6 +2159,47 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithAPITokens(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+security:
+  api-tokens:
+    - name: ci
+      token-sha256: "948b8c2427cd29047839b8e4a27a08763f8befbafa86be5cce8e46217d75e58a"
+      expires-at: 2030-01-01T00:00:00Z
+      scopes: ["read-statuses", "push-external-results"]
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(config.Security.APITokens) != 1 {
+		t.Fatalf("expected 1 API token, got %d", len(config.Security.APITokens))
+	}
+	apiToken := config.Security.APITokens[0]
+	if apiToken.Name != "ci" || len(apiToken.Scopes) != 2 || !apiToken.ExpiresAt.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected API token to have been parsed, got %+v", apiToken)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+security:
+  api-tokens:
+    - name: ci
+      token-sha256: "948b8c2427cd29047839b8e4a27a08763f8befbafa86be5cce8e46217d75e58a"
+      scopes: ["do-everything"]
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrInvalidSecurityConfig) {
+		t.Errorf("expected error %v, got %v", ErrInvalidSecurityConfig, err)
+	}
+}
+
 func TestParseAndValidateConfigBytesWithLiteralDollarSign(t *testing.T) {
 	os.Setenv("GATUS_TestParseAndValidateConfigBytesWithLiteralDollarSign", "whatever")
 	config, err := parseAndValidateConfigBytes([]byte(`
@@ -0,0 +1,90 @@
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedPlaceholder is the error with which Gatus will panic if a condition uses a placeholder that is not
+	// populated by the endpoint's type (e.g. [DNS_RCODE] on an HTTP endpoint)
+	ErrUnsupportedPlaceholder = errors.New("placeholder not supported by endpoint type")
+
+	// typeSpecificPlaceholders are the placeholders that are only populated by some endpoint types.
+	// All other placeholders ([CONNECTED], [RESPONSE_TIME], [IP], [DOMAIN_EXPIRATION] and [CONTEXT]) are supported by
+	// every endpoint type.
+	typeSpecificPlaceholders = []string{StatusPlaceholder, DNSRCodePlaceholder, DNSSECValidPlaceholder, GRPCStatusPlaceholder, WebSocketMessagesReceivedPlaceholder, WebSocketCloseCodePlaceholder, DNSTimePlaceholder, ConnectTimePlaceholder, TLSTimePlaceholder, TTFBPlaceholder, HeadersPlaceholder, FinalURLPlaceholder, RedirectCountPlaceholder, ProtocolPlaceholder, BodyPlaceholder, CertificateExpirationPlaceholder}
+
+	// checkers are the registered checkers, keyed by the URL scheme they handle
+	checkers = map[string]Checker{
+		"tcp":        &networkChecker{endpointType: TypeTCP, network: "tcp"},
+		"udp":        &networkChecker{endpointType: TypeUDP, network: "udp"},
+		"sctp":       &sctpChecker{},
+		"icmp":       &icmpChecker{},
+		"starttls":   &startTLSChecker{},
+		"tls":        &tlsChecker{},
+		"http":       &httpChecker{},
+		"https":      &httpChecker{},
+		"grpc":       &grpcChecker{},
+		"grpcs":      &grpcChecker{},
+		"ws":         &webSocketChecker{},
+		"wss":        &webSocketChecker{},
+		"ssh":        &sshChecker{},
+		"postgres":   &databaseChecker{endpointType: TypePostgres},
+		"postgresql": &databaseChecker{endpointType: TypePostgres},
+		"mysql":      &databaseChecker{endpointType: TypeMySQL},
+		"redis":      &redisChecker{},
+		"rediss":     &redisChecker{},
+	}
+)
+
+// Checker is responsible for calling an endpoint of a given type and populating the Result accordingly
+type Checker interface {
+	// Type returns the type of endpoint handled by the checker
+	Type() Type
+
+	// Check calls the endpoint and populates the result with the outcome of the call.
+	// Errors must be added to the result rather than returned.
+	Check(e *Endpoint, result *Result)
+
+	// SupportedPlaceholders returns the type-specific placeholders populated by Check.
+	// See typeSpecificPlaceholders for the list of placeholders that do not need to be declared.
+	SupportedPlaceholders() []string
+}
+
+// RegisterChecker registers a checker for one or more URL schemes (e.g. "postgres" for postgres://...).
+// If a checker is already registered for a scheme, it is replaced.
+//
+// This must be called before the configuration is loaded, as the registry is not safe for concurrent use.
+func RegisterChecker(checker Checker, schemes ...string) {
+	for _, scheme := range schemes {
+		checkers[scheme] = checker
+	}
+}
+
+// checker returns the Checker responsible for the endpoint, or nil if the endpoint's type is unknown
+func (e *Endpoint) checker() Checker {
+	// DNS endpoints are identified by their configuration rather than by their scheme, as their URL is the address of
+	// the DNS server (e.g. 8.8.8.8)
+	if e.DNSConfig != nil {
+		return &dnsChecker{}
+	}
+	scheme, _, found := strings.Cut(e.URL, "://")
+	if !found {
+		return nil
+	}
+	return checkers[scheme]
+}
+
+// validatePlaceholders checks whether all type-specific placeholders used by the condition are supported by the checker
+func (c Condition) validatePlaceholders(checker Checker) error {
+	supportedPlaceholders := checker.SupportedPlaceholders()
+	for _, placeholder := range typeSpecificPlaceholders {
+		if strings.Contains(string(c), placeholder) && !slices.Contains(supportedPlaceholders, placeholder) {
+			return fmt.Errorf("%w: %s cannot be used in condition '%s' of an endpoint of type %s", ErrUnsupportedPlaceholder, placeholder, c, checker.Type())
+		}
+	}
+	return nil
+}
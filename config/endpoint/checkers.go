@@ -0,0 +1,386 @@
+package endpoint
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	wsconfig "github.com/TwiN/gatus/v5/config/endpoint/websocket"
+	"golang.org/x/crypto/ssh"
+)
+
+// dnsChecker checks endpoints with a DNS configuration by querying the DNS server at the endpoint's URL
+type dnsChecker struct{}
+
+func (c *dnsChecker) Type() Type {
+	return TypeDNS
+}
+
+func (c *dnsChecker) SupportedPlaceholders() []string {
+	return []string{DNSRCodePlaceholder, DNSSECValidPlaceholder, BodyPlaceholder}
+}
+
+func (c *dnsChecker) Check(e *Endpoint, result *Result) {
+	var err error
+	startTime := time.Now()
+	result.Connected, result.DNSRCode, result.Body, result.DNSSECValid, err = client.QueryDNS(e.DNSConfig.QueryType, e.DNSConfig.QueryName, e.URL, e.DNSConfig.DNSSEC, e.ClientConfig)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	result.Duration = time.Since(startTime)
+}
+
+// networkChecker checks tcp:// and udp:// endpoints by creating a connection and optionally sending the body
+type networkChecker struct {
+	endpointType Type
+	network      string
+}
+
+func (c *networkChecker) Type() Type {
+	return c.endpointType
+}
+
+func (c *networkChecker) SupportedPlaceholders() []string {
+	return []string{BodyPlaceholder}
+}
+
+func (c *networkChecker) Check(e *Endpoint, result *Result) {
+	startTime := time.Now()
+	result.Connected, result.Body = client.CanCreateNetworkConnection(c.network, strings.TrimPrefix(e.URL, c.network+"://"), e.getParsedBody(), e.ClientConfig)
+	result.Duration = time.Since(startTime)
+}
+
+// sctpChecker checks sctp:// endpoints by creating a connection
+type sctpChecker struct{}
+
+func (c *sctpChecker) Type() Type {
+	return TypeSCTP
+}
+
+func (c *sctpChecker) SupportedPlaceholders() []string {
+	return nil
+}
+
+func (c *sctpChecker) Check(e *Endpoint, result *Result) {
+	startTime := time.Now()
+	result.Connected = client.CanCreateSCTPConnection(strings.TrimPrefix(e.URL, "sctp://"), e.ClientConfig)
+	result.Duration = time.Since(startTime)
+}
+
+// icmpChecker checks icmp:// endpoints by pinging them
+type icmpChecker struct{}
+
+func (c *icmpChecker) Type() Type {
+	return TypeICMP
+}
+
+func (c *icmpChecker) SupportedPlaceholders() []string {
+	return nil
+}
+
+func (c *icmpChecker) Check(e *Endpoint, result *Result) {
+	result.Connected, result.Duration = client.Ping(strings.TrimPrefix(e.URL, "icmp://"), e.ClientConfig)
+}
+
+// startTLSChecker checks starttls:// endpoints by upgrading the connection to TLS
+type startTLSChecker struct{}
+
+func (c *startTLSChecker) Type() Type {
+	return TypeSTARTTLS
+}
+
+func (c *startTLSChecker) SupportedPlaceholders() []string {
+	return []string{CertificateExpirationPlaceholder}
+}
+
+func (c *startTLSChecker) Check(e *Endpoint, result *Result) {
+	startTime := time.Now()
+	connected, certificate, err := client.CanPerformStartTLS(strings.TrimPrefix(e.URL, "starttls://"), e.ClientConfig)
+	result.Connected = connected
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	result.Duration = time.Since(startTime)
+	result.CertificateExpiration = time.Until(certificate.NotAfter)
+}
+
+// tlsChecker checks tls:// endpoints by performing a TLS handshake and optionally sending the body
+type tlsChecker struct{}
+
+func (c *tlsChecker) Type() Type {
+	return TypeTLS
+}
+
+func (c *tlsChecker) SupportedPlaceholders() []string {
+	return []string{CertificateExpirationPlaceholder, BodyPlaceholder}
+}
+
+func (c *tlsChecker) Check(e *Endpoint, result *Result) {
+	startTime := time.Now()
+	connected, body, certificate, err := client.CanPerformTLS(strings.TrimPrefix(e.URL, "tls://"), e.getParsedBody(), e.ClientConfig)
+	result.Connected, result.Body = connected, body
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	result.Duration = time.Since(startTime)
+	result.CertificateExpiration = time.Until(certificate.NotAfter)
+}
+
+// httpChecker checks http:// and https:// endpoints by sending an HTTP request
+type httpChecker struct{}
+
+func (c *httpChecker) Type() Type {
+	return TypeHTTP
+}
+
+func (c *httpChecker) SupportedPlaceholders() []string {
+	return []string{StatusPlaceholder, BodyPlaceholder, CertificateExpirationPlaceholder, DNSTimePlaceholder, ConnectTimePlaceholder, TLSTimePlaceholder, TTFBPlaceholder, HeadersPlaceholder, FinalURLPlaceholder, RedirectCountPlaceholder, ProtocolPlaceholder}
+}
+
+func (c *httpChecker) Check(e *Endpoint, result *Result) {
+	timings := &client.HTTPTimings{}
+	request := client.WithHTTPTimings(e.buildHTTPRequest(), timings)
+	// The DNS lookup, TCP connection and TLS handshake don't happen when an idle connection is reused, so the connection
+	// is closed after the request if they must be timed
+	request.Close = e.needsNewConnection()
+	startTime := time.Now()
+	response, err := client.GetHTTPClient(e.ClientConfig).Do(request)
+	result.Duration = time.Since(startTime)
+	recordedTimings := timings.Snapshot()
+	result.DNSLookupDuration = recordedTimings.DNSLookup
+	result.TCPConnectDuration = recordedTimings.TCPConnect
+	result.TLSHandshakeDuration = recordedTimings.TLSHandshake
+	result.TimeToFirstByte = recordedTimings.TimeToFirstByte
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	defer response.Body.Close()
+	if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
+		certificate := response.TLS.PeerCertificates[0]
+		result.CertificateExpiration = time.Until(certificate.NotAfter)
+	}
+	result.HTTPStatus = response.StatusCode
+	result.Connected = response.StatusCode > 0
+	result.Protocol = response.Proto
+	if response.Request != nil {
+		result.FinalURL = response.Request.URL.String()
+		// Each request made to follow a redirect references the response that caused it
+		for r := response.Request; r != nil && r.Response != nil; r = r.Response.Request {
+			result.RedirectCount++
+		}
+	}
+	// Only keep the headers if there's a condition or a store mapping that uses the HeadersPlaceholder
+	if e.needsResponseHeaders() {
+		result.Headers = response.Header
+	}
+	// Only read the Body if there's a condition that uses the BodyPlaceholder
+	if e.needsToReadBody() {
+		transferStartTime := time.Now()
+		result.Body, err = io.ReadAll(response.Body)
+		result.TransferDuration = time.Since(transferStartTime)
+		if err != nil {
+			result.AddError("error reading response body:" + err.Error())
+		}
+	}
+}
+
+// grpcChecker checks grpc:// and grpcs:// endpoints using the gRPC health checking protocol, or by calling the unary
+// method of the gRPC configuration of the endpoint
+type grpcChecker struct{}
+
+func (c *grpcChecker) Type() Type {
+	return TypeGRPC
+}
+
+func (c *grpcChecker) SupportedPlaceholders() []string {
+	return []string{GRPCStatusPlaceholder, BodyPlaceholder}
+}
+
+func (c *grpcChecker) Check(e *Endpoint, result *Result) {
+	useTLS := strings.HasPrefix(e.URL, "grpcs://")
+	address := strings.TrimPrefix(strings.TrimPrefix(e.URL, "grpcs://"), "grpc://")
+	if e.GRPCConfig != nil {
+		var err error
+		result.Connected, result.GRPCStatus, result.Body, err, result.Duration = client.PerformGRPCUnaryCall(address, useTLS, e.GRPCConfig.Method, e.getParsedBody(), e.Headers, e.GRPCConfig.Files(), e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+		}
+		return
+	}
+	connected, status, err, duration := client.PerformGRPCHealthCheck(address, useTLS, e.ClientConfig)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	result.Connected = connected
+	result.Duration = duration
+	result.GRPCStatus = "OK"
+	if e.needsToReadBody() {
+		result.Body = []byte(fmt.Sprintf("{\"status\":\"%s\"}", status))
+	}
+}
+
+// webSocketChecker checks ws:// and wss:// endpoints by sending the body and reading the response, or by executing the
+// steps of the websocket configuration of the endpoint
+type webSocketChecker struct{}
+
+func (c *webSocketChecker) Type() Type {
+	return TypeWS
+}
+
+func (c *webSocketChecker) SupportedPlaceholders() []string {
+	return []string{WebSocketMessagesReceivedPlaceholder, WebSocketCloseCodePlaceholder, BodyPlaceholder}
+}
+
+func (c *webSocketChecker) Check(e *Endpoint, result *Result) {
+	wsHeaders := map[string]string{}
+	if e.Headers != nil {
+		maps.Copy(wsHeaders, e.Headers)
+	}
+	if !hasHeader(wsHeaders, UserAgentHeader) {
+		wsHeaders[UserAgentHeader] = GatusUserAgent
+	}
+	if e.WebSocketConfig != nil {
+		c.converse(e, result, wsHeaders)
+		return
+	}
+	var err error
+	startTime := time.Now()
+	result.Connected, result.Body, err = client.QueryWebSocket(e.URL, e.getParsedBody(), wsHeaders, e.ClientConfig)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	result.Duration = time.Since(startTime)
+	result.WebSocketMessagesReceived = 1
+}
+
+// converse executes the steps of the websocket configuration of the endpoint
+func (c *webSocketChecker) converse(e *Endpoint, result *Result, wsHeaders map[string]string) {
+	steps := make([]*wsconfig.Step, 0, len(e.WebSocketConfig.Steps))
+	for _, step := range e.WebSocketConfig.Steps {
+		parsedStep := *step
+		parsedStep.Send = e.parseBodyPlaceholders(step.Send)
+		steps = append(steps, &parsedStep)
+	}
+	startTime := time.Now()
+	connected, conversation, err := client.PerformWebSocketConversation(e.URL, wsHeaders, steps, e.ClientConfig)
+	result.Duration = time.Since(startTime)
+	result.Connected = connected
+	result.WebSocketMessagesReceived = len(conversation.Messages)
+	result.WebSocketCloseCode = conversation.CloseCode
+	if len(e.WebSocketConfig.BodyFrom) > 0 {
+		result.Body = conversation.NamedMessages[e.WebSocketConfig.BodyFrom]
+	} else if len(conversation.Messages) > 0 {
+		result.Body = conversation.Messages[len(conversation.Messages)-1]
+	}
+	if err != nil {
+		result.AddError(err.Error())
+	}
+}
+
+// sshChecker checks ssh:// endpoints by executing the body as a command, or by validating the SSH banner if no
+// credentials are configured
+type sshChecker struct{}
+
+func (c *sshChecker) Type() Type {
+	return TypeSSH
+}
+
+func (c *sshChecker) SupportedPlaceholders() []string {
+	// The StatusPlaceholder is populated with the exit code of the command
+	return []string{StatusPlaceholder, BodyPlaceholder}
+}
+
+func (c *sshChecker) Check(e *Endpoint, result *Result) {
+	var err error
+	startTime := time.Now()
+	// If there's no username, password or private key specified, attempt to validate just the SSH banner
+	if e.SSHConfig == nil || (len(e.SSHConfig.Username) == 0 && len(e.SSHConfig.Password) == 0 && len(e.SSHConfig.PrivateKey) == 0) {
+		result.Connected, result.HTTPStatus, err = client.CheckSSHBanner(strings.TrimPrefix(e.URL, "ssh://"), e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Success = result.Connected
+		result.Duration = time.Since(startTime)
+		return
+	}
+	var cli *ssh.Client
+	result.Connected, cli, err = client.CanCreateSSHConnection(strings.TrimPrefix(e.URL, "ssh://"), e.SSHConfig.Username, e.SSHConfig.Password, e.SSHConfig.PrivateKey, e.ClientConfig)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	var output []byte
+	result.Success, result.HTTPStatus, output, err = client.ExecuteSSHCommand(cli, e.getParsedBody(), e.ClientConfig)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	// Only store the output in result.Body if there's a condition that uses the BodyPlaceholder
+	if e.needsToReadBody() {
+		result.Body = output
+	}
+	result.Duration = time.Since(startTime)
+}
+
+// databaseChecker checks postgres://, postgresql:// and mysql:// endpoints by connecting to the database and running
+// the body as a query
+type databaseChecker struct {
+	endpointType Type
+}
+
+func (c *databaseChecker) Type() Type {
+	return c.endpointType
+}
+
+func (c *databaseChecker) SupportedPlaceholders() []string {
+	return []string{BodyPlaceholder}
+}
+
+func (c *databaseChecker) Check(e *Endpoint, result *Result) {
+	connected, body, connectionDuration, queryDuration, err := client.QueryDatabase(e.URL, e.getParsedBody(), e.ClientConfig)
+	result.Connected = connected
+	result.ConnectionDuration, result.QueryDuration = connectionDuration, queryDuration
+	result.Duration = connectionDuration + queryDuration
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	if e.needsToReadBody() {
+		result.Body = body
+	}
+}
+
+// redisChecker checks redis:// and rediss:// endpoints by connecting to the server and executing the body as a command
+type redisChecker struct{}
+
+func (c *redisChecker) Type() Type {
+	return TypeRedis
+}
+
+func (c *redisChecker) SupportedPlaceholders() []string {
+	return []string{BodyPlaceholder}
+}
+
+func (c *redisChecker) Check(e *Endpoint, result *Result) {
+	connected, body, connectionDuration, commandDuration, err := client.ExecuteRedisCommand(e.URL, e.getParsedBody(), e.ClientConfig)
+	result.Connected = connected
+	result.ConnectionDuration, result.QueryDuration = connectionDuration, commandDuration
+	result.Duration = connectionDuration + commandDuration
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	if e.needsToReadBody() {
+		result.Body = body
+	}
+}
@@ -97,6 +97,12 @@ func (c Condition) hasBodyPlaceholder() bool {
 	return strings.Contains(string(c), BodyPlaceholder)
 }
 
+// hasHeadersPlaceholder checks whether the condition has a HeadersPlaceholder
+// Used for determining whether the response headers should be kept
+func (c Condition) hasHeadersPlaceholder() bool {
+	return strings.Contains(string(c), HeadersPlaceholder)
+}
+
 // hasDomainExpirationPlaceholder checks whether the condition has a DomainExpirationPlaceholder
 // Used for determining whether a whois operation is necessary
 func (c Condition) hasDomainExpirationPlaceholder() bool {
@@ -109,6 +115,13 @@ func (c Condition) hasIPPlaceholder() bool {
 	return strings.Contains(string(c), IPPlaceholder)
 }
 
+// hasConnectionTimePlaceholder checks whether the condition has a DNSTimePlaceholder, a ConnectTimePlaceholder or a
+// TLSTimePlaceholder
+// Used for determining whether a new connection must be established for every request
+func (c Condition) hasConnectionTimePlaceholder() bool {
+	return strings.Contains(string(c), DNSTimePlaceholder) || strings.Contains(string(c), ConnectTimePlaceholder) || strings.Contains(string(c), TLSTimePlaceholder)
+}
+
 // isEqual compares two strings.
 //
 // Supports the "pat" and the "any" functions.
@@ -270,6 +270,27 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: true,
 			ExpectedOutput:  "[BODY][0].id == 1",
 		},
+		{
+			Name:            "body-len-of-dns-answers",
+			Condition:       Condition("len([BODY]) == 2"),
+			Result:          &Result{Body: []byte(`[{"name":"example.org.","type":"A","ttl":300,"value":"192.0.2.1"},{"name":"example.org.","type":"A","ttl":300,"value":"192.0.2.2"}]`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "len([BODY]) == 2",
+		},
+		{
+			Name:            "body-jsonpath-dns-answer-value-with-any",
+			Condition:       Condition("[BODY][1].value == any(192.0.2.2, 192.0.2.3)"),
+			Result:          &Result{Body: []byte(`[{"name":"example.org.","type":"A","ttl":300,"value":"192.0.2.1"},{"name":"example.org.","type":"A","ttl":300,"value":"192.0.2.2"}]`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY][1].value == any(192.0.2.2, 192.0.2.3)",
+		},
+		{
+			Name:            "body-jsonpath-dns-answer-ttl",
+			Condition:       Condition("[BODY][0].ttl >= 60"),
+			Result:          &Result{Body: []byte(`[{"name":"example.org.","type":"A","ttl":300,"value":"192.0.2.1"},{"name":"example.org.","type":"A","ttl":300,"value":"192.0.2.2"}]`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY][0].ttl >= 60",
+		},
 		{
 			Name:            "body-jsonpath-when-body-has-null-parameter",
 			Condition:       Condition("[BODY].data == OK"),
@@ -13,6 +13,10 @@ var (
 
 	// ErrDNSWithInvalidQueryType is the error with which gatus will panic if a dns is configured with invalid query type
 	ErrDNSWithInvalidQueryType = errors.New("invalid query type in the DNS configuration")
+
+	// ErrDNSWithUnsupportedResolverScheme is the error with which gatus will panic if a dns is configured with a
+	// resolver URL whose scheme is not supported
+	ErrDNSWithUnsupportedResolverScheme = errors.New("unsupported resolver scheme in the DNS configuration: the url must be an address, or start with tls:// or https://")
 )
 
 // Config for an Endpoint of type DNS
@@ -22,6 +26,18 @@ type Config struct {
 
 	// QueryName is the query for DNS
 	QueryName string `yaml:"query-name"`
+
+	// DNSSEC is whether the answers should be validated from the root zone down to the zone of the query name
+	DNSSEC bool `yaml:"dnssec,omitempty"`
+}
+
+// ValidateResolverURL validates the URL of the resolver to send the query to, which must be an address (e.g. 8.8.8.8 or
+// 8.8.8.8:53) for plain DNS, a tls:// URL for DNS over TLS or an https:// URL for DNS over HTTPS
+func ValidateResolverURL(url string) error {
+	if scheme, _, found := strings.Cut(url, "://"); found && scheme != "tls" && scheme != "https" {
+		return ErrDNSWithUnsupportedResolverScheme
+	}
+	return nil
 }
 
 func (d *Config) ValidateAndSetDefault() error {
@@ -1,6 +1,7 @@
 package dns
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -25,3 +26,26 @@ func TestConfig_ValidateAndSetDefaultsWithInvalidDNSQueryType(t *testing.T) {
 		t.Error("Should've returned an error because endpoint's dns query type is invalid, it needs to be a valid query name like A, AAAA, CNAME...")
 	}
 }
+
+func TestValidateResolverURL(t *testing.T) {
+	scenarios := []struct {
+		url         string
+		expectedErr error
+	}{
+		{url: "8.8.8.8", expectedErr: nil},
+		{url: "8.8.8.8:53", expectedErr: nil},
+		{url: "[2001:4860:4860::8888]:53", expectedErr: nil},
+		{url: "tls://1.1.1.1", expectedErr: nil},
+		{url: "tls://1.1.1.1:853", expectedErr: nil},
+		{url: "https://dns.google/dns-query", expectedErr: nil},
+		{url: "http://dns.google/dns-query", expectedErr: ErrDNSWithUnsupportedResolverScheme},
+		{url: "udp://8.8.8.8", expectedErr: ErrDNSWithUnsupportedResolverScheme},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.url, func(t *testing.T) {
+			if err := ValidateResolverURL(scenario.url); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
@@ -2,17 +2,16 @@ package endpoint
 
 import (
 	"bytes"
-	"crypto/x509"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"maps"
 	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,12 +19,16 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+	"github.com/TwiN/gatus/v5/config/endpoint/grpc"
+	"github.com/TwiN/gatus/v5/config/endpoint/retry"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
 	sshconfig "github.com/TwiN/gatus/v5/config/endpoint/ssh"
 	"github.com/TwiN/gatus/v5/config/endpoint/ui"
+	wsconfig "github.com/TwiN/gatus/v5/config/endpoint/websocket"
 	"github.com/TwiN/gatus/v5/config/gontext"
 	"github.com/TwiN/gatus/v5/config/key"
 	"github.com/TwiN/gatus/v5/config/maintenance"
-	"golang.org/x/crypto/ssh"
+	"github.com/TwiN/logr"
 )
 
 type Type string
@@ -54,6 +57,9 @@ const (
 	TypeGRPC     Type = "GRPC"
 	TypeWS       Type = "WEBSOCKET"
 	TypeSSH      Type = "SSH"
+	TypePostgres Type = "POSTGRES"
+	TypeMySQL    Type = "MYSQL"
+	TypeRedis    Type = "REDIS"
 	TypeUNKNOWN  Type = "UNKNOWN"
 )
 
@@ -75,6 +81,30 @@ var (
 	// This is because the free whois service we are using should not be abused, especially considering the fact that
 	// the data takes a while to be updated.
 	ErrInvalidEndpointIntervalForDomainExpirationPlaceholder = errors.New("the minimum interval for an endpoint with a condition using the " + DomainExpirationPlaceholder + " placeholder is 300s (5m)")
+
+	// ErrEndpointWithBurnRateAlertAndNoSLO is the error with which Gatus will panic if an endpoint has a burn-rate alert
+	// but no SLO, since the burn rate is relative to the error budget of the SLO
+	ErrEndpointWithBurnRateAlertAndNoSLO = errors.New("an endpoint with a burn-rate alert must have an slo")
+
+	// ErrEndpointWithLocationQuorumAndNoLocations is the error with which Gatus will panic if an endpoint has a
+	// location quorum but no locations
+	ErrEndpointWithLocationQuorumAndNoLocations = errors.New("an endpoint with a location-quorum must have locations")
+
+	// ErrInvalidEndpointLocationQuorum is the error with which Gatus will panic if an endpoint has a location quorum
+	// that is lower than 1 or higher than its number of locations
+	ErrInvalidEndpointLocationQuorum = errors.New("location-quorum must be between 1 and the number of locations")
+
+	// ErrEndpointWithDuplicateLocation is the error with which Gatus will panic if an endpoint has the same location
+	// more than once
+	ErrEndpointWithDuplicateLocation = errors.New("an endpoint must not have the same location more than once")
+
+	// ErrEndpointWithGRPCConfigAndNonGRPCURL is the error with which Gatus will panic if an endpoint has a gRPC
+	// configuration, but its url doesn't start with grpc:// or grpcs://
+	ErrEndpointWithGRPCConfigAndNonGRPCURL = errors.New("an endpoint with a grpc configuration must have an url starting with grpc:// or grpcs://")
+
+	// ErrEndpointWithWebSocketConfigAndNonWebSocketURL is the error with which Gatus will panic if an endpoint has a
+	// websocket configuration, but its url doesn't start with ws:// or wss://
+	ErrEndpointWithWebSocketConfigAndNonWebSocketURL = errors.New("an endpoint with a websocket configuration must have an url starting with ws:// or wss://")
 )
 
 // Endpoint is the configuration of a service to be monitored
@@ -118,18 +148,46 @@ type Endpoint struct {
 	// MaintenanceWindow is the configuration for per-endpoint maintenance windows
 	MaintenanceWindows []*maintenance.Config `yaml:"maintenance-windows,omitempty"`
 
+	// DependsOn is a list of keys of endpoints this endpoint depends on.
+	// Alerts are not triggered while any of these endpoints is unhealthy.
+	DependsOn []string `yaml:"depends-on,omitempty"`
+
 	// DNSConfig is the configuration for DNS monitoring
 	DNSConfig *dns.Config `yaml:"dns,omitempty"`
 
 	// SSH is the configuration for SSH monitoring
 	SSHConfig *sshconfig.Config `yaml:"ssh,omitempty"`
 
+	// GRPCConfig is the configuration for calling a unary method of an endpoint of type GRPC.
+	// If nil, endpoints of type GRPC are monitored using the standard health check instead.
+	GRPCConfig *grpc.Config `yaml:"grpc,omitempty"`
+
+	// WebSocketConfig is the configuration of a scripted conversation with an endpoint of type WS.
+	// If nil, the body is sent and a single message is read instead.
+	WebSocketConfig *wsconfig.Config `yaml:"websocket,omitempty"`
+
 	// ClientConfig is the configuration of the client used to communicate with the endpoint's target
 	ClientConfig *client.Config `yaml:"client,omitempty"`
 
+	// RetryConfig is the configuration for retrying failed evaluations before the result is recorded
+	RetryConfig *retry.Config `yaml:"retries,omitempty"`
+
 	// UIConfig is the configuration for the UI
 	UIConfig *ui.Config `yaml:"ui,omitempty"`
 
+	// SLO is the configuration of the service level objectives of the endpoint
+	SLO *slo.Config `yaml:"slo,omitempty"`
+
+	// Locations are the names of the locations from which the endpoint is monitored by agents.
+	//
+	// If not empty, the endpoint isn't monitored by this instance, but by the agents of each location instead, and
+	// its health is determined by the results reported by those agents.
+	Locations []string `yaml:"locations,omitempty"`
+
+	// LocationQuorum is the number of locations from which the endpoint must be failing for it to be considered
+	// unhealthy. Defaults to a majority of the locations.
+	LocationQuorum int `yaml:"location-quorum,omitempty"`
+
 	// NumberOfFailuresInARow is the number of unsuccessful evaluations in a row
 	NumberOfFailuresInARow int `yaml:"-"`
 
@@ -162,32 +220,10 @@ func (e *Endpoint) IsEnabled() bool {
 
 // Type returns the endpoint type
 func (e *Endpoint) Type() Type {
-	switch {
-	case e.DNSConfig != nil:
-		return TypeDNS
-	case strings.HasPrefix(e.URL, "tcp://"):
-		return TypeTCP
-	case strings.HasPrefix(e.URL, "sctp://"):
-		return TypeSCTP
-	case strings.HasPrefix(e.URL, "udp://"):
-		return TypeUDP
-	case strings.HasPrefix(e.URL, "icmp://"):
-		return TypeICMP
-	case strings.HasPrefix(e.URL, "starttls://"):
-		return TypeSTARTTLS
-	case strings.HasPrefix(e.URL, "tls://"):
-		return TypeTLS
-	case strings.HasPrefix(e.URL, "http://") || strings.HasPrefix(e.URL, "https://"):
-		return TypeHTTP
-	case strings.HasPrefix(e.URL, "grpc://") || strings.HasPrefix(e.URL, "grpcs://"):
-		return TypeGRPC
-	case strings.HasPrefix(e.URL, "ws://") || strings.HasPrefix(e.URL, "wss://"):
-		return TypeWS
-	case strings.HasPrefix(e.URL, "ssh://"):
-		return TypeSSH
-	default:
-		return TypeUNKNOWN
+	if checker := e.checker(); checker != nil {
+		return checker.Type()
 	}
+	return TypeUNKNOWN
 }
 
 // ValidateAndSetDefaults validates the endpoint's configuration and sets the default value of args that have one
@@ -233,6 +269,10 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 	if len(e.Conditions) == 0 {
 		return ErrEndpointWithNoCondition
 	}
+	checker := e.checker()
+	if checker == nil {
+		return ErrUnknownEndpointType
+	}
 	for _, c := range e.Conditions {
 		if e.Interval < 5*time.Minute && c.hasDomainExpirationPlaceholder() {
 			return ErrInvalidEndpointIntervalForDomainExpirationPlaceholder
@@ -240,15 +280,63 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 		if err := c.Validate(); err != nil {
 			return fmt.Errorf("%v: %w", ErrInvalidConditionFormat, err)
 		}
+		if err := c.validatePlaceholders(checker); err != nil {
+			return err
+		}
+	}
+	if e.RetryConfig != nil {
+		if err := e.RetryConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if e.SLO != nil {
+		if err := e.SLO.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	for _, endpointAlert := range e.Alerts {
+		if endpointAlert.BurnRate != nil && e.SLO == nil {
+			return ErrEndpointWithBurnRateAlertAndNoSLO
+		}
+	}
+	if len(e.Locations) > 0 {
+		for i, location := range e.Locations {
+			if slices.Contains(e.Locations[:i], location) {
+				return ErrEndpointWithDuplicateLocation
+			}
+		}
+		if e.LocationQuorum == 0 {
+			e.LocationQuorum = len(e.Locations)/2 + 1
+		} else if e.LocationQuorum < 0 || e.LocationQuorum > len(e.Locations) {
+			return ErrInvalidEndpointLocationQuorum
+		}
+	} else if e.LocationQuorum != 0 {
+		return ErrEndpointWithLocationQuorumAndNoLocations
 	}
 	if e.DNSConfig != nil {
+		if err := dns.ValidateResolverURL(e.URL); err != nil {
+			return err
+		}
 		return e.DNSConfig.ValidateAndSetDefault()
 	}
 	if e.SSHConfig != nil {
 		return e.SSHConfig.Validate()
 	}
-	if e.Type() == TypeUNKNOWN {
-		return ErrUnknownEndpointType
+	if e.GRPCConfig != nil {
+		if e.Type() != TypeGRPC {
+			return ErrEndpointWithGRPCConfigAndNonGRPCURL
+		}
+		if err := e.GRPCConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if e.WebSocketConfig != nil {
+		if e.Type() != TypeWS {
+			return ErrEndpointWithWebSocketConfigAndNonWebSocketURL
+		}
+		if err := e.WebSocketConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
 	}
 	for _, maintenanceWindow := range e.MaintenanceWindows {
 		if err := maintenanceWindow.ValidateAndSetDefaults(); err != nil {
@@ -276,6 +364,12 @@ func (e *Endpoint) Key() string {
 	return key.ConvertGroupAndNameToKey(e.Group, e.Name)
 }
 
+// IsMonitoredFromLocations returns whether the endpoint is monitored by the agents of its locations rather than by
+// this instance
+func (e *Endpoint) IsMonitoredFromLocations() bool {
+	return len(e.Locations) > 0
+}
+
 // Close HTTP connections between watchdog and endpoints to avoid dangling socket file descriptors
 // on configuration reload.
 // More context on https://github.com/TwiN/gatus/issues/536
@@ -287,11 +381,21 @@ func (e *Endpoint) Close() {
 
 // EvaluateHealth sends a request to the endpoint's URL and evaluates the conditions of the endpoint.
 func (e *Endpoint) EvaluateHealth() *Result {
-	return e.EvaluateHealthWithContext(nil)
+	return e.evaluateHealth(context.Background(), nil)
+}
+
+// EvaluateHealthWithCancellation sends a request to the endpoint's URL and evaluates the conditions of the endpoint,
+// without retrying failed attempts once ctx is done
+func (e *Endpoint) EvaluateHealthWithCancellation(ctx context.Context) *Result {
+	return e.evaluateHealth(ctx, nil)
 }
 
 // EvaluateHealthWithContext sends a request to the endpoint's URL with context support and evaluates the conditions
-func (e *Endpoint) EvaluateHealthWithContext(context *gontext.Gontext) *Result {
+func (e *Endpoint) EvaluateHealthWithContext(gctx *gontext.Gontext) *Result {
+	return e.evaluateHealth(context.Background(), gctx)
+}
+
+func (e *Endpoint) evaluateHealth(ctx context.Context, context *gontext.Gontext) *Result {
 	result := &Result{Success: true, Errors: []string{}}
 	// Preprocess the endpoint with context if provided
 	processedEndpoint := e
@@ -299,7 +403,7 @@ func (e *Endpoint) EvaluateHealthWithContext(context *gontext.Gontext) *Result {
 		processedEndpoint = e.preprocessWithContext(result, context)
 	}
 	// Parse or extract hostname from URL
-	if processedEndpoint.DNSConfig != nil {
+	if processedEndpoint.DNSConfig != nil && !strings.Contains(processedEndpoint.URL, "://") {
 		result.Hostname = strings.TrimSuffix(processedEndpoint.URL, ":53")
 	} else if processedEndpoint.Type() == TypeICMP {
 		// To handle IPv6 addresses, we need to handle the hostname differently here. This is to avoid, for instance,
@@ -314,10 +418,6 @@ func (e *Endpoint) EvaluateHealthWithContext(context *gontext.Gontext) *Result {
 			result.port = urlObject.Port()
 		}
 	}
-	// Retrieve IP if necessary
-	if processedEndpoint.needsToRetrieveIP() {
-		processedEndpoint.getIP(result)
-	}
 	// Retrieve domain expiration if necessary
 	if processedEndpoint.needsToRetrieveDomainExpiration() && len(result.Hostname) > 0 {
 		var err error
@@ -325,40 +425,26 @@ func (e *Endpoint) EvaluateHealthWithContext(context *gontext.Gontext) *Result {
 			result.AddError(err.Error())
 		}
 	}
-	// Call the endpoint (if there's no errors)
-	if len(result.Errors) == 0 {
-		processedEndpoint.call(result)
-	} else {
-		result.Success = false
-	}
-	// Evaluate the conditions
-	for _, condition := range processedEndpoint.Conditions {
-		success := condition.evaluate(result, processedEndpoint.UIConfig.DontResolveFailedConditions, processedEndpoint.UIConfig.ResolveSuccessfulConditions, context)
-		if !success {
-			result.Success = false
-		}
-	}
+	// Call the endpoint and evaluate the conditions, retrying if necessary
+	result = processedEndpoint.attemptWithRetries(ctx, result, context)
 	result.Timestamp = time.Now()
 	// Clean up parameters that we don't need to keep in the results
 	if processedEndpoint.UIConfig.HideURL {
-		for errIdx, errorString := range result.Errors {
-			result.Errors[errIdx] = strings.ReplaceAll(errorString, processedEndpoint.URL, "<redacted>")
-		}
+		result.redactErrors(processedEndpoint.URL)
 	}
 	if processedEndpoint.UIConfig.HideHostname {
-		for errIdx, errorString := range result.Errors {
-			result.Errors[errIdx] = strings.ReplaceAll(errorString, result.Hostname, "<redacted>")
-		}
+		result.redactErrors(result.Hostname)
 		result.Hostname = "" // remove it from the result so it doesn't get exposed
 	}
 	if processedEndpoint.UIConfig.HidePort && len(result.port) > 0 {
-		for errIdx, errorString := range result.Errors {
-			result.Errors[errIdx] = strings.ReplaceAll(errorString, result.port, "<redacted>")
-		}
+		result.redactErrors(result.port)
 		result.port = ""
 	}
 	if processedEndpoint.UIConfig.HideErrors {
 		result.Errors = nil
+		for _, attempt := range result.Attempts {
+			attempt.Errors = nil
+		}
 	}
 	if processedEndpoint.UIConfig.HideConditions {
 		result.ConditionResults = nil
@@ -366,6 +452,94 @@ func (e *Endpoint) EvaluateHealthWithContext(context *gontext.Gontext) *Result {
 	return result
 }
 
+// attemptWithRetries calls the endpoint and evaluates its conditions using a copy of the base result.
+// If the attempt fails and the endpoint has retries configured, the attempt is repeated until it succeeds, the error
+// is no longer retryable or the number of retries has been exhausted, whichever comes first. Retries also stop once
+// ctx is done, or if waiting for the next retry would make the evaluation last longer than the endpoint's interval.
+//
+// The result of the final attempt is returned, and if retries are configured, it includes every attempt made.
+func (e *Endpoint) attemptWithRetries(ctx context.Context, baseResult *Result, context *gontext.Gontext) *Result {
+	var attempts []*Attempt
+	start := time.Now()
+	for retries := 0; ; retries++ {
+		result := &Result{
+			Success:          baseResult.Success,
+			Errors:           slices.Clone(baseResult.Errors),
+			Hostname:         baseResult.Hostname,
+			DomainExpiration: baseResult.DomainExpiration,
+			port:             baseResult.port,
+		}
+		e.attempt(result, context)
+		if e.RetryConfig == nil {
+			return result
+		}
+		attempts = append(attempts, &Attempt{
+			Success:   result.Success,
+			Duration:  result.Duration,
+			Errors:    slices.Clone(result.Errors),
+			Timestamp: time.Now(),
+		})
+		if result.Success || retries >= e.RetryConfig.Count || !e.isRetryable(result) {
+			result.Attempts = attempts
+			return result
+		}
+		delay := e.RetryConfig.GetDelayBeforeRetry(retries + 1)
+		if e.Interval > 0 && time.Since(start)+delay > e.Interval {
+			logr.Debugf("[endpoint.attemptWithRetries] Attempt %d for endpoint with key=%s failed, not retrying because the next retry would exceed the interval of %s", retries+1, e.Key(), e.Interval)
+			result.Attempts = attempts
+			return result
+		}
+		logr.Debugf("[endpoint.attemptWithRetries] Attempt %d for endpoint with key=%s failed, retrying in %s", retries+1, e.Key(), delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			logr.Debugf("[endpoint.attemptWithRetries] Not retrying endpoint with key=%s, because its monitoring has been canceled", e.Key())
+			result.Attempts = attempts
+			return result
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt retrieves the IP if necessary, calls the endpoint and evaluates its conditions
+func (e *Endpoint) attempt(result *Result, context *gontext.Gontext) {
+	// Retrieve IP if necessary
+	if e.needsToRetrieveIP() {
+		e.getIP(result)
+	}
+	// Call the endpoint (if there's no errors)
+	if len(result.Errors) == 0 {
+		e.call(result)
+	} else {
+		result.Success = false
+	}
+	// Evaluate the conditions
+	for _, condition := range e.Conditions {
+		success := condition.evaluate(result, e.UIConfig.DontResolveFailedConditions, e.UIConfig.ResolveSuccessfulConditions, context)
+		if !success {
+			result.Success = false
+		}
+	}
+}
+
+// isRetryable checks whether a failed attempt should be retried based on the endpoint's retry configuration
+func (e *Endpoint) isRetryable(result *Result) bool {
+	if len(result.Errors) == 0 {
+		// Some endpoint types (e.g. TCP, ICMP) don't return an error when they fail to connect
+		if !result.Connected {
+			return e.RetryConfig.IsRetryable(retry.ErrorClassConnection)
+		}
+		return e.RetryConfig.IsRetryable(retry.ErrorClassConditions)
+	}
+	for _, err := range result.Errors {
+		if e.RetryConfig.IsRetryable(retry.ClassifyError(err)) {
+			return true
+		}
+	}
+	return false
+}
+
 // preprocessWithContext creates a copy of the endpoint with context placeholders replaced
 func (e *Endpoint) preprocessWithContext(result *Result, context *gontext.Gontext) *Endpoint {
 	// Create a deep copy of the endpoint
@@ -416,7 +590,11 @@ func replaceContextPlaceholders(input string, ctx *gontext.Gontext) (string, err
 }
 
 func (e *Endpoint) getParsedBody() string {
-	body := e.Body
+	return e.parseBodyPlaceholders(e.Body)
+}
+
+// parseBodyPlaceholders replaces the placeholders supported by the body of the endpoint in the text passed
+func (e *Endpoint) parseBodyPlaceholders(body string) string {
 	body = strings.ReplaceAll(body, "[ENDPOINT_NAME]", e.Name)
 	body = strings.ReplaceAll(body, "[ENDPOINT_GROUP]", e.Group)
 	body = strings.ReplaceAll(body, "[ENDPOINT_URL]", e.URL)
@@ -447,124 +625,14 @@ func (e *Endpoint) getIP(result *Result) {
 	}
 }
 
+// call calls the endpoint using the checker responsible for the endpoint's type
 func (e *Endpoint) call(result *Result) {
-	var request *http.Request
-	var response *http.Response
-	var err error
-	var certificate *x509.Certificate
-	endpointType := e.Type()
-	if endpointType == TypeHTTP {
-		request = e.buildHTTPRequest()
-	}
-	startTime := time.Now()
-	if endpointType == TypeDNS {
-		result.Connected, result.DNSRCode, result.Body, err = client.QueryDNS(e.DNSConfig.QueryType, e.DNSConfig.QueryName, e.URL)
-		if err != nil {
-			result.AddError(err.Error())
-			return
-		}
-		result.Duration = time.Since(startTime)
-	} else if endpointType == TypeSTARTTLS || endpointType == TypeTLS {
-		if endpointType == TypeSTARTTLS {
-			result.Connected, certificate, err = client.CanPerformStartTLS(strings.TrimPrefix(e.URL, "starttls://"), e.ClientConfig)
-		} else {
-			result.Connected, result.Body, certificate, err = client.CanPerformTLS(strings.TrimPrefix(e.URL, "tls://"), e.getParsedBody(), e.ClientConfig)
-		}
-		if err != nil {
-			result.AddError(err.Error())
-			return
-		}
-		result.Duration = time.Since(startTime)
-		result.CertificateExpiration = time.Until(certificate.NotAfter)
-	} else if endpointType == TypeTCP {
-		result.Connected, result.Body = client.CanCreateNetworkConnection("tcp", strings.TrimPrefix(e.URL, "tcp://"), e.getParsedBody(), e.ClientConfig)
-		result.Duration = time.Since(startTime)
-	} else if endpointType == TypeUDP {
-		result.Connected, result.Body = client.CanCreateNetworkConnection("udp", strings.TrimPrefix(e.URL, "udp://"), e.getParsedBody(), e.ClientConfig)
-		result.Duration = time.Since(startTime)
-	} else if endpointType == TypeSCTP {
-		result.Connected = client.CanCreateSCTPConnection(strings.TrimPrefix(e.URL, "sctp://"), e.ClientConfig)
-		result.Duration = time.Since(startTime)
-	} else if endpointType == TypeICMP {
-		result.Connected, result.Duration = client.Ping(strings.TrimPrefix(e.URL, "icmp://"), e.ClientConfig)
-	} else if endpointType == TypeWS {
-		wsHeaders := map[string]string{}
-		if e.Headers != nil {
-			maps.Copy(wsHeaders, e.Headers)
-		}
-		if !hasHeader(wsHeaders, UserAgentHeader) {
-			wsHeaders[UserAgentHeader] = GatusUserAgent
-		}
-		result.Connected, result.Body, err = client.QueryWebSocket(e.URL, e.getParsedBody(), wsHeaders, e.ClientConfig)
-		if err != nil {
-			result.AddError(err.Error())
-			return
-		}
-		result.Duration = time.Since(startTime)
-	} else if endpointType == TypeSSH {
-		// If there's no username, password or private key specified, attempt to validate just the SSH banner
-		if e.SSHConfig == nil || (len(e.SSHConfig.Username) == 0 && len(e.SSHConfig.Password) == 0 && len(e.SSHConfig.PrivateKey) == 0) {
-			result.Connected, result.HTTPStatus, err = client.CheckSSHBanner(strings.TrimPrefix(e.URL, "ssh://"), e.ClientConfig)
-			if err != nil {
-				result.AddError(err.Error())
-				return
-			}
-			result.Success = result.Connected
-			result.Duration = time.Since(startTime)
-			return
-		}
-		var cli *ssh.Client
-		result.Connected, cli, err = client.CanCreateSSHConnection(strings.TrimPrefix(e.URL, "ssh://"), e.SSHConfig.Username, e.SSHConfig.Password, e.SSHConfig.PrivateKey, e.ClientConfig)
-		if err != nil {
-			result.AddError(err.Error())
-			return
-		}
-		var output []byte
-		result.Success, result.HTTPStatus, output, err = client.ExecuteSSHCommand(cli, e.getParsedBody(), e.ClientConfig)
-		if err != nil {
-			result.AddError(err.Error())
-			return
-		}
-		// Only store the output in result.Body if there's a condition that uses the BodyPlaceholder
-		if e.needsToReadBody() {
-			result.Body = output
-		}
-		result.Duration = time.Since(startTime)
-	} else if endpointType == TypeGRPC {
-		useTLS := strings.HasPrefix(e.URL, "grpcs://")
-		address := strings.TrimPrefix(strings.TrimPrefix(e.URL, "grpcs://"), "grpc://")
-		connected, status, err, duration := client.PerformGRPCHealthCheck(address, useTLS, e.ClientConfig)
-		if err != nil {
-			result.AddError(err.Error())
-			return
-		}
-		result.Connected = connected
-		result.Duration = duration
-		if e.needsToReadBody() {
-			result.Body = []byte(fmt.Sprintf("{\"status\":\"%s\"}", status))
-		}
-	} else {
-		response, err = client.GetHTTPClient(e.ClientConfig).Do(request)
-		result.Duration = time.Since(startTime)
-		if err != nil {
-			result.AddError(err.Error())
-			return
-		}
-		defer response.Body.Close()
-		if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
-			certificate = response.TLS.PeerCertificates[0]
-			result.CertificateExpiration = time.Until(certificate.NotAfter)
-		}
-		result.HTTPStatus = response.StatusCode
-		result.Connected = response.StatusCode > 0
-		// Only read the Body if there's a condition that uses the BodyPlaceholder
-		if e.needsToReadBody() {
-			result.Body, err = io.ReadAll(response.Body)
-			if err != nil {
-				result.AddError("error reading response body:" + err.Error())
-			}
-		}
+	checker := e.checker()
+	if checker == nil {
+		result.AddError(ErrUnknownEndpointType.Error())
+		return
 	}
+	checker.Check(e, result)
 }
 
 func (e *Endpoint) buildHTTPRequest() *http.Request {
@@ -606,6 +674,32 @@ func (e *Endpoint) needsToReadBody() bool {
 	return false
 }
 
+// needsResponseHeaders checks if there's any condition or store mapping that requires the response headers to be kept
+func (e *Endpoint) needsResponseHeaders() bool {
+	for _, condition := range e.Conditions {
+		if condition.hasHeadersPlaceholder() {
+			return true
+		}
+	}
+	for _, value := range e.Store {
+		if strings.Contains(value, HeadersPlaceholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsNewConnection checks if the DNS lookup, TCP connection and TLS handshake of every request must be timed, which
+// is only possible if no idle connection is reused
+func (e *Endpoint) needsNewConnection() bool {
+	for _, condition := range e.Conditions {
+		if condition.hasConnectionTimePlaceholder() {
+			return true
+		}
+	}
+	return false
+}
+
 // needsToRetrieveDomainExpiration checks if there's any condition that requires a whois query to be performed
 func (e *Endpoint) needsToRetrieveDomainExpiration() bool {
 	for _, condition := range e.Conditions {
@@ -58,6 +58,12 @@ func (externalEndpoint *ExternalEndpoint) ValidateAndSetDefaults() error {
 	if len(externalEndpoint.Token) == 0 {
 		return ErrExternalEndpointWithNoToken
 	}
+	for _, externalEndpointAlert := range externalEndpoint.Alerts {
+		if externalEndpointAlert.BurnRate != nil {
+			// External endpoints can't have an SLO
+			return ErrEndpointWithBurnRateAlertAndNoSLO
+		}
+	}
 	if externalEndpoint.Heartbeat.Interval != 0 && externalEndpoint.Heartbeat.Interval < 10*time.Second {
 		// If the heartbeat interval is set (non-0), it must be at least 10 seconds.
 		return ErrExternalEndpointHeartbeatIntervalTooLow
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	// ErrGRPCWithNoMethod is the error with which Gatus will panic if an endpoint with a gRPC configuration has no method
+	ErrGRPCWithNoMethod = errors.New("you must specify the method to call in the gRPC configuration")
+
+	// ErrGRPCWithInvalidMethod is the error with which Gatus will panic if the method of a gRPC configuration is not in
+	// the format package.Service/Method
+	ErrGRPCWithInvalidMethod = errors.New("invalid method in the gRPC configuration: must be in the format package.Service/Method")
+
+	// ErrGRPCWithInvalidDescriptorSet is the error with which Gatus will panic if the descriptor set of a gRPC
+	// configuration can't be read or doesn't describe the method
+	ErrGRPCWithInvalidDescriptorSet = errors.New("invalid descriptor set in the gRPC configuration")
+)
+
+// Config is the configuration of an endpoint of type GRPC that calls a unary method instead of the standard health
+// check
+type Config struct {
+	// Method is the fully-qualified name of the unary method to call (e.g. helloworld.Greeter/SayHello)
+	Method string `yaml:"method"`
+
+	// DescriptorSet is the path to a file containing a serialized FileDescriptorSet that describes the service, as
+	// generated by `protoc --include_imports --descriptor_set_out` or `buf build`.
+	// If empty, the service is described by the server through server reflection.
+	DescriptorSet string `yaml:"descriptor-set,omitempty"`
+
+	files *protoregistry.Files // Files of the descriptor set, if any
+}
+
+// ValidateAndSetDefaults validates the gRPC configuration and loads the descriptor set, if any
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Method) == 0 {
+		return ErrGRPCWithNoMethod
+	}
+	c.Method = strings.TrimPrefix(c.Method, "/")
+	service, method, found := strings.Cut(c.Method, "/")
+	if !found || !protoreflect.FullName(service).IsValid() || !protoreflect.Name(method).IsValid() {
+		return ErrGRPCWithInvalidMethod
+	}
+	if len(c.DescriptorSet) > 0 {
+		files, err := loadDescriptorSet(c.DescriptorSet)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrGRPCWithInvalidDescriptorSet, err)
+		}
+		if _, err = files.FindDescriptorByName(protoreflect.FullName(service)); err != nil {
+			return fmt.Errorf("%w: service %s not found", ErrGRPCWithInvalidDescriptorSet, service)
+		}
+		c.files = files
+	}
+	return nil
+}
+
+// Files returns the files of the descriptor set, or nil if the service should be described through server reflection
+func (c *Config) Files() *protoregistry.Files {
+	return c.files
+}
+
+func loadDescriptorSet(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	descriptorSet := new(descriptorpb.FileDescriptorSet)
+	if err = proto.Unmarshal(data, descriptorSet); err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(descriptorSet)
+}
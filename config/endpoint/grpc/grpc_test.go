@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	descriptorSetPath := filepath.Join(t.TempDir(), "health.binpb")
+	descriptorSet, _ := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto),
+	}})
+	if err := os.WriteFile(descriptorSetPath, descriptorSet, 0o600); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	invalidDescriptorSetPath := filepath.Join(t.TempDir(), "invalid.binpb")
+	if err := os.WriteFile(invalidDescriptorSetPath, []byte("invalid"), 0o600); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	scenarios := []struct {
+		name           string
+		config         *Config
+		expectedErr    error
+		expectedMethod string
+		expectedFiles  bool
+	}{
+		{
+			name:           "reflection",
+			config:         &Config{Method: "helloworld.Greeter/SayHello"},
+			expectedMethod: "helloworld.Greeter/SayHello",
+		},
+		{
+			name:           "leading-slash",
+			config:         &Config{Method: "/helloworld.Greeter/SayHello"},
+			expectedMethod: "helloworld.Greeter/SayHello",
+		},
+		{
+			name:           "descriptor-set",
+			config:         &Config{Method: "grpc.health.v1.Health/Check", DescriptorSet: descriptorSetPath},
+			expectedMethod: "grpc.health.v1.Health/Check",
+			expectedFiles:  true,
+		},
+		{
+			name:        "no-method",
+			config:      &Config{},
+			expectedErr: ErrGRPCWithNoMethod,
+		},
+		{
+			name:        "method-without-service",
+			config:      &Config{Method: "SayHello"},
+			expectedErr: ErrGRPCWithInvalidMethod,
+		},
+		{
+			name:        "method-with-too-many-parts",
+			config:      &Config{Method: "helloworld/Greeter/SayHello"},
+			expectedErr: ErrGRPCWithInvalidMethod,
+		},
+		{
+			name:        "descriptor-set-that-does-not-exist",
+			config:      &Config{Method: "grpc.health.v1.Health/Check", DescriptorSet: filepath.Join(t.TempDir(), "missing.binpb")},
+			expectedErr: ErrGRPCWithInvalidDescriptorSet,
+		},
+		{
+			name:        "invalid-descriptor-set",
+			config:      &Config{Method: "grpc.health.v1.Health/Check", DescriptorSet: invalidDescriptorSetPath},
+			expectedErr: ErrGRPCWithInvalidDescriptorSet,
+		},
+		{
+			name:        "descriptor-set-without-service",
+			config:      &Config{Method: "helloworld.Greeter/SayHello", DescriptorSet: descriptorSetPath},
+			expectedErr: ErrGRPCWithInvalidDescriptorSet,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.config.Method != scenario.expectedMethod {
+				t.Errorf("expected method to be %s, got %s", scenario.expectedMethod, scenario.config.Method)
+			}
+			if (scenario.config.Files() != nil) != scenario.expectedFiles {
+				t.Errorf("expected files to be loaded to be %v", scenario.expectedFiles)
+			}
+		})
+	}
+}
@@ -26,16 +26,81 @@ const (
 	// Values that could replace the placeholder: NOERROR, FORMERR, SERVFAIL, NXDOMAIN, NOTIMP, REFUSED
 	DNSRCodePlaceholder = "[DNS_RCODE]"
 
+	// DNSSECValidPlaceholder is a placeholder for whether the answers of a DNS query were validated through DNSSEC
+	//
+	// Values that could replace the placeholder: true, false
+	DNSSECValidPlaceholder = "[DNSSEC_VALID]"
+
+	// GRPCStatusPlaceholder is a placeholder for the status code of a gRPC call
+	//
+	// Values that could replace the placeholder: OK, NOT_FOUND, UNAVAILABLE, ...
+	GRPCStatusPlaceholder = "[GRPC_STATUS]"
+
+	// WebSocketMessagesReceivedPlaceholder is a placeholder for the number of messages received from a websocket server
+	//
+	// Values that could replace the placeholder: 0, 1, 2, ...
+	WebSocketMessagesReceivedPlaceholder = "[WS_MESSAGES_RECEIVED]"
+
+	// WebSocketCloseCodePlaceholder is a placeholder for the code of the close frame received from a websocket server
+	//
+	// Values that could replace the placeholder: 0 (if the server didn't close the connection), 1000, 1001, 1008, ...
+	WebSocketCloseCodePlaceholder = "[WS_CLOSE_CODE]"
+
 	// ResponseTimePlaceholder is a placeholder for the request response time, in milliseconds.
 	//
 	// Values that could replace the placeholder: 1, 500, 1000, ...
 	ResponseTimePlaceholder = "[RESPONSE_TIME]"
 
+	// DNSTimePlaceholder is a placeholder for the time it took to resolve the host of an HTTP endpoint, in milliseconds.
+	//
+	// Values that could replace the placeholder: 0 (if the host is an IP), 5, 20, ...
+	DNSTimePlaceholder = "[DNS_TIME]"
+
+	// ConnectTimePlaceholder is a placeholder for the time it took to establish the TCP connection to an HTTP endpoint,
+	// in milliseconds.
+	//
+	// Values that could replace the placeholder: 10, 50, ...
+	ConnectTimePlaceholder = "[CONNECT_TIME]"
+
+	// TLSTimePlaceholder is a placeholder for the time it took to complete the TLS handshake with an HTTP endpoint, in
+	// milliseconds.
+	//
+	// Values that could replace the placeholder: 0 (if TLS isn't used), 30, 100, ...
+	TLSTimePlaceholder = "[TLS_TIME]"
+
+	// TTFBPlaceholder is a placeholder for the time between the moment the request to an HTTP endpoint was written
+	// and the moment the first byte of the response was received, in milliseconds.
+	//
+	// Values that could replace the placeholder: 1, 150, 500, ...
+	TTFBPlaceholder = "[TTFB]"
+
 	// BodyPlaceholder is a placeholder for the Body of the response
 	//
 	// Values that could replace the placeholder: {}, {"data":{"name":"john"}}, ...
 	BodyPlaceholder = "[BODY]"
 
+	// HeadersPlaceholder is a placeholder for the headers of the response of an HTTP endpoint.
+	// The name of the header, which is case-insensitive, must follow the placeholder.
+	//
+	// Usage: [HEADERS].Content-Type, [HEADERS].cache-control
+	HeadersPlaceholder = "[HEADERS]"
+
+	// FinalURLPlaceholder is a placeholder for the URL of the last request made to an HTTP endpoint, which differs from
+	// the URL of the endpoint if redirects were followed
+	//
+	// Values that could replace the placeholder: https://example.org/login, ...
+	FinalURLPlaceholder = "[FINAL_URL]"
+
+	// RedirectCountPlaceholder is a placeholder for the number of redirects followed when requesting an HTTP endpoint
+	//
+	// Values that could replace the placeholder: 0, 1, 2, ...
+	RedirectCountPlaceholder = "[REDIRECT_COUNT]"
+
+	// ProtocolPlaceholder is a placeholder for the protocol of the response of an HTTP endpoint
+	//
+	// Values that could replace the placeholder: HTTP/1.0, HTTP/1.1, HTTP/2.0
+	ProtocolPlaceholder = "[PROTOCOL]"
+
 	// ConnectedPlaceholder is a placeholder for whether a connection was successfully established.
 	//
 	// Values that could replace the placeholder: true, false
@@ -104,12 +169,24 @@ const (
 //   - [STATUS]: HTTP status code (e.g., "200", "404")
 //   - [IP]: IP address from the response (e.g., "127.0.0.1")
 //   - [RESPONSE_TIME]: Response time in milliseconds (e.g., "250")
+//   - [DNS_TIME]: Time it took to resolve the host of an HTTP endpoint in milliseconds (e.g., "12")
+//   - [CONNECT_TIME]: Time it took to establish the TCP connection to an HTTP endpoint in milliseconds (e.g., "25")
+//   - [TLS_TIME]: Time it took to complete the TLS handshake with an HTTP endpoint in milliseconds (e.g., "40")
+//   - [TTFB]: Time it took an HTTP endpoint to start responding in milliseconds (e.g., "150")
 //   - [DNS_RCODE]: DNS response code (e.g., "NOERROR", "NXDOMAIN")
+//   - [DNSSEC_VALID]: Whether the DNS answers were validated through DNSSEC (e.g., "true", "false")
+//   - [GRPC_STATUS]: gRPC status code (e.g., "OK", "NOT_FOUND")
+//   - [WS_MESSAGES_RECEIVED]: Number of messages received from a websocket server (e.g., "3")
+//   - [WS_CLOSE_CODE]: Code of the close frame received from a websocket server (e.g., "1000")
+//   - [FINAL_URL]: URL of the last request made to an HTTP endpoint (e.g., "https://example.org/login")
+//   - [REDIRECT_COUNT]: Number of redirects followed when requesting an HTTP endpoint (e.g., "1")
+//   - [PROTOCOL]: Protocol of the response of an HTTP endpoint (e.g., "HTTP/1.1", "HTTP/2.0")
 //   - [CONNECTED]: Connection status (e.g., "true", "false")
 //   - [CERTIFICATE_EXPIRATION]: Certificate expiration time in milliseconds
 //   - [DOMAIN_EXPIRATION]: Domain expiration time in milliseconds
 //   - [BODY]: Full response body
 //   - [BODY].path: JSONPath expression on response body (e.g., [BODY].status, [BODY].data[0].name)
+//   - [HEADERS].name: Value of a header of the response of an HTTP endpoint (e.g., [HEADERS].Content-Type)
 //   - [CONTEXT].path: Suite context values (e.g., [CONTEXT].user_id, [CONTEXT].session_token)
 //
 // Function wrappers:
@@ -145,8 +222,30 @@ func ResolvePlaceholder(placeholder string, result *Result, ctx *gontext.Gontext
 		return formatWithFunction(result.IP, fn), nil
 	case ResponseTimePlaceholder:
 		return formatWithFunction(strconv.FormatInt(result.Duration.Milliseconds(), 10), fn), nil
+	case DNSTimePlaceholder:
+		return formatWithFunction(strconv.FormatInt(result.DNSLookupDuration.Milliseconds(), 10), fn), nil
+	case ConnectTimePlaceholder:
+		return formatWithFunction(strconv.FormatInt(result.TCPConnectDuration.Milliseconds(), 10), fn), nil
+	case TLSTimePlaceholder:
+		return formatWithFunction(strconv.FormatInt(result.TLSHandshakeDuration.Milliseconds(), 10), fn), nil
+	case TTFBPlaceholder:
+		return formatWithFunction(strconv.FormatInt(result.TimeToFirstByte.Milliseconds(), 10), fn), nil
 	case DNSRCodePlaceholder:
 		return formatWithFunction(result.DNSRCode, fn), nil
+	case DNSSECValidPlaceholder:
+		return formatWithFunction(strconv.FormatBool(result.DNSSECValid), fn), nil
+	case GRPCStatusPlaceholder:
+		return formatWithFunction(result.GRPCStatus, fn), nil
+	case WebSocketMessagesReceivedPlaceholder:
+		return formatWithFunction(strconv.Itoa(result.WebSocketMessagesReceived), fn), nil
+	case WebSocketCloseCodePlaceholder:
+		return formatWithFunction(strconv.Itoa(result.WebSocketCloseCode), fn), nil
+	case FinalURLPlaceholder:
+		return formatWithFunction(result.FinalURL, fn), nil
+	case RedirectCountPlaceholder:
+		return formatWithFunction(strconv.Itoa(result.RedirectCount), fn), nil
+	case ProtocolPlaceholder:
+		return formatWithFunction(result.Protocol, fn), nil
 	case ConnectedPlaceholder:
 		return formatWithFunction(strconv.FormatBool(result.Connected), fn), nil
 	case CertificateExpirationPlaceholder:
@@ -176,6 +275,11 @@ func ResolvePlaceholder(placeholder string, result *Result, ctx *gontext.Gontext
 		return resolveJSONPathPlaceholder(placeholder, fn, originalPlaceholder, result)
 	}
 
+	// Handle response headers
+	if strings.HasPrefix(uppercasePlaceholder, HeadersPlaceholder+".") {
+		return resolveHeaderPlaceholder(placeholder, fn, originalPlaceholder, result)
+	}
+
 	// Not a recognized placeholder
 	if fn != noFunction {
 		if fn == functionHas {
@@ -228,6 +332,20 @@ func resolveJSONPathPlaceholder(placeholder string, fn functionType, originalPla
 	return resolvedValue, nil
 }
 
+// resolveHeaderPlaceholder handles [HEADERS].name placeholders.
+// If the header has multiple values, they are joined with a comma, as they would be if they were sent as a single header.
+func resolveHeaderPlaceholder(placeholder string, fn functionType, originalPlaceholder string, result *Result) (string, error) {
+	name := placeholder[len(HeadersPlaceholder+"."):]
+	values := result.Headers.Values(name)
+	if fn == functionHas {
+		return strconv.FormatBool(len(values) > 0), nil
+	}
+	if len(values) == 0 {
+		return originalPlaceholder + " " + InvalidConditionElementSuffix, nil
+	}
+	return formatWithFunction(strings.Join(values, ", "), fn), nil
+}
+
 // resolveContextPlaceholder handles [CONTEXT] placeholder resolution
 func resolveContextPlaceholder(placeholder string, fn functionType, originalPlaceholder string, ctx *gontext.Gontext) (string, error) {
 	contextPath := strings.TrimPrefix(placeholder, ContextPlaceholder)
@@ -1,6 +1,7 @@
 package endpoint
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
@@ -9,14 +10,26 @@ import (
 
 func TestResolvePlaceholder(t *testing.T) {
 	result := &Result{
-		HTTPStatus:            200,
-		IP:                    "127.0.0.1",
-		Duration:              250 * time.Millisecond,
-		DNSRCode:              "NOERROR",
-		Connected:             true,
-		CertificateExpiration: 30 * 24 * time.Hour,
-		DomainExpiration:      365 * 24 * time.Hour,
-		Body:                  []byte(`{"status":"success","items":[1,2,3],"user":{"name":"john","id":123}}`),
+		HTTPStatus:                200,
+		IP:                        "127.0.0.1",
+		Duration:                  250 * time.Millisecond,
+		DNSLookupDuration:         12 * time.Millisecond,
+		TCPConnectDuration:        25 * time.Millisecond,
+		TLSHandshakeDuration:      40 * time.Millisecond,
+		TimeToFirstByte:           150 * time.Millisecond,
+		DNSRCode:                  "NOERROR",
+		DNSSECValid:               true,
+		GRPCStatus:                "NOT_FOUND",
+		WebSocketMessagesReceived: 3,
+		WebSocketCloseCode:        1008,
+		Headers:                   http.Header{"Content-Type": []string{"application/json"}, "Cache-Control": []string{"no-cache", "no-store"}},
+		FinalURL:                  "https://example.org/login",
+		RedirectCount:             2,
+		Protocol:                  "HTTP/2.0",
+		Connected:                 true,
+		CertificateExpiration:     30 * 24 * time.Hour,
+		DomainExpiration:          365 * 24 * time.Hour,
+		Body:                      []byte(`{"status":"success","items":[1,2,3],"user":{"name":"john","id":123}}`),
 	}
 
 	ctx := gontext.New(map[string]interface{}{
@@ -37,7 +50,18 @@ func TestResolvePlaceholder(t *testing.T) {
 		{"status", "[STATUS]", "200"},
 		{"ip", "[IP]", "127.0.0.1"},
 		{"response-time", "[RESPONSE_TIME]", "250"},
+		{"dns-time", "[DNS_TIME]", "12"},
+		{"connect-time", "[CONNECT_TIME]", "25"},
+		{"tls-time", "[TLS_TIME]", "40"},
+		{"ttfb", "[TTFB]", "150"},
 		{"dns-rcode", "[DNS_RCODE]", "NOERROR"},
+		{"dnssec-valid", "[DNSSEC_VALID]", "true"},
+		{"grpc-status", "[GRPC_STATUS]", "NOT_FOUND"},
+		{"ws-messages-received", "[WS_MESSAGES_RECEIVED]", "3"},
+		{"ws-close-code", "[WS_CLOSE_CODE]", "1008"},
+		{"final-url", "[FINAL_URL]", "https://example.org/login"},
+		{"redirect-count", "[REDIRECT_COUNT]", "2"},
+		{"protocol", "[PROTOCOL]", "HTTP/2.0"},
 		{"connected", "[CONNECTED]", "true"},
 		{"certificate-expiration", "[CERTIFICATE_EXPIRATION]", "2592000000"},
 		{"domain-expiration", "[DOMAIN_EXPIRATION]", "31536000000"},
@@ -62,6 +86,15 @@ func TestResolvePlaceholder(t *testing.T) {
 		{"has-body-status", "has([BODY].status)", "true"},
 		{"has-body-missing", "has([BODY].missing)", "false"},
 
+		// Header placeholders
+		{"header", "[HEADERS].Content-Type", "application/json"},
+		{"header-case-insensitive", "[headers].content-type", "application/json"},
+		{"header-with-multiple-values", "[HEADERS].Cache-Control", "no-cache, no-store"},
+		{"header-missing", "[HEADERS].Strict-Transport-Security", "[HEADERS].Strict-Transport-Security (INVALID)"},
+		{"len-header", "len([HEADERS].Content-Type)", "16"},
+		{"has-header", "has([HEADERS].Content-Type)", "true"},
+		{"has-header-missing", "has([HEADERS].Strict-Transport-Security)", "false"},
+
 		// Context placeholders
 		{"context-user-id", "[CONTEXT].user_id", "abc123"},
 		{"context-session-token", "[CONTEXT].session_token", "xyz789"},
@@ -1,7 +1,9 @@
 package endpoint
 
 import (
+	"net/http"
 	"slices"
+	"strings"
 	"time"
 )
 
@@ -15,6 +17,41 @@ type Result struct {
 	// Possible values: NOERROR, FORMERR, SERVFAIL, NXDOMAIN, NOTIMP, REFUSED
 	DNSRCode string `json:"-"`
 
+	// DNSSECValid is whether the answers of a DNS query were validated through DNSSEC
+	//
+	// Only populated if the DNS configuration of the endpoint has DNSSEC enabled.
+	DNSSECValid bool `json:"-"`
+
+	// GRPCStatus is the status code of a gRPC call in a human-readable format
+	//
+	// Possible values: OK, CANCELLED, UNKNOWN, INVALID_ARGUMENT, DEADLINE_EXCEEDED, NOT_FOUND, ALREADY_EXISTS,
+	// PERMISSION_DENIED, RESOURCE_EXHAUSTED, FAILED_PRECONDITION, ABORTED, OUT_OF_RANGE, UNIMPLEMENTED, INTERNAL,
+	// UNAVAILABLE, DATA_LOSS, UNAUTHENTICATED
+	GRPCStatus string `json:"-"`
+
+	// WebSocketMessagesReceived is the number of messages received from a websocket server
+	WebSocketMessagesReceived int `json:"-"`
+
+	// WebSocketCloseCode is the code of the close frame received from a websocket server, or 0 if the server didn't
+	// close the connection
+	WebSocketCloseCode int `json:"-"`
+
+	// Headers are the headers of the response of an HTTP endpoint
+	//
+	// Only populated if a condition or a store mapping uses the [HEADERS] placeholder.
+	// Note that this field is not persisted in the storage.
+	Headers http.Header `json:"-"`
+
+	// FinalURL is the URL of the last request made to an HTTP endpoint, which differs from Endpoint.URL if redirects
+	// were followed
+	FinalURL string `json:"-"`
+
+	// RedirectCount is the number of redirects that were followed when requesting an HTTP endpoint
+	RedirectCount int `json:"-"`
+
+	// Protocol is the protocol of the response of an HTTP endpoint (e.g. HTTP/1.1, HTTP/2.0)
+	Protocol string `json:"-"`
+
 	// Hostname extracted from Endpoint.URL
 	Hostname string `json:"hostname,omitempty"`
 
@@ -27,6 +64,38 @@ type Result struct {
 	// Duration time that the request took
 	Duration time.Duration `json:"duration"`
 
+	// ConnectionDuration is the time it took to establish an authenticated connection.
+	//
+	// Only populated by endpoint types that measure it separately from the query (e.g. POSTGRES, MYSQL, REDIS), in
+	// which case Duration is the sum of ConnectionDuration and QueryDuration.
+	ConnectionDuration time.Duration `json:"connectionDuration,omitempty"`
+
+	// QueryDuration is the time it took to execute the query or command once connected.
+	//
+	// Only populated by endpoint types that measure it separately from the connection (e.g. POSTGRES, MYSQL, REDIS).
+	QueryDuration time.Duration `json:"queryDuration,omitempty"`
+
+	// DNSLookupDuration is the time it took to resolve the host of an HTTP endpoint.
+	//
+	// Like TCPConnectDuration and TLSHandshakeDuration, it is 0 if an idle connection was reused.
+	DNSLookupDuration time.Duration `json:"dnsLookupDuration,omitempty"`
+
+	// TCPConnectDuration is the time it took to establish the TCP connection to an HTTP endpoint
+	TCPConnectDuration time.Duration `json:"tcpConnectDuration,omitempty"`
+
+	// TLSHandshakeDuration is the time it took to complete the TLS handshake with an HTTP endpoint
+	TLSHandshakeDuration time.Duration `json:"tlsHandshakeDuration,omitempty"`
+
+	// TimeToFirstByte is the time between the moment the request to an HTTP endpoint was written and the moment the
+	// first byte of the response was received
+	TimeToFirstByte time.Duration `json:"timeToFirstByte,omitempty"`
+
+	// TransferDuration is the time it took to read the body of the response of an HTTP endpoint once its headers
+	// were received.
+	//
+	// Only populated if the body was read, which is the case if a condition uses the [BODY] placeholder.
+	TransferDuration time.Duration `json:"transferDuration,omitempty"`
+
 	// Errors encountered during the evaluation of the Endpoint's health
 	Errors []string `json:"errors,omitempty"`
 
@@ -45,6 +114,17 @@ type Result struct {
 	// DomainExpiration is the duration before the domain expires
 	DomainExpiration time.Duration `json:"-"`
 
+	// SuppressedBy is the key of the endpoint the Endpoint depends on that was unhealthy when this result was taken,
+	// which caused the Endpoint's alerts to be suppressed.
+	//
+	// Only populated if the result is unsuccessful and the Endpoint has dependencies.
+	SuppressedBy string `json:"suppressedBy,omitempty"`
+
+	// Attempts are the attempts that were made to evaluate the Endpoint's health, including the final one.
+	//
+	// Only populated if the Endpoint has retries configured.
+	Attempts []*Attempt `json:"attempts,omitempty"`
+
 	// Body is the response body
 	//
 	// Note that this field is not persisted in the storage.
@@ -71,3 +151,31 @@ func (r *Result) AddError(error string) {
 		r.Errors = append(r.Errors, error+"")
 	}
 }
+
+// redactErrors replaces every occurrence of the value passed as parameter by "<redacted>" in the result's errors,
+// including the errors of each attempt
+func (r *Result) redactErrors(value string) {
+	for errIdx, errorString := range r.Errors {
+		r.Errors[errIdx] = strings.ReplaceAll(errorString, value, "<redacted>")
+	}
+	for _, attempt := range r.Attempts {
+		for errIdx, errorString := range attempt.Errors {
+			attempt.Errors[errIdx] = strings.ReplaceAll(errorString, value, "<redacted>")
+		}
+	}
+}
+
+// Attempt is a single attempt made while evaluating the health of an Endpoint with retries configured
+type Attempt struct {
+	// Success whether the attempt was successful
+	Success bool `json:"success"`
+
+	// Duration time that the request took during this attempt
+	Duration time.Duration `json:"duration"`
+
+	// Errors encountered during this attempt
+	Errors []string `json:"errors,omitempty"`
+
+	// Timestamp when the attempt was completed
+	Timestamp time.Time `json:"timestamp"`
+}
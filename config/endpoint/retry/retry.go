@@ -0,0 +1,124 @@
+package retry
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrorClass is a category of error that can be configured as retryable
+type ErrorClass string
+
+const (
+	ErrorClassTimeout    ErrorClass = "timeout"    // The request timed out
+	ErrorClassConnection ErrorClass = "connection" // The connection could not be established or was interrupted
+	ErrorClassDNS        ErrorClass = "dns"        // The hostname could not be resolved
+	ErrorClassTLS        ErrorClass = "tls"        // The TLS handshake or the certificate verification failed
+	ErrorClassConditions ErrorClass = "conditions" // The request went through, but at least one condition failed
+)
+
+const (
+	// DefaultDelay is the default duration to wait before the first retry
+	DefaultDelay = time.Second
+
+	// DefaultBackoffFactor is the default factor by which the delay is multiplied after each retry
+	DefaultBackoffFactor = 2.0
+
+	// MaximumCount is the maximum number of retries that can be configured.
+	// Retries happen within a single evaluation, so a high count could easily exceed the endpoint's interval.
+	MaximumCount = 10
+)
+
+var (
+	// ErrInvalidCount is the error returned when the number of retries is out of bounds
+	ErrInvalidCount = errors.New("retries.count must be between 1 and 10")
+
+	// ErrInvalidDelay is the error returned when the delay is negative
+	ErrInvalidDelay = errors.New("retries.delay must not be negative")
+
+	// ErrInvalidBackoffFactor is the error returned when the backoff factor is lower than 1
+	ErrInvalidBackoffFactor = errors.New("retries.backoff-factor must be greater than or equal to 1")
+
+	// ErrInvalidErrorClass is the error returned when an unknown error class is configured
+	ErrInvalidErrorClass = errors.New("retries.on must only contain the following values: timeout, connection, dns, tls, conditions")
+
+	// DefaultErrorClasses are the error classes that are retried if none are specified.
+	// Failed conditions are not retried by default, because they usually indicate an actual problem rather than a
+	// transient network issue.
+	DefaultErrorClasses = []ErrorClass{ErrorClassTimeout, ErrorClassConnection, ErrorClassDNS, ErrorClassTLS}
+)
+
+// Config is the configuration for retrying a failed health evaluation before its result is recorded
+type Config struct {
+	// Count is the number of retries to perform after the initial attempt has failed
+	Count int `yaml:"count"`
+
+	// Delay is the duration to wait before the first retry
+	Delay time.Duration `yaml:"delay,omitempty"`
+
+	// BackoffFactor is the factor by which the delay is multiplied after each retry
+	BackoffFactor float64 `yaml:"backoff-factor,omitempty"`
+
+	// On is the list of error classes that are considered retryable
+	On []ErrorClass `yaml:"on,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the retry configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.Count < 1 || c.Count > MaximumCount {
+		return ErrInvalidCount
+	}
+	if c.Delay < 0 {
+		return ErrInvalidDelay
+	} else if c.Delay == 0 {
+		c.Delay = DefaultDelay
+	}
+	if c.BackoffFactor == 0 {
+		c.BackoffFactor = DefaultBackoffFactor
+	} else if c.BackoffFactor < 1 {
+		return ErrInvalidBackoffFactor
+	}
+	if len(c.On) == 0 {
+		c.On = DefaultErrorClasses
+	}
+	for _, errorClass := range c.On {
+		switch errorClass {
+		case ErrorClassTimeout, ErrorClassConnection, ErrorClassDNS, ErrorClassTLS, ErrorClassConditions:
+		default:
+			return ErrInvalidErrorClass
+		}
+	}
+	return nil
+}
+
+// GetDelayBeforeRetry returns the duration to wait before the nth retry (starting at 1)
+func (c *Config) GetDelayBeforeRetry(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	return time.Duration(float64(c.Delay) * math.Pow(c.BackoffFactor, float64(retry-1)))
+}
+
+// IsRetryable returns whether the given error class is configured to be retried
+func (c *Config) IsRetryable(errorClass ErrorClass) bool {
+	return len(errorClass) > 0 && slices.Contains(c.On, errorClass)
+}
+
+// ClassifyError returns the ErrorClass of an error message, or an empty ErrorClass if the error couldn't be classified
+func ClassifyError(err string) ErrorClass {
+	err = strings.ToLower(err)
+	switch {
+	case strings.Contains(err, "timeout"), strings.Contains(err, "deadline exceeded"), strings.Contains(err, "timed out"):
+		return ErrorClassTimeout
+	case strings.Contains(err, "no such host"), strings.Contains(err, "server misbehaving"), strings.HasPrefix(err, "lookup "), strings.Contains(err, "dial tcp: lookup"):
+		return ErrorClassDNS
+	case strings.Contains(err, "tls:"), strings.Contains(err, "x509:"), strings.Contains(err, "certificate"):
+		return ErrorClassTLS
+	case strings.Contains(err, "connection refused"), strings.Contains(err, "connection reset"), strings.Contains(err, "broken pipe"),
+		strings.Contains(err, "no route to host"), strings.Contains(err, "network is unreachable"), strings.HasSuffix(err, "eof"):
+		return ErrorClassConnection
+	}
+	return ""
+}
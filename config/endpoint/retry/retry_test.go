@@ -0,0 +1,122 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedErr   error
+		expectedDelay time.Duration
+	}{
+		{
+			name:          "defaults",
+			cfg:           &Config{Count: 2},
+			expectedDelay: DefaultDelay,
+		},
+		{
+			name:          "custom-delay",
+			cfg:           &Config{Count: 2, Delay: 5 * time.Second, BackoffFactor: 1.5, On: []ErrorClass{ErrorClassConditions}},
+			expectedDelay: 5 * time.Second,
+		},
+		{
+			name:        "count-too-low",
+			cfg:         &Config{Count: 0},
+			expectedErr: ErrInvalidCount,
+		},
+		{
+			name:        "count-too-high",
+			cfg:         &Config{Count: MaximumCount + 1},
+			expectedErr: ErrInvalidCount,
+		},
+		{
+			name:        "negative-delay",
+			cfg:         &Config{Count: 1, Delay: -time.Second},
+			expectedErr: ErrInvalidDelay,
+		},
+		{
+			name:        "backoff-factor-lower-than-one",
+			cfg:         &Config{Count: 1, BackoffFactor: 0.5},
+			expectedErr: ErrInvalidBackoffFactor,
+		},
+		{
+			name:        "unknown-error-class",
+			cfg:         &Config{Count: 1, On: []ErrorClass{"everything"}},
+			expectedErr: ErrInvalidErrorClass,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.cfg.Delay != scenario.expectedDelay {
+				t.Errorf("expected delay to be %s, got %s", scenario.expectedDelay, scenario.cfg.Delay)
+			}
+			if scenario.cfg.BackoffFactor < 1 {
+				t.Errorf("expected backoff factor to be at least 1, got %f", scenario.cfg.BackoffFactor)
+			}
+			if len(scenario.cfg.On) == 0 {
+				t.Error("expected error classes to be set")
+			}
+		})
+	}
+}
+
+func TestConfig_GetDelayBeforeRetry(t *testing.T) {
+	cfg := &Config{Count: 3, Delay: 100 * time.Millisecond, BackoffFactor: 2}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	for retry, expectedDelay := range map[int]time.Duration{0: 100 * time.Millisecond, 1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond} {
+		if delay := cfg.GetDelayBeforeRetry(retry); delay != expectedDelay {
+			t.Errorf("expected delay before retry %d to be %s, got %s", retry, expectedDelay, delay)
+		}
+	}
+}
+
+func TestConfig_IsRetryable(t *testing.T) {
+	cfg := &Config{Count: 1}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !cfg.IsRetryable(ErrorClassTimeout) {
+		t.Error("expected timeouts to be retryable by default")
+	}
+	if cfg.IsRetryable(ErrorClassConditions) {
+		t.Error("expected failed conditions not to be retryable by default")
+	}
+	if cfg.IsRetryable("") {
+		t.Error("expected unclassified errors not to be retryable")
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	scenarios := []struct {
+		err      string
+		expected ErrorClass
+	}{
+		{err: `Get "https://example.org": context deadline exceeded (Client.Timeout exceeded while awaiting headers)`, expected: ErrorClassTimeout},
+		{err: "dial tcp 127.0.0.1:22: i/o timeout", expected: ErrorClassTimeout},
+		{err: `Get "https://example.org": dial tcp: lookup example.org: no such host`, expected: ErrorClassDNS},
+		{err: `Get "https://example.org": tls: failed to verify certificate: x509: certificate has expired`, expected: ErrorClassTLS},
+		{err: `Get "http://127.0.0.1:1": dial tcp 127.0.0.1:1: connect: connection refused`, expected: ErrorClassConnection},
+		{err: `Get "http://127.0.0.1": EOF`, expected: ErrorClassConnection},
+		{err: "invalid condition: [STATUS]", expected: ""},
+	}
+	for _, scenario := range scenarios {
+		t.Run(string(scenario.expected), func(t *testing.T) {
+			if errorClass := ClassifyError(scenario.err); errorClass != scenario.expected {
+				t.Errorf("expected error '%s' to be classified as '%s', got '%s'", scenario.err, scenario.expected, errorClass)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package slo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultWindow is the default rolling window over which the objectives are evaluated
+	DefaultWindow = "28d"
+
+	// MinimumWindow is the minimum rolling window over which the objectives can be evaluated.
+	// Uptime statistics are stored by hour, so a shorter window wouldn't make sense.
+	MinimumWindow = time.Hour
+)
+
+var (
+	// ErrInvalidTarget is the error returned when the availability target is out of bounds
+	ErrInvalidTarget = errors.New("slo.target must be greater than 0 and lower than 100")
+
+	// ErrInvalidWindow is the error returned when the window is not a valid duration of at least an hour
+	ErrInvalidWindow = errors.New("slo.window must be a duration of at least 1h, such as 28d or 720h")
+
+	// ErrInvalidLatencyThreshold is the error returned when the latency threshold is not positive
+	ErrInvalidLatencyThreshold = errors.New("slo.latency.threshold must be greater than 0")
+
+	// ErrInvalidLatencyTarget is the error returned when the latency target is out of bounds
+	ErrInvalidLatencyTarget = errors.New("slo.latency.target must be greater than 0 and lower than 100")
+)
+
+// Config is the configuration of the service level objectives of an endpoint
+type Config struct {
+	// Target is the percentage of executions that must be successful over the window (e.g. 99.9)
+	Target float64 `yaml:"target"`
+
+	// Window is the rolling window over which the objectives are evaluated, in days (e.g. 28d) or as a Go duration
+	// (e.g. 720h)
+	Window string `yaml:"window,omitempty"`
+
+	// Latency is the optional objective on the response time of the endpoint
+	Latency *LatencyConfig `yaml:"latency,omitempty"`
+
+	window time.Duration
+}
+
+// LatencyConfig is the configuration of a latency objective, i.e. the percentage of executions that must have a
+// response time under a threshold
+type LatencyConfig struct {
+	// Threshold is the response time under which an execution meets the latency objective
+	Threshold time.Duration `yaml:"threshold"`
+
+	// Target is the percentage of executions that must meet the latency objective over the window (e.g. 95)
+	Target float64 `yaml:"target"`
+}
+
+// ValidateAndSetDefaults validates the SLO configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.Target <= 0 || c.Target >= 100 {
+		return ErrInvalidTarget
+	}
+	if len(c.Window) == 0 {
+		c.Window = DefaultWindow
+	}
+	window, err := parseWindow(c.Window)
+	if err != nil || window < MinimumWindow {
+		return ErrInvalidWindow
+	}
+	c.window = window
+	if c.Latency != nil {
+		if c.Latency.Threshold <= 0 {
+			return ErrInvalidLatencyThreshold
+		}
+		if c.Latency.Target <= 0 || c.Latency.Target >= 100 {
+			return ErrInvalidLatencyTarget
+		}
+	}
+	return nil
+}
+
+// WindowDuration returns the rolling window over which the objectives are evaluated
+func (c *Config) WindowDuration() time.Duration {
+	return c.window
+}
+
+// LatencyThreshold returns the response time under which an execution meets the latency objective, or 0 if there's
+// no latency objective
+func (c *Config) LatencyThreshold() time.Duration {
+	if c == nil || c.Latency == nil {
+		return 0
+	}
+	return c.Latency.Threshold
+}
+
+// BurnRate returns the rate at which the error budget is consumed, given the number of executions and successful
+// executions during a period of time.
+//
+// A burn rate of 1 means that the error budget would be exactly exhausted at the end of the window if the error rate
+// stayed the same for the whole window, whereas a burn rate of 10 means that it'd be exhausted 10 times faster.
+func (c *Config) BurnRate(totalExecutions, successfulExecutions uint64) float64 {
+	if totalExecutions == 0 {
+		return 0
+	}
+	errorRate := float64(totalExecutions-successfulExecutions) * 100 / float64(totalExecutions)
+	return errorRate / (100 - c.Target)
+}
+
+// parseWindow parses a window expressed either in days (e.g. 28d) or as a Go duration (e.g. 720h)
+func parseWindow(window string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(window, "d"); found {
+		numberOfDays, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(numberOfDays) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(window)
+}
@@ -0,0 +1,156 @@
+package slo
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		cfg            *Config
+		expectedErr    error
+		expectedWindow time.Duration
+	}{
+		{
+			name:           "defaults",
+			cfg:            &Config{Target: 99.9},
+			expectedWindow: 28 * 24 * time.Hour,
+		},
+		{
+			name:           "window-in-days",
+			cfg:            &Config{Target: 99.9, Window: "7d"},
+			expectedWindow: 7 * 24 * time.Hour,
+		},
+		{
+			name:           "window-as-go-duration",
+			cfg:            &Config{Target: 99.9, Window: "12h"},
+			expectedWindow: 12 * time.Hour,
+		},
+		{
+			name:           "with-latency",
+			cfg:            &Config{Target: 99.9, Latency: &LatencyConfig{Threshold: 300 * time.Millisecond, Target: 95}},
+			expectedWindow: 28 * 24 * time.Hour,
+		},
+		{
+			name:        "target-too-low",
+			cfg:         &Config{Target: 0},
+			expectedErr: ErrInvalidTarget,
+		},
+		{
+			name:        "target-too-high",
+			cfg:         &Config{Target: 100},
+			expectedErr: ErrInvalidTarget,
+		},
+		{
+			name:        "invalid-window",
+			cfg:         &Config{Target: 99, Window: "a month"},
+			expectedErr: ErrInvalidWindow,
+		},
+		{
+			name:        "window-too-short",
+			cfg:         &Config{Target: 99, Window: "5m"},
+			expectedErr: ErrInvalidWindow,
+		},
+		{
+			name:        "latency-without-threshold",
+			cfg:         &Config{Target: 99, Latency: &LatencyConfig{Target: 95}},
+			expectedErr: ErrInvalidLatencyThreshold,
+		},
+		{
+			name:        "latency-with-invalid-target",
+			cfg:         &Config{Target: 99, Latency: &LatencyConfig{Threshold: time.Second, Target: 150}},
+			expectedErr: ErrInvalidLatencyTarget,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.cfg.WindowDuration() != scenario.expectedWindow {
+				t.Errorf("expected window to be %s, got %s", scenario.expectedWindow, scenario.cfg.WindowDuration())
+			}
+		})
+	}
+}
+
+func TestConfig_LatencyThreshold(t *testing.T) {
+	var cfg *Config
+	if cfg.LatencyThreshold() != 0 {
+		t.Error("expected no latency threshold for a nil config")
+	}
+	cfg = &Config{Target: 99}
+	if cfg.LatencyThreshold() != 0 {
+		t.Error("expected no latency threshold without latency objective")
+	}
+	cfg.Latency = &LatencyConfig{Threshold: 300 * time.Millisecond, Target: 95}
+	if cfg.LatencyThreshold() != 300*time.Millisecond {
+		t.Errorf("expected latency threshold to be 300ms, got %s", cfg.LatencyThreshold())
+	}
+}
+
+func TestConfig_BurnRate(t *testing.T) {
+	cfg := &Config{Target: 99}
+	scenarios := []struct {
+		name                 string
+		totalExecutions      uint64
+		successfulExecutions uint64
+		expected             float64
+	}{
+		{name: "no-executions", totalExecutions: 0, successfulExecutions: 0, expected: 0},
+		{name: "no-errors", totalExecutions: 100, successfulExecutions: 100, expected: 0},
+		{name: "error-rate-equal-to-budget", totalExecutions: 100, successfulExecutions: 99, expected: 1},
+		{name: "error-rate-ten-times-the-budget", totalExecutions: 100, successfulExecutions: 90, expected: 10},
+		{name: "only-errors", totalExecutions: 10, successfulExecutions: 0, expected: 100},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := cfg.BurnRate(scenario.totalExecutions, scenario.successfulExecutions); math.Abs(actual-scenario.expected) > 1e-9 {
+				t.Errorf("expected %f, got %f", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConfig_NewStatus(t *testing.T) {
+	cfg := &Config{Target: 99, Latency: &LatencyConfig{Threshold: 300 * time.Millisecond, Target: 90}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	status := cfg.NewStatus(1000, 995, 950)
+	if status.Window != DefaultWindow || status.Target != 99 {
+		t.Errorf("expected window=%s and target=99, got window=%s and target=%f", DefaultWindow, status.Window, status.Target)
+	}
+	if math.Abs(status.Availability-99.5) > 1e-9 {
+		t.Errorf("expected availability to be 99.5, got %f", status.Availability)
+	}
+	// 10 failures were allowed, 5 happened
+	if math.Abs(status.ErrorBudgetRemaining-0.5) > 1e-9 {
+		t.Errorf("expected half of the error budget to remain, got %f", status.ErrorBudgetRemaining)
+	}
+	if status.Latency == nil {
+		t.Fatal("expected latency status")
+	}
+	if status.Latency.Threshold != "300ms" || math.Abs(status.Latency.Attainment-95) > 1e-9 {
+		t.Errorf("expected threshold=300ms and attainment=95, got threshold=%s and attainment=%f", status.Latency.Threshold, status.Latency.Attainment)
+	}
+	// 100 slow executions were allowed, 50 happened
+	if math.Abs(status.Latency.ErrorBudgetRemaining-0.5) > 1e-9 {
+		t.Errorf("expected half of the latency error budget to remain, got %f", status.Latency.ErrorBudgetRemaining)
+	}
+	// Breached objective
+	if status = cfg.NewStatus(100, 97, 100); status.ErrorBudgetRemaining >= 0 {
+		t.Errorf("expected negative error budget remaining, got %f", status.ErrorBudgetRemaining)
+	}
+	// No executions
+	if status = cfg.NewStatus(0, 0, 0); status.ErrorBudgetRemaining != 1 || status.Availability != 100 {
+		t.Errorf("expected untouched error budget and availability of 100, got %f and %f", status.ErrorBudgetRemaining, status.Availability)
+	}
+}
@@ -0,0 +1,85 @@
+package slo
+
+// Status is the state of the service level objectives of an endpoint over their window
+type Status struct {
+	// Target is the percentage of executions that must be successful over the window
+	Target float64 `json:"target"`
+
+	// Window is the rolling window over which the objectives are evaluated
+	Window string `json:"window"`
+
+	// TotalExecutions is the number of executions during the window
+	TotalExecutions uint64 `json:"totalExecutions"`
+
+	// SuccessfulExecutions is the number of successful executions during the window
+	SuccessfulExecutions uint64 `json:"successfulExecutions"`
+
+	// Availability is the percentage of successful executions during the window
+	Availability float64 `json:"availability"`
+
+	// ErrorBudgetRemaining is the fraction of the error budget that hasn't been consumed yet.
+	// 1 means that the error budget is untouched, whereas a negative value means that the objective has been breached.
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+
+	// Latency is the state of the latency objective, if there is one
+	Latency *LatencyStatus `json:"latency,omitempty"`
+}
+
+// LatencyStatus is the state of a latency objective over the window
+type LatencyStatus struct {
+	// Threshold is the response time under which an execution meets the latency objective
+	Threshold string `json:"threshold"`
+
+	// Target is the percentage of executions that must meet the latency objective over the window
+	Target float64 `json:"target"`
+
+	// ExecutionsWithinThreshold is the number of executions that met the latency objective during the window
+	ExecutionsWithinThreshold uint64 `json:"executionsWithinThreshold"`
+
+	// Attainment is the percentage of executions that met the latency objective during the window
+	Attainment float64 `json:"attainment"`
+
+	// ErrorBudgetRemaining is the fraction of the latency error budget that hasn't been consumed yet
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+}
+
+// NewStatus creates the Status of the objectives given the number of executions, successful executions and
+// executions that met the latency objective during the window
+func (c *Config) NewStatus(totalExecutions, successfulExecutions, executionsWithinLatencyThreshold uint64) *Status {
+	status := &Status{
+		Target:               c.Target,
+		Window:               c.Window,
+		TotalExecutions:      totalExecutions,
+		SuccessfulExecutions: successfulExecutions,
+		Availability:         percentage(successfulExecutions, totalExecutions),
+		ErrorBudgetRemaining: errorBudgetRemaining(c.Target, successfulExecutions, totalExecutions),
+	}
+	if c.Latency != nil {
+		status.Latency = &LatencyStatus{
+			Threshold:                 c.Latency.Threshold.String(),
+			Target:                    c.Latency.Target,
+			ExecutionsWithinThreshold: executionsWithinLatencyThreshold,
+			Attainment:                percentage(executionsWithinLatencyThreshold, totalExecutions),
+			ErrorBudgetRemaining:      errorBudgetRemaining(c.Latency.Target, executionsWithinLatencyThreshold, totalExecutions),
+		}
+	}
+	return status
+}
+
+// percentage returns the percentage of good executions out of all executions, or 100 if there were no executions
+func percentage(goodExecutions, totalExecutions uint64) float64 {
+	if totalExecutions == 0 {
+		return 100
+	}
+	return float64(goodExecutions) / float64(totalExecutions) * 100
+}
+
+// errorBudgetRemaining returns the fraction of the error budget allowed by the target that hasn't been consumed yet
+func errorBudgetRemaining(target float64, goodExecutions, totalExecutions uint64) float64 {
+	if totalExecutions == 0 {
+		return 1
+	}
+	// The percentages are not divided by 100 to avoid unnecessary floating point errors
+	allowedBadExecutions := (100 - target) * float64(totalExecutions)
+	return 1 - float64(totalExecutions-goodExecutions)*100/allowedBadExecutions
+}
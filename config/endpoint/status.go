@@ -1,6 +1,9 @@
 package endpoint
 
-import "github.com/TwiN/gatus/v5/config/key"
+import (
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/config/key"
+)
 
 // Status contains the evaluation Results of an Endpoint
 // This is essentially a DTO
@@ -20,6 +23,16 @@ type Status struct {
 	// Events is a list of events
 	Events []*Event `json:"events,omitempty"`
 
+	// Silences are the silences that haven't expired yet and that match at least some of the endpoint's alerts
+	//
+	// Populated by the API, not by the store.
+	Silences []*silence.Silence `json:"silences,omitempty"`
+
+	// Acknowledgements are the acknowledgements of the endpoint's triggered alerts
+	//
+	// Populated by the API, not by the store.
+	Acknowledgements []*silence.Acknowledgement `json:"acknowledgements,omitempty"`
+
 	// Uptime information on the endpoint's uptime
 	//
 	// Used by the memory store.
@@ -1,5 +1,20 @@
 package endpoint
 
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// ResponseTimeHistogramBuckets are the upper bounds, in milliseconds, of the buckets of the response time histogram
+// of HourlyUptimeStatistics.
+//
+// Changing the buckets would invalidate the histograms that have already been persisted.
+var ResponseTimeHistogramBuckets = []uint64{
+	1, 2, 5, 10, 15, 20, 30, 40, 50, 65, 80, 100, 125, 150, 175, 200, 250, 300, 350, 400, 500, 600, 700, 800, 1000,
+	1250, 1500, 1750, 2000, 2500, 3000, 4000, 5000, 6000, 8000, 10000, 15000, 20000, 30000, 60000,
+}
+
 // Uptime is the struct that contains the relevant data for calculating the uptime as well as the uptime itself
 // and some other statistics
 type Uptime struct {
@@ -7,18 +22,112 @@ type Uptime struct {
 	//
 	// Used only if the storage type is memory
 	HourlyStatistics map[int64]*HourlyUptimeStatistics `json:"-"`
+
+	// DailyStatistics is a map containing metrics collected (value) for every daily unix timestamp (key), for data that
+	// is older than the hourly uptime retention
+	//
+	// Used only if the storage type is memory
+	DailyStatistics map[int64]*HourlyUptimeStatistics `json:"-"`
+
+	// MonthlyStatistics is a map containing metrics collected (value) for every monthly unix timestamp (key), for data
+	// that is older than the daily uptime retention
+	//
+	// Used only if the storage type is memory and monthly uptime retention is enabled
+	MonthlyStatistics map[int64]*HourlyUptimeStatistics `json:"-"`
 }
 
-// HourlyUptimeStatistics is a struct containing all metrics collected over the course of an hour
+// HourlyUptimeStatistics is a struct containing all metrics collected over the course of an hour, or over the course
+// of a day or a month once hourly statistics have been merged
 type HourlyUptimeStatistics struct {
 	TotalExecutions             uint64 // Total number of checks
 	SuccessfulExecutions        uint64 // Number of successful executions
 	TotalExecutionsResponseTime uint64 // Total response time for all executions in milliseconds
+
+	// ExecutionsWithinLatencyThreshold is the number of successful executions whose response time was within the
+	// latency threshold of the endpoint's SLO at the time of the execution, if it had a latency objective
+	ExecutionsWithinLatencyThreshold uint64
+
+	// ResponseTimeHistogram is the number of executions (value) for each bucket of ResponseTimeHistogramBuckets
+	// (index), followed by the number of executions whose response time exceeded the last bucket.
+	// Trailing empty buckets may be omitted.
+	ResponseTimeHistogram []uint64
+
+	// MaximumResponseTime is the highest response time of all executions in milliseconds
+	MaximumResponseTime uint64
+}
+
+// AddResponseTime adds the response time of an execution to the response time histogram and maximum response time.
+//
+// Note that this doesn't increment the total number of executions nor the total response time.
+func (s *HourlyUptimeStatistics) AddResponseTime(responseTime time.Duration) {
+	responseTimeInMilliseconds := uint64(responseTime.Milliseconds())
+	bucket, _ := slices.BinarySearch(ResponseTimeHistogramBuckets, responseTimeInMilliseconds)
+	if len(s.ResponseTimeHistogram) <= bucket {
+		s.ResponseTimeHistogram = append(s.ResponseTimeHistogram, make([]uint64, bucket+1-len(s.ResponseTimeHistogram))...)
+	}
+	s.ResponseTimeHistogram[bucket]++
+	s.MaximumResponseTime = max(s.MaximumResponseTime, responseTimeInMilliseconds)
+}
+
+// Add adds the given statistics to the statistics, e.g. to merge hourly statistics into daily statistics
+func (s *HourlyUptimeStatistics) Add(statistics *HourlyUptimeStatistics) {
+	s.TotalExecutions += statistics.TotalExecutions
+	s.SuccessfulExecutions += statistics.SuccessfulExecutions
+	s.TotalExecutionsResponseTime += statistics.TotalExecutionsResponseTime
+	s.ExecutionsWithinLatencyThreshold += statistics.ExecutionsWithinLatencyThreshold
+	if len(s.ResponseTimeHistogram) < len(statistics.ResponseTimeHistogram) {
+		s.ResponseTimeHistogram = append(s.ResponseTimeHistogram, make([]uint64, len(statistics.ResponseTimeHistogram)-len(s.ResponseTimeHistogram))...)
+	}
+	for bucket, executions := range statistics.ResponseTimeHistogram {
+		s.ResponseTimeHistogram[bucket] += executions
+	}
+	s.MaximumResponseTime = max(s.MaximumResponseTime, statistics.MaximumResponseTime)
+}
+
+// ResponseTimePercentile returns the estimated response time in milliseconds under which the given percentage of
+// executions (e.g. 95 for the 95th percentile) have completed, based on the response time histogram.
+//
+// The response time is interpolated linearly within the bucket that the percentile falls in, and it never exceeds the
+// maximum response time. Returns 0 if there is no response time histogram, which is the case for statistics that
+// were collected before response time histograms were introduced.
+func (s *HourlyUptimeStatistics) ResponseTimePercentile(percentile float64) uint64 {
+	var numberOfExecutions uint64
+	for _, executions := range s.ResponseTimeHistogram {
+		numberOfExecutions += executions
+	}
+	if numberOfExecutions == 0 {
+		return 0
+	}
+	rank := percentile / 100 * float64(numberOfExecutions)
+	var cumulativeExecutions uint64
+	for bucket, executions := range s.ResponseTimeHistogram {
+		if executions == 0 || float64(cumulativeExecutions+executions) < rank {
+			cumulativeExecutions += executions
+			continue
+		}
+		var lowerBound, upperBound uint64
+		if bucket > 0 {
+			lowerBound = ResponseTimeHistogramBuckets[bucket-1]
+		}
+		if bucket < len(ResponseTimeHistogramBuckets) {
+			upperBound = min(ResponseTimeHistogramBuckets[bucket], s.MaximumResponseTime)
+		} else {
+			upperBound = s.MaximumResponseTime
+		}
+		if upperBound <= lowerBound {
+			return upperBound
+		}
+		fraction := (rank - float64(cumulativeExecutions)) / float64(executions)
+		return lowerBound + uint64(math.Round(fraction*float64(upperBound-lowerBound)))
+	}
+	return s.MaximumResponseTime
 }
 
 // NewUptime creates a new Uptime
 func NewUptime() *Uptime {
 	return &Uptime{
-		HourlyStatistics: make(map[int64]*HourlyUptimeStatistics),
+		HourlyStatistics:  make(map[int64]*HourlyUptimeStatistics),
+		DailyStatistics:   make(map[int64]*HourlyUptimeStatistics),
+		MonthlyStatistics: make(map[int64]*HourlyUptimeStatistics),
 	}
 }
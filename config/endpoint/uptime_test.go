@@ -0,0 +1,139 @@
+package endpoint
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHourlyUptimeStatistics_AddResponseTime(t *testing.T) {
+	statistics := &HourlyUptimeStatistics{}
+	statistics.AddResponseTime(0)
+	statistics.AddResponseTime(time.Millisecond)
+	statistics.AddResponseTime(3 * time.Millisecond)
+	statistics.AddResponseTime(100 * time.Millisecond)
+	if expected := []uint64{2, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1}; !slices.Equal(statistics.ResponseTimeHistogram, expected) {
+		t.Errorf("expected histogram to be %v, got %v", expected, statistics.ResponseTimeHistogram)
+	}
+	if statistics.MaximumResponseTime != 100 {
+		t.Errorf("expected maximum response time to be 100, got %d", statistics.MaximumResponseTime)
+	}
+	statistics.AddResponseTime(time.Minute + time.Second)
+	if len(statistics.ResponseTimeHistogram) != len(ResponseTimeHistogramBuckets)+1 {
+		t.Errorf("expected histogram to have an overflow bucket, got %v", statistics.ResponseTimeHistogram)
+	}
+	if statistics.ResponseTimeHistogram[len(ResponseTimeHistogramBuckets)] != 1 {
+		t.Errorf("expected overflow bucket to have 1 execution, got %d", statistics.ResponseTimeHistogram[len(ResponseTimeHistogramBuckets)])
+	}
+	if statistics.MaximumResponseTime != 61000 {
+		t.Errorf("expected maximum response time to be 61000, got %d", statistics.MaximumResponseTime)
+	}
+	if statistics.TotalExecutions != 0 || statistics.TotalExecutionsResponseTime != 0 {
+		t.Error("expected AddResponseTime to not modify the total executions nor the total response time")
+	}
+}
+
+func TestHourlyUptimeStatistics_Add(t *testing.T) {
+	statistics := &HourlyUptimeStatistics{
+		TotalExecutions:                  2,
+		SuccessfulExecutions:             1,
+		TotalExecutionsResponseTime:      300,
+		ExecutionsWithinLatencyThreshold: 1,
+		ResponseTimeHistogram:            []uint64{0, 1},
+		MaximumResponseTime:              250,
+	}
+	statistics.Add(&HourlyUptimeStatistics{
+		TotalExecutions:                  3,
+		SuccessfulExecutions:             3,
+		TotalExecutionsResponseTime:      30,
+		ExecutionsWithinLatencyThreshold: 2,
+		ResponseTimeHistogram:            []uint64{1, 1, 0, 1},
+		MaximumResponseTime:              10,
+	})
+	if statistics.TotalExecutions != 5 || statistics.SuccessfulExecutions != 4 || statistics.TotalExecutionsResponseTime != 330 || statistics.ExecutionsWithinLatencyThreshold != 3 {
+		t.Errorf("expected counters to be summed, got %+v", statistics)
+	}
+	if expected := []uint64{1, 2, 0, 1}; !slices.Equal(statistics.ResponseTimeHistogram, expected) {
+		t.Errorf("expected histogram to be %v, got %v", expected, statistics.ResponseTimeHistogram)
+	}
+	if statistics.MaximumResponseTime != 250 {
+		t.Errorf("expected maximum response time to be 250, got %d", statistics.MaximumResponseTime)
+	}
+}
+
+func TestHourlyUptimeStatistics_ResponseTimePercentile(t *testing.T) {
+	oneToOneHundredMilliseconds := &HourlyUptimeStatistics{}
+	for i := 1; i <= 100; i++ {
+		oneToOneHundredMilliseconds.AddResponseTime(time.Duration(i) * time.Millisecond)
+	}
+	overflow := &HourlyUptimeStatistics{}
+	overflow.AddResponseTime(70 * time.Second)
+	scenarios := []struct {
+		name       string
+		statistics *HourlyUptimeStatistics
+		percentile float64
+		expected   uint64
+	}{
+		{
+			name:       "empty",
+			statistics: &HourlyUptimeStatistics{},
+			percentile: 95,
+			expected:   0,
+		},
+		{
+			name:       "without-histogram",
+			statistics: &HourlyUptimeStatistics{TotalExecutions: 10, TotalExecutionsResponseTime: 1000},
+			percentile: 95,
+			expected:   0,
+		},
+		{
+			name:       "p50",
+			statistics: oneToOneHundredMilliseconds,
+			percentile: 50,
+			expected:   50,
+		},
+		{
+			name:       "p90",
+			statistics: oneToOneHundredMilliseconds,
+			percentile: 90,
+			expected:   90,
+		},
+		{
+			name:       "p99",
+			statistics: oneToOneHundredMilliseconds,
+			percentile: 99,
+			expected:   99,
+		},
+		{
+			name:       "p100",
+			statistics: oneToOneHundredMilliseconds,
+			percentile: 100,
+			expected:   100,
+		},
+		{
+			name:       "overflow-bucket",
+			statistics: overflow,
+			percentile: 50,
+			expected:   65000,
+		},
+		{
+			name:       "overflow-bucket-max",
+			statistics: overflow,
+			percentile: 100,
+			expected:   70000,
+		},
+		{
+			name:       "capped-at-maximum-response-time",
+			statistics: &HourlyUptimeStatistics{ResponseTimeHistogram: []uint64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, MaximumResponseTime: 85},
+			percentile: 99,
+			expected:   85,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := scenario.statistics.ResponseTimePercentile(scenario.percentile); actual != scenario.expected {
+				t.Errorf("expected %d, got %d", scenario.expected, actual)
+			}
+		})
+	}
+}
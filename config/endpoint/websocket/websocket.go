@@ -0,0 +1,122 @@
+package websocket
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrWebSocketWithNoSteps is the error with which Gatus will panic if an endpoint has a WebSocket configuration
+	// without any step
+	ErrWebSocketWithNoSteps = errors.New("you must specify at least one step in the websocket configuration")
+
+	// ErrWebSocketStepWithInvalidAction is the error with which Gatus will panic if a step of a WebSocket configuration
+	// doesn't have exactly one action
+	ErrWebSocketStepWithInvalidAction = errors.New("each step of the websocket configuration must have exactly one of send, expect, ping or close")
+
+	// ErrWebSocketStepWithInvalidCloseCode is the error with which Gatus will panic if the close step of a WebSocket
+	// configuration has a code that can't be sent
+	ErrWebSocketStepWithInvalidCloseCode = errors.New("invalid close code in the websocket configuration: must be 1000-1003, 1007-1014 or 3000-4999")
+
+	// ErrWebSocketCloseStepNotLast is the error with which Gatus will panic if the close step of a WebSocket
+	// configuration is followed by other steps
+	ErrWebSocketCloseStepNotLast = errors.New("the close step must be the last step of the websocket configuration")
+
+	// ErrWebSocketStepWithInvalidTimeout is the error with which Gatus will panic if a step of a WebSocket
+	// configuration has a negative timeout
+	ErrWebSocketStepWithInvalidTimeout = errors.New("the timeout of a step of the websocket configuration must not be negative")
+
+	// ErrWebSocketStepWithInvalidName is the error with which Gatus will panic if a step of a WebSocket configuration
+	// that doesn't receive a message has a name, or if two steps have the same name
+	ErrWebSocketStepWithInvalidName = errors.New("only expect steps of the websocket configuration can have a name, and names must be unique")
+
+	// ErrWebSocketWithUnknownBodyStep is the error with which Gatus will panic if the body of a WebSocket configuration
+	// is taken from a step that doesn't exist
+	ErrWebSocketWithUnknownBodyStep = errors.New("body-from must be the name of a step of the websocket configuration")
+)
+
+// Config is the configuration of a scripted conversation with a WebSocket server
+type Config struct {
+	// Steps of the conversation, which are executed in order
+	Steps []*Step `yaml:"steps"`
+
+	// BodyFrom is the name of the step whose message is used as the body of the result.
+	// If empty, the last message received is used.
+	BodyFrom string `yaml:"body-from,omitempty"`
+}
+
+// Step is a step of a conversation with a WebSocket server, which must have exactly one action
+type Step struct {
+	// Name of the step, which can be referenced by Config.BodyFrom. Only supported by expect steps.
+	Name string `yaml:"name,omitempty"`
+
+	// Send is a text message to send
+	Send string `yaml:"send,omitempty"`
+
+	// Expect is a pattern (e.g. *"type":"update"*) that a message must match. Messages that don't match are skipped,
+	// and the step fails if no matching message is received before the timeout.
+	Expect string `yaml:"expect,omitempty"`
+
+	// Ping is whether to send a ping, and wait for the corresponding pong
+	Ping bool `yaml:"ping,omitempty"`
+
+	// Close is the code of the close frame to send, after which the server is expected to close the connection
+	Close int `yaml:"close,omitempty"`
+
+	// Timeout is the maximum amount of time to wait for the step to complete. Defaults to the timeout of the client.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the WebSocket configuration
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Steps) == 0 {
+		return ErrWebSocketWithNoSteps
+	}
+	names := make(map[string]bool)
+	for i, step := range c.Steps {
+		if err := step.validate(); err != nil {
+			return err
+		}
+		if step.Close != 0 && i != len(c.Steps)-1 {
+			return ErrWebSocketCloseStepNotLast
+		}
+		if len(step.Name) > 0 {
+			if names[step.Name] {
+				return ErrWebSocketStepWithInvalidName
+			}
+			names[step.Name] = true
+		}
+	}
+	if len(c.BodyFrom) > 0 && !names[c.BodyFrom] {
+		return ErrWebSocketWithUnknownBodyStep
+	}
+	return nil
+}
+
+func (s *Step) validate() error {
+	numberOfActions := 0
+	for _, hasAction := range []bool{len(s.Send) > 0, len(s.Expect) > 0, s.Ping, s.Close != 0} {
+		if hasAction {
+			numberOfActions++
+		}
+	}
+	if numberOfActions != 1 {
+		return ErrWebSocketStepWithInvalidAction
+	}
+	if s.Close != 0 && !isValidCloseCode(s.Close) {
+		return ErrWebSocketStepWithInvalidCloseCode
+	}
+	if s.Timeout < 0 {
+		return ErrWebSocketStepWithInvalidTimeout
+	}
+	if len(s.Name) > 0 && len(s.Expect) == 0 {
+		return ErrWebSocketStepWithInvalidName
+	}
+	return nil
+}
+
+// isValidCloseCode returns whether a close code can be sent by a client, as defined in RFC 6455 and in the IANA
+// WebSocket Close Code Number Registry
+func isValidCloseCode(code int) bool {
+	return (code >= 1000 && code <= 1003) || (code >= 1007 && code <= 1014) || (code >= 3000 && code <= 4999)
+}
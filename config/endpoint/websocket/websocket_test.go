@@ -0,0 +1,86 @@
+package websocket
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      *Config
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: &Config{
+				Steps: []*Step{
+					{Send: `{"type":"subscribe"}`},
+					{Name: "update", Expect: `*"update"*`, Timeout: 10 * time.Second},
+					{Ping: true},
+					{Close: 1000},
+				},
+				BodyFrom: "update",
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "no-steps",
+			config:      &Config{},
+			expectedErr: ErrWebSocketWithNoSteps,
+		},
+		{
+			name:        "step-without-action",
+			config:      &Config{Steps: []*Step{{Timeout: time.Second}}},
+			expectedErr: ErrWebSocketStepWithInvalidAction,
+		},
+		{
+			name:        "step-with-multiple-actions",
+			config:      &Config{Steps: []*Step{{Send: "ping", Expect: "pong"}}},
+			expectedErr: ErrWebSocketStepWithInvalidAction,
+		},
+		{
+			name:        "close-step-with-reserved-code",
+			config:      &Config{Steps: []*Step{{Close: 1006}}},
+			expectedErr: ErrWebSocketStepWithInvalidCloseCode,
+		},
+		{
+			name:        "close-step-with-application-code",
+			config:      &Config{Steps: []*Step{{Close: 4000}}},
+			expectedErr: nil,
+		},
+		{
+			name:        "close-step-not-last",
+			config:      &Config{Steps: []*Step{{Close: 1000}, {Send: "hello"}}},
+			expectedErr: ErrWebSocketCloseStepNotLast,
+		},
+		{
+			name:        "negative-timeout",
+			config:      &Config{Steps: []*Step{{Ping: true, Timeout: -time.Second}}},
+			expectedErr: ErrWebSocketStepWithInvalidTimeout,
+		},
+		{
+			name:        "name-on-send-step",
+			config:      &Config{Steps: []*Step{{Name: "subscription", Send: "subscribe"}}},
+			expectedErr: ErrWebSocketStepWithInvalidName,
+		},
+		{
+			name:        "duplicate-name",
+			config:      &Config{Steps: []*Step{{Name: "message", Expect: "*"}, {Name: "message", Expect: "*"}}},
+			expectedErr: ErrWebSocketStepWithInvalidName,
+		},
+		{
+			name:        "body-from-unknown-step",
+			config:      &Config{Steps: []*Step{{Name: "message", Expect: "*"}}, BodyFrom: "unknown"},
+			expectedErr: ErrWebSocketWithUnknownBodyStep,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.config.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error to be %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
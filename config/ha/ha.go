@@ -0,0 +1,63 @@
+package ha
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// DefaultLeaseDuration is the default duration for which the leader holds the leadership without renewing it, and
+	// therefore the maximum amount of time it takes for another instance to take over if the leader stops
+	DefaultLeaseDuration = 15 * time.Second
+
+	// MinimumLeaseDuration is the minimum lease duration
+	MinimumLeaseDuration = 3 * time.Second
+)
+
+var (
+	// ErrLeaseDurationTooShort is the error with which Gatus will panic if the lease duration is too short
+	ErrLeaseDurationTooShort = fmt.Errorf("high-availability.lease-duration must be %s or higher", MinimumLeaseDuration)
+
+	// ErrInstanceIDTooLong is the error with which Gatus will panic if the instance id is too long
+	ErrInstanceIDTooLong = errors.New("high-availability.instance-id must not be longer than 255 characters")
+)
+
+// Config is the configuration for running multiple instances of Gatus against the same storage, in which case only
+// the instance elected as the leader monitors the endpoints and handles alerting
+type Config struct {
+	// InstanceID is the unique identifier of this instance, used to keep track of which instance is the leader.
+	// Defaults to the hostname followed by the process id.
+	InstanceID string `yaml:"instance-id,omitempty"`
+
+	// LeaseDuration is the duration for which the leader holds the leadership without renewing it.
+	// The leader renews the lease every third of this duration, and if it fails to do so, another instance takes over
+	// once the lease expires.
+	LeaseDuration time.Duration `yaml:"lease-duration,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the high-availability configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.LeaseDuration == 0 {
+		c.LeaseDuration = DefaultLeaseDuration
+	} else if c.LeaseDuration < MinimumLeaseDuration {
+		return ErrLeaseDurationTooShort
+	}
+	if len(c.InstanceID) == 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "gatus"
+		}
+		c.InstanceID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	} else if len(c.InstanceID) > 255 {
+		return ErrInstanceIDTooLong
+	}
+	return nil
+}
+
+// RenewalInterval returns the interval at which the leader renews its lease, and at which the other instances try to
+// acquire it
+func (c *Config) RenewalInterval() time.Duration {
+	return c.LeaseDuration / 3
+}
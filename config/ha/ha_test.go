@@ -0,0 +1,69 @@
+package ha
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name                  string
+		config                *Config
+		expectedErr           error
+		expectedLeaseDuration time.Duration
+	}{
+		{
+			name:                  "default",
+			config:                &Config{},
+			expectedLeaseDuration: DefaultLeaseDuration,
+		},
+		{
+			name:                  "custom-lease-duration",
+			config:                &Config{InstanceID: "gatus-1", LeaseDuration: 30 * time.Second},
+			expectedLeaseDuration: 30 * time.Second,
+		},
+		{
+			name:        "lease-duration-too-short",
+			config:      &Config{LeaseDuration: time.Second},
+			expectedErr: ErrLeaseDurationTooShort,
+		},
+		{
+			name:        "instance-id-too-long",
+			config:      &Config{InstanceID: strings.Repeat("a", 256)},
+			expectedErr: ErrInstanceIDTooLong,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.config.LeaseDuration != scenario.expectedLeaseDuration {
+				t.Errorf("expected lease duration to be %s, got %s", scenario.expectedLeaseDuration, scenario.config.LeaseDuration)
+			}
+			if len(scenario.config.InstanceID) == 0 {
+				t.Error("expected instance id to be set")
+			}
+			if scenario.config.RenewalInterval() != scenario.expectedLeaseDuration/3 {
+				t.Errorf("expected renewal interval to be a third of the lease duration, got %s", scenario.config.RenewalInterval())
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsIsDeterministic(t *testing.T) {
+	// The default instance id must not change when the configuration is reloaded, otherwise the instance would have
+	// to be restarted on every reload
+	first, second := &Config{}, &Config{}
+	_ = first.ValidateAndSetDefaults()
+	_ = second.ValidateAndSetDefaults()
+	if first.InstanceID != second.InstanceID {
+		t.Errorf("expected the default instance id to be the same, got %s and %s", first.InstanceID, second.InstanceID)
+	}
+}
@@ -0,0 +1,170 @@
+package incident
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// Status is the status of an incident at a given point of its timeline
+type Status string
+
+const (
+	StatusInvestigating Status = "investigating" // The cause of the incident is being investigated
+	StatusIdentified    Status = "identified"    // The cause of the incident has been identified
+	StatusMonitoring    Status = "monitoring"    // A fix has been applied and is being monitored
+	StatusResolved      Status = "resolved"      // The incident has been resolved
+)
+
+// Severity is the severity of an incident
+type Severity string
+
+const (
+	SeverityMinor    Severity = "minor"    // Degraded performance or partial outage
+	SeverityMajor    Severity = "major"    // Major outage affecting most users
+	SeverityCritical Severity = "critical" // Complete outage
+)
+
+const (
+	// DefaultResolveMessage is the message used when an incident is resolved without a message
+	DefaultResolveMessage = "This incident has been resolved."
+)
+
+var (
+	// ErrEmptyTitle is the error returned when an incident has no title
+	ErrEmptyTitle = errors.New("incident title cannot be empty")
+
+	// ErrInvalidSeverity is the error returned when an incident has an invalid severity
+	ErrInvalidSeverity = errors.New("invalid incident severity: must be one of minor, major or critical")
+
+	// ErrInvalidStatus is the error returned when an incident update has an invalid status
+	ErrInvalidStatus = errors.New("invalid incident status: must be one of investigating, identified, monitoring or resolved")
+
+	// ErrEmptyMessage is the error returned when an incident update has no message
+	ErrEmptyMessage = errors.New("incident update message cannot be empty")
+
+	// ErrNoUpdates is the error returned when an incident has no updates
+	ErrNoUpdates = errors.New("incident must have at least one update")
+
+	// ErrAlreadyResolved is the error returned when attempting to update an incident that has already been resolved
+	ErrAlreadyResolved = errors.New("incident has already been resolved")
+)
+
+// Incident is an event affecting one or more endpoints and/or suites, which is communicated on the status page through
+// a timeline of updates
+type Incident struct {
+	// ID is the unique identifier of the incident, which is assigned by the store
+	ID int64 `json:"id"`
+
+	// Title is a short, user-facing description of the incident
+	Title string `json:"title"`
+
+	// Severity is the severity of the incident
+	Severity Severity `json:"severity"`
+
+	// Status is the status of the most recent update
+	Status Status `json:"status"`
+
+	// EndpointKeys are the keys of the endpoints affected by the incident
+	EndpointKeys []string `json:"endpointKeys,omitempty"`
+
+	// SuiteKeys are the keys of the suites affected by the incident
+	SuiteKeys []string `json:"suiteKeys,omitempty"`
+
+	// Updates is the timeline of the incident, sorted from the oldest to the most recent update
+	Updates []*Update `json:"updates"`
+
+	// CreatedAt is when the incident was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// UpdatedAt is when the incident was last updated
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Update is an entry in the timeline of an incident
+type Update struct {
+	// Status is the status of the incident as of this update
+	Status Status `json:"status"`
+
+	// Message is the user-facing text describing the update
+	Message string `json:"message"`
+
+	// Timestamp is when the update was made
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewIncident creates a new incident with an initial update.
+// If the status is empty, StatusInvestigating is used instead.
+func NewIncident(title string, severity Severity, status Status, message string) (*Incident, error) {
+	if len(status) == 0 {
+		status = StatusInvestigating
+	}
+	incident := &Incident{
+		Title:     title,
+		Severity:  severity,
+		CreatedAt: time.Now(),
+	}
+	if err := incident.AddUpdate(status, message); err != nil {
+		return nil, err
+	}
+	return incident, incident.Validate()
+}
+
+// Validate checks whether the incident is valid
+func (i *Incident) Validate() error {
+	if len(i.Title) == 0 {
+		return ErrEmptyTitle
+	}
+	if !slices.Contains([]Severity{SeverityMinor, SeverityMajor, SeverityCritical}, i.Severity) {
+		return ErrInvalidSeverity
+	}
+	if len(i.Updates) == 0 {
+		return ErrNoUpdates
+	}
+	for _, update := range i.Updates {
+		if err := update.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddUpdate appends an update to the timeline of the incident and updates the incident's status accordingly
+func (i *Incident) AddUpdate(status Status, message string) error {
+	if i.IsResolved() {
+		return ErrAlreadyResolved
+	}
+	update := &Update{Status: status, Message: message, Timestamp: time.Now()}
+	if err := update.Validate(); err != nil {
+		return err
+	}
+	i.Updates = append(i.Updates, update)
+	i.Status = status
+	i.UpdatedAt = update.Timestamp
+	return nil
+}
+
+// Resolve resolves the incident by adding an update with the StatusResolved status.
+// If the message is empty, DefaultResolveMessage is used instead.
+func (i *Incident) Resolve(message string) error {
+	if len(message) == 0 {
+		message = DefaultResolveMessage
+	}
+	return i.AddUpdate(StatusResolved, message)
+}
+
+// IsResolved returns whether the incident has been resolved
+func (i *Incident) IsResolved() bool {
+	return i.Status == StatusResolved
+}
+
+// Validate checks whether the update is valid
+func (u *Update) Validate() error {
+	if !slices.Contains([]Status{StatusInvestigating, StatusIdentified, StatusMonitoring, StatusResolved}, u.Status) {
+		return ErrInvalidStatus
+	}
+	if len(u.Message) == 0 {
+		return ErrEmptyMessage
+	}
+	return nil
+}
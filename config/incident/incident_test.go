@@ -0,0 +1,118 @@
+package incident
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewIncident(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		title          string
+		severity       Severity
+		status         Status
+		message        string
+		expectedErr    error
+		expectedStatus Status
+	}{
+		{
+			name:           "valid",
+			title:          "Frontend is down",
+			severity:       SeverityMajor,
+			status:         StatusIdentified,
+			message:        "The database is unreachable",
+			expectedStatus: StatusIdentified,
+		},
+		{
+			name:           "valid-with-empty-status-should-default-to-investigating",
+			title:          "Frontend is down",
+			severity:       SeverityMinor,
+			message:        "We are investigating",
+			expectedStatus: StatusInvestigating,
+		},
+		{
+			name:        "empty-title",
+			severity:    SeverityMajor,
+			message:     "We are investigating",
+			expectedErr: ErrEmptyTitle,
+		},
+		{
+			name:        "invalid-severity",
+			title:       "Frontend is down",
+			severity:    "catastrophic",
+			message:     "We are investigating",
+			expectedErr: ErrInvalidSeverity,
+		},
+		{
+			name:        "invalid-status",
+			title:       "Frontend is down",
+			severity:    SeverityCritical,
+			status:      "panicking",
+			message:     "We are investigating",
+			expectedErr: ErrInvalidStatus,
+		},
+		{
+			name:        "empty-message",
+			title:       "Frontend is down",
+			severity:    SeverityCritical,
+			expectedErr: ErrEmptyMessage,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			incident, err := NewIncident(scenario.title, scenario.severity, scenario.status, scenario.message)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if incident.Status != scenario.expectedStatus {
+				t.Errorf("expected status %s, got %s", scenario.expectedStatus, incident.Status)
+			}
+			if len(incident.Updates) != 1 {
+				t.Errorf("expected 1 update, got %d", len(incident.Updates))
+			}
+			if incident.UpdatedAt != incident.Updates[0].Timestamp {
+				t.Error("expected UpdatedAt to be the timestamp of the last update")
+			}
+		})
+	}
+}
+
+func TestIncident_AddUpdateAndResolve(t *testing.T) {
+	incident, err := NewIncident("Frontend is down", SeverityMajor, "", "We are investigating")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err = incident.AddUpdate(StatusMonitoring, ""); !errors.Is(err, ErrEmptyMessage) {
+		t.Errorf("expected error %v, got %v", ErrEmptyMessage, err)
+	}
+	if err = incident.AddUpdate(StatusMonitoring, "A fix has been deployed"); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if incident.Status != StatusMonitoring {
+		t.Errorf("expected status %s, got %s", StatusMonitoring, incident.Status)
+	}
+	if incident.IsResolved() {
+		t.Error("expected incident to not be resolved")
+	}
+	if err = incident.Resolve(""); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !incident.IsResolved() {
+		t.Error("expected incident to be resolved")
+	}
+	if len(incident.Updates) != 3 {
+		t.Fatalf("expected 3 updates, got %d", len(incident.Updates))
+	}
+	if incident.Updates[2].Message != DefaultResolveMessage {
+		t.Errorf("expected message %s, got %s", DefaultResolveMessage, incident.Updates[2].Message)
+	}
+	if err = incident.AddUpdate(StatusInvestigating, "It's back"); !errors.Is(err, ErrAlreadyResolved) {
+		t.Errorf("expected error %v, got %v", ErrAlreadyResolved, err)
+	}
+	if err = incident.Resolve("Resolved again"); !errors.Is(err, ErrAlreadyResolved) {
+		t.Errorf("expected error %v, got %v", ErrAlreadyResolved, err)
+	}
+}
@@ -0,0 +1,173 @@
+package maintenance
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errInvalidCronExpression = errors.New("invalid maintenance cron expression: must have 5 fields (minute, hour, day of month, month, day of week) or be one of @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly")
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+
+	cronMonthNames = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	cronDayNames   = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+const (
+	// maximumCronSearchYears is how many years ahead cronSchedule.next searches for a match before giving up, which
+	// only happens for expressions that can never match (e.g. 0 0 30 2 *)
+	maximumCronSearchYears = 5
+)
+
+// cronSchedule is a parsed cron expression. Each field is a bitset of the values that match.
+type cronSchedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	// restrictedDayOfMonth and restrictedDayOfWeek are used to determine how the day of the month and the day of the
+	// week are combined: if both are restricted, a day matches if either of them matches, like in standard cron.
+	restrictedDayOfMonth bool
+	restrictedDayOfWeek  bool
+}
+
+// parseCronExpression parses a standard 5 field cron expression (e.g. 0 2 * * SAT)
+func parseCronExpression(expression string) (*cronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	if descriptor, exists := cronDescriptors[strings.ToLower(expression)]; exists {
+		expression = descriptor
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, errInvalidCronExpression
+	}
+	schedule := &cronSchedule{
+		restrictedDayOfMonth: !strings.HasPrefix(fields[2], "*") && fields[2] != "?",
+		restrictedDayOfWeek:  !strings.HasPrefix(fields[4], "*") && fields[4] != "?",
+	}
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfMonth, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, err
+	}
+	// 7 is also accepted for Sunday
+	if schedule.dayOfWeek, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	return schedule, nil
+}
+
+// parseCronField parses a single field of a cron expression, which is a comma-separated list of *, values or
+// ranges, each optionally followed by a step (e.g. 1-5,*/15)
+func parseCronField(field string, minimum, maximum int, names []string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, errInvalidCronExpression
+			}
+		}
+		var start, end int
+		if rangePart == "*" || rangePart == "?" {
+			start, end = minimum, maximum
+		} else {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(startPart, minimum, names); err != nil {
+				return 0, err
+			}
+			if isRange {
+				if end, err = parseCronValue(endPart, minimum, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = maximum
+			} else {
+				end = start
+			}
+		}
+		if start < minimum || end > maximum || start > end {
+			return 0, errInvalidCronExpression
+		}
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, minimum int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(value, name) {
+			return minimum + i, nil
+		}
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errInvalidCronExpression
+	}
+	return number, nil
+}
+
+// next returns the first time matching the schedule that is strictly after t, or false if there is none within the
+// next maximumCronSearchYears years
+func (schedule *cronSchedule) next(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + maximumCronSearchYears
+	for t.Year() <= yearLimit {
+		if schedule.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !schedule.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if schedule.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if schedule.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func (schedule *cronSchedule) matchesDay(t time.Time) bool {
+	matchesDayOfMonth := schedule.dayOfMonth&(1<<uint(t.Day())) != 0
+	matchesDayOfWeek := schedule.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if schedule.restrictedDayOfMonth && schedule.restrictedDayOfWeek {
+		return matchesDayOfMonth || matchesDayOfWeek
+	}
+	return matchesDayOfMonth && matchesDayOfWeek
+}
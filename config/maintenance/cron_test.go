@@ -0,0 +1,81 @@
+package maintenance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		expression    string
+		expectedError error
+	}{
+		{name: "every-minute", expression: "* * * * *"},
+		{name: "lists-ranges-and-steps", expression: "0,30 1-5 */2 1-12/3 MON-FRI"},
+		{name: "names", expression: "0 2 * JAN,jul sat"},
+		{name: "sunday-as-7", expression: "0 2 * * 7"},
+		{name: "descriptor", expression: "@daily"},
+		{name: "too-few-fields", expression: "0 2 * *", expectedError: errInvalidCronExpression},
+		{name: "too-many-fields", expression: "0 0 2 * * *", expectedError: errInvalidCronExpression},
+		{name: "minute-out-of-range", expression: "60 * * * *", expectedError: errInvalidCronExpression},
+		{name: "day-of-month-out-of-range", expression: "0 0 0 * *", expectedError: errInvalidCronExpression},
+		{name: "inverted-range", expression: "0 5-1 * * *", expectedError: errInvalidCronExpression},
+		{name: "invalid-step", expression: "*/0 * * * *", expectedError: errInvalidCronExpression},
+		{name: "invalid-name", expression: "0 0 * * FUNDAY", expectedError: errInvalidCronExpression},
+		{name: "unknown-descriptor", expression: "@fortnightly", expectedError: errInvalidCronExpression},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if _, err := parseCronExpression(scenario.expression); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_next(t *testing.T) {
+	from := time.Date(2025, time.January, 29, 12, 30, 15, 0, time.UTC) // Wednesday
+	scenarios := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{expression: "* * * * *", expected: time.Date(2025, time.January, 29, 12, 31, 0, 0, time.UTC)},
+		{expression: "30 12 * * *", expected: time.Date(2025, time.January, 30, 12, 30, 0, 0, time.UTC)},
+		{expression: "*/15 * * * *", expected: time.Date(2025, time.January, 29, 12, 45, 0, 0, time.UTC)},
+		{expression: "0 2 * * SAT", expected: time.Date(2025, time.February, 1, 2, 0, 0, 0, time.UTC)},
+		{expression: "0 2 * * 0", expected: time.Date(2025, time.February, 2, 2, 0, 0, 0, time.UTC)},
+		{expression: "0 2 * * 7", expected: time.Date(2025, time.February, 2, 2, 0, 0, 0, time.UTC)},
+		{expression: "0 0 1 * *", expected: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 29 2 *", expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{expression: "@yearly", expected: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		// When both the day of the month and the day of the week are restricted, either of them must match
+		{expression: "0 0 15 * FRI", expected: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.expression, func(t *testing.T) {
+			schedule, err := parseCronExpression(scenario.expression)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			next, ok := schedule.next(from)
+			if !ok {
+				t.Fatal("expected a next time to be found")
+			}
+			if !next.Equal(scenario.expected) {
+				t.Errorf("expected %s, got %s", scenario.expected, next)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_nextWithImpossibleExpression(t *testing.T) {
+	schedule, err := parseCronExpression("0 0 31 2 *")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if _, ok := schedule.next(time.Now()); ok {
+		t.Error("expected no next time to be found")
+	}
+}
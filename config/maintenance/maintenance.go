@@ -15,6 +15,9 @@ var (
 	errInvalidMaintenanceDuration    = errors.New("invalid maintenance duration: must be bigger than 0 (e.g. 30m)")
 	errInvalidDayName                = fmt.Errorf("invalid value specified for 'on'. supported values are %s", longDayNames)
 	errInvalidTimezone               = errors.New("invalid timezone specified or format not supported. Use IANA timezone format (e.g. America/Sao_Paulo)")
+	errInvalidMaintenanceFromOrUntil = errors.New("invalid maintenance from/until: both must be set using the RFC3339 format (e.g. 2025-01-31T22:00:00Z), and until must be after from")
+	errMultipleMaintenanceSchedules  = errors.New("invalid maintenance schedule: only one of start, cron or from/until may be set")
+	errEveryWithCron                 = errors.New("invalid maintenance schedule: every cannot be used with cron, use the day of week field of the cron expression instead")
 
 	longDayNames = []string{
 		"Sunday",
@@ -30,6 +33,9 @@ var (
 // Config allows for the configuration of a maintenance period.
 // During this maintenance period, no alerts will be sent.
 //
+// A maintenance period is either recurring daily or weekly (start, duration and every), recurring based on a cron
+// expression (cron and duration), or a one-off window (from and until).
+//
 // Uses UTC by default.
 type Config struct {
 	Enabled  *bool         `yaml:"enabled"`            // Whether the maintenance period is enabled. Enabled by default if nil.
@@ -42,8 +48,18 @@ type Config struct {
 	// Every day if empty.
 	Every []string `yaml:"every,omitempty"`
 
+	// Cron is a standard 5 field cron expression (e.g. 0 2 * * SAT) determining when the maintenance period starts.
+	// Evaluated in the configured timezone.
+	Cron string `yaml:"cron,omitempty"`
+
+	From  string `yaml:"from,omitempty"`  // Time at which a one-off maintenance period starts, in RFC3339 format (e.g. 2025-01-31T22:00:00Z)
+	Until string `yaml:"until,omitempty"` // Time at which a one-off maintenance period ends, in RFC3339 format (e.g. 2025-02-01T02:00:00Z)
+
 	timezoneLocation            *time.Location
 	durationToStartFromMidnight time.Duration
+	cronSchedule                *cronSchedule
+	from                        time.Time
+	until                       time.Time
 }
 
 func GetDefaultConfig() *Config {
@@ -70,6 +86,12 @@ func (c *Config) ValidateAndSetDefaults() error {
 		// Don't waste time validating if maintenance is not enabled.
 		return nil
 	}
+	if len(c.From) > 0 || len(c.Until) > 0 {
+		return c.validateAndSetDefaultsForOneOffSchedule()
+	}
+	if len(c.Cron) > 0 {
+		return c.validateAndSetDefaultsForCronSchedule()
+	}
 	for _, day := range c.Every {
 		isDayValid := slices.Contains(longDayNames, day)
 		if !isDayValid {
@@ -84,7 +106,46 @@ func (c *Config) ValidateAndSetDefaults() error {
 	if c.Duration <= 0 || c.Duration > 24*time.Hour {
 		return errInvalidMaintenanceDuration
 	}
+	return c.validateAndSetDefaultTimezone()
+}
+
+func (c *Config) validateAndSetDefaultsForOneOffSchedule() error {
+	if len(c.Start) > 0 || len(c.Cron) > 0 {
+		return errMultipleMaintenanceSchedules
+	}
+	var err error
+	if c.from, err = time.Parse(time.RFC3339, c.From); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidMaintenanceFromOrUntil, err)
+	}
+	if c.until, err = time.Parse(time.RFC3339, c.Until); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidMaintenanceFromOrUntil, err)
+	}
+	if !c.until.After(c.from) {
+		return errInvalidMaintenanceFromOrUntil
+	}
+	return nil
+}
+
+func (c *Config) validateAndSetDefaultsForCronSchedule() error {
+	if len(c.Start) > 0 {
+		return errMultipleMaintenanceSchedules
+	}
+	if len(c.Every) > 0 {
+		return errEveryWithCron
+	}
+	var err error
+	if c.cronSchedule, err = parseCronExpression(c.Cron); err != nil {
+		return err
+	}
+	if c.Duration <= 0 {
+		return errInvalidMaintenanceDuration
+	}
+	return c.validateAndSetDefaultTimezone()
+}
+
+func (c *Config) validateAndSetDefaultTimezone() error {
 	if c.Timezone != "" {
+		var err error
 		c.timezoneLocation, err = time.LoadLocation(c.Timezone)
 		if err != nil {
 			return fmt.Errorf("%w: %w", errInvalidTimezone, err)
@@ -102,6 +163,10 @@ func (c *Config) IsUnderMaintenance() bool {
 		return false
 	}
 	now := time.Now()
+	if !c.isDailyOrWeekly() {
+		start, end, ok := c.NextWindow(now)
+		return ok && !now.Before(start) && now.Before(end)
+	}
 	if c.timezoneLocation != nil {
 		now = now.In(c.timezoneLocation)
 	}
@@ -124,6 +189,47 @@ func (c *Config) IsUnderMaintenance() bool {
 	return now.After(startOfMaintenancePeriod) && now.Before(endOfMaintenancePeriod)
 }
 
+// NextWindow returns the start and the end of the maintenance period that is ongoing at the given time or, if there is
+// none, of the next maintenance period after the given time.
+// Returns false if there is no such maintenance period, such as when a one-off maintenance period has already ended.
+//
+// Like IsUnderMaintenance, must only be called after ValidateAndSetDefaults.
+func (c *Config) NextWindow(t time.Time) (start, end time.Time, ok bool) {
+	if !c.IsEnabled() {
+		return time.Time{}, time.Time{}, false
+	}
+	if len(c.From) > 0 {
+		return c.from, c.until, t.Before(c.until)
+	}
+	if c.timezoneLocation != nil {
+		t = t.In(c.timezoneLocation)
+	}
+	if c.cronSchedule != nil {
+		// The first period starting after t minus its duration is the one that is ongoing at t, if any
+		start, ok = c.cronSchedule.next(t.Add(-c.Duration))
+		return start, start.Add(c.Duration), ok
+	}
+	// Starting from the day before, because a maintenance period may span midnight
+	for i := -1; i <= len(longDayNames); i++ {
+		day := time.Date(t.Year(), t.Month(), t.Day()+i, 0, 0, 0, 0, t.Location())
+		if len(c.Every) > 0 && !slices.Contains(c.Every, day.Weekday().String()) {
+			continue
+		}
+		start = day.Add(c.durationToStartFromMidnight)
+		end = start.Add(c.Duration)
+		if t.Before(end) {
+			return start, end, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// isDailyOrWeekly returns whether the maintenance period recurs daily or weekly, as opposed to being a one-off
+// maintenance period or recurring based on a cron expression
+func (c *Config) isDailyOrWeekly() bool {
+	return len(c.From) == 0 && len(c.Cron) == 0
+}
+
 func hhmmToDuration(s string) (time.Duration, error) {
 	if len(s) != 5 {
 		return 0, errInvalidMaintenanceStartFormat
@@ -162,6 +162,105 @@ func TestConfig_ValidateAndSetDefaults(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "one-off",
+			cfg: &Config{
+				From:  "2025-01-31T22:00:00Z",
+				Until: "2025-02-01T02:00:00+01:00",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "one-off-without-until",
+			cfg: &Config{
+				From: "2025-01-31T22:00:00Z",
+			},
+			expectedError: errInvalidMaintenanceFromOrUntil,
+		},
+		{
+			name: "one-off-with-invalid-from",
+			cfg: &Config{
+				From:  "2025-01-31 22:00",
+				Until: "2025-02-01T02:00:00Z",
+			},
+			expectedError: errInvalidMaintenanceFromOrUntil,
+		},
+		{
+			name: "one-off-with-until-before-from",
+			cfg: &Config{
+				From:  "2025-02-01T02:00:00Z",
+				Until: "2025-01-31T22:00:00Z",
+			},
+			expectedError: errInvalidMaintenanceFromOrUntil,
+		},
+		{
+			name: "one-off-with-start",
+			cfg: &Config{
+				Start: "23:00",
+				From:  "2025-01-31T22:00:00Z",
+				Until: "2025-02-01T02:00:00Z",
+			},
+			expectedError: errMultipleMaintenanceSchedules,
+		},
+		{
+			name: "cron",
+			cfg: &Config{
+				Cron:     "0 2 * * SAT",
+				Duration: 2 * time.Hour,
+				Timezone: "Europe/Amsterdam",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "cron-descriptor",
+			cfg: &Config{
+				Cron:     "@weekly",
+				Duration: 48 * time.Hour,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "cron-invalid",
+			cfg: &Config{
+				Cron:     "0 2 * *",
+				Duration: 2 * time.Hour,
+			},
+			expectedError: errInvalidCronExpression,
+		},
+		{
+			name: "cron-without-duration",
+			cfg: &Config{
+				Cron: "0 2 * * SAT",
+			},
+			expectedError: errInvalidMaintenanceDuration,
+		},
+		{
+			name: "cron-with-start",
+			cfg: &Config{
+				Start:    "23:00",
+				Cron:     "0 2 * * SAT",
+				Duration: 2 * time.Hour,
+			},
+			expectedError: errMultipleMaintenanceSchedules,
+		},
+		{
+			name: "cron-with-every",
+			cfg: &Config{
+				Cron:     "0 2 * * *",
+				Duration: 2 * time.Hour,
+				Every:    []string{"Saturday"},
+			},
+			expectedError: errEveryWithCron,
+		},
+		{
+			name: "cron-with-invalid-timezone",
+			cfg: &Config{
+				Cron:     "0 2 * * SAT",
+				Duration: 2 * time.Hour,
+				Timezone: "Invalid/Timezone",
+			},
+			expectedError: errInvalidTimezone,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -326,6 +425,71 @@ func TestConfig_IsUnderMaintenance(t *testing.T) {
 			},
 			expectedUnderMaintenance: false,
 		},
+		{
+			name: "under-maintenance-one-off",
+			cfg: &Config{
+				From:  now.Add(-time.Hour).Format(time.RFC3339),
+				Until: now.Add(time.Hour).Format(time.RFC3339),
+			},
+			expectedUnderMaintenance: true,
+		},
+		{
+			name: "not-under-maintenance-one-off-that-ended",
+			cfg: &Config{
+				From:  now.Add(-2 * time.Hour).Format(time.RFC3339),
+				Until: now.Add(-time.Hour).Format(time.RFC3339),
+			},
+			expectedUnderMaintenance: false,
+		},
+		{
+			name: "not-under-maintenance-one-off-that-has-not-started",
+			cfg: &Config{
+				From:  now.Add(time.Hour).Format(time.RFC3339),
+				Until: now.Add(2 * time.Hour).Format(time.RFC3339),
+			},
+			expectedUnderMaintenance: false,
+		},
+		{
+			name: "under-maintenance-cron-starting-every-hour-for-2h",
+			cfg: &Config{
+				Cron:     "0 * * * *",
+				Duration: 2 * time.Hour,
+			},
+			expectedUnderMaintenance: true,
+		},
+		{
+			name: "under-maintenance-cron-starting-4h-ago-for-8h",
+			cfg: &Config{
+				Cron:     fmt.Sprintf("0 %d * * *", normalizeHour(now.Hour()-4)),
+				Duration: 8 * time.Hour,
+			},
+			expectedUnderMaintenance: true,
+		},
+		{
+			name: "under-maintenance-cron-perth-timezone-starting-now-for-2h",
+			cfg: &Config{
+				Cron:     fmt.Sprintf("0 %d * * *", inTimezone(now, "Australia/Perth", t).Hour()),
+				Duration: 2 * time.Hour,
+				Timezone: "Australia/Perth",
+			},
+			expectedUnderMaintenance: true,
+		},
+		{
+			name: "not-under-maintenance-cron-starting-5h-ago-for-1h",
+			cfg: &Config{
+				Cron:     fmt.Sprintf("0 %d * * *", normalizeHour(now.Hour()-5)),
+				Duration: time.Hour,
+			},
+			expectedUnderMaintenance: false,
+		},
+		{
+			name: "not-under-maintenance-cron-today",
+			cfg: &Config{
+				Cron:     fmt.Sprintf("0 %d * * %d", now.Hour(), now.Add(48*time.Hour).Weekday()),
+				Duration: time.Hour,
+			},
+			expectedUnderMaintenance: false,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -343,6 +507,106 @@ func TestConfig_IsUnderMaintenance(t *testing.T) {
 	}
 }
 
+func TestConfig_NextWindow(t *testing.T) {
+	now := time.Date(2025, time.January, 29, 12, 30, 0, 0, time.UTC) // Wednesday
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectedOk    bool
+	}{
+		{
+			name:       "disabled",
+			cfg:        GetDefaultConfig(),
+			expectedOk: false,
+		},
+		{
+			name:          "daily-ongoing",
+			cfg:           &Config{Start: "12:00", Duration: time.Hour},
+			expectedStart: time.Date(2025, time.January, 29, 12, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.January, 29, 13, 0, 0, 0, time.UTC),
+			expectedOk:    true,
+		},
+		{
+			name:          "daily-ongoing-since-yesterday",
+			cfg:           &Config{Start: "23:00", Duration: 14 * time.Hour},
+			expectedStart: time.Date(2025, time.January, 28, 23, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.January, 29, 13, 0, 0, 0, time.UTC),
+			expectedOk:    true,
+		},
+		{
+			name:          "daily-upcoming",
+			cfg:           &Config{Start: "23:00", Duration: time.Hour},
+			expectedStart: time.Date(2025, time.January, 29, 23, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.January, 30, 0, 0, 0, 0, time.UTC),
+			expectedOk:    true,
+		},
+		{
+			name:          "weekly-upcoming",
+			cfg:           &Config{Start: "02:00", Duration: time.Hour, Every: []string{"Monday"}},
+			expectedStart: time.Date(2025, time.February, 3, 2, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.February, 3, 3, 0, 0, 0, time.UTC),
+			expectedOk:    true,
+		},
+		{
+			name:          "weekly-upcoming-with-timezone",
+			cfg:           &Config{Start: "02:00", Duration: time.Hour, Every: []string{"Monday"}, Timezone: "Europe/Amsterdam"},
+			expectedStart: time.Date(2025, time.February, 3, 1, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.February, 3, 2, 0, 0, 0, time.UTC),
+			expectedOk:    true,
+		},
+		{
+			name:          "one-off-upcoming",
+			cfg:           &Config{From: "2025-01-31T22:00:00Z", Until: "2025-02-01T02:00:00Z"},
+			expectedStart: time.Date(2025, time.January, 31, 22, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.February, 1, 2, 0, 0, 0, time.UTC),
+			expectedOk:    true,
+		},
+		{
+			name:       "one-off-ended",
+			cfg:        &Config{From: "2025-01-01T22:00:00Z", Until: "2025-01-02T02:00:00Z"},
+			expectedOk: false,
+		},
+		{
+			name:          "cron-ongoing",
+			cfg:           &Config{Cron: "0 12 * * WED", Duration: time.Hour},
+			expectedStart: time.Date(2025, time.January, 29, 12, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.January, 29, 13, 0, 0, 0, time.UTC),
+			expectedOk:    true,
+		},
+		{
+			name:          "cron-upcoming",
+			cfg:           &Config{Cron: "30 1 1 * *", Duration: 2 * time.Hour},
+			expectedStart: time.Date(2025, time.February, 1, 1, 30, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.February, 1, 3, 30, 0, 0, time.UTC),
+			expectedOk:    true,
+		},
+		{
+			name:       "cron-never",
+			cfg:        &Config{Cron: "0 0 30 2 *", Duration: time.Hour},
+			expectedOk: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("validation shouldn't have returned an error, got", err)
+			}
+			start, end, ok := scenario.cfg.NextWindow(now)
+			if ok != scenario.expectedOk {
+				t.Fatalf("expected ok to be %v, got %v", scenario.expectedOk, ok)
+			}
+			if !ok {
+				return
+			}
+			if !start.Equal(scenario.expectedStart) || !end.Equal(scenario.expectedEnd) {
+				t.Errorf("expected window from %s to %s, got from %s to %s", scenario.expectedStart, scenario.expectedEnd, start, end)
+			}
+		})
+	}
+}
+
 func normalizeHour(hour int) int {
 	if hour < 0 {
 		return hour + 24
@@ -0,0 +1,116 @@
+package maintenance
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrWindowWithoutScope is the error returned when a maintenance window doesn't apply to any endpoint, group or suite
+	ErrWindowWithoutScope = errors.New("maintenance window must apply to at least one endpoint, group or suite")
+
+	// ErrWindowWithoutDescription is the error returned when a maintenance window has no description
+	ErrWindowWithoutDescription = errors.New("maintenance window description cannot be empty")
+
+	// ErrWindowWithInvalidSchedule is the error returned when a maintenance window is neither a one-off window nor
+	// a window recurring based on a cron expression
+	ErrWindowWithInvalidSchedule = errors.New("maintenance window must either have from and until, or cron and duration")
+)
+
+// Window is a maintenance window managed at runtime through the API, as opposed to maintenance windows defined in the
+// configuration file, and which applies to specific endpoints, groups and/or suites.
+type Window struct {
+	// ID is the unique identifier of the maintenance window, which is assigned by the store
+	ID int64 `json:"id"`
+
+	// Description is a short, user-facing description of the maintenance (e.g. Database migration)
+	Description string `json:"description"`
+
+	// EndpointKeys are the keys of the endpoints the maintenance window applies to
+	EndpointKeys []string `json:"endpointKeys,omitempty"`
+
+	// Groups are the groups whose endpoints the maintenance window applies to
+	Groups []string `json:"groups,omitempty"`
+
+	// SuiteKeys are the keys of the suites the maintenance window applies to
+	SuiteKeys []string `json:"suiteKeys,omitempty"`
+
+	From     string `json:"from,omitempty"`     // Start of a one-off window, in RFC3339 format
+	Until    string `json:"until,omitempty"`    // End of a one-off window, in RFC3339 format
+	Cron     string `json:"cron,omitempty"`     // Cron expression determining when a recurring window starts
+	Duration string `json:"duration,omitempty"` // Duration of a recurring window (e.g. 2h)
+	Timezone string `json:"timezone,omitempty"` // Timezone in which the cron expression is evaluated. Defaults to UTC.
+
+	// CreatedBy is who created the maintenance window
+	CreatedBy string `json:"createdBy,omitempty"`
+
+	// CreatedAt is when the maintenance window was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	config *Config
+}
+
+// ValidateAndSetDefaults validates the maintenance window and sets the default values if necessary.
+//
+// Must be called before IsActive, NextWindow or ExpiresAt are called.
+func (w *Window) ValidateAndSetDefaults() error {
+	if len(w.EndpointKeys) == 0 && len(w.Groups) == 0 && len(w.SuiteKeys) == 0 {
+		return ErrWindowWithoutScope
+	}
+	if len(w.Description) == 0 {
+		return ErrWindowWithoutDescription
+	}
+	isOneOff := len(w.From) > 0 || len(w.Until) > 0
+	isCron := len(w.Cron) > 0 || len(w.Duration) > 0
+	if isOneOff == isCron {
+		return ErrWindowWithInvalidSchedule
+	}
+	config := &Config{From: w.From, Until: w.Until, Cron: w.Cron, Timezone: w.Timezone}
+	if isCron {
+		if len(w.Cron) == 0 {
+			return ErrWindowWithInvalidSchedule
+		}
+		var err error
+		if config.Duration, err = time.ParseDuration(w.Duration); err != nil {
+			return errInvalidMaintenanceDuration
+		}
+	}
+	if err := config.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	w.Timezone = config.Timezone
+	w.config = config
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// AppliesTo returns whether the maintenance window applies to the endpoint with the given key and group, which may
+// be part of the suite with the given key
+func (w *Window) AppliesTo(endpointKey, group, suiteKey string) bool {
+	return slices.Contains(w.EndpointKeys, endpointKey) || (len(group) > 0 && slices.Contains(w.Groups, group)) || (len(suiteKey) > 0 && slices.Contains(w.SuiteKeys, suiteKey))
+}
+
+// IsActive returns whether the maintenance window is ongoing
+func (w *Window) IsActive() bool {
+	return w.config.IsUnderMaintenance()
+}
+
+// NextWindow returns the start and the end of the ongoing or next occurrence of the maintenance window after the
+// given time. See Config.NextWindow.
+func (w *Window) NextWindow(t time.Time) (start, end time.Time, ok bool) {
+	return w.config.NextWindow(t)
+}
+
+// ExpiresAt returns when a one-off maintenance window ends, or the zero value if the maintenance window is recurring
+func (w *Window) ExpiresAt() time.Time {
+	return w.config.until
+}
+
+// HasExpired returns whether the maintenance window is a one-off window that has already ended
+func (w *Window) HasExpired() bool {
+	expiresAt := w.ExpiresAt()
+	return !expiresAt.IsZero() && !time.Now().Before(expiresAt)
+}
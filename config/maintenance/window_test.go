@@ -0,0 +1,137 @@
+package maintenance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWindow_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		window        *Window
+		expectedError error
+	}{
+		{
+			name:          "no-scope",
+			window:        &Window{Description: "Database migration", From: "2025-01-31T22:00:00Z", Until: "2025-02-01T02:00:00Z"},
+			expectedError: ErrWindowWithoutScope,
+		},
+		{
+			name:          "no-description",
+			window:        &Window{Groups: []string{"core"}, From: "2025-01-31T22:00:00Z", Until: "2025-02-01T02:00:00Z"},
+			expectedError: ErrWindowWithoutDescription,
+		},
+		{
+			name:          "no-schedule",
+			window:        &Window{Description: "Database migration", Groups: []string{"core"}},
+			expectedError: ErrWindowWithInvalidSchedule,
+		},
+		{
+			name:          "both-one-off-and-cron",
+			window:        &Window{Description: "Database migration", Groups: []string{"core"}, From: "2025-01-31T22:00:00Z", Until: "2025-02-01T02:00:00Z", Cron: "0 2 * * *", Duration: "1h"},
+			expectedError: ErrWindowWithInvalidSchedule,
+		},
+		{
+			name:          "duration-without-cron",
+			window:        &Window{Description: "Database migration", Groups: []string{"core"}, Duration: "1h"},
+			expectedError: ErrWindowWithInvalidSchedule,
+		},
+		{
+			name:          "cron-with-invalid-duration",
+			window:        &Window{Description: "Database migration", Groups: []string{"core"}, Cron: "0 2 * * *", Duration: "forever"},
+			expectedError: errInvalidMaintenanceDuration,
+		},
+		{
+			name:          "one-off-with-invalid-until",
+			window:        &Window{Description: "Database migration", Groups: []string{"core"}, From: "2025-01-31T22:00:00Z", Until: "tomorrow"},
+			expectedError: errInvalidMaintenanceFromOrUntil,
+		},
+		{
+			name:   "one-off",
+			window: &Window{Description: "Database migration", EndpointKeys: []string{"core_frontend"}, From: "2025-01-31T22:00:00Z", Until: "2025-02-01T02:00:00Z"},
+		},
+		{
+			name:   "cron",
+			window: &Window{Description: "Weekly backup", SuiteKeys: []string{"flows_checkout"}, Cron: "0 2 * * SAT", Duration: "2h"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.window.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	now := time.Now()
+	oneOffWindow := &Window{
+		Description: "Database migration",
+		Groups:      []string{"core"},
+		From:        now.Add(-time.Hour).Format(time.RFC3339),
+		Until:       now.Add(time.Hour).Format(time.RFC3339),
+	}
+	if err := oneOffWindow.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !oneOffWindow.IsActive() {
+		t.Error("expected one-off window to be active")
+	}
+	if oneOffWindow.HasExpired() {
+		t.Error("expected one-off window to not have expired")
+	}
+	if oneOffWindow.ExpiresAt().IsZero() {
+		t.Error("expected one-off window to expire")
+	}
+	expiredWindow := &Window{
+		Description: "Database migration",
+		Groups:      []string{"core"},
+		From:        now.Add(-2 * time.Hour).Format(time.RFC3339),
+		Until:       now.Add(-time.Hour).Format(time.RFC3339),
+	}
+	if err := expiredWindow.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if expiredWindow.IsActive() || !expiredWindow.HasExpired() {
+		t.Error("expected window that ended to have expired")
+	}
+	cronWindow := &Window{Description: "Weekly backup", Groups: []string{"core"}, Cron: "0 2 * * SAT", Duration: "2h"}
+	if err := cronWindow.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if cronWindow.Timezone != "UTC" {
+		t.Errorf("expected timezone to default to UTC, got %s", cronWindow.Timezone)
+	}
+	if !cronWindow.ExpiresAt().IsZero() || cronWindow.HasExpired() {
+		t.Error("expected recurring window to never expire")
+	}
+	if _, _, ok := cronWindow.NextWindow(now); !ok {
+		t.Error("expected recurring window to have a next occurrence")
+	}
+}
+
+func TestWindow_AppliesTo(t *testing.T) {
+	window := &Window{EndpointKeys: []string{"core_frontend"}, Groups: []string{"internal"}, SuiteKeys: []string{"flows_checkout"}}
+	scenarios := []struct {
+		name        string
+		endpointKey string
+		group       string
+		suiteKey    string
+		expected    bool
+	}{
+		{name: "endpoint-key", endpointKey: "core_frontend", group: "core", expected: true},
+		{name: "group", endpointKey: "internal_backend", group: "internal", expected: true},
+		{name: "suite-key", endpointKey: "flows_login", group: "flows", suiteKey: "flows_checkout", expected: true},
+		{name: "none", endpointKey: "core_backend", group: "core", expected: false},
+		{name: "no-group", endpointKey: "_backend", expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := window.AppliesTo(scenario.endpointKey, scenario.group, scenario.suiteKey); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
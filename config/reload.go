@@ -0,0 +1,170 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/TwiN/logr"
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// configurationChangeDebounceDelay is how long to wait after the last filesystem event before checking whether the
+	// configuration has been modified, since configuration files are often written in multiple steps
+	configurationChangeDebounceDelay = time.Second
+
+	// configurationChangePollingInterval is the interval at which the configuration is checked for modifications
+	// regardless of filesystem events, for filesystems that don't support them
+	configurationChangePollingInterval = 30 * time.Second
+)
+
+// WaitForModification blocks until one of the files that the configuration has been loaded from has been modified.
+//
+// Modifications are detected through filesystem notifications, with a periodic check as a fallback.
+func (config *Config) WaitForModification() {
+	var events <-chan fsnotify.Event
+	var watcherErrors <-chan error
+	if watcher, err := config.newWatcher(); err != nil {
+		logr.Warnf("[config.WaitForModification] Failed to watch configuration for changes, falling back to polling every %s: %s", configurationChangePollingInterval, err.Error())
+	} else {
+		defer watcher.Close()
+		events, watcherErrors = watcher.Events, watcher.Errors
+	}
+	ticker := time.NewTicker(configurationChangePollingInterval)
+	defer ticker.Stop()
+	var debounce <-chan time.Time
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			debounce = time.After(configurationChangeDebounceDelay)
+		case err, ok := <-watcherErrors:
+			if !ok {
+				watcherErrors = nil
+				continue
+			}
+			logr.Warnf("[config.WaitForModification] Error while watching configuration for changes: %s", err.Error())
+		case <-debounce:
+			debounce = nil
+			if config.HasLoadedConfigurationBeenModified() {
+				return
+			}
+		case <-ticker.C:
+			if config.HasLoadedConfigurationBeenModified() {
+				return
+			}
+		}
+	}
+}
+
+// newWatcher creates a watcher for the directories containing the files that the configuration has been loaded from.
+//
+// Directories are watched rather than files, because files are often replaced rather than modified in place (e.g.
+// by editors, or by Kubernetes when a ConfigMap is updated).
+func (config *Config) newWatcher() (*fsnotify.Watcher, error) {
+	if len(config.configPath) == 0 {
+		return nil, ErrConfigFileNotFound
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	fileInfo, err := os.Stat(config.configPath)
+	if err == nil {
+		if fileInfo.IsDir() {
+			err = filepath.WalkDir(config.configPath, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || !d.IsDir() {
+					return err
+				}
+				return watcher.Add(path)
+			})
+		} else {
+			err = watcher.Add(filepath.Dir(config.configPath))
+		}
+	}
+	if err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// CanBeReloadedWithoutRestart returns whether the updated configuration can replace the configuration by only
+// restarting the monitoring of the endpoints, external endpoints and suites that have changed.
+//
+// If false, the updated configuration can only be applied by stopping everything, reopening the storage and starting
+// everything again. This is the case when the storage configuration, the concurrency, the extra metric labels, the
+// agent configuration, the high-availability configuration or the tunneling configuration changes.
+func (config *Config) CanBeReloadedWithoutRestart(updatedConfig *Config) bool {
+	if config.Concurrency != updatedConfig.Concurrency {
+		return false
+	}
+	if !reflect.DeepEqual(config.Storage, updatedConfig.Storage) {
+		return false
+	}
+	if !slices.Equal(config.GetUniqueExtraMetricLabels(), updatedConfig.GetUniqueExtraMetricLabels()) {
+		return false
+	}
+	if !reflect.DeepEqual(config.Agent, updatedConfig.Agent) {
+		return false
+	}
+	if !reflect.DeepEqual(config.HighAvailability, updatedConfig.HighAvailability) {
+		return false
+	}
+	// Endpoints reference the SSH tunnels they use, so they must all be recreated alongside the tunnels
+	return config.Tunneling == nil && updatedConfig.Tunneling == nil
+}
+
+// ReuseUnchangedMonitors replaces each endpoint, external endpoint and suite of the configuration by its counterpart
+// from the previous configuration if its configuration hasn't changed. This preserves their state (e.g. the number of
+// failures in a row and the triggered alerts), and allows them to keep being monitored without interruption.
+//
+// Returns the keys of the endpoints, external endpoints and suite endpoints that have been reused.
+func (config *Config) ReuseUnchangedMonitors(previousConfig *Config) map[string]bool {
+	reusedKeys := make(map[string]bool)
+	for i, ep := range config.Endpoints {
+		if previousEndpoint := previousConfig.GetEndpointByKey(ep.Key()); previousEndpoint != nil && hasSameConfiguration(ep, previousEndpoint) {
+			config.Endpoints[i] = previousEndpoint
+			reusedKeys[ep.Key()] = true
+		}
+	}
+	for i, ee := range config.ExternalEndpoints {
+		if previousExternalEndpoint := previousConfig.GetExternalEndpointByKey(ee.Key()); previousExternalEndpoint != nil && hasSameConfiguration(ee, previousExternalEndpoint) {
+			config.ExternalEndpoints[i] = previousExternalEndpoint
+			reusedKeys[ee.Key()] = true
+		}
+	}
+	for i, s := range config.Suites {
+		for _, previousSuite := range previousConfig.Suites {
+			if previousSuite.Key() == s.Key() && hasSameConfiguration(s, previousSuite) {
+				config.Suites[i] = previousSuite
+				for _, ep := range previousSuite.Endpoints {
+					reusedKeys[ep.Key()] = true
+				}
+				break
+			}
+		}
+	}
+	return reusedKeys
+}
+
+// hasSameConfiguration returns whether two endpoints, external endpoints or suites have the same configuration,
+// ignoring their state
+func hasSameConfiguration(a, b any) bool {
+	marshalledA, errA := yaml.Marshal(a)
+	marshalledB, errB := yaml.Marshal(b)
+	if err := errors.Join(errA, errB); err != nil {
+		logr.Warnf("[config.hasSameConfiguration] Failed to compare configurations: %s", err.Error())
+		return false
+	}
+	return string(marshalledA) == string(marshalledB)
+}
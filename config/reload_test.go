@@ -0,0 +1,191 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/config/ha"
+	"github.com/TwiN/gatus/v5/config/tunneling"
+	"github.com/TwiN/gatus/v5/storage"
+)
+
+func TestConfig_WaitForModification(t *testing.T) {
+	dir := t.TempDir()
+	configFilePath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configFilePath, []byte(`endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	config, err := LoadConfiguration(configFilePath)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	modified := make(chan bool)
+	go func() {
+		config.WaitForModification()
+		close(modified)
+	}()
+	time.Sleep(100 * time.Millisecond) // Give the watcher some time to start
+	select {
+	case <-modified:
+		t.Fatal("expected WaitForModification to block because nothing has happened since the configuration was loaded")
+	default:
+	}
+	time.Sleep(time.Second) // Because the file mod time only has second precision, we have to wait for a second
+	if err = os.WriteFile(configFilePath, []byte(`endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 201"
+`), 0o644); err != nil {
+		t.Fatalf("failed to overwrite config file: %v", err)
+	}
+	select {
+	case <-modified:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected WaitForModification to return after the configuration file was modified")
+	}
+}
+
+func TestConfig_CanBeReloadedWithoutRestart(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		currentConfig *Config
+		updatedConfig *Config
+		expected      bool
+	}{
+		{
+			name:          "same",
+			currentConfig: &Config{Concurrency: 3, Storage: &storage.Config{Type: storage.TypeMemory}},
+			updatedConfig: &Config{Concurrency: 3, Storage: &storage.Config{Type: storage.TypeMemory}},
+			expected:      true,
+		},
+		{
+			name:          "different-concurrency",
+			currentConfig: &Config{Concurrency: 3},
+			updatedConfig: &Config{Concurrency: 5},
+			expected:      false,
+		},
+		{
+			name:          "different-storage",
+			currentConfig: &Config{Storage: &storage.Config{Type: storage.TypeMemory}},
+			updatedConfig: &Config{Storage: &storage.Config{Type: storage.TypeSQLite, Path: "data.db"}},
+			expected:      false,
+		},
+		{
+			name:          "different-agent",
+			currentConfig: &Config{Agent: &agent.Config{URL: "https://status.example.org", Token: "potato"}},
+			updatedConfig: &Config{Agent: &agent.Config{URL: "https://status.example.org", Token: "tomato"}},
+			expected:      false,
+		},
+		{
+			name:          "different-high-availability",
+			currentConfig: &Config{HighAvailability: &ha.Config{InstanceID: "gatus-1", LeaseDuration: 15 * time.Second}},
+			updatedConfig: &Config{HighAvailability: &ha.Config{InstanceID: "gatus-1", LeaseDuration: 30 * time.Second}},
+			expected:      false,
+		},
+		{
+			name:          "tunneling",
+			currentConfig: &Config{Tunneling: &tunneling.Config{}},
+			updatedConfig: &Config{Tunneling: &tunneling.Config{}},
+			expected:      false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := scenario.currentConfig.CanBeReloadedWithoutRestart(scenario.updatedConfig); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConfig_ReuseUnchangedMonitors(t *testing.T) {
+	currentConfig, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: unchanged
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: changed
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: removed
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+external-endpoints:
+  - name: unchanged-external
+    token: potato
+suites:
+  - name: unchanged-suite
+    endpoints:
+      - name: step-1
+        url: https://example.org
+        conditions:
+          - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	updatedConfig, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: unchanged
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+  - name: changed
+    url: https://example.org
+    interval: 5m
+    conditions:
+      - "[STATUS] == 200"
+  - name: added
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+external-endpoints:
+  - name: unchanged-external
+    token: potato
+suites:
+  - name: unchanged-suite
+    endpoints:
+      - name: step-1
+        url: https://example.org
+        conditions:
+          - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	currentConfig.Endpoints[0].NumberOfFailuresInARow = 2
+	reusedKeys := updatedConfig.ReuseUnchangedMonitors(currentConfig)
+	if len(reusedKeys) != 3 {
+		t.Errorf("expected 3 reused keys, got %d: %v", len(reusedKeys), reusedKeys)
+	}
+	if updatedConfig.Endpoints[0] != currentConfig.Endpoints[0] || !reusedKeys["_unchanged"] {
+		t.Error("expected unchanged endpoint to be reused")
+	}
+	if updatedConfig.Endpoints[0].NumberOfFailuresInARow != 2 {
+		t.Error("expected the state of the unchanged endpoint to be preserved")
+	}
+	if updatedConfig.Endpoints[1] == currentConfig.Endpoints[1] || reusedKeys["_changed"] {
+		t.Error("expected changed endpoint to not be reused")
+	}
+	if updatedConfig.Endpoints[2].Name != "added" || reusedKeys["_added"] {
+		t.Error("expected added endpoint to not be reused")
+	}
+	if updatedConfig.ExternalEndpoints[0] != currentConfig.ExternalEndpoints[0] || !reusedKeys["_unchanged-external"] {
+		t.Error("expected unchanged external endpoint to be reused")
+	}
+	if updatedConfig.Suites[0] != currentConfig.Suites[0] || !reusedKeys[currentConfig.Suites[0].Endpoints[0].Key()] {
+		t.Error("expected unchanged suite to be reused")
+	}
+}
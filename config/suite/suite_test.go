@@ -1,6 +1,7 @@
 package suite
 
 import (
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -241,6 +242,43 @@ func TestStoreResultValues(t *testing.T) {
 	}
 }
 
+func TestStoreResultValuesWithHeadersAndRedirects(t *testing.T) {
+	ctx := gontext.New(nil)
+	result := &endpoint.Result{
+		HTTPStatus:    200,
+		Headers:       http.Header{"X-Request-Id": []string{"abc-123"}},
+		FinalURL:      "https://example.org/login",
+		RedirectCount: 2,
+		Protocol:      "HTTP/2.0",
+	}
+	mappings := map[string]string{
+		"request_id":     "[HEADERS].x-request-id",
+		"final_url":      "[FINAL_URL]",
+		"redirect_count": "[REDIRECT_COUNT]",
+		"protocol":       "[PROTOCOL]",
+	}
+	stored, err := StoreResultValues(ctx, mappings, result)
+	if err != nil {
+		t.Fatalf("Unexpected error storing values: %v", err)
+	}
+	if stored["request_id"] != "abc-123" {
+		t.Errorf("Expected request_id=abc-123, got %v", stored["request_id"])
+	}
+	if stored["final_url"] != "https://example.org/login" {
+		t.Errorf("Expected final_url=https://example.org/login, got %v", stored["final_url"])
+	}
+	if stored["redirect_count"] != int64(2) {
+		t.Errorf("Expected redirect_count=2, got %v", stored["redirect_count"])
+	}
+	if stored["protocol"] != "HTTP/2.0" {
+		t.Errorf("Expected protocol=HTTP/2.0, got %v", stored["protocol"])
+	}
+	// A header that wasn't sent can't be stored
+	if _, err = StoreResultValues(ctx, map[string]string{"etag": "[HEADERS].ETag"}, result); err == nil || !strings.Contains(err.Error(), "invalid path") {
+		t.Errorf("Expected an invalid path error for a missing header, got %v", err)
+	}
+}
+
 func TestStoreResultValuesWithInvalidPath(t *testing.T) {
 	ctx := gontext.New(map[string]interface{}{})
 	result := &endpoint.Result{
@@ -7,7 +7,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/controller"
 	"github.com/TwiN/gatus/v5/metrics"
 	"github.com/TwiN/gatus/v5/storage/store"
@@ -51,6 +53,7 @@ func main() {
 func start(cfg *config.Config) {
 	go controller.Handle(cfg)
 	metrics.InitializePrometheusMetrics(cfg, nil)
+	watchdog.OnLeadershipAcquired(loadAlertingStateOfPreviousLeader)
 	watchdog.Monitor(cfg)
 	go listenToConfigurationFileChanges(cfg)
 }
@@ -104,6 +107,12 @@ func initializeStorage(cfg *config.Config) {
 	if err != nil {
 		panic(err)
 	}
+	synchronizeStorage(cfg, nil)
+}
+
+// synchronizeStorage removes the data of the endpoints and suites that no longer exist in the configuration, and
+// loads the persisted triggered alerts of every endpoint whose key isn't part of alreadyLoadedKeys
+func synchronizeStorage(cfg *config.Config, alreadyLoadedKeys map[string]bool) {
 	// Remove all SuiteStatuses that represent suites which no longer exist in the configuration
 	var suiteKeys []string
 	for _, suite := range cfg.Suites {
@@ -135,6 +144,9 @@ func initializeStorage(cfg *config.Config) {
 	// Clean up the triggered alerts from the storage provider and load valid triggered endpoint alerts
 	numberOfPersistedTriggeredAlertsLoaded := 0
 	for _, ep := range cfg.Endpoints {
+		if alreadyLoadedKeys[ep.Key()] {
+			continue
+		}
 		var checksums []string
 		for _, alert := range ep.Alerts {
 			if alert.IsEnabled() {
@@ -153,12 +165,16 @@ func initializeStorage(cfg *config.Config) {
 			}
 			if exists {
 				alert.Triggered, alert.ResolveKey = true, resolveKey
+				loadTriggeredEndpointAlertEscalation(ep, alert)
 				ep.NumberOfSuccessesInARow, ep.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
 				numberOfPersistedTriggeredAlertsLoaded++
 			}
 		}
 	}
 	for _, ee := range cfg.ExternalEndpoints {
+		if alreadyLoadedKeys[ee.Key()] {
+			continue
+		}
 		var checksums []string
 		for _, alert := range ee.Alerts {
 			if alert.IsEnabled() {
@@ -178,6 +194,7 @@ func initializeStorage(cfg *config.Config) {
 			}
 			if exists {
 				alert.Triggered, alert.ResolveKey = true, resolveKey
+				loadTriggeredEndpointAlertEscalation(convertedEndpoint, alert)
 				ee.NumberOfSuccessesInARow, ee.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
 				numberOfPersistedTriggeredAlertsLoaded++
 			}
@@ -186,6 +203,9 @@ func initializeStorage(cfg *config.Config) {
 	// Load persisted triggered alerts for suite endpoints
 	for _, suite := range cfg.Suites {
 		for _, ep := range suite.Endpoints {
+			if alreadyLoadedKeys[ep.Key()] {
+				continue
+			}
 			var checksums []string
 			for _, alert := range ep.Alerts {
 				if alert.IsEnabled() {
@@ -204,6 +224,7 @@ func initializeStorage(cfg *config.Config) {
 				}
 				if exists {
 					alert.Triggered, alert.ResolveKey = true, resolveKey
+					loadTriggeredEndpointAlertEscalation(ep, alert)
 					ep.NumberOfSuccessesInARow, ep.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
 					numberOfPersistedTriggeredAlertsLoaded++
 				}
@@ -215,6 +236,77 @@ func initializeStorage(cfg *config.Config) {
 	}
 }
 
+// loadAlertingStateOfPreviousLeader replaces the alerting state of every endpoint by the triggered alerts persisted by
+// the previous leader, since this instance wasn't handling alerting while another instance was the leader
+//
+// The alerting state of every endpoint is locked while being replaced, since endpoints may be executed concurrently.
+func loadAlertingStateOfPreviousLeader(cfg *config.Config) {
+	defer lockAlertingStateOfEndpoints(cfg)()
+	for _, ep := range cfg.Endpoints {
+		ep.NumberOfSuccessesInARow, ep.NumberOfFailuresInARow = 0, 0
+		resetTriggeredAlerts(ep.Alerts)
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		ee.NumberOfSuccessesInARow, ee.NumberOfFailuresInARow = 0, 0
+		resetTriggeredAlerts(ee.Alerts)
+	}
+	for _, suite := range cfg.Suites {
+		for _, ep := range suite.Endpoints {
+			ep.NumberOfSuccessesInARow, ep.NumberOfFailuresInARow = 0, 0
+			resetTriggeredAlerts(ep.Alerts)
+		}
+	}
+	synchronizeStorage(cfg, nil)
+}
+
+// lockAlertingStateOfEndpoints locks the alerting state of every endpoint, external endpoint and suite endpoint (see
+// watchdog.LockEndpoint), and returns the function unlocking them
+func lockAlertingStateOfEndpoints(cfg *config.Config) func() {
+	unlockFuncs := make(map[string]func())
+	lock := func(key string) {
+		// Endpoints may share the same key, and the lock is not reentrant
+		if _, alreadyLocked := unlockFuncs[key]; !alreadyLocked {
+			unlockFuncs[key] = watchdog.LockEndpoint(key)
+		}
+	}
+	for _, ep := range cfg.Endpoints {
+		lock(ep.Key())
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		lock(ee.Key())
+	}
+	for _, suite := range cfg.Suites {
+		for _, ep := range suite.Endpoints {
+			lock(ep.Key())
+		}
+	}
+	return func() {
+		for _, unlock := range unlockFuncs {
+			unlock()
+		}
+	}
+}
+
+// resetTriggeredAlerts marks the alerts as not triggered
+func resetTriggeredAlerts(alerts []*alert.Alert) {
+	for _, a := range alerts {
+		a.Triggered, a.ResolveKey, a.TriggeredAt, a.EscalationResolveKeys = false, "", time.Time{}, nil
+	}
+}
+
+// loadTriggeredEndpointAlertEscalation loads the progress of a triggered alert through its escalation policy, if any
+func loadTriggeredEndpointAlertEscalation(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) {
+	if len(triggeredAlert.EscalationPolicy) == 0 {
+		return
+	}
+	triggeredAt, resolveKeys, err := store.Get().GetTriggeredEndpointAlertEscalation(ep, triggeredAlert)
+	if err != nil {
+		logr.Errorf("[main.loadTriggeredEndpointAlertEscalation] Failed to get escalation steps of triggered alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+		return
+	}
+	triggeredAlert.TriggeredAt, triggeredAlert.EscalationResolveKeys = triggeredAt, resolveKeys
+}
+
 func closeTunnels(cfg *config.Config) {
 	if cfg.Tunneling != nil {
 		if err := cfg.Tunneling.Close(); err != nil {
@@ -225,28 +317,50 @@ func closeTunnels(cfg *config.Config) {
 
 func listenToConfigurationFileChanges(cfg *config.Config) {
 	for {
-		time.Sleep(30 * time.Second)
-		if cfg.HasLoadedConfigurationBeenModified() {
-			logr.Info("[main.listenToConfigurationFileChanges] Configuration file has been modified")
-			stop(cfg)
-			time.Sleep(time.Second) // Wait a bit to make sure everything is done.
-			save()
-			updatedConfig, err := loadConfiguration()
-			if err != nil {
-				if cfg.SkipInvalidConfigUpdate {
-					logr.Errorf("[main.listenToConfigurationFileChanges] Failed to load new configuration: %s", err.Error())
-					logr.Error("[main.listenToConfigurationFileChanges] The configuration file was updated, but it is not valid. The old configuration will continue being used.")
-					// Update the last file modification time to avoid trying to process the same invalid configuration again
-					cfg.UpdateLastFileModTime()
-					continue
-				} else {
-					panic(err)
-				}
+		cfg.WaitForModification()
+		logr.Info("[main.listenToConfigurationFileChanges] Configuration file has been modified")
+		updatedConfig, err := loadConfiguration()
+		if err != nil {
+			if cfg.SkipInvalidConfigUpdate {
+				logr.Errorf("[main.listenToConfigurationFileChanges] Failed to load new configuration: %s", err.Error())
+				logr.Error("[main.listenToConfigurationFileChanges] The configuration file was updated, but it is not valid. The old configuration will continue being used.")
+				// Update the last file modification time to avoid trying to process the same invalid configuration again
+				cfg.UpdateLastFileModTime()
+				continue
+			} else {
+				panic(err)
 			}
-			store.Get().Close()
-			initializeStorage(updatedConfig)
-			start(updatedConfig)
-			return
 		}
+		if cfg.CanBeReloadedWithoutRestart(updatedConfig) {
+			reload(cfg, updatedConfig)
+		} else {
+			restart(cfg, updatedConfig)
+		}
+		return
 	}
 }
+
+// reload replaces the configuration while only restarting the monitoring of the endpoints, external endpoints and
+// suites that have changed. The state of the others, as well as the storage, are preserved.
+func reload(cfg, updatedConfig *config.Config) {
+	reusedKeys := updatedConfig.ReuseUnchangedMonitors(cfg)
+	logr.Infof("[main.reload] Reloading configuration; %d endpoint(s) are unchanged", len(reusedKeys))
+	controller.Shutdown()
+	metrics.UnregisterPrometheusMetrics()
+	synchronizeStorage(updatedConfig, reusedKeys)
+	go controller.Handle(updatedConfig)
+	metrics.InitializePrometheusMetrics(updatedConfig, nil)
+	watchdog.Reload(updatedConfig)
+	go listenToConfigurationFileChanges(updatedConfig)
+}
+
+// restart stops everything, reopens the storage and starts everything again with the updated configuration
+func restart(cfg, updatedConfig *config.Config) {
+	logr.Info("[main.restart] Restarting with the updated configuration")
+	stop(cfg)
+	time.Sleep(time.Second) // Wait a bit to make sure everything is done.
+	save()
+	store.Get().Close()
+	initializeStorage(updatedConfig)
+	start(updatedConfig)
+}
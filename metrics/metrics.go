@@ -5,6 +5,7 @@ import (
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
 	"github.com/TwiN/gatus/v5/config/suite"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -20,6 +21,18 @@ var (
 	resultDomainExpirationSeconds      *prometheus.GaugeVec
 	resultEndpointSuccess              *prometheus.GaugeVec
 
+	// HTTP timing metrics
+	resultDNSLookupDurationSeconds    *prometheus.HistogramVec
+	resultTCPConnectDurationSeconds   *prometheus.HistogramVec
+	resultTLSHandshakeDurationSeconds *prometheus.HistogramVec
+	resultTimeToFirstByteSeconds      *prometheus.HistogramVec
+	resultTransferDurationSeconds     *prometheus.HistogramVec
+
+	// SLO metrics
+	sloTarget               *prometheus.GaugeVec
+	sloAttainment           *prometheus.GaugeVec
+	sloErrorBudgetRemaining *prometheus.GaugeVec
+
 	// Suite metrics
 	suiteResultTotal           *prometheus.CounterVec
 	suiteResultDurationSeconds *prometheus.GaugeVec
@@ -59,6 +72,34 @@ func UnregisterPrometheusMetrics() {
 		currentRegisterer.Unregister(resultEndpointSuccess)
 	}
 
+	// Unregister HTTP timing metrics
+	if resultDNSLookupDurationSeconds != nil {
+		currentRegisterer.Unregister(resultDNSLookupDurationSeconds)
+	}
+	if resultTCPConnectDurationSeconds != nil {
+		currentRegisterer.Unregister(resultTCPConnectDurationSeconds)
+	}
+	if resultTLSHandshakeDurationSeconds != nil {
+		currentRegisterer.Unregister(resultTLSHandshakeDurationSeconds)
+	}
+	if resultTimeToFirstByteSeconds != nil {
+		currentRegisterer.Unregister(resultTimeToFirstByteSeconds)
+	}
+	if resultTransferDurationSeconds != nil {
+		currentRegisterer.Unregister(resultTransferDurationSeconds)
+	}
+
+	// Unregister SLO metrics
+	if sloTarget != nil {
+		currentRegisterer.Unregister(sloTarget)
+	}
+	if sloAttainment != nil {
+		currentRegisterer.Unregister(sloAttainment)
+	}
+	if sloErrorBudgetRemaining != nil {
+		currentRegisterer.Unregister(sloErrorBudgetRemaining)
+	}
+
 	// Unregister suite metrics
 	if suiteResultTotal != nil {
 		currentRegisterer.Unregister(suiteResultTotal)
@@ -137,6 +178,64 @@ func InitializePrometheusMetrics(cfg *config.Config, reg prometheus.Registerer)
 	}, append([]string{"key", "group", "name", "type"}, extraLabels...))
 	reg.MustRegister(resultEndpointSuccess)
 
+	// HTTP timing metrics
+	resultDNSLookupDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "results_dns_lookup_duration_seconds",
+		Help:      "Duration of the DNS lookup of the request in seconds",
+	}, append([]string{"key", "group", "name", "type"}, extraLabels...))
+	reg.MustRegister(resultDNSLookupDurationSeconds)
+
+	resultTCPConnectDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "results_tcp_connect_duration_seconds",
+		Help:      "Duration of the establishment of the TCP connection of the request in seconds",
+	}, append([]string{"key", "group", "name", "type"}, extraLabels...))
+	reg.MustRegister(resultTCPConnectDurationSeconds)
+
+	resultTLSHandshakeDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "results_tls_handshake_duration_seconds",
+		Help:      "Duration of the TLS handshake of the request in seconds",
+	}, append([]string{"key", "group", "name", "type"}, extraLabels...))
+	reg.MustRegister(resultTLSHandshakeDurationSeconds)
+
+	resultTimeToFirstByteSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "results_time_to_first_byte_seconds",
+		Help:      "Time between the moment the request was written and the moment the first byte of the response was received in seconds",
+	}, append([]string{"key", "group", "name", "type"}, extraLabels...))
+	reg.MustRegister(resultTimeToFirstByteSeconds)
+
+	resultTransferDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "results_transfer_duration_seconds",
+		Help:      "Duration of the transfer of the body of the response in seconds",
+	}, append([]string{"key", "group", "name", "type"}, extraLabels...))
+	reg.MustRegister(resultTransferDurationSeconds)
+
+	// SLO metrics
+	sloTarget = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "slo_target",
+		Help:      "Percentage of executions that must meet the objective over the window of the SLO",
+	}, append([]string{"key", "group", "name", "objective"}, extraLabels...))
+	reg.MustRegister(sloTarget)
+
+	sloAttainment = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "slo_attainment",
+		Help:      "Percentage of executions that met the objective over the window of the SLO",
+	}, append([]string{"key", "group", "name", "objective"}, extraLabels...))
+	reg.MustRegister(sloAttainment)
+
+	sloErrorBudgetRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "slo_error_budget_remaining",
+		Help:      "Fraction of the error budget of the objective that hasn't been consumed over the window of the SLO",
+	}, append([]string{"key", "group", "name", "objective"}, extraLabels...))
+	reg.MustRegister(sloErrorBudgetRemaining)
+
 	// Suite metrics
 	suiteResultTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: namespace,
@@ -166,14 +265,7 @@ func InitializePrometheusMetrics(cfg *config.Config, reg prometheus.Registerer)
 // PublishMetricsForEndpoint publishes metrics for the given endpoint and its result.
 // These metrics will be exposed at /metrics if the metrics are enabled
 func PublishMetricsForEndpoint(ep *endpoint.Endpoint, result *endpoint.Result, extraLabels []string) {
-	var labelValues []string
-	for _, label := range extraLabels {
-		if value, ok := ep.ExtraLabels[label]; ok {
-			labelValues = append(labelValues, value)
-		} else {
-			labelValues = append(labelValues, "")
-		}
-	}
+	labelValues := getExtraLabelValues(ep, extraLabels)
 	endpointType := ep.Type()
 	resultTotal.WithLabelValues(append([]string{ep.Key(), ep.Group, ep.Name, string(endpointType), strconv.FormatBool(result.Success)}, labelValues...)...).Inc()
 	resultDurationSeconds.WithLabelValues(append([]string{ep.Key(), ep.Group, ep.Name, string(endpointType)}, labelValues...)...).Set(result.Duration.Seconds())
@@ -197,6 +289,55 @@ func PublishMetricsForEndpoint(ep *endpoint.Endpoint, result *endpoint.Result, e
 	} else {
 		resultEndpointSuccess.WithLabelValues(append([]string{ep.Key(), ep.Group, ep.Name, string(endpointType)}, labelValues...)...).Set(0)
 	}
+	// The phases that didn't happen (e.g. the DNS lookup, TCP connection and TLS handshake of a request that reused an
+	// idle connection) are not observed, so that they don't skew the distributions
+	if result.DNSLookupDuration != 0 {
+		resultDNSLookupDurationSeconds.WithLabelValues(append([]string{ep.Key(), ep.Group, ep.Name, string(endpointType)}, labelValues...)...).Observe(result.DNSLookupDuration.Seconds())
+	}
+	if result.TCPConnectDuration != 0 {
+		resultTCPConnectDurationSeconds.WithLabelValues(append([]string{ep.Key(), ep.Group, ep.Name, string(endpointType)}, labelValues...)...).Observe(result.TCPConnectDuration.Seconds())
+	}
+	if result.TLSHandshakeDuration != 0 {
+		resultTLSHandshakeDurationSeconds.WithLabelValues(append([]string{ep.Key(), ep.Group, ep.Name, string(endpointType)}, labelValues...)...).Observe(result.TLSHandshakeDuration.Seconds())
+	}
+	if result.TimeToFirstByte != 0 {
+		resultTimeToFirstByteSeconds.WithLabelValues(append([]string{ep.Key(), ep.Group, ep.Name, string(endpointType)}, labelValues...)...).Observe(result.TimeToFirstByte.Seconds())
+	}
+	if result.TransferDuration != 0 {
+		resultTransferDurationSeconds.WithLabelValues(append([]string{ep.Key(), ep.Group, ep.Name, string(endpointType)}, labelValues...)...).Observe(result.TransferDuration.Seconds())
+	}
+}
+
+// PublishSLOMetricsForEndpoint publishes the metrics of the service level objectives of the given endpoint.
+// These metrics will be exposed at /metrics if the metrics are enabled
+func PublishSLOMetricsForEndpoint(ep *endpoint.Endpoint, status *slo.Status, extraLabels []string) {
+	if !metricsInitialized {
+		return
+	}
+	labelValues := getExtraLabelValues(ep, extraLabels)
+	availabilityLabelValues := append([]string{ep.Key(), ep.Group, ep.Name, "availability"}, labelValues...)
+	sloTarget.WithLabelValues(availabilityLabelValues...).Set(status.Target)
+	sloAttainment.WithLabelValues(availabilityLabelValues...).Set(status.Availability)
+	sloErrorBudgetRemaining.WithLabelValues(availabilityLabelValues...).Set(status.ErrorBudgetRemaining)
+	if status.Latency != nil {
+		latencyLabelValues := append([]string{ep.Key(), ep.Group, ep.Name, "latency"}, labelValues...)
+		sloTarget.WithLabelValues(latencyLabelValues...).Set(status.Latency.Target)
+		sloAttainment.WithLabelValues(latencyLabelValues...).Set(status.Latency.Attainment)
+		sloErrorBudgetRemaining.WithLabelValues(latencyLabelValues...).Set(status.Latency.ErrorBudgetRemaining)
+	}
+}
+
+// getExtraLabelValues returns the values of the extra labels for the given endpoint, in the same order as extraLabels
+func getExtraLabelValues(ep *endpoint.Endpoint, extraLabels []string) []string {
+	var labelValues []string
+	for _, label := range extraLabels {
+		if value, ok := ep.ExtraLabels[label]; ok {
+			labelValues = append(labelValues, value)
+		} else {
+			labelValues = append(labelValues, "")
+		}
+	}
+	return labelValues
 }
 
 // PublishMetricsForSuite publishes metrics for the given suite and its result.
@@ -2,12 +2,14 @@ package metrics
 
 import (
 	"bytes"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
 	"github.com/TwiN/gatus/v5/config/suite"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -241,6 +243,92 @@ gatus_results_endpoint_success{group="http-ep-group",key="http-ep-group_http-ep-
 	}
 }
 
+func TestPublishMetricsForEndpoint_withHTTPTimings(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	InitializePrometheusMetrics(&config.Config{}, reg)
+
+	ep := &endpoint.Endpoint{Name: "ep-name", Group: "ep-group", URL: "https://example.org"}
+	PublishMetricsForEndpoint(ep, &endpoint.Result{
+		HTTPStatus:           200,
+		Connected:            true,
+		Duration:             300 * time.Millisecond,
+		DNSLookupDuration:    20 * time.Millisecond,
+		TCPConnectDuration:   30 * time.Millisecond,
+		TLSHandshakeDuration: 50 * time.Millisecond,
+		TimeToFirstByte:      200 * time.Millisecond,
+		TransferDuration:     3 * time.Millisecond,
+		Success:              true,
+	}, []string{})
+	// The DNS lookup, TCP connection and TLS handshake didn't happen, since an idle connection was reused
+	PublishMetricsForEndpoint(ep, &endpoint.Result{
+		HTTPStatus:      200,
+		Connected:       true,
+		Duration:        150 * time.Millisecond,
+		TimeToFirstByte: 150 * time.Millisecond,
+		Success:         true,
+	}, []string{})
+	for _, scenario := range []struct {
+		metric        string
+		expectedCount int
+		expectedSum   float64
+	}{
+		{metric: "gatus_results_dns_lookup_duration_seconds", expectedCount: 1, expectedSum: 0.02},
+		{metric: "gatus_results_tcp_connect_duration_seconds", expectedCount: 1, expectedSum: 0.03},
+		{metric: "gatus_results_tls_handshake_duration_seconds", expectedCount: 1, expectedSum: 0.05},
+		{metric: "gatus_results_time_to_first_byte_seconds", expectedCount: 2, expectedSum: 0.35},
+		{metric: "gatus_results_transfer_duration_seconds", expectedCount: 1, expectedSum: 0.003},
+	} {
+		t.Run(scenario.metric, func(t *testing.T) {
+			metricFamilies, err := reg.Gather()
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			for _, metricFamily := range metricFamilies {
+				if metricFamily.GetName() != scenario.metric {
+					continue
+				}
+				if len(metricFamily.GetMetric()) != 1 {
+					t.Fatalf("expected 1 metric, got %d", len(metricFamily.GetMetric()))
+				}
+				histogram := metricFamily.GetMetric()[0].GetHistogram()
+				if int(histogram.GetSampleCount()) != scenario.expectedCount {
+					t.Errorf("expected %d observations, got %d", scenario.expectedCount, histogram.GetSampleCount())
+				}
+				if math.Abs(histogram.GetSampleSum()-scenario.expectedSum) > 1e-9 {
+					t.Errorf("expected the sum of the observations to be %f, got %f", scenario.expectedSum, histogram.GetSampleSum())
+				}
+				return
+			}
+			t.Errorf("expected metric %s to have been published", scenario.metric)
+		})
+	}
+}
+
+func TestPublishSLOMetricsForEndpoint(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	InitializePrometheusMetrics(&config.Config{}, reg)
+
+	ep := &endpoint.Endpoint{Name: "ep-name", Group: "ep-group", SLO: &slo.Config{Target: 99, Window: "28d", Latency: &slo.LatencyConfig{Threshold: 300 * time.Millisecond, Target: 95}}}
+	PublishSLOMetricsForEndpoint(ep, ep.SLO.NewStatus(1000, 995, 900), []string{})
+	err := testutil.GatherAndCompare(reg, bytes.NewBufferString(`
+# HELP gatus_slo_attainment Percentage of executions that met the objective over the window of the SLO
+# TYPE gatus_slo_attainment gauge
+gatus_slo_attainment{group="ep-group",key="ep-group_ep-name",name="ep-name",objective="availability"} 99.5
+gatus_slo_attainment{group="ep-group",key="ep-group_ep-name",name="ep-name",objective="latency"} 90
+# HELP gatus_slo_error_budget_remaining Fraction of the error budget of the objective that hasn't been consumed over the window of the SLO
+# TYPE gatus_slo_error_budget_remaining gauge
+gatus_slo_error_budget_remaining{group="ep-group",key="ep-group_ep-name",name="ep-name",objective="availability"} 0.5
+gatus_slo_error_budget_remaining{group="ep-group",key="ep-group_ep-name",name="ep-name",objective="latency"} -1
+# HELP gatus_slo_target Percentage of executions that must meet the objective over the window of the SLO
+# TYPE gatus_slo_target gauge
+gatus_slo_target{group="ep-group",key="ep-group_ep-name",name="ep-name",objective="availability"} 99
+gatus_slo_target{group="ep-group",key="ep-group_ep-name",name="ep-name",objective="latency"} 95
+`), "gatus_slo_attainment", "gatus_slo_error_budget_remaining", "gatus_slo_target")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+}
+
 func TestPublishMetricsForSuite(t *testing.T) {
 	reg := prometheus.NewRegistry()
 	InitializePrometheusMetrics(&config.Config{}, reg)
@@ -0,0 +1,180 @@
+package security
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// apiTokenUsageRecordingInterval is the minimum interval between two updates of the last time an API token was used,
+	// so that the storage isn't written to on every request made with the token
+	apiTokenUsageRecordingInterval = time.Minute
+)
+
+// APITokenScope determines which routes an API token can be used for
+type APITokenScope string
+
+const (
+	// APITokenScopeReadStatuses allows retrieving the statuses, results and events of endpoints and suites, as well as
+	// their alerts, silences, maintenance windows and incidents
+	APITokenScopeReadStatuses APITokenScope = "read-statuses"
+
+	// APITokenScopePushExternalResults allows pushing the results of every external endpoint
+	APITokenScopePushExternalResults APITokenScope = "push-external-results"
+
+	// APITokenScopeManageSilences allows creating and deleting silences, as well as acknowledging alerts
+	APITokenScopeManageSilences APITokenScope = "manage-silences"
+
+	// APITokenScopeManageMaintenanceWindows allows creating and deleting maintenance windows
+	APITokenScopeManageMaintenanceWindows APITokenScope = "manage-maintenance-windows"
+
+	// APITokenScopeManageIncidents allows creating, updating and resolving incidents
+	APITokenScopeManageIncidents APITokenScope = "manage-incidents"
+)
+
+// isValid returns whether the scope is one of the supported scopes
+func (scope APITokenScope) isValid() bool {
+	switch scope {
+	case APITokenScopeReadStatuses, APITokenScopePushExternalResults, APITokenScopeManageSilences, APITokenScopeManageMaintenanceWindows, APITokenScopeManageIncidents:
+		return true
+	}
+	return false
+}
+
+// APIToken is a bearer token that can be used by scripts and CI pipelines to call the protected routes of the API,
+// regardless of whether basic authentication or OIDC is used for users
+type APIToken struct {
+	// Name identifies the token
+	Name string `yaml:"name"`
+
+	// TokenSHA256 is the hex-encoded SHA-256 hash of the token
+	TokenSHA256 string `yaml:"token-sha256"`
+
+	// ExpiresAt is when the token stops being accepted. If zero, the token never expires.
+	ExpiresAt time.Time `yaml:"expires-at,omitempty"`
+
+	// Scopes are what the token can be used for
+	Scopes []APITokenScope `yaml:"scopes"`
+
+	// EndpointGroups are the patterns of the groups of the endpoints and suites that the token can see and manage what
+	// applies to (e.g. team-a-*). If empty, the token can see every group.
+	EndpointGroups []string `yaml:"endpoint-groups,omitempty"`
+
+	hash           []byte       // decoded TokenSHA256
+	lastRecordedAt atomic.Int64 // Unix time in nanoseconds at which the usage of the token was last written to the storage
+}
+
+// isValid returns whether the API token is valid, and decodes its hash
+func (t *APIToken) isValid() bool {
+	if len(t.Name) == 0 || len(t.Scopes) == 0 {
+		return false
+	}
+	hash, err := hex.DecodeString(t.TokenSHA256)
+	if err != nil || len(hash) != sha256.Size {
+		return false
+	}
+	for _, scope := range t.Scopes {
+		if !scope.isValid() {
+			return false
+		}
+	}
+	t.hash = hash
+	return true
+}
+
+// HasExpired returns whether the token has an expiration and has expired
+func (t *APIToken) HasExpired() bool {
+	return !t.ExpiresAt.IsZero() && time.Now().After(t.ExpiresAt)
+}
+
+// recordUsage writes the last time the token was used to the storage, unless it was already written recently
+func (t *APIToken) recordUsage() {
+	now := time.Now()
+	lastRecordedAt := t.lastRecordedAt.Load()
+	if now.Sub(time.Unix(0, lastRecordedAt)) < apiTokenUsageRecordingInterval || !t.lastRecordedAt.CompareAndSwap(lastRecordedAt, now.UnixNano()) {
+		return
+	}
+	if err := store.Get().UpdateAPITokenLastUsedTime(t.Name, now); err != nil {
+		logr.Errorf("[security.recordUsage] Failed to update last used time of API token with name=%s: %s", t.Name, err.Error())
+	}
+}
+
+// principal returns the principal of the requests authenticated with the token.
+// What the token can do is determined by its scopes, so its role is the highest role needed by any scope.
+func (t *APIToken) principal() *Principal {
+	principal := &Principal{Username: t.Name, Role: RoleOperator, Scopes: t.Scopes}
+	if len(t.EndpointGroups) > 0 {
+		principal.EndpointGroups = t.EndpointGroups
+	}
+	return principal
+}
+
+// isValidAPITokens returns whether every API token is valid and has a unique name
+func isValidAPITokens(apiTokens []*APIToken) bool {
+	names := make(map[string]bool, len(apiTokens))
+	for _, apiToken := range apiTokens {
+		if apiToken == nil || !apiToken.isValid() || names[apiToken.Name] {
+			return false
+		}
+		names[apiToken.Name] = true
+	}
+	return true
+}
+
+// getAPIToken returns the API token matching the token passed, or nil if there's none or if it has expired
+func (c *Config) getAPIToken(token string) *APIToken {
+	hash := sha256.Sum256([]byte(token))
+	for _, apiToken := range c.APITokens {
+		if subtle.ConstantTimeCompare(hash[:], apiToken.hash) == 1 {
+			if apiToken.HasExpired() {
+				return nil
+			}
+			return apiToken
+		}
+	}
+	return nil
+}
+
+// AuthorizeAPIToken returns whether the token passed is an API token with the given scope that can see the endpoints
+// of the given group.
+// This is meant for the routes that aren't behind the security middleware, but that can be called with an API token.
+func (c *Config) AuthorizeAPIToken(token string, scope APITokenScope, group string) bool {
+	apiToken := c.getAPIToken(token)
+	if apiToken == nil || !slices.Contains(apiToken.Scopes, scope) || !apiToken.principal().CanSeeGroup(group) {
+		return false
+	}
+	apiToken.recordUsage()
+	return true
+}
+
+// authenticateAPIToken returns a handler that authenticates the requests with an API token as bearer token, and that
+// passes the other requests to the next authentication handler, if any
+func (c *Config) authenticateAPIToken(next fiber.Handler) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		authorizationHeader := ctx.Get(fiber.HeaderAuthorization)
+		if !strings.HasPrefix(authorizationHeader, "Bearer ") {
+			if next == nil {
+				return ctx.Status(401).SendString("Unauthorized")
+			}
+			return next(ctx)
+		}
+		apiToken := c.getAPIToken(strings.TrimSpace(strings.TrimPrefix(authorizationHeader, "Bearer ")))
+		if apiToken == nil {
+			return ctx.Status(401).SendString("Unauthorized")
+		}
+		apiToken.recordUsage()
+		principal := apiToken.principal()
+		ctx.Locals(localsKeyPrincipal, principal)
+		ctx.Locals(localsKeyUsername, principal.Username)
+		return ctx.Next()
+	}
+}
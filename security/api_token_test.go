@@ -0,0 +1,245 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	testAPIToken       = "ci-token"
+	testAPITokenSHA256 = "948b8c2427cd29047839b8e4a27a08763f8befbafa86be5cce8e46217d75e58a"
+)
+
+func TestAPIToken_isValid(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		apiTokens     []*APIToken
+		expectedValid bool
+	}{
+		{
+			name:          "no-tokens",
+			apiTokens:     nil,
+			expectedValid: true,
+		},
+		{
+			name:          "valid",
+			apiTokens:     []*APIToken{{Name: "ci", TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{APITokenScopeReadStatuses, APITokenScopeManageSilences}}},
+			expectedValid: true,
+		},
+		{
+			name:          "no-name",
+			apiTokens:     []*APIToken{{TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{APITokenScopeReadStatuses}}},
+			expectedValid: false,
+		},
+		{
+			name:          "no-scopes",
+			apiTokens:     []*APIToken{{Name: "ci", TokenSHA256: testAPITokenSHA256}},
+			expectedValid: false,
+		},
+		{
+			name:          "invalid-scope",
+			apiTokens:     []*APIToken{{Name: "ci", TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{"potato"}}},
+			expectedValid: false,
+		},
+		{
+			name:          "token-instead-of-hash",
+			apiTokens:     []*APIToken{{Name: "ci", TokenSHA256: testAPIToken, Scopes: []APITokenScope{APITokenScopeReadStatuses}}},
+			expectedValid: false,
+		},
+		{
+			name:          "hash-of-wrong-length",
+			apiTokens:     []*APIToken{{Name: "ci", TokenSHA256: testAPITokenSHA256[:32], Scopes: []APITokenScope{APITokenScopeReadStatuses}}},
+			expectedValid: false,
+		},
+		{
+			name: "duplicate-names",
+			apiTokens: []*APIToken{
+				{Name: "ci", TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{APITokenScopeReadStatuses}},
+				{Name: "ci", TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{APITokenScopeManageSilences}},
+			},
+			expectedValid: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if valid := isValidAPITokens(scenario.apiTokens); valid != scenario.expectedValid {
+				t.Errorf("expected valid to be %v, got %v", scenario.expectedValid, valid)
+			}
+		})
+	}
+}
+
+func TestAPIToken_principal(t *testing.T) {
+	apiToken := &APIToken{Name: "ci", Scopes: []APITokenScope{APITokenScopeReadStatuses}}
+	if principal := apiToken.principal(); principal.Username != "ci" || principal.Role != RoleOperator || !principal.CanSeeAllGroups() {
+		t.Errorf("expected token without endpoint groups to be able to see every group, got %+v", principal)
+	}
+	apiToken.EndpointGroups = []string{}
+	if principal := apiToken.principal(); !principal.CanSeeAllGroups() {
+		t.Error("expected token with empty endpoint groups to be able to see every group")
+	}
+	apiToken.EndpointGroups = []string{"team-a", "team-a-*"}
+	principal := apiToken.principal()
+	if principal.CanSeeAllGroups() || !principal.CanSeeGroup("team-a") || !principal.CanSeeGroup("team-a-frontend") || principal.CanSeeGroup("team-b") {
+		t.Errorf("expected token to only be able to see the groups matching its endpoint groups, got %+v", principal)
+	}
+}
+
+func TestConfig_AuthorizeAPIToken(t *testing.T) {
+	defer store.Get().Clear()
+	c := &Config{APITokens: []*APIToken{
+		{Name: "ci", TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{APITokenScopePushExternalResults}},
+	}}
+	if !c.ValidateAndSetDefaults() {
+		t.Fatal("expected config to be valid")
+	}
+	if !c.AuthorizeAPIToken(testAPIToken, APITokenScopePushExternalResults, "core") {
+		t.Error("expected token to be authorized for its scope")
+	}
+	if c.AuthorizeAPIToken(testAPIToken, APITokenScopeManageSilences, "core") {
+		t.Error("expected token to not be authorized for a scope it doesn't have")
+	}
+	if c.AuthorizeAPIToken("bad-token", APITokenScopePushExternalResults, "core") {
+		t.Error("expected unknown token to not be authorized")
+	}
+	c.APITokens[0].EndpointGroups = []string{"team-*"}
+	if !c.AuthorizeAPIToken(testAPIToken, APITokenScopePushExternalResults, "team-a") {
+		t.Error("expected token to be authorized for a group it can see")
+	}
+	if c.AuthorizeAPIToken(testAPIToken, APITokenScopePushExternalResults, "core") {
+		t.Error("expected token to not be authorized for a group it can't see")
+	}
+	c.APITokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+	if c.AuthorizeAPIToken(testAPIToken, APITokenScopePushExternalResults, "core") {
+		t.Error("expected expired token to not be authorized")
+	}
+}
+
+func TestConfig_ApplySecurityMiddlewareWithAPITokens(t *testing.T) {
+	defer store.Get().Clear()
+	c := &Config{
+		Basic: &BasicConfig{
+			Username:                        "john.doe",
+			PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+		},
+		APITokens: []*APIToken{
+			{Name: "ci", TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{APITokenScopeReadStatuses}},
+		},
+	}
+	if !c.ValidateAndSetDefaults() {
+		t.Fatal("expected config to be valid")
+	}
+	app := fiber.New()
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	app.Get("/test", RequireScope(APITokenScopeReadStatuses), func(c *fiber.Ctx) error {
+		return c.SendString(GetPrincipal(c).Username)
+	})
+	app.Post("/test", RequireScope(APITokenScopeManageSilences), func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	scenarios := []struct {
+		name          string
+		method        string
+		authorization string
+		basicAuth     bool
+		expectedCode  int
+	}{
+		{
+			name:         "unauthenticated",
+			method:       "GET",
+			expectedCode: 401,
+		},
+		{
+			name:          "api-token",
+			method:        "GET",
+			authorization: "Bearer " + testAPIToken,
+			expectedCode:  200,
+		},
+		{
+			name:          "bad-api-token",
+			method:        "GET",
+			authorization: "Bearer bad-token",
+			expectedCode:  401,
+		},
+		{
+			name:          "api-token-without-scope",
+			method:        "POST",
+			authorization: "Bearer " + testAPIToken,
+			expectedCode:  403,
+		},
+		{
+			name:         "basic-auth",
+			method:       "GET",
+			basicAuth:    true,
+			expectedCode: 200,
+		},
+		{
+			name:         "basic-auth-is-not-limited-by-scopes",
+			method:       "POST",
+			basicAuth:    true,
+			expectedCode: 200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.method, "/test", http.NoBody)
+			if len(scenario.authorization) > 0 {
+				request.Header.Set("Authorization", scenario.authorization)
+			}
+			if scenario.basicAuth {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if response.StatusCode != scenario.expectedCode {
+				t.Errorf("expected code to be %d, but was %d", scenario.expectedCode, response.StatusCode)
+			}
+		})
+	}
+	lastUsedTimes, err := store.Get().GetAllAPITokenLastUsedTimes()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if lastUsedAt, exists := lastUsedTimes["ci"]; !exists || time.Since(lastUsedAt) > time.Minute {
+		t.Errorf("expected the last time the API token was used to be recorded, got %v", lastUsedTimes)
+	}
+}
+
+func TestConfig_ApplySecurityMiddlewareWithOnlyAPITokens(t *testing.T) {
+	defer store.Get().Clear()
+	c := &Config{APITokens: []*APIToken{{Name: "ci", TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{APITokenScopeReadStatuses}}}}
+	if !c.ValidateAndSetDefaults() {
+		t.Fatal("expected config to be valid")
+	}
+	app := fiber.New()
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	response, err := app.Test(httptest.NewRequest("GET", "/test", http.NoBody))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if response.StatusCode != 401 {
+		t.Error("expected code to be 401, but was", response.StatusCode)
+	}
+	request := httptest.NewRequest("GET", "/test", http.NoBody)
+	request.Header.Set("Authorization", "Bearer "+testAPIToken)
+	if response, err = app.Test(request); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if response.StatusCode != 200 {
+		t.Error("expected code to be 200, but was", response.StatusCode)
+	}
+}
@@ -0,0 +1,213 @@
+package security
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/pattern"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// localsKeyPrincipal is the key under which the principal of an authenticated request is stored in its locals
+	localsKeyPrincipal = "principal"
+
+	// localsKeyUsername is the key under which the username of an authenticated request is stored in its locals.
+	// This is also the key used by the basic authentication middleware.
+	localsKeyUsername = "username"
+)
+
+// Role determines what a user is allowed to do
+type Role string
+
+const (
+	// RoleViewer can see the status of endpoints and suites
+	RoleViewer Role = "viewer"
+
+	// RoleOperator can do everything a viewer can, as well as silence and acknowledge alerts, and manage maintenance
+	// windows and incidents
+	RoleOperator Role = "operator"
+
+	// RoleAdmin can do everything
+	RoleAdmin Role = "admin"
+)
+
+// level returns the level of the role, which is higher for roles that grant more permissions, or 0 if the role is
+// invalid
+func (role Role) level() int {
+	switch role {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// AuthorizationConfig is the configuration for determining the role of authenticated users and which endpoint groups
+// they can see
+type AuthorizationConfig struct {
+	// DefaultRole is the role given to the authenticated users that aren't matched by any rule, in which case they can
+	// see every group. If blank, these users are denied access.
+	DefaultRole Role `yaml:"default-role,omitempty"`
+
+	// Rules determine the role of the users they match, as well as which endpoint groups these users can see
+	Rules []*AuthorizationRule `yaml:"rules,omitempty"`
+}
+
+// AuthorizationRule grants a role to the users whose subject or groups match the rule
+type AuthorizationRule struct {
+	// Role is the role granted to the users matched by the rule
+	Role Role `yaml:"role"`
+
+	// Subjects are the OIDC subjects or basic authentication usernames of the users matched by the rule
+	Subjects []string `yaml:"subjects,omitempty"`
+
+	// Groups are the groups of the users matched by the rule, as listed in the groups claim of the OIDC ID token
+	Groups []string `yaml:"groups,omitempty"`
+
+	// EndpointGroups are the patterns of the groups of the endpoints and suites that the users matched by the rule can
+	// see (e.g. team-a-*). If empty, these users can see every group.
+	EndpointGroups []string `yaml:"endpoint-groups,omitempty"`
+}
+
+// isValid returns whether the authorization configuration is valid
+func (c *AuthorizationConfig) isValid() bool {
+	if len(c.DefaultRole) > 0 && c.DefaultRole.level() == 0 {
+		return false
+	}
+	for _, rule := range c.Rules {
+		if rule.Role.level() == 0 || (len(rule.Subjects) == 0 && len(rule.Groups) == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// getPrincipal returns the principal of the user with the given username and groups, or nil if the user isn't
+// matched by any rule and there's no default role
+func (c *AuthorizationConfig) getPrincipal(username string, groups []string) *Principal {
+	if c == nil {
+		// Without authorization configuration, every authenticated user can do everything
+		return &Principal{Username: username, Role: RoleAdmin}
+	}
+	var principal *Principal
+	canSeeAllGroups := false
+	for _, rule := range c.Rules {
+		if !rule.matches(username, groups) {
+			continue
+		}
+		if principal == nil {
+			principal = &Principal{Username: username, Role: rule.Role}
+		} else if rule.Role.level() > principal.Role.level() {
+			principal.Role = rule.Role
+		}
+		if len(rule.EndpointGroups) == 0 {
+			canSeeAllGroups = true
+		}
+		principal.EndpointGroups = append(principal.EndpointGroups, rule.EndpointGroups...)
+	}
+	if principal == nil {
+		if len(c.DefaultRole) == 0 {
+			return nil
+		}
+		return &Principal{Username: username, Role: c.DefaultRole}
+	}
+	if canSeeAllGroups {
+		principal.EndpointGroups = nil
+	}
+	return principal
+}
+
+// matches returns whether the user with the given username and groups is matched by the rule
+func (rule *AuthorizationRule) matches(username string, groups []string) bool {
+	for _, subject := range rule.Subjects {
+		if strings.EqualFold(subject, username) {
+			return true
+		}
+	}
+	for _, ruleGroup := range rule.Groups {
+		for _, group := range groups {
+			if ruleGroup == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Principal is an authenticated user, along with what the user is allowed to do
+//
+// A nil Principal is allowed to do everything, as it's what requests have when security isn't configured.
+type Principal struct {
+	// Username is the OIDC subject or the basic authentication username of the user
+	Username string
+
+	// Role is the role of the user
+	Role Role
+
+	// EndpointGroups are the patterns of the groups of the endpoints and suites that the user can see.
+	// If nil, the user can see every group.
+	EndpointGroups []string
+
+	// Scopes are what the principal can do if it's an API token. If nil, the principal isn't limited to any scope.
+	Scopes []APITokenScope
+}
+
+// HasRole returns whether the principal has at least the permissions of the given role
+func (p *Principal) HasRole(role Role) bool {
+	return p == nil || p.Role.level() >= role.level()
+}
+
+// HasScope returns whether the principal is allowed to do what the given scope covers
+func (p *Principal) HasScope(scope APITokenScope) bool {
+	return p == nil || p.Scopes == nil || slices.Contains(p.Scopes, scope)
+}
+
+// CanSeeAllGroups returns whether the principal can see the endpoints and suites of every group
+func (p *Principal) CanSeeAllGroups() bool {
+	return p == nil || p.EndpointGroups == nil
+}
+
+// CanSeeGroup returns whether the principal can see the endpoints and suites of the given group
+func (p *Principal) CanSeeGroup(group string) bool {
+	if p.CanSeeAllGroups() {
+		return true
+	}
+	for _, endpointGroup := range p.EndpointGroups {
+		if pattern.Match(endpointGroup, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPrincipal returns the principal of the request, or nil if the request hasn't gone through the security
+// middleware, e.g. because security isn't configured
+func GetPrincipal(ctx *fiber.Ctx) *Principal {
+	principal, _ := ctx.Locals(localsKeyPrincipal).(*Principal)
+	return principal
+}
+
+// RequireRole returns a handler that only lets through the requests whose principal has at least the given role
+func RequireRole(role Role) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !GetPrincipal(ctx).HasRole(role) {
+			return ctx.Status(403).SendString("Forbidden")
+		}
+		return ctx.Next()
+	}
+}
+
+// RequireScope returns a handler that only lets through the requests whose principal is allowed to do what the given
+// scope covers, which is always the case for the principals that aren't API tokens
+func RequireScope(scope APITokenScope) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !GetPrincipal(ctx).HasScope(scope) {
+			return ctx.Status(403).SendString("Forbidden")
+		}
+		return ctx.Next()
+	}
+}
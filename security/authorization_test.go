@@ -0,0 +1,213 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAuthorizationConfig_isValid(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		config        *AuthorizationConfig
+		expectedValid bool
+	}{
+		{
+			name:          "empty",
+			config:        &AuthorizationConfig{},
+			expectedValid: true,
+		},
+		{
+			name:          "valid",
+			config:        &AuthorizationConfig{DefaultRole: RoleViewer, Rules: []*AuthorizationRule{{Role: RoleAdmin, Subjects: []string{"john.doe"}}, {Role: RoleOperator, Groups: []string{"team-a"}, EndpointGroups: []string{"team-a-*"}}}},
+			expectedValid: true,
+		},
+		{
+			name:          "invalid-default-role",
+			config:        &AuthorizationConfig{DefaultRole: "potato"},
+			expectedValid: false,
+		},
+		{
+			name:          "rule-with-invalid-role",
+			config:        &AuthorizationConfig{Rules: []*AuthorizationRule{{Role: "potato", Subjects: []string{"john.doe"}}}},
+			expectedValid: false,
+		},
+		{
+			name:          "rule-without-subjects-or-groups",
+			config:        &AuthorizationConfig{Rules: []*AuthorizationRule{{Role: RoleViewer, EndpointGroups: []string{"team-a"}}}},
+			expectedValid: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if valid := scenario.config.isValid(); valid != scenario.expectedValid {
+				t.Errorf("expected valid to be %v, got %v", scenario.expectedValid, valid)
+			}
+		})
+	}
+}
+
+func TestAuthorizationConfig_getPrincipal(t *testing.T) {
+	config := &AuthorizationConfig{
+		Rules: []*AuthorizationRule{
+			{Role: RoleAdmin, Subjects: []string{"Jane.Doe@example.com"}},
+			{Role: RoleOperator, Groups: []string{"team-a"}, EndpointGroups: []string{"team-a-*"}},
+			{Role: RoleViewer, Groups: []string{"team-b"}, EndpointGroups: []string{"team-b"}},
+		},
+	}
+	scenarios := []struct {
+		name                   string
+		config                 *AuthorizationConfig
+		username               string
+		groups                 []string
+		expectedNil            bool
+		expectedRole           Role
+		expectedEndpointGroups []string
+	}{
+		{
+			name:         "no-authorization-config",
+			config:       nil,
+			username:     "john.doe",
+			expectedRole: RoleAdmin,
+		},
+		{
+			name:         "matched-by-subject-case-insensitively",
+			config:       config,
+			username:     "jane.doe@example.com",
+			expectedRole: RoleAdmin,
+		},
+		{
+			name:                   "matched-by-group",
+			config:                 config,
+			username:               "john.doe",
+			groups:                 []string{"team-a"},
+			expectedRole:           RoleOperator,
+			expectedEndpointGroups: []string{"team-a-*"},
+		},
+		{
+			name:                   "matched-by-multiple-rules",
+			config:                 config,
+			username:               "john.doe",
+			groups:                 []string{"team-b", "team-a"},
+			expectedRole:           RoleOperator,
+			expectedEndpointGroups: []string{"team-a-*", "team-b"},
+		},
+		{
+			name:         "matched-by-rule-without-endpoint-groups",
+			config:       config,
+			username:     "jane.doe@example.com",
+			groups:       []string{"team-a"},
+			expectedRole: RoleAdmin,
+		},
+		{
+			name:        "not-matched-without-default-role",
+			config:      config,
+			username:    "john.doe",
+			groups:      []string{"team-c"},
+			expectedNil: true,
+		},
+		{
+			name:         "not-matched-with-default-role",
+			config:       &AuthorizationConfig{DefaultRole: RoleViewer, Rules: config.Rules},
+			username:     "john.doe",
+			expectedRole: RoleViewer,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			principal := scenario.config.getPrincipal(scenario.username, scenario.groups)
+			if scenario.expectedNil {
+				if principal != nil {
+					t.Errorf("expected no principal, got %+v", principal)
+				}
+				return
+			}
+			if principal == nil {
+				t.Fatal("expected a principal, got nil")
+			}
+			if principal.Username != scenario.username {
+				t.Errorf("expected username %s, got %s", scenario.username, principal.Username)
+			}
+			if principal.Role != scenario.expectedRole {
+				t.Errorf("expected role %s, got %s", scenario.expectedRole, principal.Role)
+			}
+			if !slices.Equal(principal.EndpointGroups, scenario.expectedEndpointGroups) {
+				t.Errorf("expected endpoint groups %v, got %v", scenario.expectedEndpointGroups, principal.EndpointGroups)
+			}
+		})
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	var nilPrincipal *Principal
+	if !nilPrincipal.HasRole(RoleAdmin) || !nilPrincipal.CanSeeAllGroups() || !nilPrincipal.CanSeeGroup("core") {
+		t.Error("expected a nil principal to be allowed to do everything")
+	}
+	viewer := &Principal{Username: "john.doe", Role: RoleViewer, EndpointGroups: []string{"team-a-*", "core"}}
+	if !viewer.HasRole(RoleViewer) || viewer.HasRole(RoleOperator) || viewer.HasRole(RoleAdmin) {
+		t.Error("expected viewer to only have the viewer role")
+	}
+	if viewer.CanSeeAllGroups() {
+		t.Error("expected viewer to not see all groups")
+	}
+	if !viewer.CanSeeGroup("team-a-frontend") || !viewer.CanSeeGroup("core") || viewer.CanSeeGroup("team-b") || viewer.CanSeeGroup("") {
+		t.Error("expected viewer to only see the groups matching its endpoint groups")
+	}
+	admin := &Principal{Username: "jane.doe", Role: RoleAdmin}
+	if !admin.HasRole(RoleOperator) || !admin.CanSeeAllGroups() || !admin.CanSeeGroup("team-b") {
+		t.Error("expected admin to have every role and to see every group")
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	scenarios := []struct {
+		name         string
+		principal    *Principal
+		expectedCode int
+	}{
+		{
+			name:         "no-principal",
+			principal:    nil,
+			expectedCode: 200,
+		},
+		{
+			name:         "viewer",
+			principal:    &Principal{Username: "john.doe", Role: RoleViewer},
+			expectedCode: 403,
+		},
+		{
+			name:         "operator",
+			principal:    &Principal{Username: "john.doe", Role: RoleOperator},
+			expectedCode: 200,
+		},
+		{
+			name:         "admin",
+			principal:    &Principal{Username: "john.doe", Role: RoleAdmin},
+			expectedCode: 200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				if scenario.principal != nil {
+					c.Locals(localsKeyPrincipal, scenario.principal)
+				}
+				return c.Next()
+			})
+			app.Post("/test", RequireRole(RoleOperator), func(c *fiber.Ctx) error {
+				return c.SendStatus(200)
+			})
+			response, err := app.Test(httptest.NewRequest("POST", "/test", http.NoBody))
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if response.StatusCode != scenario.expectedCode {
+				t.Errorf("expected code to be %d, but was %d", scenario.expectedCode, response.StatusCode)
+			}
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package security
 
+import (
+	"encoding/base64"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
 // BasicConfig is the configuration for Basic authentication
 type BasicConfig struct {
 	// Username is the name which will need to be used for a successful authentication
@@ -8,9 +15,68 @@ type BasicConfig struct {
 	// PasswordBcryptHashBase64Encoded is the base64 encoded string of the Bcrypt hash of the password to use to
 	// authenticate using basic auth.
 	PasswordBcryptHashBase64Encoded string `yaml:"password-bcrypt-base64"`
+
+	// Users is the list of additional users that can authenticate using basic auth, which is useful for giving them
+	// different roles (see AuthorizationConfig)
+	Users []*BasicUser `yaml:"users,omitempty"`
+}
+
+// BasicUser is a user that can authenticate using basic auth
+type BasicUser struct {
+	// Username is the name which will need to be used for a successful authentication
+	Username string `yaml:"username"`
+
+	// PasswordBcryptHashBase64Encoded is the base64 encoded string of the Bcrypt hash of the password of the user
+	PasswordBcryptHashBase64Encoded string `yaml:"password-bcrypt-base64"`
 }
 
 // isValid returns whether the basic security configuration is valid or not
 func (c *BasicConfig) isValid() bool {
-	return len(c.Username) > 0 && len(c.PasswordBcryptHashBase64Encoded) > 0
+	// The username and password may be omitted if there are additional users, but not one without the other
+	hasUsernameOrPassword := len(c.Username) > 0 || len(c.PasswordBcryptHashBase64Encoded) > 0
+	if (hasUsernameOrPassword || len(c.Users) == 0) && (len(c.Username) == 0 || len(c.PasswordBcryptHashBase64Encoded) == 0) {
+		return false
+	}
+	for _, user := range c.Users {
+		if len(user.Username) == 0 || len(user.PasswordBcryptHashBase64Encoded) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// getUsers returns every user that can authenticate using basic auth
+func (c *BasicConfig) getUsers() []*BasicUser {
+	var users []*BasicUser
+	if len(c.Username) > 0 {
+		users = append(users, &BasicUser{Username: c.Username, PasswordBcryptHashBase64Encoded: c.PasswordBcryptHashBase64Encoded})
+	}
+	return append(users, c.Users...)
+}
+
+// authenticate returns the username of the user whose credentials are in the basic authorization header passed, or an
+// empty string if the header doesn't have the credentials of any user
+func (c *BasicConfig) authenticate(authorizationHeader string) string {
+	encodedCredentials, found := strings.CutPrefix(authorizationHeader, "Basic ")
+	if !found {
+		return ""
+	}
+	credentials, err := base64.StdEncoding.DecodeString(encodedCredentials)
+	if err != nil {
+		return ""
+	}
+	username, password, found := strings.Cut(string(credentials), ":")
+	if !found {
+		return ""
+	}
+	for _, user := range c.getUsers() {
+		if user.Username != username {
+			continue
+		}
+		decodedBcryptHash, err := base64.URLEncoding.DecodeString(user.PasswordBcryptHashBase64Encoded)
+		if err == nil && bcrypt.CompareHashAndPassword(decodedBcryptHash, []byte(password)) == nil {
+			return username
+		}
+	}
+	return ""
 }
@@ -21,3 +21,19 @@ func TestBasicConfig_IsValidWhenPasswordIsInvalidUsingBcrypt(t *testing.T) {
 		t.Error("basicConfig shouldn't have been valid")
 	}
 }
+
+func TestBasicConfig_IsValidWithUsers(t *testing.T) {
+	user := &BasicUser{Username: "jane.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"}
+	if !(&BasicConfig{Users: []*BasicUser{user}}).isValid() {
+		t.Error("basicConfig with only users should've been valid")
+	}
+	if !(&BasicConfig{Username: "admin", PasswordBcryptHashBase64Encoded: user.PasswordBcryptHashBase64Encoded, Users: []*BasicUser{user}}).isValid() {
+		t.Error("basicConfig with username and users should've been valid")
+	}
+	if (&BasicConfig{Username: "admin", Users: []*BasicUser{user}}).isValid() {
+		t.Error("basicConfig with username but without password shouldn't have been valid")
+	}
+	if (&BasicConfig{Users: []*BasicUser{{Username: "jane.doe"}}}).isValid() {
+		t.Error("basicConfig with user without password shouldn't have been valid")
+	}
+}
@@ -2,9 +2,9 @@ package security
 
 import (
 	"encoding/base64"
-	"net/http"
+	"strings"
 
-	g8 "github.com/TwiN/g8/v2"
+	"github.com/TwiN/gatus/v5/security/session"
 	"github.com/TwiN/logr"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
@@ -23,12 +23,17 @@ type Config struct {
 	Basic *BasicConfig `yaml:"basic,omitempty"`
 	OIDC  *OIDCConfig  `yaml:"oidc,omitempty"`
 
-	gate *g8.Gate
+	// Authorization determines the role of authenticated users and which endpoint groups they can see.
+	// If nil, every authenticated user is an admin and can see every group.
+	Authorization *AuthorizationConfig `yaml:"authorization,omitempty"`
+
+	// APITokens are the bearer tokens accepted alongside basic authentication and OIDC, e.g. for scripts
+	APITokens []*APIToken `yaml:"api-tokens,omitempty"`
 }
 
 // ValidateAndSetDefaults returns whether the security configuration is valid or not and sets default values.
 func (c *Config) ValidateAndSetDefaults() bool {
-	return (c.Basic == nil || c.Basic.isValid()) && (c.OIDC == nil || c.OIDC.ValidateAndSetDefaults())
+	return (c.Basic == nil || c.Basic.isValid()) && (c.OIDC == nil || c.OIDC.ValidateAndSetDefaults()) && (c.Authorization == nil || c.Authorization.isValid()) && isValidAPITokens(c.APITokens)
 }
 
 // RegisterHandlers registers all handlers required based on the security configuration
@@ -39,72 +44,106 @@ func (c *Config) RegisterHandlers(router fiber.Router) error {
 		}
 		router.All("/oidc/login", c.OIDC.loginHandler)
 		router.All("/authorization-code/callback", adaptor.HTTPHandlerFunc(c.OIDC.callbackHandler))
+		router.All("/oidc/logout", c.OIDC.logoutHandler)
 	}
 	return nil
 }
 
-// ApplySecurityMiddleware applies an authentication middleware to the router passed.
+// ApplySecurityMiddleware applies an authentication middleware to the router passed, followed by a middleware that
+// determines the principal of each authenticated request (see GetPrincipal).
 // The router passed should be a sub-router in charge of handlers that require authentication.
 func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
+	var authenticate fiber.Handler
 	if c.OIDC != nil {
-		// We're going to use g8 for session handling
-		clientProvider := g8.NewClientProvider(func(token string) *g8.Client {
-			if _, exists := sessions.Get(token); exists {
-				return g8.NewClient(token)
-			}
-			return nil
-		})
-		customTokenExtractorFunc := func(request *http.Request) string {
-			sessionCookie, err := request.Cookie(cookieNameSession)
-			if err != nil {
-				return ""
-			}
-			return sessionCookie.Value
-		}
-		// TODO: g8: Add a way to update cookie after? would need the writer
-		authorizationService := g8.NewAuthorizationService().WithClientProvider(clientProvider)
-		c.gate = g8.New().WithAuthorizationService(authorizationService).WithCustomTokenExtractor(customTokenExtractorFunc)
-		router.Use(adaptor.HTTPMiddleware(c.gate.Protect))
+		authenticate = c.OIDC.authenticateSession
 	} else if c.Basic != nil {
-		var decodedBcryptHash []byte
-		if len(c.Basic.PasswordBcryptHashBase64Encoded) > 0 {
-			var err error
-			decodedBcryptHash, err = base64.URLEncoding.DecodeString(c.Basic.PasswordBcryptHashBase64Encoded)
+		decodedBcryptHashByUsername := make(map[string][]byte)
+		for _, user := range c.Basic.getUsers() {
+			decodedBcryptHash, err := base64.URLEncoding.DecodeString(user.PasswordBcryptHashBase64Encoded)
 			if err != nil {
 				return err
 			}
+			decodedBcryptHashByUsername[user.Username] = decodedBcryptHash
 		}
-		router.Use(basicauth.New(basicauth.Config{
+		authenticate = basicauth.New(basicauth.Config{
 			Authorizer: func(username, password string) bool {
-				if len(c.Basic.PasswordBcryptHashBase64Encoded) > 0 {
-					if username != c.Basic.Username || bcrypt.CompareHashAndPassword(decodedBcryptHash, []byte(password)) != nil {
-						return false
-					}
-				}
-				return true
+				decodedBcryptHash, exists := decodedBcryptHashByUsername[username]
+				return exists && bcrypt.CompareHashAndPassword(decodedBcryptHash, []byte(password)) == nil
 			},
 			Unauthorized: func(ctx *fiber.Ctx) error {
 				ctx.Set("WWW-Authenticate", "Basic")
 				return ctx.Status(401).SendString("Unauthorized")
 			},
-		}))
+		})
+	}
+	if len(c.APITokens) > 0 {
+		// Requests with a bearer token are authenticated with the API tokens, and every other request is authenticated
+		// with basic authentication or OIDC, if configured
+		authenticate = c.authenticateAPIToken(authenticate)
+	}
+	if authenticate != nil {
+		router.Use(authenticate)
+		router.Use(c.authorize)
 	}
 	return nil
 }
 
+// authorize determines the principal of an authenticated request, and denies access if the user has no role
+func (c *Config) authorize(ctx *fiber.Ctx) error {
+	if GetPrincipal(ctx) != nil {
+		// The request has been authenticated with an API token, which isn't subject to the authorization rules
+		return ctx.Next()
+	}
+	var username string
+	var groups []string
+	if c.OIDC != nil {
+		if sess, ok := ctx.Locals(localsKeySession).(*session.Session); ok {
+			username, groups = sess.Subject, sess.Groups
+		}
+	} else {
+		username, _ = ctx.Locals(localsKeyUsername).(string)
+	}
+	principal := c.Authorization.getPrincipal(username, groups)
+	if principal == nil {
+		logr.Debugf("[security.authorize] User %s has no role", username)
+		return ctx.Status(403).SendString("Forbidden")
+	}
+	ctx.Locals(localsKeyPrincipal, principal)
+	ctx.Locals(localsKeyUsername, principal.Username)
+	return ctx.Next()
+}
+
 // IsAuthenticated checks whether the user is authenticated
 // If the Config does not warrant authentication, it will always return true.
 func (c *Config) IsAuthenticated(ctx *fiber.Ctx) bool {
-	if c.gate != nil {
-		// TODO: Update g8 to support fasthttp natively? (see g8's fasthttp branch)
-		request, err := adaptor.ConvertRequest(ctx, false)
-		if err != nil {
-			logr.Errorf("[security.IsAuthenticated] Unexpected error converting request: %v", err)
-			return false
-		}
-		token := c.gate.ExtractTokenFromRequest(request)
-		_, hasSession := sessions.Get(token)
-		return hasSession
+	if c.OIDC != nil {
+		return getSession(ctx.Cookies(cookieNameSession)) != nil
 	}
 	return false
 }
+
+// ResolvePrincipal returns the principal of a request that hasn't gone through the security middleware, as well as
+// whether the request is authenticated by any of the configured means of authentication and authorized.
+// This is meant for the routes that aren't behind the security middleware, but whose response depends on what the
+// principal of the request can see.
+func (c *Config) ResolvePrincipal(ctx *fiber.Ctx) (*Principal, bool) {
+	authorizationHeader := ctx.Get(fiber.HeaderAuthorization)
+	if len(c.APITokens) > 0 && strings.HasPrefix(authorizationHeader, "Bearer ") {
+		apiToken := c.getAPIToken(strings.TrimSpace(strings.TrimPrefix(authorizationHeader, "Bearer ")))
+		if apiToken == nil {
+			return nil, false
+		}
+		return apiToken.principal(), true
+	}
+	var principal *Principal
+	if c.OIDC != nil {
+		if sess := getSession(ctx.Cookies(cookieNameSession)); sess != nil {
+			principal = c.Authorization.getPrincipal(sess.Subject, sess.Groups)
+		}
+	} else if c.Basic != nil {
+		if username := c.Basic.authenticate(authorizationHeader); len(username) > 0 {
+			principal = c.Authorization.getPrincipal(username, nil)
+		}
+	}
+	return principal, principal != nil
+}
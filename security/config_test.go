@@ -1,8 +1,10 @@
 package security
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -68,6 +70,22 @@ func TestConfig_ValidateAndSetDefaults(t *testing.T) {
 			},
 			ExpectValid: true,
 		},
+		{
+			Name: "valid-basic-with-authorization",
+			Config: &Config{
+				Basic:         validBasicConfig,
+				Authorization: &AuthorizationConfig{DefaultRole: RoleViewer},
+			},
+			ExpectValid: true,
+		},
+		{
+			Name: "invalid-authorization",
+			Config: &Config{
+				Basic:         validBasicConfig,
+				Authorization: &AuthorizationConfig{DefaultRole: "potato"},
+			},
+			ExpectValid: false,
+		},
 		{
 			Name: "valid-basic-and-oidc",
 			Config: &Config{
@@ -126,6 +144,57 @@ func TestConfig_ApplySecurityMiddleware(t *testing.T) {
 			t.Error("expected code to be 200, but was", response.StatusCode)
 		}
 	})
+	t.Run("basic-with-users-and-authorization", func(t *testing.T) {
+		passwordBcryptHashBase64Encoded := "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"
+		c := &Config{
+			Basic: &BasicConfig{Users: []*BasicUser{
+				{Username: "john.doe", PasswordBcryptHashBase64Encoded: passwordBcryptHashBase64Encoded},
+				{Username: "jane.doe", PasswordBcryptHashBase64Encoded: passwordBcryptHashBase64Encoded},
+			}},
+			Authorization: &AuthorizationConfig{Rules: []*AuthorizationRule{{Role: RoleViewer, Subjects: []string{"john.doe"}, EndpointGroups: []string{"core"}}}},
+		}
+		app := fiber.New()
+		if err := c.ApplySecurityMiddleware(app); err != nil {
+			t.Error("expected no error, got", err)
+		}
+		app.Get("/test", func(c *fiber.Ctx) error {
+			principal := GetPrincipal(c)
+			if principal == nil || principal.Username != "john.doe" || principal.Role != RoleViewer || !principal.CanSeeGroup("core") || principal.CanSeeGroup("other") {
+				return c.SendStatus(500)
+			}
+			return c.SendStatus(200)
+		})
+		// john.doe is matched by a rule
+		request := httptest.NewRequest("GET", "/test", http.NoBody)
+		request.SetBasicAuth("john.doe", "hunter2")
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if response.StatusCode != 200 {
+			t.Error("expected code to be 200, but was", response.StatusCode)
+		}
+		// jane.doe can authenticate, but isn't matched by any rule and there's no default role
+		request = httptest.NewRequest("GET", "/test", http.NoBody)
+		request.SetBasicAuth("jane.doe", "hunter2")
+		response, err = app.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if response.StatusCode != 403 {
+			t.Error("expected code to be 403, but was", response.StatusCode)
+		}
+		// Unknown users can't authenticate
+		request = httptest.NewRequest("GET", "/test", http.NoBody)
+		request.SetBasicAuth("bob", "hunter2")
+		response, err = app.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if response.StatusCode != 401 {
+			t.Error("expected code to be 401, but was", response.StatusCode)
+		}
+	})
 	//////////
 	// OIDC //
 	//////////
@@ -205,3 +274,78 @@ func TestConfig_RegisterHandlers(t *testing.T) {
 		t.Error("expected code to be 302, but was", response.StatusCode)
 	}
 }
+
+func TestConfig_ResolvePrincipal(t *testing.T) {
+	c := &Config{
+		Basic: &BasicConfig{Users: []*BasicUser{
+			{Username: "john.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"},
+			{Username: "jane.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"},
+		}},
+		Authorization: &AuthorizationConfig{Rules: []*AuthorizationRule{
+			{Role: RoleViewer, Subjects: []string{"john.doe"}, EndpointGroups: []string{"team-a"}},
+		}},
+		APITokens: []*APIToken{{Name: "ci", TokenSHA256: testAPITokenSHA256, Scopes: []APITokenScope{APITokenScopeReadStatuses}, EndpointGroups: []string{"team-b"}}},
+	}
+	if !c.ValidateAndSetDefaults() {
+		t.Fatal("expected the security configuration to be valid")
+	}
+	app := fiber.New()
+	app.Get("/test", func(ctx *fiber.Ctx) error {
+		principal, ok := c.ResolvePrincipal(ctx)
+		if !ok {
+			return ctx.SendString("unauthorized")
+		}
+		return ctx.SendString(principal.Username + ":" + strings.Join(principal.EndpointGroups, ","))
+	})
+	scenarios := []struct {
+		name     string
+		prepare  func(request *http.Request)
+		expected string
+	}{
+		{
+			name:     "no-credentials",
+			prepare:  func(request *http.Request) {},
+			expected: "unauthorized",
+		},
+		{
+			name:     "basic-with-role",
+			prepare:  func(request *http.Request) { request.SetBasicAuth("john.doe", "hunter2") },
+			expected: "john.doe:team-a",
+		},
+		{
+			name:     "basic-with-invalid-password",
+			prepare:  func(request *http.Request) { request.SetBasicAuth("john.doe", "hunter3") },
+			expected: "unauthorized",
+		},
+		{
+			name:     "basic-without-role",
+			prepare:  func(request *http.Request) { request.SetBasicAuth("jane.doe", "hunter2") },
+			expected: "unauthorized",
+		},
+		{
+			name:     "api-token",
+			prepare:  func(request *http.Request) { request.Header.Set("Authorization", "Bearer "+testAPIToken) },
+			expected: "ci:team-b",
+		},
+		{
+			name:     "invalid-api-token",
+			prepare:  func(request *http.Request) { request.Header.Set("Authorization", "Bearer invalid") },
+			expected: "unauthorized",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/test", http.NoBody)
+			scenario.prepare(request)
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			body, _ := io.ReadAll(response.Body)
+			if string(body) != scenario.expected {
+				t.Errorf("expected %s, got %s", scenario.expected, body)
+			}
+		})
+	}
+}
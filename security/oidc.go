@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/logr"
@@ -15,6 +16,9 @@ import (
 
 const (
 	DefaultOIDCSessionTTL = 8 * time.Hour
+
+	// DefaultOIDCGroupsClaim is the default name of the claim of the ID token listing the groups of the user
+	DefaultOIDCGroupsClaim = "groups"
 )
 
 // OIDCConfig is the configuration for OIDC authentication
@@ -26,9 +30,12 @@ type OIDCConfig struct {
 	Scopes          []string      `yaml:"scopes"`           // e.g. ["openid"]
 	AllowedSubjects []string      `yaml:"allowed-subjects"` // e.g. ["user1@example.com"]. If empty, all subjects are allowed
 	SessionTTL      time.Duration `yaml:"session-ttl"`      // e.g. 8h. Defaults to 8 hours
+	GroupsClaim     string        `yaml:"groups-claim"`     // e.g. roles. Defaults to groups
 
-	oauth2Config oauth2.Config
-	verifier     *oidc.IDTokenVerifier
+	oauth2Config      oauth2.Config
+	verifier          *oidc.IDTokenVerifier
+	refreshLocks      map[string]*sessionRefreshLock // Prevents a session from being refreshed concurrently, see refreshSession
+	refreshLocksMutex sync.Mutex                     // Guards refreshLocks
 }
 
 // ValidateAndSetDefaults returns whether the OIDC configuration is valid and sets default values.
@@ -36,6 +43,9 @@ func (c *OIDCConfig) ValidateAndSetDefaults() bool {
 	if c.SessionTTL <= 0 {
 		c.SessionTTL = DefaultOIDCSessionTTL
 	}
+	if len(c.GroupsClaim) == 0 {
+		c.GroupsClaim = DefaultOIDCGroupsClaim
+	}
 	return len(c.IssuerURL) > 0 && len(c.RedirectURL) > 0 && strings.HasSuffix(c.RedirectURL, "/authorization-code/callback") && len(c.ClientID) > 0 && len(c.ClientSecret) > 0 && len(c.Scopes) > 0
 }
 
@@ -121,14 +131,12 @@ func (c *OIDCConfig) callbackHandler(w http.ResponseWriter, r *http.Request) { /
 	}
 	if len(c.AllowedSubjects) == 0 {
 		// If there's no allowed subjects, all subjects are allowed.
-		c.setSessionCookie(w, idToken)
-		http.Redirect(w, r, "/", http.StatusFound)
+		c.login(w, r, idToken, oauth2Token.RefreshToken)
 		return
 	}
 	for _, subject := range c.AllowedSubjects {
 		if strings.ToLower(subject) == strings.ToLower(idToken.Subject) {
-			c.setSessionCookie(w, idToken)
-			http.Redirect(w, r, "/", http.StatusFound)
+			c.login(w, r, idToken, oauth2Token.RefreshToken)
 			return
 		}
 	}
@@ -136,15 +144,39 @@ func (c *OIDCConfig) callbackHandler(w http.ResponseWriter, r *http.Request) { /
 	http.Redirect(w, r, "/?error=access_denied", http.StatusFound)
 }
 
-func (c *OIDCConfig) setSessionCookie(w http.ResponseWriter, idToken *oidc.IDToken) {
-	// At this point, the user has been confirmed. All that's left to do is create a session.
-	sessionID := uuid.NewString()
-	sessions.SetWithTTL(sessionID, idToken.Subject, c.SessionTTL)
-	http.SetCookie(w, &http.Cookie{
-		Name:     cookieNameSession,
-		Value:    sessionID,
-		Path:     "/",
-		MaxAge:   int(c.SessionTTL.Seconds()),
-		SameSite: http.SameSiteStrictMode,
-	})
+// login creates a session for the user of the ID token passed and redirects them to the dashboard
+func (c *OIDCConfig) login(w http.ResponseWriter, r *http.Request, idToken *oidc.IDToken, refreshToken string) {
+	if err := c.createSession(w, idToken.Subject, c.getGroups(idToken), refreshToken); err != nil {
+		logr.Errorf("[security.login] Failed to create session for subject %s: %s", idToken.Subject, err.Error())
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// getGroups returns the groups of the user listed in the groups claim of the ID token, if any
+func (c *OIDCConfig) getGroups(idToken *oidc.IDToken) []string {
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		logr.Debugf("[security.getGroups] Failed to parse claims of ID token: %s", err.Error())
+		return nil
+	}
+	return getGroupsFromClaim(claims[c.GroupsClaim])
+}
+
+// getGroupsFromClaim returns the groups listed in the value of a claim, which is either a list or a single group
+func getGroupsFromClaim(claim any) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var groups []string
+		for _, group := range value {
+			if groupAsString, ok := group.(string); ok {
+				groups = append(groups, groupAsString)
+			}
+		}
+		return groups
+	}
+	return nil
 }
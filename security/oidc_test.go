@@ -3,10 +3,12 @@ package security
 import (
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
-	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/gatus/v5/storage/store"
 )
 
 func TestOIDCConfig_ValidateAndSetDefaults(t *testing.T) {
@@ -65,26 +67,58 @@ func TestOIDCConfig_callbackHandler(t *testing.T) {
 	}
 }
 
-func TestOIDCConfig_setSessionCookie(t *testing.T) {
-	c := &OIDCConfig{}
+func TestOIDCConfig_createSession(t *testing.T) {
+	defer store.Get().Clear()
+	c := &OIDCConfig{ClientSecret: "client-secret", SessionTTL: DefaultOIDCSessionTTL}
 	responseRecorder := httptest.NewRecorder()
-	c.setSessionCookie(responseRecorder, &oidc.IDToken{Subject: "test@example.com"})
-	if len(responseRecorder.Result().Cookies()) == 0 {
-		t.Error("expected cookie to be set")
+	if err := c.createSession(responseRecorder, "test@example.com", []string{"team-a"}, "refresh-token"); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	cookies := responseRecorder.Result().Cookies()
+	if len(cookies) == 0 {
+		t.Fatal("expected cookie to be set")
+	}
+	sess, err := store.Get().GetSessionByID(session.IDFromToken(cookies[0].Value))
+	if err != nil {
+		t.Fatal("expected session to be stored, got", err)
+	}
+	if sess.RefreshToken == "refresh-token" {
+		t.Error("expected refresh token to not be stored in plaintext")
+	}
+	if refreshToken, _ := c.decryptRefreshToken(sess.RefreshToken); sess.Subject != "test@example.com" || !slices.Equal(sess.Groups, []string{"team-a"}) || refreshToken != "refresh-token" {
+		t.Errorf("expected session to be stored with the subject, groups and refresh token of the user, got %+v", sess)
 	}
 }
 
-func TestOIDCConfig_setSessionCookieWithCustomTTL(t *testing.T) {
+func TestOIDCConfig_createSessionWithCustomTTL(t *testing.T) {
+	defer store.Get().Clear()
 	customTTL := 30 * time.Minute
 	c := &OIDCConfig{SessionTTL: customTTL}
 	responseRecorder := httptest.NewRecorder()
-	c.setSessionCookie(responseRecorder, &oidc.IDToken{Subject: "test@example.com"})
+	if err := c.createSession(responseRecorder, "test@example.com", nil, ""); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
 	cookies := responseRecorder.Result().Cookies()
 	if len(cookies) == 0 {
-		t.Error("expected cookie to be set")
+		t.Fatal("expected cookie to be set")
 	}
 	sessionCookie := cookies[0]
 	if sessionCookie.MaxAge != int(customTTL.Seconds()) {
 		t.Errorf("expected cookie MaxAge to be %d, but was %d", int(customTTL.Seconds()), sessionCookie.MaxAge)
 	}
+	if sess := getSession(sessionCookie.Value); sess == nil || time.Until(sess.ExpiresAt) > customTTL {
+		t.Errorf("expected session to expire within %s, got %+v", customTTL, sess)
+	}
+}
+
+func TestGetGroupsFromClaim(t *testing.T) {
+	if groups := getGroupsFromClaim([]any{"team-a", 1, "team-b"}); !slices.Equal(groups, []string{"team-a", "team-b"}) {
+		t.Errorf("expected groups to be [team-a team-b], got %v", groups)
+	}
+	if groups := getGroupsFromClaim("team-a"); !slices.Equal(groups, []string{"team-a"}) {
+		t.Errorf("expected groups to be [team-a], got %v", groups)
+	}
+	if groups := getGroupsFromClaim(nil); groups != nil {
+		t.Errorf("expected no groups, got %v", groups)
+	}
 }
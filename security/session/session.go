@@ -0,0 +1,69 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Session is what is kept about a user authenticated through OIDC for as long as the session lasts
+type Session struct {
+	// ID is the hex-encoded SHA-256 hash of the token stored in the session cookie of the user.
+	// Only the hash is stored, so that the sessions can be listed and revoked without disclosing their token.
+	ID string `json:"id"`
+
+	// Subject is the subject of the ID token of the user
+	Subject string `json:"subject"`
+
+	// Groups are the groups of the user, as listed in the groups claim of the ID token
+	Groups []string `json:"groups,omitempty"`
+
+	// RefreshToken is the refresh token issued by the identity provider, if any, which is used to extend the session
+	// without redirecting the user to the identity provider.
+	// It's encrypted by the OIDC configuration before being stored, so it's never persisted in plaintext.
+	RefreshToken string `json:"-"`
+
+	// CreatedAt is when the user logged in
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt is when the session expires, unless it's extended before then
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// New creates a new session for the given subject, and returns it along with the token that identifies it, which
+// is meant to be stored in the session cookie of the user
+func New(token, subject string, groups []string, refreshToken string, ttl time.Duration) *Session {
+	now := time.Now()
+	return &Session{
+		ID:           IDFromToken(token),
+		Subject:      subject,
+		Groups:       groups,
+		RefreshToken: refreshToken,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+	}
+}
+
+// IDFromToken returns the ID of the session identified by the given token
+func IDFromToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// HasExpired returns whether the session has expired
+func (s *Session) HasExpired() bool {
+	return !time.Now().Before(s.ExpiresAt)
+}
+
+// IsRefreshable returns whether the session can be extended with a refresh token
+func (s *Session) IsRefreshable() bool {
+	return len(s.RefreshToken) > 0
+}
+
+// NeedsRefresh returns whether less than half of the given time to live is left before the session expires, in which
+// case it should be extended if it's refreshable.
+// Sessions are only extended past that point so that the identity provider isn't called on every request, while
+// still giving it a chance to revoke the access of the user long before the session expires.
+func (s *Session) NeedsRefresh(ttl time.Duration) bool {
+	return time.Until(s.ExpiresAt) < ttl/2
+}
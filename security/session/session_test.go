@@ -0,0 +1,68 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	s := New("token", "john.doe", []string{"team-a"}, "refresh-token", time.Hour)
+	if s.ID != IDFromToken("token") {
+		t.Errorf("expected ID to be the hash of the token, got %s", s.ID)
+	}
+	if s.ID == "token" {
+		t.Error("expected ID to not be the token itself")
+	}
+	if s.HasExpired() {
+		t.Error("expected session to not have expired")
+	}
+	if !s.IsRefreshable() {
+		t.Error("expected session with a refresh token to be refreshable")
+	}
+	if s.NeedsRefresh(time.Hour) {
+		t.Error("expected session that was just created to not need a refresh")
+	}
+}
+
+func TestSession_HasExpired(t *testing.T) {
+	s := &Session{ExpiresAt: time.Now().Add(-time.Second)}
+	if !s.HasExpired() {
+		t.Error("expected session to have expired")
+	}
+	s.ExpiresAt = time.Now().Add(time.Minute)
+	if s.HasExpired() {
+		t.Error("expected session to not have expired")
+	}
+}
+
+func TestSession_NeedsRefresh(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		timeLeft time.Duration
+		expected bool
+	}{
+		{
+			name:     "most-of-ttl-left",
+			timeLeft: 7 * time.Hour,
+			expected: false,
+		},
+		{
+			name:     "less-than-half-of-ttl-left",
+			timeLeft: 3 * time.Hour,
+			expected: true,
+		},
+		{
+			name:     "expired",
+			timeLeft: -time.Hour,
+			expected: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			s := &Session{ExpiresAt: time.Now().Add(scenario.timeLeft)}
+			if needsRefresh := s.NeedsRefresh(8 * time.Hour); needsRefresh != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, needsRefresh)
+			}
+		})
+	}
+}
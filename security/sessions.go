@@ -1,5 +1,257 @@
 package security
 
-import "github.com/TwiN/gocache/v2"
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
 
-var sessions = gocache.NewCache().WithEvictionPolicy(gocache.LeastRecentlyUsed) // TODO: Move this to storage
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// localsKeySession is the key under which the session of a request authenticated through OIDC is stored in its
+	// locals
+	localsKeySession = "session"
+
+	// sessionRefreshTimeout is the maximum amount of time to wait for the identity provider when extending a session
+	sessionRefreshTimeout = 10 * time.Second
+)
+
+// errInvalidEncryptedRefreshToken is the error returned when a refresh token could not be decrypted, e.g. because the
+// client secret has changed since the session was created
+var errInvalidEncryptedRefreshToken = errors.New("invalid encrypted refresh token")
+
+// createSession creates a session for the user with the given subject, stores it and sets the session cookie
+func (c *OIDCConfig) createSession(w http.ResponseWriter, subject string, groups []string, refreshToken string) error {
+	token := uuid.NewString()
+	encryptedRefreshToken, err := c.encryptRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+	if err = store.Get().UpsertSession(session.New(token, subject, groups, encryptedRefreshToken, c.SessionTTL)); err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieNameSession,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(c.SessionTTL.Seconds()),
+		SameSite: http.SameSiteStrictMode,
+	})
+	return nil
+}
+
+// getSession returns the session identified by the token passed, or nil if there's no such session or if it has
+// expired
+func getSession(token string) *session.Session {
+	if len(token) == 0 {
+		return nil
+	}
+	sess, err := store.Get().GetSessionByID(session.IDFromToken(token))
+	if err != nil {
+		if !errors.Is(err, common.ErrSessionNotFound) {
+			logr.Errorf("[security.getSession] Failed to retrieve session: %s", err.Error())
+		}
+		return nil
+	}
+	return sess
+}
+
+// authenticateSession authenticates the requests with the session cookie set once the user has logged in through
+// OIDC, and extends the session if it's about to expire and the identity provider issued a refresh token
+func (c *OIDCConfig) authenticateSession(ctx *fiber.Ctx) error {
+	token := ctx.Cookies(cookieNameSession)
+	sess := getSession(token)
+	if sess == nil {
+		return ctx.Status(401).SendString("Unauthorized")
+	}
+	if sess.IsRefreshable() && sess.NeedsRefresh(c.SessionTTL) {
+		if sess = c.refreshSession(sess); sess == nil {
+			return ctx.Status(401).SendString("Unauthorized")
+		}
+		ctx.Cookie(&fiber.Cookie{
+			Name:     cookieNameSession,
+			Value:    token,
+			Path:     "/",
+			MaxAge:   int(time.Until(sess.ExpiresAt).Seconds()),
+			SameSite: fiber.CookieSameSiteStrictMode,
+		})
+	}
+	ctx.Locals(localsKeySession, sess)
+	return ctx.Next()
+}
+
+// refreshSession extends a session using its refresh token, and returns the extended session.
+//
+// If the identity provider rejects the refresh token, the access of the user has been revoked, so the session is
+// deleted and nil is returned. If the identity provider could not be reached, the session is returned as is, since it
+// remains valid until it expires.
+func (c *OIDCConfig) refreshSession(sess *session.Session) *session.Session {
+	// Refresh tokens may be rotated, in which case they can only be used once, so concurrent requests must not
+	// refresh the same session more than once
+	unlock := c.lockSessionRefresh(sess.ID)
+	defer unlock()
+	if latestSession, err := store.Get().GetSessionByID(sess.ID); err != nil {
+		return nil
+	} else if !latestSession.NeedsRefresh(c.SessionTTL) {
+		// The session was extended by another request in the meantime
+		return latestSession
+	}
+	refreshToken, err := c.decryptRefreshToken(sess.RefreshToken)
+	if err != nil {
+		// The session remains valid until it expires, but it can no longer be extended
+		logr.Errorf("[security.refreshSession] Failed to decrypt refresh token of subject %s, session will not be extended: %s", sess.Subject, err.Error())
+		sess.RefreshToken = ""
+		if err = store.Get().UpsertSession(sess); err != nil {
+			logr.Errorf("[security.refreshSession] Failed to update session of subject %s: %s", sess.Subject, err.Error())
+		}
+		return sess
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sessionRefreshTimeout)
+	defer cancel()
+	oauth2Token, err := c.oauth2Config.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		var retrieveError *oauth2.RetrieveError
+		if !errors.As(err, &retrieveError) {
+			logr.Errorf("[security.refreshSession] Failed to refresh session of subject %s: %s", sess.Subject, err.Error())
+			return sess
+		}
+		logr.Infof("[security.refreshSession] Identity provider refused to refresh session of subject %s, deleting session: %s", sess.Subject, err.Error())
+		if err = store.Get().DeleteSession(sess.ID); err != nil && !errors.Is(err, common.ErrSessionNotFound) {
+			logr.Errorf("[security.refreshSession] Failed to delete session of subject %s: %s", sess.Subject, err.Error())
+		}
+		return nil
+	}
+	// The identity provider may issue a new ID token, in which case the groups of the user are updated
+	if rawIDToken, ok := oauth2Token.Extra("id_token").(string); ok {
+		idToken, err := c.verifier.Verify(ctx, rawIDToken)
+		if err != nil || idToken.Subject != sess.Subject {
+			logr.Errorf("[security.refreshSession] Failed to verify refreshed ID token of subject %s, deleting session", sess.Subject)
+			_ = store.Get().DeleteSession(sess.ID)
+			return nil
+		}
+		sess.Groups = c.getGroups(idToken)
+	}
+	if len(oauth2Token.RefreshToken) > 0 {
+		if sess.RefreshToken, err = c.encryptRefreshToken(oauth2Token.RefreshToken); err != nil {
+			logr.Errorf("[security.refreshSession] Failed to encrypt refresh token of subject %s: %s", sess.Subject, err.Error())
+		}
+	}
+	sess.ExpiresAt = time.Now().Add(c.SessionTTL)
+	if err = store.Get().UpsertSession(sess); err != nil {
+		logr.Errorf("[security.refreshSession] Failed to update session of subject %s: %s", sess.Subject, err.Error())
+	}
+	return sess
+}
+
+// encryptRefreshToken encrypts a refresh token before it's persisted along with its session, so that the refresh tokens
+// of the users can't be used by whoever has access to the storage.
+//
+// The key is derived from the client secret, which every instance sharing the same storage has. If the client secret
+// changes, the refresh tokens of the existing sessions can no longer be decrypted, so these sessions are no longer
+// extended and the users have to log in again once their session expires.
+func (c *OIDCConfig) encryptRefreshToken(refreshToken string) (string, error) {
+	if len(refreshToken) == 0 {
+		return "", nil
+	}
+	aead, err := c.refreshTokenCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawStdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(refreshToken), nil)), nil
+}
+
+// decryptRefreshToken decrypts a refresh token encrypted with encryptRefreshToken
+func (c *OIDCConfig) decryptRefreshToken(encryptedRefreshToken string) (string, error) {
+	aead, err := c.refreshTokenCipher()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encryptedRefreshToken)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errInvalidEncryptedRefreshToken
+	}
+	refreshToken, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", errInvalidEncryptedRefreshToken
+	}
+	return string(refreshToken), nil
+}
+
+// refreshTokenCipher returns the cipher with which the refresh tokens are encrypted
+func (c *OIDCConfig) refreshTokenCipher() (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte("gatus-refresh-token:" + c.ClientSecret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sessionRefreshLock is the lock held while a session is being refreshed
+type sessionRefreshLock struct {
+	sync.Mutex
+	references int // Number of requests holding or waiting for the lock. Guarded by OIDCConfig.refreshLocksMutex.
+}
+
+// lockSessionRefresh locks the refresh of the session with the given ID, and returns the function unlocking it.
+//
+// Each session has its own lock, so that waiting for the identity provider to refresh a session doesn't hold up the
+// requests of other sessions.
+func (c *OIDCConfig) lockSessionRefresh(sessionID string) func() {
+	c.refreshLocksMutex.Lock()
+	if c.refreshLocks == nil {
+		c.refreshLocks = make(map[string]*sessionRefreshLock)
+	}
+	lock, exists := c.refreshLocks[sessionID]
+	if !exists {
+		lock = &sessionRefreshLock{}
+		c.refreshLocks[sessionID] = lock
+	}
+	lock.references++
+	c.refreshLocksMutex.Unlock()
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		c.refreshLocksMutex.Lock()
+		if lock.references--; lock.references == 0 {
+			delete(c.refreshLocks, sessionID)
+		}
+		c.refreshLocksMutex.Unlock()
+	}
+}
+
+// logoutHandler deletes the session of the user, if any, and clears the session cookie
+func (c *OIDCConfig) logoutHandler(ctx *fiber.Ctx) error {
+	if token := ctx.Cookies(cookieNameSession); len(token) > 0 {
+		if err := store.Get().DeleteSession(session.IDFromToken(token)); err != nil && !errors.Is(err, common.ErrSessionNotFound) {
+			logr.Errorf("[security.logoutHandler] Failed to delete session: %s", err.Error())
+			return ctx.Status(500).SendString("Failed to delete session")
+		}
+	}
+	ctx.Cookie(&fiber.Cookie{
+		Name:     cookieNameSession,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		SameSite: fiber.CookieSameSiteStrictMode,
+	})
+	return ctx.Redirect("/", http.StatusFound)
+}
@@ -0,0 +1,247 @@
+package security
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/oauth2"
+)
+
+func TestOIDCConfig_authenticateSession(t *testing.T) {
+	defer store.Get().Clear()
+	identityProvider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("refresh_token") != "valid-refresh-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"access-token","token_type":"Bearer","refresh_token":"rotated-refresh-token","expires_in":3600}`))
+	}))
+	defer identityProvider.Close()
+	c := &Config{OIDC: &OIDCConfig{
+		ClientSecret: "client-secret",
+		SessionTTL:   time.Hour,
+		oauth2Config: oauth2.Config{ClientID: "client-id", Endpoint: oauth2.Endpoint{TokenURL: identityProvider.URL}},
+	}}
+	app := fiber.New()
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return c.SendString(GetPrincipal(c).Username)
+	})
+	scenarios := []struct {
+		name                  string
+		session               *session.Session
+		expectedCode          int
+		expectedRefreshToken  string
+		plaintextRefreshToken bool
+		expectedSessionCookie bool
+		expectedSessionExists bool
+	}{
+		{
+			name:         "no-session",
+			expectedCode: 401,
+		},
+		{
+			name:                  "session",
+			session:               &session.Session{Subject: "john.doe", RefreshToken: "valid-refresh-token", ExpiresAt: time.Now().Add(time.Hour)},
+			expectedCode:          200,
+			expectedRefreshToken:  "valid-refresh-token",
+			expectedSessionExists: true,
+		},
+		{
+			name:         "expired-session",
+			session:      &session.Session{Subject: "john.doe", RefreshToken: "valid-refresh-token", ExpiresAt: time.Now().Add(-time.Minute)},
+			expectedCode: 401,
+		},
+		{
+			name:                  "session-about-to-expire-is-extended",
+			session:               &session.Session{Subject: "john.doe", RefreshToken: "valid-refresh-token", ExpiresAt: time.Now().Add(10 * time.Minute)},
+			expectedCode:          200,
+			expectedRefreshToken:  "rotated-refresh-token",
+			expectedSessionCookie: true,
+			expectedSessionExists: true,
+		},
+		{
+			name:                  "session-about-to-expire-without-refresh-token",
+			session:               &session.Session{Subject: "john.doe", ExpiresAt: time.Now().Add(10 * time.Minute)},
+			expectedCode:          200,
+			expectedSessionExists: true,
+		},
+		{
+			name:                  "session-about-to-expire-with-plaintext-refresh-token",
+			session:               &session.Session{Subject: "john.doe", RefreshToken: "valid-refresh-token", ExpiresAt: time.Now().Add(10 * time.Minute)},
+			plaintextRefreshToken: true,
+			expectedCode:          200,
+			expectedSessionExists: true,
+		},
+		{
+			name:         "session-about-to-expire-with-revoked-refresh-token",
+			session:      &session.Session{Subject: "john.doe", RefreshToken: "revoked-refresh-token", ExpiresAt: time.Now().Add(10 * time.Minute)},
+			expectedCode: 401,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/test", http.NoBody)
+			var sessionID string
+			if scenario.session != nil {
+				token := scenario.name
+				sessionID = session.IDFromToken(token)
+				scenario.session.ID, scenario.session.CreatedAt = sessionID, time.Now()
+				if !scenario.plaintextRefreshToken {
+					scenario.session.RefreshToken, _ = c.OIDC.encryptRefreshToken(scenario.session.RefreshToken)
+				}
+				if err := store.Get().UpsertSession(scenario.session); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+				request.AddCookie(&http.Cookie{Name: cookieNameSession, Value: token})
+			}
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if response.StatusCode != scenario.expectedCode {
+				t.Errorf("expected code to be %d, but was %d", scenario.expectedCode, response.StatusCode)
+			}
+			hasSessionCookie := false
+			for _, cookie := range response.Cookies() {
+				if cookie.Name == cookieNameSession && cookie.MaxAge > int((50*time.Minute).Seconds()) {
+					hasSessionCookie = true
+				}
+			}
+			if hasSessionCookie != scenario.expectedSessionCookie {
+				t.Errorf("expected session cookie to be renewed to be %v, got %v", scenario.expectedSessionCookie, hasSessionCookie)
+			}
+			if len(sessionID) == 0 {
+				return
+			}
+			sess, _ := store.Get().GetSessionByID(sessionID)
+			if (sess != nil) != scenario.expectedSessionExists {
+				t.Fatalf("expected session to exist to be %v, got %v", scenario.expectedSessionExists, sess != nil)
+			}
+			if sess != nil {
+				if refreshToken, _ := c.OIDC.decryptRefreshToken(sess.RefreshToken); refreshToken != scenario.expectedRefreshToken {
+					t.Errorf("expected refresh token to be %s, got %s", scenario.expectedRefreshToken, refreshToken)
+				}
+			}
+			if scenario.expectedSessionCookie && time.Until(sess.ExpiresAt) < 50*time.Minute {
+				t.Errorf("expected session to have been extended, but it expires at %s", sess.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestOIDCConfig_encryptRefreshToken(t *testing.T) {
+	c := &OIDCConfig{ClientSecret: "client-secret"}
+	encryptedRefreshToken, err := c.encryptRefreshToken("refresh-token")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if strings.Contains(encryptedRefreshToken, "refresh-token") {
+		t.Error("expected refresh token to be encrypted, got", encryptedRefreshToken)
+	}
+	if otherEncryptedRefreshToken, _ := c.encryptRefreshToken("refresh-token"); otherEncryptedRefreshToken == encryptedRefreshToken {
+		t.Error("expected each encryption of the same refresh token to be different")
+	}
+	if refreshToken, err := c.decryptRefreshToken(encryptedRefreshToken); err != nil || refreshToken != "refresh-token" {
+		t.Errorf("expected refresh token to be decrypted to refresh-token, got %s (%v)", refreshToken, err)
+	}
+	if _, err := (&OIDCConfig{ClientSecret: "other-client-secret"}).decryptRefreshToken(encryptedRefreshToken); !errors.Is(err, errInvalidEncryptedRefreshToken) {
+		t.Errorf("expected error %v when decrypting with another client secret, got %v", errInvalidEncryptedRefreshToken, err)
+	}
+	if _, err := c.decryptRefreshToken("refresh-token"); !errors.Is(err, errInvalidEncryptedRefreshToken) {
+		t.Errorf("expected error %v when decrypting a plaintext refresh token, got %v", errInvalidEncryptedRefreshToken, err)
+	}
+	if encryptedRefreshToken, _ := c.encryptRefreshToken(""); encryptedRefreshToken != "" {
+		t.Error("expected empty refresh token to remain empty, got", encryptedRefreshToken)
+	}
+}
+
+func TestOIDCConfig_lockSessionRefresh(t *testing.T) {
+	c := &OIDCConfig{}
+	unlock := c.lockSessionRefresh("session-1")
+	// Refreshing another session must not wait for the refresh of the first one
+	done := make(chan struct{})
+	go func() {
+		c.lockSessionRefresh("session-2")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the refresh of another session to not be blocked")
+	}
+	// Refreshing the same session must wait for the ongoing refresh to be done
+	locked := make(chan struct{})
+	go func() {
+		c.lockSessionRefresh("session-1")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("expected the refresh of the same session to be blocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("expected the refresh of the same session to be unblocked")
+	}
+	c.refreshLocksMutex.Lock()
+	defer c.refreshLocksMutex.Unlock()
+	if len(c.refreshLocks) != 0 {
+		t.Errorf("expected no lock to remain once every refresh is done, got %d", len(c.refreshLocks))
+	}
+}
+
+func TestOIDCConfig_logoutHandler(t *testing.T) {
+	defer store.Get().Clear()
+	c := &Config{OIDC: &OIDCConfig{SessionTTL: time.Hour}}
+	app := fiber.New()
+	app.All("/oidc/logout", c.OIDC.logoutHandler)
+	app.Get("/authenticated", func(ctx *fiber.Ctx) error {
+		return ctx.SendString(strconv.FormatBool(c.IsAuthenticated(ctx)))
+	})
+	if err := store.Get().UpsertSession(session.New("token", "john.doe", nil, "", time.Hour)); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	request := httptest.NewRequest("GET", "/authenticated", http.NoBody)
+	request.AddCookie(&http.Cookie{Name: cookieNameSession, Value: "token"})
+	response, err := app.Test(request)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if body, _ := io.ReadAll(response.Body); string(body) != "true" {
+		t.Error("expected user to be authenticated before logging out")
+	}
+	request = httptest.NewRequest("GET", "/oidc/logout", http.NoBody)
+	request.AddCookie(&http.Cookie{Name: cookieNameSession, Value: "token"})
+	response, err = app.Test(request)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if response.StatusCode != http.StatusFound {
+		t.Errorf("expected code to be %d, but was %d", http.StatusFound, response.StatusCode)
+	}
+	if getSession("token") != nil {
+		t.Error("expected session to have been deleted")
+	}
+	cookies := response.Cookies()
+	if len(cookies) != 1 || cookies[0].Name != cookieNameSession || cookies[0].Value != "" {
+		t.Errorf("expected session cookie to be cleared, got %v", cookies)
+	}
+}
@@ -2,23 +2,29 @@ package storage
 
 import (
 	"errors"
+	"time"
 )
 
 const (
 	DefaultMaximumNumberOfResults = 100
 	DefaultMaximumNumberOfEvents  = 50
+
+	DefaultUptimeHourlyRetentionDays = 2
+	DefaultUptimeDailyRetentionDays  = 30
 )
 
 var (
-	ErrSQLStorageRequiresPath          = errors.New("sql storage requires a non-empty path to be defined")
-	ErrMemoryStorageDoesNotSupportPath = errors.New("memory storage does not support persistence, use sqlite if you want persistence on file")
+	ErrSQLStorageRequiresPath       = errors.New("sql storage requires a non-empty path to be defined")
+	ErrInvalidUptimeHourlyRetention = errors.New("uptime-retention.hourly-days must be at least 2")
+	ErrInvalidUptimeDailyRetention  = errors.New("uptime-retention.daily-days must be at least 30 and greater than uptime-retention.hourly-days")
 )
 
 // Config is the configuration for storage
 type Config struct {
 	// Path is the path used by the store to achieve persistence
 	// If blank, persistence is disabled.
-	// Note that not all Type support persistence
+	// Note that TypePostgres requires a connection URL rather than a file path, and that TypeMemory also writes a
+	// write-ahead log next to the path.
 	Path string `yaml:"path"`
 
 	// Type of store
@@ -36,6 +42,9 @@ type Config struct {
 
 	// MaximumNumberOfEvents is the number of events each endpoint should be able to provide
 	MaximumNumberOfEvents int `yaml:"maximum-number-of-events,omitempty"`
+
+	// UptimeRetention is the configuration for how long uptime data is kept, and at which granularity
+	UptimeRetention *UptimeRetentionConfig `yaml:"uptime-retention,omitempty"`
 }
 
 // ValidateAndSetDefaults validates the configuration and sets the default values (if applicable)
@@ -46,14 +55,66 @@ func (c *Config) ValidateAndSetDefaults() error {
 	if (c.Type == TypePostgres || c.Type == TypeSQLite) && len(c.Path) == 0 {
 		return ErrSQLStorageRequiresPath
 	}
-	if c.Type == TypeMemory && len(c.Path) > 0 {
-		return ErrMemoryStorageDoesNotSupportPath
-	}
 	if c.MaximumNumberOfResults <= 0 {
 		c.MaximumNumberOfResults = DefaultMaximumNumberOfResults
 	}
 	if c.MaximumNumberOfEvents <= 0 {
 		c.MaximumNumberOfEvents = DefaultMaximumNumberOfEvents
 	}
+	if c.UptimeRetention == nil {
+		c.UptimeRetention = GetDefaultUptimeRetentionConfig()
+	}
+	return c.UptimeRetention.ValidateAndSetDefaults()
+}
+
+// UptimeRetentionConfig is the configuration for how long uptime data is kept, and at which granularity.
+//
+// Uptime data is first kept with an hourly granularity, then merged into daily entries, and finally either deleted
+// or merged into monthly entries, which are kept forever.
+type UptimeRetentionConfig struct {
+	// HourlyDays is the number of days for which uptime data is kept with an hourly granularity
+	HourlyDays int `yaml:"hourly-days,omitempty"`
+
+	// DailyDays is the number of days for which uptime data is kept with at least a daily granularity
+	DailyDays int `yaml:"daily-days,omitempty"`
+
+	// Monthly is whether to keep uptime data older than DailyDays forever with a monthly granularity, rather than
+	// deleting it
+	Monthly bool `yaml:"monthly,omitempty"`
+}
+
+// GetDefaultUptimeRetentionConfig returns a UptimeRetentionConfig struct with the default values
+func GetDefaultUptimeRetentionConfig() *UptimeRetentionConfig {
+	return &UptimeRetentionConfig{
+		HourlyDays: DefaultUptimeHourlyRetentionDays,
+		DailyDays:  DefaultUptimeDailyRetentionDays,
+	}
+}
+
+// ValidateAndSetDefaults validates the uptime retention configuration and sets the default values (if applicable)
+func (c *UptimeRetentionConfig) ValidateAndSetDefaults() error {
+	if c.HourlyDays == 0 {
+		c.HourlyDays = DefaultUptimeHourlyRetentionDays
+	}
+	if c.DailyDays == 0 {
+		c.DailyDays = DefaultUptimeDailyRetentionDays
+	}
+	// Hourly entries are needed for at least 48 hours to calculate the uptime of the last 24 hours accurately
+	if c.HourlyDays < DefaultUptimeHourlyRetentionDays {
+		return ErrInvalidUptimeHourlyRetention
+	}
+	if c.DailyDays < DefaultUptimeDailyRetentionDays || c.DailyDays <= c.HourlyDays {
+		return ErrInvalidUptimeDailyRetention
+	}
 	return nil
 }
+
+// HourlyRetention returns how long uptime data is kept with an hourly granularity
+func (c *UptimeRetentionConfig) HourlyRetention() time.Duration {
+	return time.Duration(c.HourlyDays) * 24 * time.Hour
+}
+
+// DailyRetention returns how long uptime data is kept with at least a daily granularity
+func (c *UptimeRetentionConfig) DailyRetention() time.Duration {
+	return time.Duration(c.DailyDays) * 24 * time.Hour
+}
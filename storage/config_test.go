@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name                    string
+		config                  *Config
+		expectedErr             error
+		expectedUptimeRetention *UptimeRetentionConfig
+	}{
+		{
+			name:                    "default",
+			config:                  &Config{},
+			expectedUptimeRetention: &UptimeRetentionConfig{HourlyDays: 2, DailyDays: 30},
+		},
+		{
+			name:        "sqlite-without-path",
+			config:      &Config{Type: TypeSQLite},
+			expectedErr: ErrSQLStorageRequiresPath,
+		},
+		{
+			name:                    "memory-with-path",
+			config:                  &Config{Type: TypeMemory, Path: "data.gob"},
+			expectedUptimeRetention: &UptimeRetentionConfig{HourlyDays: 2, DailyDays: 30},
+		},
+		{
+			name:                    "uptime-retention-with-defaults",
+			config:                  &Config{UptimeRetention: &UptimeRetentionConfig{Monthly: true}},
+			expectedUptimeRetention: &UptimeRetentionConfig{HourlyDays: 2, DailyDays: 30, Monthly: true},
+		},
+		{
+			name:                    "uptime-retention",
+			config:                  &Config{UptimeRetention: &UptimeRetentionConfig{HourlyDays: 7, DailyDays: 365}},
+			expectedUptimeRetention: &UptimeRetentionConfig{HourlyDays: 7, DailyDays: 365},
+		},
+		{
+			name:        "uptime-retention-with-hourly-days-too-low",
+			config:      &Config{UptimeRetention: &UptimeRetentionConfig{HourlyDays: 1}},
+			expectedErr: ErrInvalidUptimeHourlyRetention,
+		},
+		{
+			name:        "uptime-retention-with-daily-days-too-low",
+			config:      &Config{UptimeRetention: &UptimeRetentionConfig{DailyDays: 7}},
+			expectedErr: ErrInvalidUptimeDailyRetention,
+		},
+		{
+			name:        "uptime-retention-with-daily-days-lower-than-hourly-days",
+			config:      &Config{UptimeRetention: &UptimeRetentionConfig{HourlyDays: 60, DailyDays: 45}},
+			expectedErr: ErrInvalidUptimeDailyRetention,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if *scenario.config.UptimeRetention != *scenario.expectedUptimeRetention {
+				t.Errorf("expected uptime retention %+v, got %+v", scenario.expectedUptimeRetention, scenario.config.UptimeRetention)
+			}
+		})
+	}
+}
+
+func TestUptimeRetentionConfig_Retention(t *testing.T) {
+	config := GetDefaultUptimeRetentionConfig()
+	if config.HourlyRetention() != 48*time.Hour {
+		t.Errorf("expected hourly retention to be 48h, got %s", config.HourlyRetention())
+	}
+	if config.DailyRetention() != 30*24*time.Hour {
+		t.Errorf("expected daily retention to be 720h, got %s", config.DailyRetention())
+	}
+}
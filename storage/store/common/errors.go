@@ -6,4 +6,11 @@ var (
 	ErrEndpointNotFound = errors.New("endpoint not found")               // When an endpoint does not exist in the store
 	ErrSuiteNotFound    = errors.New("suite not found")                  // When a suite does not exist in the store
 	ErrInvalidTimeRange = errors.New("'from' cannot be older than 'to'") // When an invalid time range is provided
+	ErrIncidentNotFound = errors.New("incident not found")               // When an incident does not exist in the store
+	ErrSilenceNotFound  = errors.New("silence not found")                // When a silence does not exist in the store
+
+	ErrAcknowledgementNotFound   = errors.New("acknowledgement not found")    // When an alert acknowledgement does not exist in the store
+	ErrMaintenanceWindowNotFound = errors.New("maintenance window not found") // When a maintenance window does not exist in the store
+	ErrInvalidCursor             = errors.New("invalid cursor")               // When a cursor that wasn't returned by the store is provided
+	ErrSessionNotFound           = errors.New("session not found")            // When a session does not exist in the store
 )
@@ -0,0 +1,48 @@
+package paging
+
+import "time"
+
+// EndpointHistoryParams represents all parameters that can be used to page through the stored history of an endpoint,
+// from the oldest entry to the most recent one
+type EndpointHistoryParams struct {
+	From    time.Time // Entries older than this are excluded. Ignored if zero.
+	To      time.Time // Entries more recent than this are excluded. Ignored if zero.
+	Success *bool     // If not nil, only the results whose success matches are included. Ignored for events.
+	Cursor  string    // Cursor returned alongside the previous page, or empty for the first page
+	Limit   int       // Maximum number of entries per page, which must be greater than 0
+}
+
+// NewEndpointHistoryParams creates a new EndpointHistoryParams
+func NewEndpointHistoryParams(limit int) *EndpointHistoryParams {
+	return &EndpointHistoryParams{Limit: limit}
+}
+
+// WithTimeRange sets the values for From and To
+func (params *EndpointHistoryParams) WithTimeRange(from, to time.Time) *EndpointHistoryParams {
+	params.From = from
+	params.To = to
+	return params
+}
+
+// WithSuccess sets the value for Success
+func (params *EndpointHistoryParams) WithSuccess(success bool) *EndpointHistoryParams {
+	params.Success = &success
+	return params
+}
+
+// WithCursor sets the value for Cursor
+func (params *EndpointHistoryParams) WithCursor(cursor string) *EndpointHistoryParams {
+	params.Cursor = cursor
+	return params
+}
+
+// Includes returns whether an entry with the given timestamp is within the time range
+func (params *EndpointHistoryParams) Includes(timestamp time.Time) bool {
+	if !params.From.IsZero() && timestamp.Before(params.From) {
+		return false
+	}
+	if !params.To.IsZero() && timestamp.After(params.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package paging
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewEndpointHistoryParams(t *testing.T) {
+	now := time.Now()
+	params := NewEndpointHistoryParams(50).WithTimeRange(now.Add(-time.Hour), now).WithSuccess(false).WithCursor("42")
+	if params.Limit != 50 {
+		t.Errorf("expected Limit to be 50, got %d", params.Limit)
+	}
+	if !params.From.Equal(now.Add(-time.Hour)) || !params.To.Equal(now) {
+		t.Errorf("expected time range to be [%s, %s], got [%s, %s]", now.Add(-time.Hour), now, params.From, params.To)
+	}
+	if params.Success == nil || *params.Success {
+		t.Error("expected Success to be false")
+	}
+	if params.Cursor != "42" {
+		t.Errorf("expected Cursor to be 42, got %s", params.Cursor)
+	}
+}
+
+func TestEndpointHistoryParams_Includes(t *testing.T) {
+	now := time.Now()
+	scenarios := []struct {
+		Name      string
+		Params    *EndpointHistoryParams
+		Timestamp time.Time
+		Expected  bool
+	}{
+		{
+			Name:      "no-time-range",
+			Params:    NewEndpointHistoryParams(10),
+			Timestamp: now,
+			Expected:  true,
+		},
+		{
+			Name:      "within-time-range",
+			Params:    NewEndpointHistoryParams(10).WithTimeRange(now.Add(-time.Hour), now),
+			Timestamp: now.Add(-time.Minute),
+			Expected:  true,
+		},
+		{
+			Name:      "at-boundaries",
+			Params:    NewEndpointHistoryParams(10).WithTimeRange(now, now),
+			Timestamp: now,
+			Expected:  true,
+		},
+		{
+			Name:      "before-from",
+			Params:    NewEndpointHistoryParams(10).WithTimeRange(now.Add(-time.Hour), time.Time{}),
+			Timestamp: now.Add(-2 * time.Hour),
+			Expected:  false,
+		},
+		{
+			Name:      "after-to",
+			Params:    NewEndpointHistoryParams(10).WithTimeRange(time.Time{}, now.Add(-time.Hour)),
+			Timestamp: now,
+			Expected:  false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if actual := scenario.Params.Includes(scenario.Timestamp); actual != scenario.Expected {
+				t.Errorf("expected %v, got %v", scenario.Expected, actual)
+			}
+		})
+	}
+}
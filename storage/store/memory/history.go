@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+// GetEndpointResultsByKey returns a page of the results of the endpoint with the given key, from the oldest to the most
+// recent, as well as the cursor of the next page, which is empty if there are no more results.
+//
+// Because the memory store doesn't assign IDs to results, the cursor is the timestamp of the last result of the page.
+// Note that only the last MaximumNumberOfResults results are retained.
+func (s *Store) GetEndpointResultsByKey(key string, params *paging.EndpointHistoryParams) ([]*endpoint.Result, string, error) {
+	cursor, err := parseCursor(params)
+	if err != nil {
+		return nil, "", err
+	}
+	s.RLock()
+	defer s.RUnlock()
+	endpointStatus := s.endpointCache.GetValue(key)
+	if endpointStatus == nil {
+		return nil, "", common.ErrEndpointNotFound
+	}
+	allResults := endpointStatus.(*endpoint.Status).Results
+	start := indexAfterCursor(len(allResults), func(i int) time.Time { return allResults[i].Timestamp }, cursor)
+	var results []*endpoint.Result
+	for _, result := range allResults[start:] {
+		if len(results) == params.Limit {
+			return results, formatCursor(results[len(results)-1].Timestamp), nil
+		}
+		if !params.Includes(result.Timestamp) {
+			continue
+		}
+		if params.Success != nil && result.Success != *params.Success {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, "", nil
+}
+
+// GetEndpointEventsByKey returns a page of the events of the endpoint with the given key, from the oldest to the most
+// recent, as well as the cursor of the next page, which is empty if there are no more events.
+//
+// Because the memory store doesn't assign IDs to events, the cursor is the timestamp of the last event of the page.
+// Note that only the last MaximumNumberOfEvents events are retained.
+func (s *Store) GetEndpointEventsByKey(key string, params *paging.EndpointHistoryParams) ([]*endpoint.Event, string, error) {
+	cursor, err := parseCursor(params)
+	if err != nil {
+		return nil, "", err
+	}
+	s.RLock()
+	defer s.RUnlock()
+	endpointStatus := s.endpointCache.GetValue(key)
+	if endpointStatus == nil {
+		return nil, "", common.ErrEndpointNotFound
+	}
+	allEvents := endpointStatus.(*endpoint.Status).Events
+	start := indexAfterCursor(len(allEvents), func(i int) time.Time { return allEvents[i].Timestamp }, cursor)
+	var events []*endpoint.Event
+	for _, event := range allEvents[start:] {
+		if len(events) == params.Limit {
+			return events, formatCursor(events[len(events)-1].Timestamp), nil
+		}
+		if !params.Includes(event.Timestamp) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, "", nil
+}
+
+// parseCursor returns the timestamp of the entry after which the page described by params starts, in nanoseconds, or
+// -1 if the page is the first one. The time range is validated as well.
+func parseCursor(params *paging.EndpointHistoryParams) (int64, error) {
+	if !params.From.IsZero() && !params.To.IsZero() && params.From.After(params.To) {
+		return 0, common.ErrInvalidTimeRange
+	}
+	if len(params.Cursor) == 0 {
+		return -1, nil
+	}
+	unixNano, err := strconv.ParseInt(params.Cursor, 10, 64)
+	if err != nil || unixNano < 0 {
+		return 0, common.ErrInvalidCursor
+	}
+	return unixNano, nil
+}
+
+// indexAfterCursor returns the index of the entry following the one identified by the cursor, out of n entries sorted
+// by insertion order.
+//
+// Entries are searched by timestamp rather than compared to it, because entries aren't necessarily sorted by timestamp
+// (e.g. EventStart is timestamped when the first result is inserted, which is after that result has been timestamped).
+// If the entry is no longer there, it has been evicted, and so have all the entries that preceded it.
+func indexAfterCursor(n int, timestampAt func(i int) time.Time, cursor int64) int {
+	if cursor < 0 {
+		return 0
+	}
+	for i := n - 1; i >= 0; i-- {
+		if timestampAt(i).UnixNano() == cursor {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func formatCursor(timestamp time.Time) string {
+	return strconv.FormatInt(timestamp.UnixNano(), 10)
+}
@@ -1,15 +1,22 @@
 package memory
 
 import (
+	"context"
+	"os"
 	"slices"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/silence"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/incident"
 	"github.com/TwiN/gatus/v5/config/key"
+	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gocache/v2"
@@ -23,24 +30,93 @@ type Store struct {
 	endpointCache *gocache.Cache // Cache for endpoint statuses
 	suiteCache    *gocache.Cache // Cache for suite statuses
 
+	incidents      map[int64]*incident.Incident // Incidents, keyed by ID
+	lastIncidentID int64                        // ID of the last incident inserted
+
+	silences         map[int64]*silence.Silence          // Silences, keyed by ID
+	lastSilenceID    int64                               // ID of the last silence inserted
+	acknowledgements map[string]*silence.Acknowledgement // Alert acknowledgements, keyed by endpoint key and alert checksum
+
+	maintenanceWindows      map[int64]*maintenance.Window // Maintenance windows, keyed by ID
+	lastMaintenanceWindowID int64                         // ID of the last maintenance window inserted
+
+	triggeredAlerts map[string]map[string]*triggeredEndpointAlert // Triggered alerts, keyed by endpoint key and alert checksum
+
+	leases map[string]*lease // Leases, keyed by name. Not persisted, since they're only relevant while the process runs
+
+	// Latest result reported from each location, keyed by endpoint key and location name. Not persisted, since results
+	// reported from a location are only taken into account for a couple of intervals
+	locationResults map[string]map[string]*endpoint.Result
+
+	apiTokenLastUsedTimes map[string]time.Time // Last time each API token was used, keyed by name
+
+	sessions map[string]*session.Session // Sessions, keyed by ID
+
 	maximumNumberOfResults int // maximum number of results that an endpoint can have
 	maximumNumberOfEvents  int // maximum number of events that an endpoint can have
+
+	uptimeRetention *storage.UptimeRetentionConfig // how long uptime statistics are kept, and at which granularity
+
+	path                  string   // path of the snapshot, or blank if persistence is disabled
+	writeAheadLog         *os.File // file to which every mutation made since the last snapshot is appended
+	writeAheadLogSequence uint64   // sequence number of the last mutation appended to the write-ahead log
+}
+
+// triggeredEndpointAlert is the information necessary to resolve an alert that has been triggered
+type triggeredEndpointAlert struct {
+	ResolveKey              string
+	NumberOfSuccessesInARow int
+	TriggeredAt             time.Time
+	EscalationResolveKeys   []string
+}
+
+// lease is the holder of a lease and the time at which it expires unless it's renewed
+type lease struct {
+	holder    string
+	expiresAt time.Time
 }
 
 // NewStore creates a new store using gocache.Cache
 //
-// This store holds everything in memory, and if the file parameter is not blank,
-// supports eventual persistence.
-func NewStore(maximumNumberOfResults, maximumNumberOfEvents int) (*Store, error) {
+// This store holds everything in memory, and if the path parameter is not blank, supports persistence by
+// periodically writing a compressed snapshot of its content to the path, and by appending every mutation made since
+// the last snapshot to a write-ahead log next to it. Both are loaded when the store is created.
+func NewStore(path string, maximumNumberOfResults, maximumNumberOfEvents int) (*Store, error) {
 	store := &Store{
 		endpointCache:          gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
 		suiteCache:             gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		incidents:              make(map[int64]*incident.Incident),
+		silences:               make(map[int64]*silence.Silence),
+		acknowledgements:       make(map[string]*silence.Acknowledgement),
+		maintenanceWindows:     make(map[int64]*maintenance.Window),
+		triggeredAlerts:        make(map[string]map[string]*triggeredEndpointAlert),
+		leases:                 make(map[string]*lease),
+		locationResults:        make(map[string]map[string]*endpoint.Result),
+		apiTokenLastUsedTimes:  make(map[string]time.Time),
+		sessions:               make(map[string]*session.Session),
 		maximumNumberOfResults: maximumNumberOfResults,
 		maximumNumberOfEvents:  maximumNumberOfEvents,
+		uptimeRetention:        storage.GetDefaultUptimeRetentionConfig(),
+		path:                   path,
+	}
+	if len(path) > 0 {
+		if err := store.load(); err != nil {
+			return nil, err
+		}
 	}
 	return store, nil
 }
 
+// WithUptimeRetention sets how long uptime entries are kept, and at which granularity.
+// If nil, the default uptime retention is used.
+func (s *Store) WithUptimeRetention(uptimeRetention *storage.UptimeRetentionConfig) *Store {
+	if uptimeRetention == nil {
+		uptimeRetention = storage.GetDefaultUptimeRetentionConfig()
+	}
+	s.uptimeRetention = uptimeRetention
+	return s
+}
+
 // GetAllEndpointStatuses returns all monitored endpoint.Status
 // with a subset of endpoint.Result defined by the page and pageSize parameters
 func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*endpoint.Status, error) {
@@ -115,18 +191,10 @@ func (s *Store) GetUptimeByKey(key string, from, to time.Time) (float64, error)
 	}
 	successfulExecutions := uint64(0)
 	totalExecutions := uint64(0)
-	current := from
-	for to.Sub(current) >= 0 {
-		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
-		hourlyStats := endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics[hourlyUnixTimestamp]
-		if hourlyStats == nil || hourlyStats.TotalExecutions == 0 {
-			current = current.Add(time.Hour)
-			continue
-		}
-		successfulExecutions += hourlyStats.SuccessfulExecutions
-		totalExecutions += hourlyStats.TotalExecutions
-		current = current.Add(time.Hour)
-	}
+	forEachUptimeStatisticsBetween(endpointStatus.(*endpoint.Status).Uptime, from, to, func(_ int64, statistics *endpoint.HourlyUptimeStatistics) {
+		successfulExecutions += statistics.SuccessfulExecutions
+		totalExecutions += statistics.TotalExecutions
+	})
 	if totalExecutions == 0 {
 		return 0, nil
 	}
@@ -144,19 +212,11 @@ func (s *Store) GetAverageResponseTimeByKey(key string, from, to time.Time) (int
 	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
 		return 0, common.ErrEndpointNotFound
 	}
-	current := from
 	var totalExecutions, totalResponseTime uint64
-	for to.Sub(current) >= 0 {
-		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
-		hourlyStats := endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics[hourlyUnixTimestamp]
-		if hourlyStats == nil || hourlyStats.TotalExecutions == 0 {
-			current = current.Add(time.Hour)
-			continue
-		}
-		totalExecutions += hourlyStats.TotalExecutions
-		totalResponseTime += hourlyStats.TotalExecutionsResponseTime
-		current = current.Add(time.Hour)
-	}
+	forEachUptimeStatisticsBetween(endpointStatus.(*endpoint.Status).Uptime, from, to, func(_ int64, statistics *endpoint.HourlyUptimeStatistics) {
+		totalExecutions += statistics.TotalExecutions
+		totalResponseTime += statistics.TotalExecutionsResponseTime
+	})
 	if totalExecutions == 0 {
 		return 0, nil
 	}
@@ -175,49 +235,106 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 		return nil, common.ErrEndpointNotFound
 	}
 	hourlyAverageResponseTimes := make(map[int64]int)
-	current := from
-	for to.Sub(current) >= 0 {
-		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
-		hourlyStats := endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics[hourlyUnixTimestamp]
-		if hourlyStats == nil || hourlyStats.TotalExecutions == 0 {
-			current = current.Add(time.Hour)
-			continue
+	forEachUptimeStatisticsBetween(endpointStatus.(*endpoint.Status).Uptime, from, to, func(unixTimestamp int64, statistics *endpoint.HourlyUptimeStatistics) {
+		if statistics.TotalExecutions > 0 {
+			hourlyAverageResponseTimes[unixTimestamp] = int(float64(statistics.TotalExecutionsResponseTime) / float64(statistics.TotalExecutions))
 		}
-		hourlyAverageResponseTimes[hourlyUnixTimestamp] = int(float64(hourlyStats.TotalExecutionsResponseTime) / float64(hourlyStats.TotalExecutions))
-		current = current.Add(time.Hour)
-	}
+	})
 	return hourlyAverageResponseTimes, nil
 }
 
+// GetUptimeStatisticsByKey returns the sum of the uptime statistics during a time range
+func (s *Store) GetUptimeStatisticsByKey(key string, from, to time.Time) (*endpoint.HourlyUptimeStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	s.RLock()
+	defer s.RUnlock()
+	endpointStatus := s.endpointCache.GetValue(key)
+	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	totalStatistics := &endpoint.HourlyUptimeStatistics{}
+	forEachUptimeStatisticsBetween(endpointStatus.(*endpoint.Status).Uptime, from, to, func(_ int64, statistics *endpoint.HourlyUptimeStatistics) {
+		totalStatistics.Add(statistics)
+	})
+	return totalStatistics, nil
+}
+
+// GetHourlyUptimeStatisticsByKey returns a map of hourly (key) uptime statistics (value) during a time range
+func (s *Store) GetHourlyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*endpoint.HourlyUptimeStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	s.RLock()
+	defer s.RUnlock()
+	endpointStatus := s.endpointCache.GetValue(key)
+	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	hourlyStatistics := make(map[int64]*endpoint.HourlyUptimeStatistics)
+	forEachUptimeStatisticsBetween(endpointStatus.(*endpoint.Status).Uptime, from, to, func(unixTimestamp int64, statistics *endpoint.HourlyUptimeStatistics) {
+		// Copy the statistics, since they may be modified as soon as the lock is released
+		hourlyStatistics[unixTimestamp] = &endpoint.HourlyUptimeStatistics{}
+		hourlyStatistics[unixTimestamp].Add(statistics)
+	})
+	return hourlyStatistics, nil
+}
+
 // InsertEndpointResult adds the observed result for the specified endpoint into the store
 func (s *Store) InsertEndpointResult(ep *endpoint.Endpoint, result *endpoint.Result) error {
-	endpointKey := ep.Key()
 	s.Lock()
+	defer s.Unlock()
+	now := time.Now()
+	latencyThreshold := ep.SLO.LatencyThreshold()
+	s.addEndpointResult(ep.Key(), ep.Group, ep.Name, result, now, s.uptimeRetention, latencyThreshold)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{
+		Type:             recordTypeEndpointResult,
+		Timestamp:        now,
+		Key:              ep.Key(),
+		Group:            ep.Group,
+		Name:             ep.Name,
+		EndpointResult:   copyEndpointResultForPersistence(result),
+		LatencyThreshold: latencyThreshold,
+	})
+}
+
+// addEndpointResult adds a result to the status of the endpoint with the given key, creating the status if needed
+func (s *Store) addEndpointResult(endpointKey, group, name string, result *endpoint.Result, now time.Time, uptimeRetention *storage.UptimeRetentionConfig, latencyThreshold time.Duration) {
 	status, exists := s.endpointCache.Get(endpointKey)
 	if !exists {
-		status = endpoint.NewStatus(ep.Group, ep.Name)
+		status = endpoint.NewStatus(group, name)
 		status.(*endpoint.Status).Events = append(status.(*endpoint.Status).Events, &endpoint.Event{
 			Type:      endpoint.EventStart,
-			Timestamp: time.Now(),
+			Timestamp: now,
 		})
 	}
-	AddResult(status.(*endpoint.Status), result, s.maximumNumberOfResults, s.maximumNumberOfEvents)
+	AddResult(status.(*endpoint.Status), result, s.maximumNumberOfResults, s.maximumNumberOfEvents, uptimeRetention, latencyThreshold)
 	s.endpointCache.Set(endpointKey, status)
-	s.Unlock()
-	return nil
 }
 
 // InsertSuiteResult adds the observed result for the specified suite into the store
 func (s *Store) InsertSuiteResult(su *suite.Suite, result *suite.Result) error {
 	s.Lock()
 	defer s.Unlock()
-	suiteKey := su.Key()
+	s.addSuiteResult(su.Key(), su.Group, su.Name, result)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{
+		Type:        recordTypeSuiteResult,
+		Key:         su.Key(),
+		Group:       su.Group,
+		Name:        su.Name,
+		SuiteResult: copySuiteResultForPersistence(result),
+	})
+}
+
+// addSuiteResult adds a result to the status of the suite with the given key, creating the status if needed
+func (s *Store) addSuiteResult(suiteKey, group, name string, result *suite.Result) {
 	suiteStatus := s.suiteCache.GetValue(suiteKey)
 	if suiteStatus == nil {
 		suiteStatus = &suite.Status{
-			Name:    su.Name,
-			Group:   su.Group,
-			Key:     su.Key(),
+			Name:    name,
+			Group:   group,
+			Key:     suiteKey,
 			Results: []*suite.Result{},
 		}
 		logr.Debugf("[memory.InsertSuiteResult] Created new suite status for suiteKey=%s", suiteKey)
@@ -231,11 +348,20 @@ func (s *Store) InsertSuiteResult(su *suite.Suite, result *suite.Result) error {
 	}
 	s.suiteCache.Set(suiteKey, status)
 	logr.Debugf("[memory.InsertSuiteResult] Stored suite result for suiteKey=%s, total results=%d", suiteKey, len(status.Results))
-	return nil
 }
 
 // DeleteAllEndpointStatusesNotInKeys removes all Status that are not within the keys provided
 func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
+	s.Lock()
+	defer s.Unlock()
+	numberOfDeletedStatuses := s.deleteAllEndpointStatusesNotInKeys(keys)
+	_ = s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeDeleteEndpointStatusesNotInKeys, Keys: keys})
+	return numberOfDeletedStatuses
+}
+
+// deleteAllEndpointStatusesNotInKeys removes all Status that are not within the keys provided, as well as the
+// triggered alerts and the results reported from each location of their endpoints
+func (s *Store) deleteAllEndpointStatusesNotInKeys(keys []string) int {
 	var keysToDelete []string
 	for _, existingKey := range s.endpointCache.GetKeysByPattern("*", 0) {
 		shouldDelete := !slices.Contains(keys, existingKey)
@@ -243,6 +369,16 @@ func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
 			keysToDelete = append(keysToDelete, existingKey)
 		}
 	}
+	for endpointKey := range s.triggeredAlerts {
+		if !slices.Contains(keys, endpointKey) {
+			delete(s.triggeredAlerts, endpointKey)
+		}
+	}
+	for endpointKey := range s.locationResults {
+		if !slices.Contains(keys, endpointKey) {
+			delete(s.locationResults, endpointKey)
+		}
+	}
 	return s.endpointCache.DeleteAll(keysToDelete)
 }
 
@@ -250,6 +386,13 @@ func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
 func (s *Store) DeleteAllSuiteStatusesNotInKeys(keys []string) int {
 	s.Lock()
 	defer s.Unlock()
+	numberOfDeletedStatuses := s.deleteAllSuiteStatusesNotInKeys(keys)
+	_ = s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeDeleteSuiteStatusesNotInKeys, Keys: keys})
+	return numberOfDeletedStatuses
+}
+
+// deleteAllSuiteStatusesNotInKeys removes all suite statuses that are not within the keys provided
+func (s *Store) deleteAllSuiteStatusesNotInKeys(keys []string) int {
 	keysToKeep := make(map[string]bool, len(keys))
 	for _, k := range keys {
 		keysToKeep[k] = true
@@ -264,34 +407,102 @@ func (s *Store) DeleteAllSuiteStatusesNotInKeys(keys []string) int {
 }
 
 // GetTriggeredEndpointAlert returns whether the triggered alert for the specified endpoint as well as the necessary information to resolve it
-//
-// Always returns that the alert does not exist for the in-memory store since it does not support persistence across restarts
 func (s *Store) GetTriggeredEndpointAlert(ep *endpoint.Endpoint, alert *alert.Alert) (exists bool, resolveKey string, numberOfSuccessesInARow int, err error) {
-	return false, "", 0, nil
+	s.RLock()
+	defer s.RUnlock()
+	triggeredAlert, exists := s.triggeredAlerts[ep.Key()][alert.Checksum()]
+	if !exists {
+		return false, "", 0, nil
+	}
+	return true, triggeredAlert.ResolveKey, triggeredAlert.NumberOfSuccessesInARow, nil
 }
 
 // UpsertTriggeredEndpointAlert inserts/updates a triggered alert for an endpoint
 // Used for persistence of triggered alerts across application restarts
-//
-// Does nothing for the in-memory store since it does not support persistence across restarts
 func (s *Store) UpsertTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
-	return nil
+	s.Lock()
+	defer s.Unlock()
+	alertChecksum := triggeredAlert.Checksum()
+	newTriggeredAlert := &triggeredEndpointAlert{
+		ResolveKey:              triggeredAlert.ResolveKey,
+		NumberOfSuccessesInARow: ep.NumberOfSuccessesInARow, // We only persist NumberOfSuccessesInARow, because all alerts stored here are already triggered
+		TriggeredAt:             triggeredAlert.TriggeredAt,
+	}
+	if len(triggeredAlert.EscalationPolicy) > 0 {
+		newTriggeredAlert.EscalationResolveKeys = slices.Clone(triggeredAlert.EscalationResolveKeys)
+	} else if existingTriggeredAlert, exists := s.triggeredAlerts[ep.Key()][alertChecksum]; exists {
+		newTriggeredAlert.EscalationResolveKeys = existingTriggeredAlert.EscalationResolveKeys
+	}
+	s.upsertTriggeredAlert(ep.Key(), alertChecksum, newTriggeredAlert)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{
+		Type:           recordTypeUpsertTriggeredAlert,
+		Key:            ep.Key(),
+		AlertChecksum:  alertChecksum,
+		TriggeredAlert: newTriggeredAlert,
+	})
+}
+
+// upsertTriggeredAlert inserts/updates the triggered alert with the given checksum for the endpoint with the given key
+func (s *Store) upsertTriggeredAlert(endpointKey, alertChecksum string, triggeredAlert *triggeredEndpointAlert) {
+	if s.triggeredAlerts[endpointKey] == nil {
+		s.triggeredAlerts[endpointKey] = make(map[string]*triggeredEndpointAlert)
+	}
+	s.triggeredAlerts[endpointKey][alertChecksum] = triggeredAlert
+}
+
+// GetTriggeredEndpointAlertEscalation returns the time at which the triggered alert for the specified endpoint was
+// triggered as well as the resolve keys of the escalation steps that have been reached, in order
+func (s *Store) GetTriggeredEndpointAlertEscalation(ep *endpoint.Endpoint, alert *alert.Alert) (triggeredAt time.Time, resolveKeys []string, err error) {
+	s.RLock()
+	defer s.RUnlock()
+	triggeredAlert, exists := s.triggeredAlerts[ep.Key()][alert.Checksum()]
+	if !exists {
+		return time.Time{}, nil, nil
+	}
+	return triggeredAlert.TriggeredAt, slices.Clone(triggeredAlert.EscalationResolveKeys), nil
 }
 
 // DeleteTriggeredEndpointAlert deletes a triggered alert for an endpoint
-//
-// Does nothing for the in-memory store since it does not support persistence across restarts
 func (s *Store) DeleteTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
-	return nil
+	s.Lock()
+	defer s.Unlock()
+	alertChecksum := triggeredAlert.Checksum()
+	s.deleteTriggeredAlert(ep.Key(), alertChecksum)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeDeleteTriggeredAlert, Key: ep.Key(), AlertChecksum: alertChecksum})
+}
+
+// deleteTriggeredAlert deletes the triggered alert with the given checksum for the endpoint with the given key
+func (s *Store) deleteTriggeredAlert(endpointKey, alertChecksum string) {
+	delete(s.triggeredAlerts[endpointKey], alertChecksum)
+	if len(s.triggeredAlerts[endpointKey]) == 0 {
+		delete(s.triggeredAlerts, endpointKey)
+	}
 }
 
 // DeleteAllTriggeredAlertsNotInChecksumsByEndpoint removes all triggered alerts owned by an endpoint whose alert
 // configurations are not provided in the checksums list.
 // This prevents triggered alerts that have been removed or modified from lingering in the database.
-//
-// Does nothing for the in-memory store since it does not support persistence across restarts
 func (s *Store) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.Endpoint, checksums []string) int {
-	return 0
+	s.Lock()
+	defer s.Unlock()
+	numberOfDeletedTriggeredAlerts := s.deleteAllTriggeredAlertsNotInChecksums(ep.Key(), checksums)
+	if numberOfDeletedTriggeredAlerts > 0 {
+		_ = s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeDeleteTriggeredAlertsNotInChecksums, Key: ep.Key(), Keys: checksums})
+	}
+	return numberOfDeletedTriggeredAlerts
+}
+
+// deleteAllTriggeredAlertsNotInChecksums removes all triggered alerts of the endpoint with the given key whose
+// checksum is not within the checksums provided
+func (s *Store) deleteAllTriggeredAlertsNotInChecksums(endpointKey string, checksums []string) int {
+	numberOfDeletedTriggeredAlerts := 0
+	for alertChecksum := range s.triggeredAlerts[endpointKey] {
+		if !slices.Contains(checksums, alertChecksum) {
+			s.deleteTriggeredAlert(endpointKey, alertChecksum)
+			numberOfDeletedTriggeredAlerts++
+		}
+	}
+	return numberOfDeletedTriggeredAlerts
 }
 
 // HasEndpointStatusNewerThan checks whether an endpoint has a status newer than the provided timestamp
@@ -315,18 +526,344 @@ func (s *Store) HasEndpointStatusNewerThan(key string, timestamp time.Time) (boo
 	return false, nil
 }
 
+// GetAllIncidents returns all incidents, sorted from the most recent to the oldest
+func (s *Store) GetAllIncidents() ([]*incident.Incident, error) {
+	s.RLock()
+	defer s.RUnlock()
+	incidents := make([]*incident.Incident, 0, len(s.incidents))
+	for _, inc := range s.incidents {
+		incidents = append(incidents, CopyIncident(inc))
+	}
+	sort.Slice(incidents, func(i, j int) bool {
+		return incidents[i].ID > incidents[j].ID
+	})
+	return incidents, nil
+}
+
+// GetIncidentByID returns the incident with the given ID
+func (s *Store) GetIncidentByID(id int64) (*incident.Incident, error) {
+	s.RLock()
+	defer s.RUnlock()
+	inc, exists := s.incidents[id]
+	if !exists {
+		return nil, common.ErrIncidentNotFound
+	}
+	return CopyIncident(inc), nil
+}
+
+// InsertIncident inserts a new incident in the store and sets its ID
+func (s *Store) InsertIncident(inc *incident.Incident) error {
+	s.Lock()
+	defer s.Unlock()
+	s.lastIncidentID++
+	inc.ID = s.lastIncidentID
+	s.incidents[inc.ID] = CopyIncident(inc)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeUpsertIncident, Incident: inc})
+}
+
+// UpdateIncident replaces an existing incident, including its timeline of updates
+func (s *Store) UpdateIncident(inc *incident.Incident) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.incidents[inc.ID]; !exists {
+		return common.ErrIncidentNotFound
+	}
+	s.incidents[inc.ID] = CopyIncident(inc)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeUpsertIncident, Incident: inc})
+}
+
+// GetAllSilences returns all silences that haven't expired yet, sorted from the most recent to the oldest
+func (s *Store) GetAllSilences() ([]*silence.Silence, error) {
+	s.RLock()
+	defer s.RUnlock()
+	silences := make([]*silence.Silence, 0, len(s.silences))
+	for _, sil := range s.silences {
+		if sil.IsActive() {
+			silenceCopy := *sil
+			silences = append(silences, &silenceCopy)
+		}
+	}
+	sort.Slice(silences, func(i, j int) bool {
+		return silences[i].ID > silences[j].ID
+	})
+	return silences, nil
+}
+
+// InsertSilence inserts a new silence in the store and sets its ID
+//
+// Silences that have expired are deleted in the process
+func (s *Store) InsertSilence(sil *silence.Silence) error {
+	s.Lock()
+	defer s.Unlock()
+	for id, existingSilence := range s.silences {
+		if !existingSilence.IsActive() {
+			delete(s.silences, id)
+		}
+	}
+	s.lastSilenceID++
+	sil.ID = s.lastSilenceID
+	silenceCopy := *sil
+	s.silences[sil.ID] = &silenceCopy
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeInsertSilence, Silence: sil})
+}
+
+// DeleteSilence deletes the silence with the given ID
+func (s *Store) DeleteSilence(id int64) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.silences[id]; !exists {
+		return common.ErrSilenceNotFound
+	}
+	delete(s.silences, id)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeDeleteSilence, ID: id})
+}
+
+// GetAllAlertAcknowledgements returns all acknowledgements of triggered alerts
+func (s *Store) GetAllAlertAcknowledgements() ([]*silence.Acknowledgement, error) {
+	s.RLock()
+	defer s.RUnlock()
+	acknowledgements := make([]*silence.Acknowledgement, 0, len(s.acknowledgements))
+	for _, ack := range s.acknowledgements {
+		ackCopy := *ack
+		acknowledgements = append(acknowledgements, &ackCopy)
+	}
+	sort.Slice(acknowledgements, func(i, j int) bool {
+		return acknowledgements[i].AcknowledgedAt.After(acknowledgements[j].AcknowledgedAt)
+	})
+	return acknowledgements, nil
+}
+
+// UpsertAlertAcknowledgement inserts/updates the acknowledgement of a triggered alert
+func (s *Store) UpsertAlertAcknowledgement(ack *silence.Acknowledgement) error {
+	s.Lock()
+	defer s.Unlock()
+	ackCopy := *ack
+	s.acknowledgements[ack.EndpointKey+"|"+ack.AlertChecksum] = &ackCopy
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeUpsertAcknowledgement, Acknowledgement: ack})
+}
+
+// DeleteAlertAcknowledgement deletes the acknowledgement of an alert
+func (s *Store) DeleteAlertAcknowledgement(endpointKey, alertChecksum string) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.acknowledgements[endpointKey+"|"+alertChecksum]; !exists {
+		return common.ErrAcknowledgementNotFound
+	}
+	delete(s.acknowledgements, endpointKey+"|"+alertChecksum)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeDeleteAcknowledgement, Key: endpointKey, AlertChecksum: alertChecksum})
+}
+
+// GetAllMaintenanceWindows returns all maintenance windows that haven't expired yet, sorted from the most recent to
+// the oldest
+func (s *Store) GetAllMaintenanceWindows() ([]*maintenance.Window, error) {
+	s.RLock()
+	defer s.RUnlock()
+	windows := make([]*maintenance.Window, 0, len(s.maintenanceWindows))
+	for _, window := range s.maintenanceWindows {
+		if !window.HasExpired() {
+			windows = append(windows, CopyMaintenanceWindow(window))
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].ID > windows[j].ID
+	})
+	return windows, nil
+}
+
+// InsertMaintenanceWindow inserts a new maintenance window in the store and sets its ID
+//
+// Maintenance windows that have expired are deleted in the process
+func (s *Store) InsertMaintenanceWindow(window *maintenance.Window) error {
+	s.Lock()
+	defer s.Unlock()
+	for id, existingWindow := range s.maintenanceWindows {
+		if existingWindow.HasExpired() {
+			delete(s.maintenanceWindows, id)
+		}
+	}
+	s.lastMaintenanceWindowID++
+	window.ID = s.lastMaintenanceWindowID
+	s.maintenanceWindows[window.ID] = CopyMaintenanceWindow(window)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeInsertMaintenanceWindow, MaintenanceWindow: window})
+}
+
+// DeleteMaintenanceWindow deletes the maintenance window with the given ID
+func (s *Store) DeleteMaintenanceWindow(id int64) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.maintenanceWindows[id]; !exists {
+		return common.ErrMaintenanceWindowNotFound
+	}
+	delete(s.maintenanceWindows, id)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeDeleteMaintenanceWindow, ID: id})
+}
+
+// AcquireLease acquires the lease with the given name for the given holder if it isn't held by anybody else, or renews
+// it if it's already held by the given holder
+//
+// Returns whether the given holder holds the lease
+func (s *Store) AcquireLease(_ context.Context, name, holder string, duration time.Duration) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+	now := time.Now()
+	if existingLease, exists := s.leases[name]; exists && existingLease.holder != holder && existingLease.expiresAt.After(now) {
+		return false, nil
+	}
+	s.leases[name] = &lease{holder: holder, expiresAt: now.Add(duration)}
+	return true, nil
+}
+
+// ReleaseLease releases the lease with the given name if it's held by the given holder
+func (s *Store) ReleaseLease(name, holder string) error {
+	s.Lock()
+	defer s.Unlock()
+	if existingLease, exists := s.leases[name]; exists && existingLease.holder == holder {
+		delete(s.leases, name)
+	}
+	return nil
+}
+
+// GetLatestLocationResults returns the latest result reported from each location for the endpoint with the given key,
+// keyed by the name of the location
+func (s *Store) GetLatestLocationResults(key string) (map[string]*endpoint.Result, error) {
+	s.RLock()
+	defer s.RUnlock()
+	latestResults := make(map[string]*endpoint.Result, len(s.locationResults[key]))
+	for location, result := range s.locationResults[key] {
+		latestResults[location] = result
+	}
+	return latestResults, nil
+}
+
+// UpsertLatestLocationResult replaces the latest result reported from the given location for the endpoint
+func (s *Store) UpsertLatestLocationResult(ep *endpoint.Endpoint, location string, result *endpoint.Result) error {
+	s.Lock()
+	defer s.Unlock()
+	key := ep.Key()
+	if _, exists := s.locationResults[key]; !exists {
+		s.locationResults[key] = make(map[string]*endpoint.Result)
+	}
+	s.locationResults[key][location] = result
+	return nil
+}
+
+// GetAllAPITokenLastUsedTimes returns the last time each API token was used, keyed by the name of the token
+func (s *Store) GetAllAPITokenLastUsedTimes() (map[string]time.Time, error) {
+	s.RLock()
+	defer s.RUnlock()
+	lastUsedTimes := make(map[string]time.Time, len(s.apiTokenLastUsedTimes))
+	for name, lastUsedAt := range s.apiTokenLastUsedTimes {
+		lastUsedTimes[name] = lastUsedAt
+	}
+	return lastUsedTimes, nil
+}
+
+// UpdateAPITokenLastUsedTime records the last time the API token with the given name was used
+func (s *Store) UpdateAPITokenLastUsedTime(name string, lastUsedAt time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+	s.apiTokenLastUsedTimes[name] = lastUsedAt
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeUpdateAPITokenLastUsedTime, Name: name, Timestamp: lastUsedAt})
+}
+
+// GetAllSessions returns all sessions that haven't expired yet, sorted from the most recent to the oldest
+func (s *Store) GetAllSessions() ([]*session.Session, error) {
+	s.RLock()
+	defer s.RUnlock()
+	sessions := make([]*session.Session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		if !sess.HasExpired() {
+			sessions = append(sessions, CopySession(sess))
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+// GetSessionByID returns the session with the given ID, or returns common.ErrSessionNotFound if it doesn't exist or
+// if it has expired
+func (s *Store) GetSessionByID(id string) (*session.Session, error) {
+	s.RLock()
+	defer s.RUnlock()
+	sess, exists := s.sessions[id]
+	if !exists || sess.HasExpired() {
+		return nil, common.ErrSessionNotFound
+	}
+	return CopySession(sess), nil
+}
+
+// UpsertSession inserts/updates a session
+//
+// Sessions that have expired are deleted in the process
+func (s *Store) UpsertSession(sess *session.Session) error {
+	s.Lock()
+	defer s.Unlock()
+	for id, existingSession := range s.sessions {
+		if existingSession.HasExpired() {
+			delete(s.sessions, id)
+		}
+	}
+	s.sessions[sess.ID] = CopySession(sess)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeUpsertSession, Session: sess})
+}
+
+// DeleteSession deletes the session with the given ID
+func (s *Store) DeleteSession(id string) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.sessions[id]; !exists {
+		return common.ErrSessionNotFound
+	}
+	delete(s.sessions, id)
+	return s.appendToWriteAheadLog(&writeAheadLogRecord{Type: recordTypeDeleteSession, Key: id})
+}
+
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	s.endpointCache.Clear()
 	s.suiteCache.Clear()
+	s.Lock()
+	defer s.Unlock()
+	s.incidents = make(map[int64]*incident.Incident)
+	s.lastIncidentID = 0
+	s.silences = make(map[int64]*silence.Silence)
+	s.lastSilenceID = 0
+	s.acknowledgements = make(map[string]*silence.Acknowledgement)
+	s.maintenanceWindows = make(map[int64]*maintenance.Window)
+	s.lastMaintenanceWindowID = 0
+	s.triggeredAlerts = make(map[string]map[string]*triggeredEndpointAlert)
+	s.leases = make(map[string]*lease)
+	s.locationResults = make(map[string]map[string]*endpoint.Result)
+	s.apiTokenLastUsedTimes = make(map[string]time.Time)
+	s.sessions = make(map[string]*session.Session)
+	if s.writeAheadLog != nil {
+		if err := s.writeSnapshot(); err != nil {
+			logr.Errorf("[memory.Clear] Failed to write snapshot: %s", err.Error())
+		}
+	}
 }
 
-// Save persists the cache to the store file
+// Save writes a snapshot of the store to its path and empties the write-ahead log, if persistence is enabled
 func (s *Store) Save() error {
-	return nil
+	s.Lock()
+	defer s.Unlock()
+	if s.writeAheadLog == nil {
+		return nil
+	}
+	return s.writeSnapshot()
 }
 
-// Close does nothing, because there's nothing to close
+// Close closes the write-ahead log, if persistence is enabled.
+// Mutations made after the store has been closed are no longer persisted.
 func (s *Store) Close() {
-	return
+	s.Lock()
+	defer s.Unlock()
+	if s.writeAheadLog != nil {
+		if err := s.writeAheadLog.Close(); err != nil {
+			logr.Errorf("[memory.Close] Failed to close write-ahead log: %s", err.Error())
+		}
+		s.writeAheadLog = nil
+	}
 }
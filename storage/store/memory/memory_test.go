@@ -1,13 +1,22 @@
 package memory
 
 import (
+	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/silence"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+	"github.com/TwiN/gatus/v5/config/incident"
+	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security/session"
 	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
 
@@ -85,7 +94,7 @@ var (
 // Note that are much more extensive tests in /storage/store/store_test.go.
 // This test is simply an extra sanity check
 func TestStore_SanityCheck(t *testing.T) {
-	store, _ := NewStore(storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
 	defer store.Clear()
 	defer store.Close()
 	store.InsertEndpointResult(&testEndpoint, &testSuccessfulResult)
@@ -126,7 +135,7 @@ func TestStore_SanityCheck(t *testing.T) {
 }
 
 func TestStore_Save(t *testing.T) {
-	store, err := NewStore(storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	store, err := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
 	if err != nil {
 		t.Fatal("expected no error, got", err.Error())
 	}
@@ -139,7 +148,7 @@ func TestStore_Save(t *testing.T) {
 }
 
 func TestStore_HasEndpointStatusNewerThan(t *testing.T) {
-	store, _ := NewStore(storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
 	defer store.Clear()
 	defer store.Close()
 	// InsertEndpointResult a result
@@ -170,7 +179,7 @@ func TestStore_HasEndpointStatusNewerThan(t *testing.T) {
 func TestStore_MixedEndpointsAndSuites(t *testing.T) {
 	// Helper function to create and populate a store with test data
 	setupStore := func(t *testing.T) (*Store, *endpoint.Endpoint, *endpoint.Endpoint, *endpoint.Endpoint, *endpoint.Endpoint, *suite.Suite) {
-		store, err := NewStore(100, 50)
+		store, err := NewStore("", 100, 50)
 		if err != nil {
 			t.Fatal("expected no error, got", err)
 		}
@@ -640,7 +649,7 @@ func TestStore_MixedEndpointsAndSuites(t *testing.T) {
 
 // TestStore_EndpointStatusCastingSafety tests that type assertions are safe
 func TestStore_EndpointStatusCastingSafety(t *testing.T) {
-	store, err := NewStore(100, 50)
+	store, err := NewStore("", 100, 50)
 	if err != nil {
 		t.Fatal("expected no error, got", err)
 	}
@@ -691,7 +700,7 @@ func TestStore_MaximumLimits(t *testing.T) {
 	// Use small limits to test trimming behavior
 	maxResults := 5
 	maxEvents := 3
-	store, err := NewStore(maxResults, maxEvents)
+	store, err := NewStore("", maxResults, maxEvents)
 	if err != nil {
 		t.Fatal("expected no error, got", err)
 	}
@@ -785,7 +794,7 @@ func TestStore_MaximumLimits(t *testing.T) {
 }
 
 func TestSuiteResultOrdering(t *testing.T) {
-	store, err := NewStore(10, 5)
+	store, err := NewStore("", 10, 5)
 	if err != nil {
 		t.Fatal("expected no error, got", err)
 	}
@@ -869,7 +878,7 @@ func TestSuiteResultOrdering(t *testing.T) {
 	})
 
 	t.Run("trimming-preserves-newest", func(t *testing.T) {
-		limitedStore, err := NewStore(3, 2) // Very small limits
+		limitedStore, err := NewStore("", 3, 2) // Very small limits
 		if err != nil {
 			t.Fatal("expected no error, got", err)
 		}
@@ -912,7 +921,7 @@ func TestSuiteResultOrdering(t *testing.T) {
 }
 
 func TestStore_ConcurrentAccess(t *testing.T) {
-	store, err := NewStore(100, 50)
+	store, err := NewStore("", 100, 50)
 	if err != nil {
 		t.Fatal("expected no error, got", err)
 	}
@@ -1093,3 +1102,497 @@ func TestStore_ConcurrentAccess(t *testing.T) {
 		}
 	})
 }
+
+func TestStore_Incidents(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Clear()
+	defer store.Close()
+	if _, err := store.GetIncidentByID(1); !errors.Is(err, common.ErrIncidentNotFound) {
+		t.Fatalf("expected error %v, got %v", common.ErrIncidentNotFound, err)
+	}
+	firstIncident, _ := incident.NewIncident("Frontend is down", incident.SeverityMajor, "", "We are investigating")
+	firstIncident.EndpointKeys = []string{"core_frontend", "core_backend"}
+	if err := store.InsertIncident(firstIncident); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	secondIncident, _ := incident.NewIncident("Checkout is slow", incident.SeverityMinor, incident.StatusIdentified, "Payment provider is degraded")
+	secondIncident.SuiteKeys = []string{"flows_checkout"}
+	if err := store.InsertIncident(secondIncident); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if firstIncident.ID == 0 || firstIncident.ID == secondIncident.ID {
+		t.Fatalf("expected unique IDs to be assigned, got %d and %d", firstIncident.ID, secondIncident.ID)
+	}
+	incidents, err := store.GetAllIncidents()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(incidents) != 2 {
+		t.Fatalf("expected 2 incidents, got %d", len(incidents))
+	}
+	if incidents[0].ID != secondIncident.ID || incidents[1].ID != firstIncident.ID {
+		t.Error("expected incidents to be sorted from the most recent to the oldest")
+	}
+	if len(incidents[0].SuiteKeys) != 1 || len(incidents[0].EndpointKeys) != 0 {
+		t.Errorf("expected 1 suite key and no endpoint keys, got %v and %v", incidents[0].SuiteKeys, incidents[0].EndpointKeys)
+	}
+	// Update the first incident
+	_ = firstIncident.AddUpdate(incident.StatusMonitoring, "A fix has been deployed")
+	_ = firstIncident.Resolve("")
+	firstIncident.Severity = incident.SeverityCritical
+	if err = store.UpdateIncident(firstIncident); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	retrievedIncident, err := store.GetIncidentByID(firstIncident.ID)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if retrievedIncident.Severity != incident.SeverityCritical || !retrievedIncident.IsResolved() {
+		t.Errorf("expected incident to be critical and resolved, got severity=%s and status=%s", retrievedIncident.Severity, retrievedIncident.Status)
+	}
+	if len(retrievedIncident.EndpointKeys) != 2 {
+		t.Errorf("expected 2 endpoint keys, got %v", retrievedIncident.EndpointKeys)
+	}
+	if len(retrievedIncident.Updates) != 3 {
+		t.Fatalf("expected 3 updates, got %d", len(retrievedIncident.Updates))
+	}
+	if retrievedIncident.Updates[0].Status != incident.StatusInvestigating || retrievedIncident.Updates[2].Status != incident.StatusResolved {
+		t.Error("expected updates to be sorted from the oldest to the most recent")
+	}
+	// Updating a nonexistent incident should fail
+	if err = store.UpdateIncident(&incident.Incident{ID: 999, Updates: firstIncident.Updates}); !errors.Is(err, common.ErrIncidentNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrIncidentNotFound, err)
+	}
+	store.Clear()
+	if incidents, _ = store.GetAllIncidents(); len(incidents) != 0 {
+		t.Errorf("expected no incidents after clearing the store, got %d", len(incidents))
+	}
+}
+
+func TestStore_SilencesAndAcknowledgements(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Clear()
+	defer store.Close()
+	expiredSilence, _ := silence.NewSilence(silence.Matcher{Group: "core"}, time.Hour, "Expired", "")
+	expiredSilence.ExpiresAt = time.Now().Add(-time.Minute)
+	activeSilence, _ := silence.NewSilence(silence.Matcher{EndpointKey: "core_*", AlertType: alert.TypeSlack}, time.Hour, "Maintenance", "john.doe")
+	for _, sil := range []*silence.Silence{activeSilence, expiredSilence} {
+		if err := store.InsertSilence(sil); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	if activeSilence.ID == 0 || activeSilence.ID == expiredSilence.ID {
+		t.Fatalf("expected unique IDs to be assigned, got %d and %d", expiredSilence.ID, activeSilence.ID)
+	}
+	silences, err := store.GetAllSilences()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(silences) != 1 || silences[0].ID != activeSilence.ID {
+		t.Fatalf("expected only the active silence to be returned, got %d silences", len(silences))
+	}
+	if silences[0].Matcher.AlertType != alert.TypeSlack || silences[0].CreatedBy != "john.doe" {
+		t.Errorf("expected silence to have been persisted as is, got %+v", silences[0])
+	}
+	if err = store.DeleteSilence(activeSilence.ID); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err = store.DeleteSilence(activeSilence.ID); !errors.Is(err, common.ErrSilenceNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrSilenceNotFound, err)
+	}
+	// Acknowledgements
+	ack := &silence.Acknowledgement{EndpointKey: "core_frontend", AlertChecksum: "checksum", AcknowledgedBy: "john.doe", AcknowledgedAt: time.Now()}
+	if err = store.UpsertAlertAcknowledgement(ack); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	ack.Comment = "Looking into it"
+	if err = store.UpsertAlertAcknowledgement(ack); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	acknowledgements, err := store.GetAllAlertAcknowledgements()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(acknowledgements) != 1 || acknowledgements[0].Comment != "Looking into it" {
+		t.Fatalf("expected 1 updated acknowledgement, got %d", len(acknowledgements))
+	}
+	if err = store.DeleteAlertAcknowledgement("core_frontend", "checksum"); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err = store.DeleteAlertAcknowledgement("core_frontend", "checksum"); !errors.Is(err, common.ErrAcknowledgementNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrAcknowledgementNotFound, err)
+	}
+}
+
+func TestStore_Leases(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Clear()
+	defer store.Close()
+	acquired, err := store.AcquireLease(context.Background(), "leader", "gatus-1", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("expected gatus-1 to acquire the lease, got acquired=%v and err=%v", acquired, err)
+	}
+	if acquired, err = store.AcquireLease(context.Background(), "leader", "gatus-2", time.Minute); err != nil || acquired {
+		t.Fatalf("expected gatus-2 to not acquire the lease held by gatus-1, got acquired=%v and err=%v", acquired, err)
+	}
+	if acquired, err = store.AcquireLease(context.Background(), "leader", "gatus-1", 50*time.Millisecond); err != nil || !acquired {
+		t.Fatalf("expected gatus-1 to renew the lease, got acquired=%v and err=%v", acquired, err)
+	}
+	if acquired, err = store.AcquireLease(context.Background(), "other", "gatus-2", time.Minute); err != nil || !acquired {
+		t.Fatalf("expected gatus-2 to acquire a different lease, got acquired=%v and err=%v", acquired, err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if acquired, err = store.AcquireLease(context.Background(), "leader", "gatus-2", time.Minute); err != nil || !acquired {
+		t.Fatalf("expected gatus-2 to take over the lease after it expired, got acquired=%v and err=%v", acquired, err)
+	}
+	if acquired, err = store.AcquireLease(context.Background(), "leader", "gatus-1", time.Minute); err != nil || acquired {
+		t.Fatalf("expected gatus-1 to no longer hold the lease, got acquired=%v and err=%v", acquired, err)
+	}
+	if err = store.ReleaseLease("leader", "gatus-1"); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if acquired, err = store.AcquireLease(context.Background(), "leader", "gatus-1", time.Minute); err != nil || acquired {
+		t.Fatal("expected the lease to not be released by an instance that doesn't hold it")
+	}
+	if err = store.ReleaseLease("leader", "gatus-2"); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if acquired, err = store.AcquireLease(context.Background(), "leader", "gatus-1", time.Minute); err != nil || !acquired {
+		t.Fatalf("expected gatus-1 to acquire the lease after it was released, got acquired=%v and err=%v", acquired, err)
+	}
+}
+
+func TestStore_MaintenanceWindows(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Clear()
+	defer store.Close()
+	now := time.Now()
+	recurringWindow := &maintenance.Window{Description: "Weekly backup", Groups: []string{"core"}, Cron: "0 2 * * SAT", Duration: "2h"}
+	oneOffWindow := &maintenance.Window{Description: "Database migration", EndpointKeys: []string{"core_frontend", "core_backend"}, SuiteKeys: []string{"flows_checkout"}, From: now.Add(-time.Hour).Format(time.RFC3339), Until: now.Add(time.Hour).Format(time.RFC3339)}
+	expiredWindow := &maintenance.Window{Description: "Expired", Groups: []string{"core"}, From: now.Add(-2 * time.Hour).Format(time.RFC3339), Until: now.Add(-time.Hour).Format(time.RFC3339)}
+	for _, window := range []*maintenance.Window{recurringWindow, oneOffWindow, expiredWindow} {
+		if err := window.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if err := store.InsertMaintenanceWindow(window); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	if recurringWindow.ID == 0 || recurringWindow.ID == oneOffWindow.ID {
+		t.Fatalf("expected unique IDs to be assigned, got %d and %d", recurringWindow.ID, oneOffWindow.ID)
+	}
+	windows, err := store.GetAllMaintenanceWindows()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(windows) != 2 {
+		t.Fatalf("expected only the windows that haven't expired to be returned, got %d windows", len(windows))
+	}
+	if windows[0].ID != oneOffWindow.ID || windows[1].ID != recurringWindow.ID {
+		t.Error("expected maintenance windows to be sorted from the most recent to the oldest")
+	}
+	if len(windows[0].EndpointKeys) != 2 || len(windows[0].SuiteKeys) != 1 || len(windows[0].Groups) != 0 {
+		t.Errorf("expected 2 endpoint keys, 1 suite key and no groups, got %v, %v and %v", windows[0].EndpointKeys, windows[0].SuiteKeys, windows[0].Groups)
+	}
+	if !windows[0].IsActive() {
+		t.Error("expected the one-off maintenance window to be active")
+	}
+	if err = store.DeleteMaintenanceWindow(oneOffWindow.ID); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err = store.DeleteMaintenanceWindow(oneOffWindow.ID); !errors.Is(err, common.ErrMaintenanceWindowNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrMaintenanceWindowNotFound, err)
+	}
+	store.Clear()
+	if windows, _ = store.GetAllMaintenanceWindows(); len(windows) != 0 {
+		t.Errorf("expected no maintenance windows after clearing the store, got %d", len(windows))
+	}
+}
+
+func TestStore_GetEndpointResultsAndEventsByKey(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Close()
+	baseTime := time.Now().Truncate(time.Minute).Add(-10 * time.Minute)
+	for i := 0; i < 5; i++ {
+		result := testSuccessfulResult
+		if i%2 == 1 {
+			result = testUnsuccessfulResult
+		}
+		result.Timestamp = baseTime.Add(time.Duration(i) * time.Minute)
+		if err := store.InsertEndpointResult(&testEndpoint, &result); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	// Page through all results
+	var results []*endpoint.Result
+	var numberOfPages int
+	params := paging.NewEndpointHistoryParams(2)
+	for {
+		page, cursor, err := store.GetEndpointResultsByKey(testEndpoint.Key(), params)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		results = append(results, page...)
+		numberOfPages++
+		if len(cursor) == 0 {
+			break
+		}
+		params.WithCursor(cursor)
+	}
+	if numberOfPages != 3 {
+		t.Errorf("expected 3 pages, got %d", numberOfPages)
+	}
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+	for i, result := range results {
+		if !result.Timestamp.Equal(baseTime.Add(time.Duration(i) * time.Minute)) {
+			t.Errorf("expected results to be sorted from the oldest to the most recent, got %s at index %d", result.Timestamp, i)
+		}
+		if len(result.ConditionResults) != 3 {
+			t.Errorf("expected 3 condition results, got %d", len(result.ConditionResults))
+		}
+	}
+	// Filter by success
+	results, cursor, err := store.GetEndpointResultsByKey(testEndpoint.Key(), paging.NewEndpointHistoryParams(10).WithSuccess(false))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(results) != 2 || len(cursor) != 0 {
+		t.Fatalf("expected 2 unsuccessful results and no cursor, got %d results and cursor=%s", len(results), cursor)
+	}
+	if len(results[0].Errors) != 2 || results[0].Success {
+		t.Errorf("expected unsuccessful result with 2 errors, got success=%v and %d errors", results[0].Success, len(results[0].Errors))
+	}
+	// Filter by time range
+	results, _, err = store.GetEndpointResultsByKey(testEndpoint.Key(), paging.NewEndpointHistoryParams(10).WithTimeRange(baseTime.Add(30*time.Second), baseTime.Add(2*time.Minute)))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(results) != 2 || !results[0].Timestamp.Equal(baseTime.Add(time.Minute)) {
+		t.Errorf("expected the 2 results within the time range, got %d results", len(results))
+	}
+	// Page through all events
+	var events []*endpoint.Event
+	params = paging.NewEndpointHistoryParams(1)
+	for {
+		page, cursor, err := store.GetEndpointEventsByKey(testEndpoint.Key(), params)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		events = append(events, page...)
+		if len(cursor) == 0 {
+			break
+		}
+		params.WithCursor(cursor)
+	}
+	// START, followed by an event for each change of health
+	if len(events) != 6 {
+		t.Fatalf("expected 6 events, got %d", len(events))
+	}
+	if events[0].Type != endpoint.EventStart || events[5].Type != endpoint.EventHealthy {
+		t.Errorf("expected events to be sorted from the oldest to the most recent, got %s first and %s last", events[0].Type, events[5].Type)
+	}
+	// Errors
+	if _, _, err = store.GetEndpointResultsByKey("nonexistent", paging.NewEndpointHistoryParams(10)); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	if _, _, err = store.GetEndpointEventsByKey(testEndpoint.Key(), paging.NewEndpointHistoryParams(10).WithCursor("invalid")); !errors.Is(err, common.ErrInvalidCursor) {
+		t.Errorf("expected %v, got %v", common.ErrInvalidCursor, err)
+	}
+	if _, _, err = store.GetEndpointResultsByKey(testEndpoint.Key(), paging.NewEndpointHistoryParams(10).WithTimeRange(baseTime, baseTime.Add(-time.Minute))); !errors.Is(err, common.ErrInvalidTimeRange) {
+		t.Errorf("expected %v, got %v", common.ErrInvalidTimeRange, err)
+	}
+}
+
+func TestStore_GetUptimeStatisticsByKey(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Close()
+	ep := testEndpoint
+	ep.SLO = &slo.Config{Target: 99, Latency: &slo.LatencyConfig{Threshold: 200 * time.Millisecond, Target: 95}}
+	now := time.Now()
+	for _, result := range []endpoint.Result{testSuccessfulResult, testSuccessfulResult, testUnsuccessfulResult} {
+		result.Timestamp = now
+		if err := store.InsertEndpointResult(&ep, &result); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	if _, err := store.GetUptimeStatisticsByKey(ep.Key(), now, now.Add(-time.Hour)); !errors.Is(err, common.ErrInvalidTimeRange) {
+		t.Errorf("expected error %v, got %v", common.ErrInvalidTimeRange, err)
+	}
+	if _, err := store.GetUptimeStatisticsByKey("nonexistent", now.Add(-time.Hour), now); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	// Even though the time range is shorter than an hour, the statistics of the current hour should be included
+	statistics, err := store.GetUptimeStatisticsByKey(ep.Key(), now.Add(-5*time.Minute), now)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if statistics.TotalExecutions != 3 || statistics.SuccessfulExecutions != 2 {
+		t.Errorf("expected 3 executions of which 2 successful, got %d executions of which %d successful", statistics.TotalExecutions, statistics.SuccessfulExecutions)
+	}
+	if statistics.TotalExecutionsResponseTime != 1050 {
+		t.Errorf("expected a total response time of 1050ms, got %dms", statistics.TotalExecutionsResponseTime)
+	}
+	if statistics.ExecutionsWithinLatencyThreshold != 2 {
+		t.Errorf("expected 2 executions within the latency threshold, got %d", statistics.ExecutionsWithinLatencyThreshold)
+	}
+	if statistics.MaximumResponseTime != 750 {
+		t.Errorf("expected a maximum response time of 750ms, got %dms", statistics.MaximumResponseTime)
+	}
+	if p50 := statistics.ResponseTimePercentile(50); p50 < 125 || p50 > 150 {
+		t.Errorf("expected the median response time to be between 125ms and 150ms, got %dms", p50)
+	}
+}
+
+func TestStore_GetHourlyUptimeStatisticsByKey(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Close()
+	now := time.Now()
+	for _, result := range []endpoint.Result{testSuccessfulResult, testUnsuccessfulResult} {
+		result.Timestamp = now
+		if err := store.InsertEndpointResult(&testEndpoint, &result); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	if _, err := store.GetHourlyUptimeStatisticsByKey(testEndpoint.Key(), now, now.Add(-time.Hour)); !errors.Is(err, common.ErrInvalidTimeRange) {
+		t.Errorf("expected error %v, got %v", common.ErrInvalidTimeRange, err)
+	}
+	if _, err := store.GetHourlyUptimeStatisticsByKey("nonexistent", now.Add(-time.Hour), now); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	hourlyStatistics, err := store.GetHourlyUptimeStatisticsByKey(testEndpoint.Key(), now.Add(-time.Hour), now)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(hourlyStatistics) != 1 {
+		t.Fatalf("expected 1 hourly statistics entry, got %d", len(hourlyStatistics))
+	}
+	statistics, exists := hourlyStatistics[now.Truncate(time.Hour).Unix()]
+	if !exists {
+		t.Fatal("expected hourly statistics for the current hour")
+	}
+	if statistics.TotalExecutions != 2 || statistics.TotalExecutionsResponseTime != 900 {
+		t.Errorf("expected 2 executions with a total response time of 900ms, got %d executions with a total response time of %dms", statistics.TotalExecutions, statistics.TotalExecutionsResponseTime)
+	}
+	if statistics.MaximumResponseTime != 750 || statistics.ResponseTimePercentile(100) != 750 {
+		t.Errorf("expected a maximum response time of 750ms, got %dms", statistics.MaximumResponseTime)
+	}
+}
+
+func TestStore_LatestLocationResults(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Clear()
+	defer store.Close()
+	ep := &endpoint.Endpoint{Name: "website", Group: "core", Locations: []string{"eu-west", "us-east"}}
+	if latestResults, err := store.GetLatestLocationResults(ep.Key()); err != nil || len(latestResults) != 0 {
+		t.Fatalf("expected no results, got %v and err=%v", latestResults, err)
+	}
+	timestamp := time.Now().Truncate(time.Second)
+	if err := store.UpsertLatestLocationResult(ep, "eu-west", &endpoint.Result{Success: false, Connected: true, Errors: []string{"timeout"}, Timestamp: timestamp.Add(-time.Minute)}); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err := store.UpsertLatestLocationResult(ep, "eu-west", &endpoint.Result{Success: true, Connected: true, CertificateExpiration: time.Hour, Timestamp: timestamp}); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err := store.UpsertLatestLocationResult(ep, "us-east", &endpoint.Result{Success: false, Errors: []string{"timeout"}, Timestamp: timestamp}); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	latestResults, err := store.GetLatestLocationResults(ep.Key())
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(latestResults) != 2 {
+		t.Fatalf("expected the latest result of 2 locations, got %d", len(latestResults))
+	}
+	if result := latestResults["eu-west"]; !result.Success || !result.Connected || result.CertificateExpiration != time.Hour || !result.Timestamp.Equal(timestamp) {
+		t.Errorf("expected the latest result from eu-west to have replaced the previous one, got %+v", result)
+	}
+	if result := latestResults["us-east"]; result.Success || result.Connected || len(result.Errors) != 1 {
+		t.Errorf("expected the latest result from us-east to be returned as it was upserted, got %+v", result)
+	}
+	// Removing the endpoint should also remove the results of its locations
+	store.DeleteAllEndpointStatusesNotInKeys(nil)
+	if latestResults, _ = store.GetLatestLocationResults(ep.Key()); len(latestResults) != 0 {
+		t.Errorf("expected no results after the endpoint was removed, got %v", latestResults)
+	}
+}
+
+func TestStore_APITokenLastUsedTimes(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Clear()
+	defer store.Close()
+	if lastUsedTimes, err := store.GetAllAPITokenLastUsedTimes(); err != nil || len(lastUsedTimes) != 0 {
+		t.Fatalf("expected no last used times, got %v and err=%v", lastUsedTimes, err)
+	}
+	firstUsedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	lastUsedAt := time.Now().Truncate(time.Second)
+	if err := store.UpdateAPITokenLastUsedTime("ci", firstUsedAt); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err := store.UpdateAPITokenLastUsedTime("ci", lastUsedAt); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err := store.UpdateAPITokenLastUsedTime("backup", firstUsedAt); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	lastUsedTimes, err := store.GetAllAPITokenLastUsedTimes()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(lastUsedTimes) != 2 || !lastUsedTimes["ci"].Equal(lastUsedAt) || !lastUsedTimes["backup"].Equal(firstUsedAt) {
+		t.Errorf("expected the last used time of each API token, got %v", lastUsedTimes)
+	}
+}
+
+func TestStore_Sessions(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Clear()
+	defer store.Close()
+	if sessions, err := store.GetAllSessions(); err != nil || len(sessions) != 0 {
+		t.Fatalf("expected no sessions, got %d and err=%v", len(sessions), err)
+	}
+	older := &session.Session{ID: "older", Subject: "john.doe", Groups: []string{"team-a", "team-b"}, RefreshToken: "refresh-token", CreatedAt: time.Now().Add(-time.Hour).Truncate(time.Second), ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second)}
+	newer := &session.Session{ID: "newer", Subject: "jane.doe", CreatedAt: time.Now().Truncate(time.Second), ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second)}
+	expired := &session.Session{ID: "expired", Subject: "john.doe", CreatedAt: time.Now().Add(-2 * time.Hour), ExpiresAt: time.Now().Add(-time.Hour)}
+	for _, sess := range []*session.Session{older, newer, expired} {
+		if err := store.UpsertSession(sess); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	sessions, err := store.GetAllSessions()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != "newer" || sessions[1].ID != "older" {
+		t.Fatalf("expected sessions that haven't expired to be returned from the most recent to the oldest, got %d sessions", len(sessions))
+	}
+	sess, err := store.GetSessionByID("older")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if sess.Subject != "john.doe" || len(sess.Groups) != 2 || sess.RefreshToken != "refresh-token" || !sess.ExpiresAt.Equal(older.ExpiresAt) {
+		t.Errorf("expected session to be returned as it was upserted, got %+v", sess)
+	}
+	if _, err = store.GetSessionByID("expired"); !errors.Is(err, common.ErrSessionNotFound) {
+		t.Errorf("expected %v for expired session, got %v", common.ErrSessionNotFound, err)
+	}
+	// Extend the session
+	older.ExpiresAt = time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	older.RefreshToken = "rotated-refresh-token"
+	if err = store.UpsertSession(older); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if sess, _ = store.GetSessionByID("older"); sess == nil || !sess.ExpiresAt.Equal(older.ExpiresAt) || sess.RefreshToken != "rotated-refresh-token" {
+		t.Errorf("expected session to have been extended, got %+v", sess)
+	}
+	if err = store.DeleteSession("older"); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err = store.DeleteSession("older"); !errors.Is(err, common.ErrSessionNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrSessionNotFound, err)
+	}
+	if _, err = store.GetSessionByID("older"); !errors.Is(err, common.ErrSessionNotFound) {
+		t.Errorf("expected %v for deleted session, got %v", common.ErrSessionNotFound, err)
+	}
+}
@@ -0,0 +1,423 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/incident"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/logr"
+)
+
+const (
+	// writeAheadLogFileSuffix is the suffix appended to the path of the snapshot to get the path of the write-ahead log
+	writeAheadLogFileSuffix = ".wal"
+
+	// maximumWriteAheadLogRecordSize is the maximum size of a record of the write-ahead log.
+	// A record with a bigger size can only be the result of a corrupted write-ahead log.
+	maximumWriteAheadLogRecordSize = 64 * 1024 * 1024
+)
+
+var (
+	// ErrInvalidWriteAheadLogRecord is the error returned when a record of the write-ahead log cannot be applied
+	ErrInvalidWriteAheadLogRecord = errors.New("invalid write-ahead log record")
+)
+
+type writeAheadLogRecordType uint8
+
+const (
+	recordTypeEndpointResult writeAheadLogRecordType = iota + 1
+	recordTypeSuiteResult
+	recordTypeDeleteEndpointStatusesNotInKeys
+	recordTypeDeleteSuiteStatusesNotInKeys
+	recordTypeUpsertTriggeredAlert
+	recordTypeDeleteTriggeredAlert
+	recordTypeDeleteTriggeredAlertsNotInChecksums
+	recordTypeUpsertIncident
+	recordTypeInsertSilence
+	recordTypeDeleteSilence
+	recordTypeUpsertAcknowledgement
+	recordTypeDeleteAcknowledgement
+	recordTypeInsertMaintenanceWindow
+	recordTypeDeleteMaintenanceWindow
+	recordTypeUpdateAPITokenLastUsedTime
+	recordTypeUpsertSession
+	recordTypeDeleteSession
+)
+
+// writeAheadLogRecord is a mutation of the store, as appended to the write-ahead log.
+// Only the fields relevant to the type of the record are set.
+type writeAheadLogRecord struct {
+	Sequence  uint64
+	Type      writeAheadLogRecordType
+	Timestamp time.Time
+
+	Key   string   // Key of the endpoint or suite, or ID of the session affected by the mutation
+	Group string   // Group of the endpoint or suite affected by the mutation
+	Name  string   // Name of the endpoint, suite or API token affected by the mutation
+	Keys  []string // Keys of the endpoints or suites, or checksums of the triggered alerts to keep
+	ID    int64    // ID of the silence or maintenance window to delete
+
+	EndpointResult    *endpoint.Result
+	LatencyThreshold  time.Duration // Latency threshold of the SLO of the endpoint when the result was inserted
+	SuiteResult       *suite.Result
+	AlertChecksum     string
+	TriggeredAlert    *triggeredEndpointAlert
+	Incident          *incident.Incident
+	Silence           *silence.Silence
+	Acknowledgement   *silence.Acknowledgement
+	MaintenanceWindow *maintenance.Window
+	Session           *session.Session
+}
+
+// snapshot is the state of the store, as written to the snapshot file
+type snapshot struct {
+	// Sequence is the sequence number of the last record of the write-ahead log included in the snapshot
+	Sequence uint64
+
+	EndpointStatuses        []*endpoint.Status
+	SuiteStatuses           []*suite.Status
+	TriggeredAlerts         map[string]map[string]*triggeredEndpointAlert
+	Incidents               map[int64]*incident.Incident
+	LastIncidentID          int64
+	Silences                map[int64]*silence.Silence
+	LastSilenceID           int64
+	Acknowledgements        map[string]*silence.Acknowledgement
+	MaintenanceWindows      map[int64]*maintenance.Window
+	LastMaintenanceWindowID int64
+	APITokenLastUsedTimes   map[string]time.Time
+	Sessions                map[string]*session.Session
+}
+
+// load restores the state of the store from the snapshot and the write-ahead log, and opens the write-ahead log so
+// that every subsequent mutation is appended to it
+func (s *Store) load() error {
+	lastSequence, err := s.readSnapshot()
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot at %s: %w", s.path, err)
+	}
+	s.writeAheadLogSequence = lastSequence
+	writeAheadLog, err := os.OpenFile(s.path+writeAheadLogFileSuffix, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	numberOfRecordsReplayed, size := s.replayWriteAheadLog(writeAheadLog, lastSequence)
+	// Any trailing bytes are the remains of a record that failed to be fully written, so they're discarded in order to
+	// append the next records right after the last valid one
+	if err = writeAheadLog.Truncate(size); err == nil {
+		_, err = writeAheadLog.Seek(size, io.SeekStart)
+	}
+	if err != nil {
+		_ = writeAheadLog.Close()
+		return err
+	}
+	s.writeAheadLog = writeAheadLog
+	logr.Infof("[memory.load] Loaded %d endpoint statuses and %d suite statuses, including %d mutations replayed from the write-ahead log", s.endpointCache.Count(), s.suiteCache.Count(), numberOfRecordsReplayed)
+	return nil
+}
+
+// readSnapshot restores the state of the store from the snapshot, if there's one, and returns the sequence number of
+// the last record of the write-ahead log included in it
+func (s *Store) readSnapshot() (uint64, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return 0, err
+	}
+	defer gzipReader.Close()
+	var snap snapshot
+	if err = gob.NewDecoder(gzipReader).Decode(&snap); err != nil {
+		return 0, err
+	}
+	for _, status := range snap.EndpointStatuses {
+		if status.Uptime == nil {
+			status.Uptime = endpoint.NewUptime()
+		}
+		s.endpointCache.Set(status.Key, status)
+	}
+	for _, status := range snap.SuiteStatuses {
+		s.suiteCache.Set(status.Key, status)
+	}
+	for endpointKey, triggeredAlerts := range snap.TriggeredAlerts {
+		s.triggeredAlerts[endpointKey] = triggeredAlerts
+	}
+	for id, inc := range snap.Incidents {
+		s.incidents[id] = inc
+	}
+	for id, sil := range snap.Silences {
+		s.silences[id] = sil
+	}
+	for ackKey, ack := range snap.Acknowledgements {
+		s.acknowledgements[ackKey] = ack
+	}
+	for id, window := range snap.MaintenanceWindows {
+		// The schedule of a maintenance window isn't persisted, so it must be parsed again
+		if err = window.ValidateAndSetDefaults(); err != nil {
+			logr.Warnf("[memory.readSnapshot] Skipping invalid maintenance window with id=%d: %s", id, err.Error())
+			continue
+		}
+		s.maintenanceWindows[id] = window
+	}
+	for name, lastUsedAt := range snap.APITokenLastUsedTimes {
+		s.apiTokenLastUsedTimes[name] = lastUsedAt
+	}
+	for id, sess := range snap.Sessions {
+		if !sess.HasExpired() {
+			s.sessions[id] = sess
+		}
+	}
+	s.lastIncidentID, s.lastSilenceID, s.lastMaintenanceWindowID = snap.LastIncidentID, snap.LastSilenceID, snap.LastMaintenanceWindowID
+	return snap.Sequence, nil
+}
+
+// replayWriteAheadLog applies every record of the write-ahead log that isn't already included in the snapshot, and
+// returns the number of records applied as well as the size of the valid part of the write-ahead log
+func (s *Store) replayWriteAheadLog(writeAheadLog io.Reader, lastSequence uint64) (numberOfRecordsReplayed int, size int64) {
+	reader := bufio.NewReader(writeAheadLog)
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if !errors.Is(err, io.EOF) {
+				logr.Warnf("[memory.replayWriteAheadLog] Discarding incomplete record at the end of the write-ahead log: %s", err.Error())
+			}
+			break
+		}
+		recordSize := binary.BigEndian.Uint32(header)
+		if recordSize > maximumWriteAheadLogRecordSize {
+			logr.Warnf("[memory.replayWriteAheadLog] Discarding the rest of the write-ahead log, because its record at offset %d is corrupted", size)
+			break
+		}
+		data := make([]byte, recordSize)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			logr.Warnf("[memory.replayWriteAheadLog] Discarding incomplete record at the end of the write-ahead log: %s", err.Error())
+			break
+		}
+		var record writeAheadLogRecord
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&record); err != nil {
+			logr.Warnf("[memory.replayWriteAheadLog] Discarding the rest of the write-ahead log, because its record at offset %d is corrupted: %s", size, err.Error())
+			break
+		}
+		size += int64(len(header) + len(data))
+		// If the application stopped after writing a snapshot but before truncating the write-ahead log, its records
+		// are already included in the snapshot
+		if record.Sequence <= lastSequence {
+			continue
+		}
+		if err := s.apply(&record); err != nil {
+			logr.Warnf("[memory.replayWriteAheadLog] Skipping record with sequence=%d: %s", record.Sequence, err.Error())
+		}
+		s.writeAheadLogSequence = record.Sequence
+		numberOfRecordsReplayed++
+	}
+	return numberOfRecordsReplayed, size
+}
+
+// apply applies a mutation from the write-ahead log to the store
+func (s *Store) apply(record *writeAheadLogRecord) error {
+	switch record.Type {
+	case recordTypeEndpointResult:
+		if record.EndpointResult == nil {
+			return ErrInvalidWriteAheadLogRecord
+		}
+		// The uptime retention isn't known yet, so uptime statistics can't be merged until the next result is inserted
+		s.addEndpointResult(record.Key, record.Group, record.Name, record.EndpointResult, record.Timestamp, nil, record.LatencyThreshold)
+	case recordTypeSuiteResult:
+		if record.SuiteResult == nil {
+			return ErrInvalidWriteAheadLogRecord
+		}
+		s.addSuiteResult(record.Key, record.Group, record.Name, record.SuiteResult)
+	case recordTypeDeleteEndpointStatusesNotInKeys:
+		s.deleteAllEndpointStatusesNotInKeys(record.Keys)
+	case recordTypeDeleteSuiteStatusesNotInKeys:
+		s.deleteAllSuiteStatusesNotInKeys(record.Keys)
+	case recordTypeUpsertTriggeredAlert:
+		if record.TriggeredAlert == nil {
+			return ErrInvalidWriteAheadLogRecord
+		}
+		s.upsertTriggeredAlert(record.Key, record.AlertChecksum, record.TriggeredAlert)
+	case recordTypeDeleteTriggeredAlert:
+		s.deleteTriggeredAlert(record.Key, record.AlertChecksum)
+	case recordTypeDeleteTriggeredAlertsNotInChecksums:
+		s.deleteAllTriggeredAlertsNotInChecksums(record.Key, record.Keys)
+	case recordTypeUpsertIncident:
+		if record.Incident == nil {
+			return ErrInvalidWriteAheadLogRecord
+		}
+		s.incidents[record.Incident.ID] = record.Incident
+		s.lastIncidentID = max(s.lastIncidentID, record.Incident.ID)
+	case recordTypeInsertSilence:
+		if record.Silence == nil {
+			return ErrInvalidWriteAheadLogRecord
+		}
+		s.silences[record.Silence.ID] = record.Silence
+		s.lastSilenceID = max(s.lastSilenceID, record.Silence.ID)
+	case recordTypeDeleteSilence:
+		delete(s.silences, record.ID)
+	case recordTypeUpsertAcknowledgement:
+		if record.Acknowledgement == nil {
+			return ErrInvalidWriteAheadLogRecord
+		}
+		s.acknowledgements[record.Acknowledgement.EndpointKey+"|"+record.Acknowledgement.AlertChecksum] = record.Acknowledgement
+	case recordTypeDeleteAcknowledgement:
+		delete(s.acknowledgements, record.Key+"|"+record.AlertChecksum)
+	case recordTypeInsertMaintenanceWindow:
+		if record.MaintenanceWindow == nil {
+			return ErrInvalidWriteAheadLogRecord
+		}
+		if err := record.MaintenanceWindow.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		s.maintenanceWindows[record.MaintenanceWindow.ID] = record.MaintenanceWindow
+		s.lastMaintenanceWindowID = max(s.lastMaintenanceWindowID, record.MaintenanceWindow.ID)
+	case recordTypeDeleteMaintenanceWindow:
+		delete(s.maintenanceWindows, record.ID)
+	case recordTypeUpdateAPITokenLastUsedTime:
+		s.apiTokenLastUsedTimes[record.Name] = record.Timestamp
+	case recordTypeUpsertSession:
+		if record.Session == nil {
+			return ErrInvalidWriteAheadLogRecord
+		}
+		s.sessions[record.Session.ID] = record.Session
+	case recordTypeDeleteSession:
+		delete(s.sessions, record.Key)
+	default:
+		return ErrInvalidWriteAheadLogRecord
+	}
+	return nil
+}
+
+// appendToWriteAheadLog appends a mutation to the write-ahead log, if persistence is enabled.
+// The caller must hold the write lock.
+func (s *Store) appendToWriteAheadLog(record *writeAheadLogRecord) error {
+	if s.writeAheadLog == nil {
+		return nil
+	}
+	s.writeAheadLogSequence++
+	record.Sequence = s.writeAheadLogSequence
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	// Each record is encoded independently, with its size as prefix, so that the write-ahead log can be read
+	// record by record, and so that a record that was only partially written can be detected
+	var buffer bytes.Buffer
+	buffer.Write(make([]byte, 4))
+	if err := gob.NewEncoder(&buffer).Encode(record); err != nil {
+		logr.Errorf("[memory.appendToWriteAheadLog] Failed to encode record: %s", err.Error())
+		return err
+	}
+	data := buffer.Bytes()
+	binary.BigEndian.PutUint32(data, uint32(len(data)-4))
+	if _, err := s.writeAheadLog.Write(data); err != nil {
+		logr.Errorf("[memory.appendToWriteAheadLog] Failed to append record to write-ahead log: %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// writeSnapshot writes the state of the store to the snapshot file and empties the write-ahead log, since all of its
+// records are then part of the snapshot.
+// The caller must hold the write lock.
+func (s *Store) writeSnapshot() error {
+	snap := snapshot{
+		Sequence:                s.writeAheadLogSequence,
+		TriggeredAlerts:         s.triggeredAlerts,
+		Incidents:               s.incidents,
+		LastIncidentID:          s.lastIncidentID,
+		Silences:                s.silences,
+		LastSilenceID:           s.lastSilenceID,
+		Acknowledgements:        s.acknowledgements,
+		MaintenanceWindows:      s.maintenanceWindows,
+		LastMaintenanceWindowID: s.lastMaintenanceWindowID,
+		APITokenLastUsedTimes:   s.apiTokenLastUsedTimes,
+		Sessions:                s.sessions,
+	}
+	for _, value := range s.endpointCache.GetAll() {
+		if status, ok := value.(*endpoint.Status); ok {
+			statusCopy := *status
+			statusCopy.Results = make([]*endpoint.Result, 0, len(status.Results))
+			for _, result := range status.Results {
+				statusCopy.Results = append(statusCopy.Results, copyEndpointResultForPersistence(result))
+			}
+			snap.EndpointStatuses = append(snap.EndpointStatuses, &statusCopy)
+		}
+	}
+	for _, value := range s.suiteCache.GetAll() {
+		if status, ok := value.(*suite.Status); ok {
+			statusCopy := *status
+			statusCopy.Results = make([]*suite.Result, 0, len(status.Results))
+			for _, result := range status.Results {
+				statusCopy.Results = append(statusCopy.Results, copySuiteResultForPersistence(result))
+			}
+			snap.SuiteStatuses = append(snap.SuiteStatuses, &statusCopy)
+		}
+	}
+	// The snapshot is written to a temporary file first, so that a crash while writing it can't corrupt the
+	// previous snapshot
+	temporaryPath := s.path + ".tmp"
+	file, err := os.Create(temporaryPath)
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(file)
+	err = gob.NewEncoder(gzipWriter).Encode(&snap)
+	if err == nil {
+		err = gzipWriter.Close()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temporaryPath, s.path)
+	}
+	if err != nil {
+		_ = os.Remove(temporaryPath)
+		return err
+	}
+	if err = s.writeAheadLog.Truncate(0); err != nil {
+		return err
+	}
+	_, err = s.writeAheadLog.Seek(0, io.SeekStart)
+	return err
+}
+
+// copyEndpointResultForPersistence returns a copy of the result without the fields that aren't persisted
+func copyEndpointResultForPersistence(result *endpoint.Result) *endpoint.Result {
+	resultCopy := *result
+	resultCopy.Body = nil
+	resultCopy.Headers = nil
+	return &resultCopy
+}
+
+// copySuiteResultForPersistence returns a copy of the suite result without the fields that aren't persisted.
+// The context in particular can't be persisted, because it may contain values of any type.
+func copySuiteResultForPersistence(result *suite.Result) *suite.Result {
+	resultCopy := *result
+	resultCopy.Context = nil
+	resultCopy.EndpointResults = make([]*endpoint.Result, 0, len(result.EndpointResults))
+	for _, endpointResult := range result.EndpointResults {
+		resultCopy.EndpointResults = append(resultCopy.EndpointResults, copyEndpointResultForPersistence(endpointResult))
+	}
+	return &resultCopy
+}
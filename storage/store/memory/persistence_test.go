@@ -0,0 +1,256 @@
+package memory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/config/incident"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestStore_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.gob")
+	store, err := NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	triggeredAlert := &alert.Alert{EscalationPolicy: "on-call", ResolveKey: "resolve-key", TriggeredAt: now.Add(-time.Hour), EscalationResolveKeys: []string{"step-1"}}
+	testSuite := &suite.Suite{Name: "checkout", Group: "flows"}
+	window := &maintenance.Window{Description: "Weekly backup", Groups: []string{"core"}, Cron: "0 2 * * SAT", Duration: "2h"}
+	if err = window.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	// Mutations made before the first snapshot can only be restored from the write-ahead log
+	_ = store.InsertEndpointResult(&testEndpoint, &testSuccessfulResult)
+	_ = store.InsertSuiteResult(testSuite, &suite.Result{Name: testSuite.Name, Group: testSuite.Group, Success: true, Timestamp: now, Context: map[string]interface{}{"token": map[string]interface{}{"value": "abc"}}})
+	_ = store.UpsertTriggeredEndpointAlert(&testEndpoint, triggeredAlert)
+	_ = store.InsertIncident(&incident.Incident{Title: "Frontend is down", Severity: incident.SeverityMajor, Status: incident.StatusInvestigating})
+	_ = store.InsertSilence(&silence.Silence{Matcher: silence.Matcher{Group: "group"}, Comment: "Investigating", ExpiresAt: now.Add(time.Hour)})
+	_ = store.UpsertAlertAcknowledgement(&silence.Acknowledgement{EndpointKey: testEndpoint.Key(), AlertChecksum: triggeredAlert.Checksum()})
+	_ = store.InsertMaintenanceWindow(window)
+	_ = store.UpdateAPITokenLastUsedTime("ci", now)
+	_ = store.UpsertSession(&session.Session{ID: "session", Subject: "john.doe", Groups: []string{"team-a"}, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+	store.Close()
+
+	store, err = NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	checkPersistedStore(t, store, 1)
+	// Mutations made after a snapshot are restored from both the snapshot and the write-ahead log
+	if err = store.Save(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	_ = store.InsertEndpointResult(&testEndpoint, &testUnsuccessfulResult)
+	store.Close()
+
+	store, err = NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer store.Close()
+	checkPersistedStore(t, store, 2)
+	// IDs must keep being unique after a restart
+	newIncident := &incident.Incident{Title: "Backend is down", Severity: incident.SeverityMinor, Status: incident.StatusInvestigating}
+	_ = store.InsertIncident(newIncident)
+	if newIncident.ID != 2 {
+		t.Errorf("expected new incident to have id=2, got %d", newIncident.ID)
+	}
+	uptime, err := store.GetUptimeByKey(testEndpoint.Key(), now.Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if uptime != 0.5 {
+		t.Errorf("expected uptime to be 0.5, got %f", uptime)
+	}
+	// Clearing the store must clear its persisted data as well
+	store.Clear()
+	store.Close()
+	store, err = NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if statuses, _ := store.GetAllEndpointStatuses(paging.NewEndpointStatusParams()); len(statuses) != 0 {
+		t.Errorf("expected no endpoint statuses after clearing the store, got %d", len(statuses))
+	}
+}
+
+func checkPersistedStore(t *testing.T, store *Store, expectedNumberOfResults int) {
+	t.Helper()
+	status, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 20))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(status.Results) != expectedNumberOfResults {
+		t.Fatalf("expected %d results, got %d", expectedNumberOfResults, len(status.Results))
+	}
+	if status.Results[0].Hostname != testSuccessfulResult.Hostname || status.Results[0].CertificateExpiration != testSuccessfulResult.CertificateExpiration {
+		t.Error("expected result to be restored")
+	}
+	suiteStatus, err := store.GetSuiteStatusByKey("flows_checkout", paging.NewSuiteStatusParams().WithPagination(1, 20))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(suiteStatus.Results) != 1 || !suiteStatus.Results[0].Success {
+		t.Errorf("expected suite result to be restored, got %d results", len(suiteStatus.Results))
+	}
+	triggeredAlert := &alert.Alert{EscalationPolicy: "on-call"}
+	exists, resolveKey, _, _ := store.GetTriggeredEndpointAlert(&testEndpoint, triggeredAlert)
+	if !exists || resolveKey != "resolve-key" {
+		t.Errorf("expected triggered alert to be restored, got exists=%v and resolveKey=%s", exists, resolveKey)
+	}
+	triggeredAt, resolveKeys, _ := store.GetTriggeredEndpointAlertEscalation(&testEndpoint, triggeredAlert)
+	if !triggeredAt.Equal(now.Add(-time.Hour)) || len(resolveKeys) != 1 || resolveKeys[0] != "step-1" {
+		t.Errorf("expected escalation of triggered alert to be restored, got triggeredAt=%s and resolveKeys=%v", triggeredAt, resolveKeys)
+	}
+	if incidents, _ := store.GetAllIncidents(); len(incidents) != 1 || incidents[0].Title != "Frontend is down" {
+		t.Error("expected incident to be restored")
+	}
+	if silences, _ := store.GetAllSilences(); len(silences) != 1 || silences[0].Matcher.Group != "group" {
+		t.Error("expected silence to be restored")
+	}
+	if acknowledgements, _ := store.GetAllAlertAcknowledgements(); len(acknowledgements) != 1 {
+		t.Error("expected acknowledgement to be restored")
+	}
+	if lastUsedTimes, _ := store.GetAllAPITokenLastUsedTimes(); !lastUsedTimes["ci"].Equal(now) {
+		t.Error("expected last used time of API token to be restored")
+	}
+	if sess, _ := store.GetSessionByID("session"); sess == nil || sess.Subject != "john.doe" || len(sess.Groups) != 1 {
+		t.Error("expected session to be restored")
+	}
+	windows, _ := store.GetAllMaintenanceWindows()
+	if len(windows) != 1 {
+		t.Fatal("expected maintenance window to be restored")
+	}
+	if _, _, ok := windows[0].NextWindow(time.Now()); !ok {
+		t.Error("expected the schedule of the maintenance window to be restored")
+	}
+}
+
+func TestStore_PersistenceWithIncompleteWriteAheadLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.gob")
+	store, err := NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	_ = store.InsertEndpointResult(&testEndpoint, &testSuccessfulResult)
+	store.Close()
+	// Simulate a crash while a record was being appended
+	writeAheadLog, err := os.OpenFile(path+writeAheadLogFileSuffix, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	_, _ = writeAheadLog.Write([]byte{0, 0, 1, 0, 42})
+	_ = writeAheadLog.Close()
+	store, err = NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	_ = store.InsertEndpointResult(&testEndpoint, &testUnsuccessfulResult)
+	store.Close()
+	store, err = NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer store.Close()
+	status, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20))
+	if status == nil || len(status.Results) != 2 {
+		t.Fatal("expected the records appended after the incomplete record was discarded to be restored")
+	}
+}
+
+func TestStore_PersistenceWithHTTPTimings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.gob")
+	store, err := NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	result := testSuccessfulResult
+	result.DNSLookupDuration = 5 * time.Millisecond
+	result.TCPConnectDuration = 10 * time.Millisecond
+	result.TLSHandshakeDuration = 20 * time.Millisecond
+	result.TimeToFirstByte = 100 * time.Millisecond
+	result.TransferDuration = 15 * time.Millisecond
+	_ = store.InsertEndpointResult(&testEndpoint, &result)
+	store.Close()
+	store, err = NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer store.Close()
+	status, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20))
+	if status == nil || len(status.Results) != 1 {
+		t.Fatal("expected the result to be restored")
+	}
+	restored := status.Results[0]
+	if restored.DNSLookupDuration != result.DNSLookupDuration || restored.TCPConnectDuration != result.TCPConnectDuration || restored.TLSHandshakeDuration != result.TLSHandshakeDuration || restored.TimeToFirstByte != result.TimeToFirstByte || restored.TransferDuration != result.TransferDuration {
+		t.Errorf("expected the HTTP timings to be restored, got dns=%s connect=%s tls=%s ttfb=%s transfer=%s", restored.DNSLookupDuration, restored.TCPConnectDuration, restored.TLSHandshakeDuration, restored.TimeToFirstByte, restored.TransferDuration)
+	}
+}
+
+func TestStore_PersistenceWithWriteAheadLogIncludedInSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.gob")
+	store, err := NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	_ = store.InsertEndpointResult(&testEndpoint, &testSuccessfulResult)
+	writeAheadLogBeforeSnapshot, err := os.ReadFile(path + writeAheadLogFileSuffix)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err = store.Save(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	store.Close()
+	// Simulate a crash after the snapshot was written, but before the write-ahead log was truncated
+	if err = os.WriteFile(path+writeAheadLogFileSuffix, writeAheadLogBeforeSnapshot, 0o644); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	store, err = NewStore(path, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer store.Close()
+	status, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20))
+	if status == nil || len(status.Results) != 1 {
+		t.Fatal("expected records of the write-ahead log that are already part of the snapshot to be skipped")
+	}
+}
+
+func TestStore_TriggeredEndpointAlerts(t *testing.T) {
+	store, _ := NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	defer store.Close()
+	firstAlert := &alert.Alert{Type: alert.TypeSlack, ResolveKey: "1"}
+	secondAlert := &alert.Alert{Type: alert.TypePagerDuty, ResolveKey: "2"}
+	ep := testEndpoint
+	ep.NumberOfSuccessesInARow = 1
+	_ = store.UpsertTriggeredEndpointAlert(&ep, firstAlert)
+	_ = store.UpsertTriggeredEndpointAlert(&ep, secondAlert)
+	if exists, resolveKey, numberOfSuccessesInARow, _ := store.GetTriggeredEndpointAlert(&ep, firstAlert); !exists || resolveKey != "1" || numberOfSuccessesInARow != 1 {
+		t.Errorf("expected triggered alert to exist with resolveKey=1 and numberOfSuccessesInARow=1, got exists=%v, resolveKey=%s and numberOfSuccessesInARow=%d", exists, resolveKey, numberOfSuccessesInARow)
+	}
+	if numberOfDeletedTriggeredAlerts := store.DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(&ep, []string{secondAlert.Checksum()}); numberOfDeletedTriggeredAlerts != 1 {
+		t.Errorf("expected 1 triggered alert to be deleted, got %d", numberOfDeletedTriggeredAlerts)
+	}
+	if exists, _, _, _ := store.GetTriggeredEndpointAlert(&ep, firstAlert); exists {
+		t.Error("expected triggered alert whose checksum wasn't provided to be deleted")
+	}
+	_ = store.DeleteTriggeredEndpointAlert(&ep, secondAlert)
+	if exists, _, _, _ := store.GetTriggeredEndpointAlert(&ep, secondAlert); exists {
+		t.Error("expected triggered alert to be deleted")
+	}
+	_ = store.UpsertTriggeredEndpointAlert(&ep, firstAlert)
+	store.DeleteAllEndpointStatusesNotInKeys([]string{})
+	if exists, _, _, _ := store.GetTriggeredEndpointAlert(&ep, firstAlert); exists {
+		t.Error("expected triggered alerts of endpoints that no longer exist to be deleted")
+	}
+}
@@ -4,16 +4,25 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage"
 )
 
 const (
-	uptimeCleanUpThreshold = 32 * 24
-	uptimeRetention        = 30 * 24 * time.Hour
+	// hourlyUptimeStatisticsMergeThreshold is the number of hourly entries in excess of the hourly uptime retention
+	// before they are merged into daily entries
+	hourlyUptimeStatisticsMergeThreshold = 24
+
+	// dailyUptimeStatisticsMergeThreshold is the number of daily entries in excess of the daily uptime retention before
+	// they are merged into monthly entries, or deleted if monthly entries aren't kept
+	dailyUptimeStatisticsMergeThreshold = 2
 )
 
 // processUptimeAfterResult processes the result by extracting the relevant from the result and recalculating the uptime
 // if necessary
-func processUptimeAfterResult(uptime *endpoint.Uptime, result *endpoint.Result) {
+//
+// If uptimeRetention is nil, uptime statistics are not merged. If latencyThreshold is 0, the endpoint has no latency
+// objective, so executions within the latency threshold are not counted.
+func processUptimeAfterResult(uptime *endpoint.Uptime, result *endpoint.Result, uptimeRetention *storage.UptimeRetentionConfig, latencyThreshold time.Duration) {
 	if uptime.HourlyStatistics == nil {
 		uptime.HourlyStatistics = make(map[int64]*endpoint.HourlyUptimeStatistics)
 	}
@@ -25,18 +34,91 @@ func processUptimeAfterResult(uptime *endpoint.Uptime, result *endpoint.Result)
 	}
 	if result.Success {
 		hourlyStats.SuccessfulExecutions++
+		if latencyThreshold > 0 && result.Duration <= latencyThreshold {
+			hourlyStats.ExecutionsWithinLatencyThreshold++
+		}
 	}
 	hourlyStats.TotalExecutions++
 	hourlyStats.TotalExecutionsResponseTime += uint64(result.Duration.Milliseconds())
-	// Clean up only when we're starting to have too many useless keys
-	// Note that this is only triggered when there are more entries than there should be after
-	// 32 days, despite the fact that we are deleting everything that's older than 30 days.
-	// This is to prevent re-iterating on every `processUptimeAfterResult` as soon as the uptime has been logged for 30 days.
-	if len(uptime.HourlyStatistics) > uptimeCleanUpThreshold {
-		sevenDaysAgo := time.Now().Add(-(uptimeRetention + time.Hour)).Unix()
-		for hourlyUnixTimestamp := range uptime.HourlyStatistics {
-			if sevenDaysAgo > hourlyUnixTimestamp {
-				delete(uptime.HourlyStatistics, hourlyUnixTimestamp)
+	hourlyStats.AddResponseTime(result.Duration)
+	if uptimeRetention == nil {
+		return
+	}
+	// Merge only when we're starting to have too many entries.
+	// This is to prevent re-iterating on every `processUptimeAfterResult` as soon as the uptime has been logged for
+	// longer than the retention.
+	if len(uptime.HourlyStatistics) > uptimeRetention.HourlyDays*24+hourlyUptimeStatisticsMergeThreshold {
+		mergeHourlyUptimeStatistics(uptime, uptimeRetention)
+	}
+	if len(uptime.DailyStatistics) > uptimeRetention.DailyDays+dailyUptimeStatisticsMergeThreshold {
+		mergeDailyUptimeStatistics(uptime, uptimeRetention)
+	}
+}
+
+// mergeHourlyUptimeStatistics merges the hourly statistics older than the hourly uptime retention into daily
+// statistics. Like for the SQL store, only full days are merged so that the uptime of the last 24 hours stays accurate.
+func mergeHourlyUptimeStatistics(uptime *endpoint.Uptime, uptimeRetention *storage.UptimeRetentionConfig) {
+	if uptime.DailyStatistics == nil {
+		uptime.DailyStatistics = make(map[int64]*endpoint.HourlyUptimeStatistics)
+	}
+	threshold := time.Now().Add(-uptimeRetention.HourlyRetention())
+	threshold = time.Date(threshold.Year(), threshold.Month(), threshold.Day(), 0, 0, 0, 0, threshold.Location())
+	for hourlyUnixTimestamp, hourlyStats := range uptime.HourlyStatistics {
+		if hourlyUnixTimestamp >= threshold.Unix() {
+			continue
+		}
+		timestamp := time.Unix(hourlyUnixTimestamp, 0)
+		dailyUnixTimestamp := time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), 0, 0, 0, 0, timestamp.Location()).Unix()
+		addUptimeStatistics(uptime.DailyStatistics, dailyUnixTimestamp, hourlyStats)
+		delete(uptime.HourlyStatistics, hourlyUnixTimestamp)
+	}
+}
+
+// mergeDailyUptimeStatistics merges the daily statistics older than the daily uptime retention into monthly
+// statistics, or deletes them if monthly statistics aren't kept
+func mergeDailyUptimeStatistics(uptime *endpoint.Uptime, uptimeRetention *storage.UptimeRetentionConfig) {
+	if uptime.MonthlyStatistics == nil {
+		uptime.MonthlyStatistics = make(map[int64]*endpoint.HourlyUptimeStatistics)
+	}
+	threshold := time.Now().Add(-uptimeRetention.DailyRetention()).Unix()
+	for dailyUnixTimestamp, dailyStats := range uptime.DailyStatistics {
+		if dailyUnixTimestamp >= threshold {
+			continue
+		}
+		if uptimeRetention.Monthly {
+			timestamp := time.Unix(dailyUnixTimestamp, 0)
+			monthlyUnixTimestamp := time.Date(timestamp.Year(), timestamp.Month(), 1, 0, 0, 0, 0, timestamp.Location()).Unix()
+			addUptimeStatistics(uptime.MonthlyStatistics, monthlyUnixTimestamp, dailyStats)
+		}
+		delete(uptime.DailyStatistics, dailyUnixTimestamp)
+	}
+}
+
+// addUptimeStatistics adds the given statistics to the statistics of the given unix timestamp
+func addUptimeStatistics(statistics map[int64]*endpoint.HourlyUptimeStatistics, unixTimestamp int64, statisticsToAdd *endpoint.HourlyUptimeStatistics) {
+	existingStatistics := statistics[unixTimestamp]
+	if existingStatistics == nil {
+		existingStatistics = &endpoint.HourlyUptimeStatistics{}
+		statistics[unixTimestamp] = existingStatistics
+	}
+	existingStatistics.Add(statisticsToAdd)
+}
+
+// forEachUptimeStatisticsBetween calls fn with the hourly, daily and monthly statistics within the given time range,
+// whichever granularity the data of the time range has been kept at.
+//
+// Hourly statistics are included if the hour they cover overlaps with the time range, whereas daily and monthly
+// statistics are only included if the day or month they cover starts within the time range.
+func forEachUptimeStatisticsBetween(uptime *endpoint.Uptime, from, to time.Time, fn func(unixTimestamp int64, statistics *endpoint.HourlyUptimeStatistics)) {
+	for hourlyUnixTimestamp, hourlyStats := range uptime.HourlyStatistics {
+		if hourlyUnixTimestamp >= from.Truncate(time.Hour).Unix() && hourlyUnixTimestamp <= to.Unix() {
+			fn(hourlyUnixTimestamp, hourlyStats)
+		}
+	}
+	for _, statistics := range []map[int64]*endpoint.HourlyUptimeStatistics{uptime.DailyStatistics, uptime.MonthlyStatistics} {
+		for unixTimestamp, stats := range statistics {
+			if unixTimestamp >= from.Unix() && unixTimestamp <= to.Unix() {
+				fn(unixTimestamp, stats)
 			}
 		}
 	}
@@ -5,10 +5,12 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage"
 )
 
 func BenchmarkProcessUptimeAfterResult(b *testing.B) {
 	uptime := endpoint.NewUptime()
+	uptimeRetention := storage.GetDefaultUptimeRetentionConfig()
 	now := time.Now()
 	now = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
 	// Start 12000 days ago
@@ -18,7 +20,7 @@ func BenchmarkProcessUptimeAfterResult(b *testing.B) {
 			Duration:  18 * time.Millisecond,
 			Success:   n%15 == 0,
 			Timestamp: timestamp,
-		})
+		}, uptimeRetention, 0)
 		// Simulate an endpoint with an interval of 3 minutes
 		timestamp = timestamp.Add(3 * time.Minute)
 	}
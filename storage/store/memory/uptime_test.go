@@ -15,32 +15,32 @@ func TestProcessUptimeAfterResult(t *testing.T) {
 
 	now := time.Now()
 	now = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-7 * 24 * time.Hour), Success: true})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-7 * 24 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
 
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-6 * 24 * time.Hour), Success: false})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-6 * 24 * time.Hour), Success: false}, storage.GetDefaultUptimeRetentionConfig(), 0)
 
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-8 * 24 * time.Hour), Success: true})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-8 * 24 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
 
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-24 * time.Hour), Success: true})
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-12 * time.Hour), Success: true})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-24 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-12 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
 
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-1 * time.Hour), Success: true, Duration: 10 * time.Millisecond})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-1 * time.Hour), Success: true, Duration: 10 * time.Millisecond}, storage.GetDefaultUptimeRetentionConfig(), 0)
 	checkHourlyStatistics(t, uptime.HourlyStatistics[now.Unix()-now.Unix()%3600-3600], 10, 1, 1)
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-30 * time.Minute), Success: false, Duration: 500 * time.Millisecond})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-30 * time.Minute), Success: false, Duration: 500 * time.Millisecond}, storage.GetDefaultUptimeRetentionConfig(), 0)
 	checkHourlyStatistics(t, uptime.HourlyStatistics[now.Unix()-now.Unix()%3600-3600], 510, 2, 1)
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-15 * time.Minute), Success: false, Duration: 25 * time.Millisecond})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-15 * time.Minute), Success: false, Duration: 25 * time.Millisecond}, storage.GetDefaultUptimeRetentionConfig(), 0)
 	checkHourlyStatistics(t, uptime.HourlyStatistics[now.Unix()-now.Unix()%3600-3600], 535, 3, 1)
 
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-10 * time.Minute), Success: false})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-10 * time.Minute), Success: false}, storage.GetDefaultUptimeRetentionConfig(), 0)
 
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-120 * time.Hour), Success: true})
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-119 * time.Hour), Success: true})
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-118 * time.Hour), Success: true})
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-117 * time.Hour), Success: true})
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-10 * time.Hour), Success: true})
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-8 * time.Hour), Success: true})
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-30 * time.Minute), Success: true})
-	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-25 * time.Minute), Success: true})
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-120 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-119 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-118 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-117 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-10 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-8 * time.Hour), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-30 * time.Minute), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
+	processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: now.Add(-25 * time.Minute), Success: true}, storage.GetDefaultUptimeRetentionConfig(), 0)
 }
 
 func TestAddResultUptimeIsCleaningUpAfterItself(t *testing.T) {
@@ -51,15 +51,76 @@ func TestAddResultUptimeIsCleaningUpAfterItself(t *testing.T) {
 	// Start 12 days ago
 	timestamp := now.Add(-12 * 24 * time.Hour)
 	for timestamp.Unix() <= now.Unix() {
-		AddResult(status, &endpoint.Result{Timestamp: timestamp, Success: true}, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
-		if len(status.Uptime.HourlyStatistics) > uptimeCleanUpThreshold {
-			t.Errorf("At no point in time should there be more than %d entries in status.SuccessfulExecutionsPerHour, but there are %d", uptimeCleanUpThreshold, len(status.Uptime.HourlyStatistics))
+		AddResult(status, &endpoint.Result{Timestamp: timestamp, Success: true}, storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents, storage.GetDefaultUptimeRetentionConfig(), 0)
+		if maximumNumberOfHourlyStatistics := storage.DefaultUptimeHourlyRetentionDays*24 + hourlyUptimeStatisticsMergeThreshold; len(status.Uptime.HourlyStatistics) > maximumNumberOfHourlyStatistics {
+			t.Errorf("At no point in time should there be more than %d entries in status.Uptime.HourlyStatistics, but there are %d", maximumNumberOfHourlyStatistics, len(status.Uptime.HourlyStatistics))
 		}
 		// Simulate endpoint with an interval of 3 minutes
 		timestamp = timestamp.Add(3 * time.Minute)
 	}
 }
 
+func TestProcessUptimeAfterResultWithUptimeRetention(t *testing.T) {
+	scenarios := []struct {
+		name                              string
+		uptimeRetention                   *storage.UptimeRetentionConfig
+		expectedTotalExecutions           uint64
+		expectedMaximumNumberOfDailyStats int
+		expectMonthlyStatistics           bool
+	}{
+		{
+			name:                              "default",
+			uptimeRetention:                   storage.GetDefaultUptimeRetentionConfig(),
+			expectedMaximumNumberOfDailyStats: storage.DefaultUptimeDailyRetentionDays + dailyUptimeStatisticsMergeThreshold,
+		},
+		{
+			name:                              "monthly",
+			uptimeRetention:                   &storage.UptimeRetentionConfig{HourlyDays: 2, DailyDays: 30, Monthly: true},
+			expectedMaximumNumberOfDailyStats: storage.DefaultUptimeDailyRetentionDays + dailyUptimeStatisticsMergeThreshold,
+			expectMonthlyStatistics:           true,
+		},
+		{
+			name:                              "longer-retention",
+			uptimeRetention:                   &storage.UptimeRetentionConfig{HourlyDays: 7, DailyDays: 90},
+			expectedMaximumNumberOfDailyStats: 90 + dailyUptimeStatisticsMergeThreshold,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			uptime := endpoint.NewUptime()
+			now := time.Now()
+			now = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
+			// Start 120 days ago, with one result per hour
+			var numberOfResults uint64
+			for timestamp := now.Add(-120 * 24 * time.Hour); !timestamp.After(now); timestamp = timestamp.Add(time.Hour) {
+				processUptimeAfterResult(uptime, &endpoint.Result{Timestamp: timestamp, Success: true, Duration: 10 * time.Millisecond}, scenario.uptimeRetention, 0)
+				numberOfResults++
+			}
+			if maximumNumberOfHourlyStats := scenario.uptimeRetention.HourlyDays*24 + hourlyUptimeStatisticsMergeThreshold; len(uptime.HourlyStatistics) > maximumNumberOfHourlyStats {
+				t.Errorf("expected at most %d hourly statistics, got %d", maximumNumberOfHourlyStats, len(uptime.HourlyStatistics))
+			}
+			if len(uptime.DailyStatistics) == 0 || len(uptime.DailyStatistics) > scenario.expectedMaximumNumberOfDailyStats {
+				t.Errorf("expected between 1 and %d daily statistics, got %d", scenario.expectedMaximumNumberOfDailyStats, len(uptime.DailyStatistics))
+			}
+			if scenario.expectMonthlyStatistics != (len(uptime.MonthlyStatistics) > 0) {
+				t.Errorf("expected monthly statistics to be kept to be %v, got %d monthly statistics", scenario.expectMonthlyStatistics, len(uptime.MonthlyStatistics))
+			}
+			var totalExecutions uint64
+			forEachUptimeStatisticsBetween(uptime, now.Add(-365*24*time.Hour), now, func(_ int64, statistics *endpoint.HourlyUptimeStatistics) {
+				totalExecutions += statistics.TotalExecutions
+				if statistics.TotalExecutionsResponseTime != statistics.TotalExecutions*10 {
+					t.Errorf("expected response time to be preserved when merging statistics, got %d for %d executions", statistics.TotalExecutionsResponseTime, statistics.TotalExecutions)
+				}
+			})
+			if scenario.expectMonthlyStatistics && totalExecutions != numberOfResults {
+				t.Errorf("expected all %d executions to be kept, got %d", numberOfResults, totalExecutions)
+			} else if !scenario.expectMonthlyStatistics && totalExecutions >= numberOfResults {
+				t.Errorf("expected executions older than the daily retention to be deleted, got %d out of %d", totalExecutions, numberOfResults)
+			}
+		})
+	}
+}
+
 func checkHourlyStatistics(t *testing.T, hourlyUptimeStatistics *endpoint.HourlyUptimeStatistics, expectedTotalExecutionsResponseTime uint64, expectedTotalExecutions uint64, expectedSuccessfulExecutions uint64) {
 	if hourlyUptimeStatistics.TotalExecutionsResponseTime != expectedTotalExecutionsResponseTime {
 		t.Error("TotalExecutionsResponseTime should've been", expectedTotalExecutionsResponseTime, "got", hourlyUptimeStatistics.TotalExecutionsResponseTime)
@@ -1,8 +1,15 @@
 package memory
 
 import (
+	"slices"
+	"time"
+
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/incident"
+	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
 
@@ -59,6 +66,35 @@ func ShallowCopySuiteStatus(ss *suite.Status, params *paging.SuiteStatusParams)
 	return shallowCopy
 }
 
+// CopyIncident returns a copy of an incident that can be modified without affecting the original
+func CopyIncident(inc *incident.Incident) *incident.Incident {
+	incidentCopy := *inc
+	incidentCopy.EndpointKeys = slices.Clone(inc.EndpointKeys)
+	incidentCopy.SuiteKeys = slices.Clone(inc.SuiteKeys)
+	incidentCopy.Updates = make([]*incident.Update, 0, len(inc.Updates))
+	for _, update := range inc.Updates {
+		updateCopy := *update
+		incidentCopy.Updates = append(incidentCopy.Updates, &updateCopy)
+	}
+	return &incidentCopy
+}
+
+// CopyMaintenanceWindow returns a copy of a maintenance window that can be modified without affecting the original
+func CopyMaintenanceWindow(window *maintenance.Window) *maintenance.Window {
+	windowCopy := *window
+	windowCopy.EndpointKeys = slices.Clone(window.EndpointKeys)
+	windowCopy.Groups = slices.Clone(window.Groups)
+	windowCopy.SuiteKeys = slices.Clone(window.SuiteKeys)
+	return &windowCopy
+}
+
+// CopySession returns a copy of a session that can be modified without affecting the original
+func CopySession(sess *session.Session) *session.Session {
+	sessionCopy := *sess
+	sessionCopy.Groups = slices.Clone(sess.Groups)
+	return &sessionCopy
+}
+
 func getStartAndEndIndex(numberOfResults int, page, pageSize int) (int, int) {
 	if page < 1 || pageSize < 0 {
 		return -1, -1
@@ -78,7 +114,7 @@ func getStartAndEndIndex(numberOfResults int, page, pageSize int) (int, int) {
 
 // AddResult adds a Result to Status.Results and makes sure that there are
 // no more than MaximumNumberOfResults results in the Results slice
-func AddResult(ss *endpoint.Status, result *endpoint.Result, maximumNumberOfResults, maximumNumberOfEvents int) {
+func AddResult(ss *endpoint.Status, result *endpoint.Result, maximumNumberOfResults, maximumNumberOfEvents int, uptimeRetention *storage.UptimeRetentionConfig, latencyThreshold time.Duration) {
 	if ss == nil {
 		return
 	}
@@ -104,5 +140,5 @@ func AddResult(ss *endpoint.Status, result *endpoint.Result, maximumNumberOfResu
 		// MaximumNumberOfResults by using ss.Results[len(ss.Results)-MaximumNumberOfResults:] instead
 		ss.Results = ss.Results[len(ss.Results)-maximumNumberOfResults:]
 	}
-	processUptimeAfterResult(ss.Uptime, result)
+	processUptimeAfterResult(ss.Uptime, result, uptimeRetention, latencyThreshold)
 }
@@ -0,0 +1,38 @@
+package sql
+
+import (
+	"time"
+)
+
+// GetAllAPITokenLastUsedTimes returns the last time each API token was used, keyed by the name of the token
+func (s *Store) GetAllAPITokenLastUsedTimes() (map[string]time.Time, error) {
+	rows, err := s.db.Query("SELECT api_token_name, last_used_at FROM api_tokens")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	lastUsedTimes := make(map[string]time.Time)
+	for rows.Next() {
+		var name string
+		var lastUsedAt time.Time
+		if err = rows.Scan(&name, &lastUsedAt); err != nil {
+			return nil, err
+		}
+		lastUsedTimes[name] = lastUsedAt
+	}
+	return lastUsedTimes, rows.Err()
+}
+
+// UpdateAPITokenLastUsedTime records the last time the API token with the given name was used
+func (s *Store) UpdateAPITokenLastUsedTime(name string, lastUsedAt time.Time) error {
+	_, err := s.db.Exec(
+		`
+			INSERT INTO api_tokens (api_token_name, last_used_at)
+			VALUES ($1, $2)
+			ON CONFLICT(api_token_name) DO UPDATE SET last_used_at = $2
+		`,
+		name,
+		lastUsedAt.UTC(),
+	)
+	return err
+}
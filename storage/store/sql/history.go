@@ -0,0 +1,186 @@
+package sql
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/logr"
+)
+
+// GetEndpointResultsByKey returns a page of the results of the endpoint with the given key, from the oldest to the most
+// recent, as well as the cursor of the next page, which is empty if there are no more results.
+//
+// The cursor is the ID of the last result of the page.
+func (s *Store) GetEndpointResultsByKey(key string, params *paging.EndpointHistoryParams) ([]*endpoint.Result, string, error) {
+	afterID, err := parseCursor(params)
+	if err != nil {
+		return nil, "", err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, "", err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, "", err
+	}
+	results, cursor, err := s.getEndpointResultsAfterID(tx, endpointID, afterID, params)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, "", err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return results, cursor, err
+}
+
+// GetEndpointEventsByKey returns a page of the events of the endpoint with the given key, from the oldest to the most
+// recent, as well as the cursor of the next page, which is empty if there are no more events.
+//
+// The cursor is the ID of the last event of the page.
+func (s *Store) GetEndpointEventsByKey(key string, params *paging.EndpointHistoryParams) ([]*endpoint.Event, string, error) {
+	afterID, err := parseCursor(params)
+	if err != nil {
+		return nil, "", err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, "", err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, "", err
+	}
+	events, cursor, err := s.getEndpointEventsAfterID(tx, endpointID, afterID, params)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, "", err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return events, cursor, err
+}
+
+func (s *Store) getEndpointResultsAfterID(tx *sql.Tx, endpointID, afterID int64, params *paging.EndpointHistoryParams) (results []*endpoint.Result, cursor string, err error) {
+	query, args := buildHistoryQuery(
+		`SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, connection_duration, query_duration, dns_lookup_duration, tcp_connect_duration, tls_handshake_duration, time_to_first_byte, transfer_duration, suppressed_by, timestamp
+			FROM endpoint_results
+			WHERE endpoint_id = $1 AND endpoint_result_id > $2`,
+		"timestamp",
+		"endpoint_result_id",
+		endpointID,
+		afterID,
+		params,
+		true,
+	)
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	idResultMap := make(map[int64]*endpoint.Result)
+	var id int64
+	for rows.Next() {
+		if len(results) == params.Limit {
+			// The query retrieves one more result than requested to know whether there's a next page
+			cursor = strconv.FormatInt(id, 10)
+			break
+		}
+		result := &endpoint.Result{}
+		var joinedErrors string
+		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.ConnectionDuration, &result.QueryDuration, &result.DNSLookupDuration, &result.TCPConnectDuration, &result.TLSHandshakeDuration, &result.TimeToFirstByte, &result.TransferDuration, &result.SuppressedBy, &result.Timestamp)
+		if err != nil {
+			logr.Errorf("[sql.getEndpointResultsAfterID] Silently failed to retrieve endpoint result for endpointID=%d: %s", endpointID, err.Error())
+			err = nil
+		}
+		if len(joinedErrors) != 0 {
+			result.Errors = strings.Split(joinedErrors, arraySeparator)
+		}
+		results = append(results, result)
+		idResultMap[id] = result
+	}
+	_ = rows.Close()
+	if len(idResultMap) == 0 {
+		return
+	}
+	if err = s.populateConditionResultsAndAttempts(tx, idResultMap); err != nil {
+		return nil, "", err
+	}
+	return
+}
+
+func (s *Store) getEndpointEventsAfterID(tx *sql.Tx, endpointID, afterID int64, params *paging.EndpointHistoryParams) (events []*endpoint.Event, cursor string, err error) {
+	query, args := buildHistoryQuery(
+		`SELECT endpoint_event_id, event_type, event_timestamp
+			FROM endpoint_events
+			WHERE endpoint_id = $1 AND endpoint_event_id > $2`,
+		"event_timestamp",
+		"endpoint_event_id",
+		endpointID,
+		afterID,
+		params,
+		false,
+	)
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	var id int64
+	for rows.Next() {
+		if len(events) == params.Limit {
+			// The query retrieves one more event than requested to know whether there's a next page
+			cursor = strconv.FormatInt(id, 10)
+			break
+		}
+		event := &endpoint.Event{}
+		if err = rows.Scan(&id, &event.Type, &event.Timestamp); err != nil {
+			return nil, "", err
+		}
+		events = append(events, event)
+	}
+	return
+}
+
+// buildHistoryQuery appends the filters of params to a query that already filters on the endpoint ID ($1) and on the
+// cursor ($2), and returns the query alongside its arguments
+func buildHistoryQuery(query, timestampColumn, idColumn string, endpointID, afterID int64, params *paging.EndpointHistoryParams, filterOnSuccess bool) (string, []any) {
+	args := []any{endpointID, afterID}
+	if !params.From.IsZero() {
+		args = append(args, params.From.UTC())
+		query += " AND " + timestampColumn + " >= $" + strconv.Itoa(len(args))
+	}
+	if !params.To.IsZero() {
+		args = append(args, params.To.UTC())
+		query += " AND " + timestampColumn + " <= $" + strconv.Itoa(len(args))
+	}
+	if filterOnSuccess && params.Success != nil {
+		args = append(args, *params.Success)
+		query += " AND success = $" + strconv.Itoa(len(args))
+	}
+	args = append(args, params.Limit+1)
+	query += " ORDER BY " + idColumn + " ASC LIMIT $" + strconv.Itoa(len(args))
+	return query, args
+}
+
+// parseCursor returns the ID after which the page described by params starts, after validating the time range
+func parseCursor(params *paging.EndpointHistoryParams) (int64, error) {
+	if !params.From.IsZero() && !params.To.IsZero() && params.From.After(params.To) {
+		return 0, common.ErrInvalidTimeRange
+	}
+	if len(params.Cursor) == 0 {
+		return 0, nil
+	}
+	afterID, err := strconv.ParseInt(params.Cursor, 10, 64)
+	if err != nil || afterID < 0 {
+		return 0, common.ErrInvalidCursor
+	}
+	return afterID, nil
+}
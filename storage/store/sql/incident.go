@@ -0,0 +1,220 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config/incident"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+)
+
+// GetAllIncidents returns all incidents, sorted from the most recent to the oldest
+func (s *Store) GetAllIncidents() ([]*incident.Incident, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	incidents, err := s.getIncidents(tx, "")
+	if err != nil {
+		return nil, err
+	}
+	if err = s.populateIncidentUpdates(tx, incidents); err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// GetIncidentByID returns the incident with the given ID
+func (s *Store) GetIncidentByID(id int64) (*incident.Incident, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	incidents, err := s.getIncidents(tx, "WHERE incident_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(incidents) == 0 {
+		return nil, common.ErrIncidentNotFound
+	}
+	if err = s.populateIncidentUpdates(tx, incidents); err != nil {
+		return nil, err
+	}
+	return incidents[0], nil
+}
+
+// InsertIncident inserts a new incident in the store and sets its ID
+func (s *Store) InsertIncident(inc *incident.Incident) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	var id int64
+	err = tx.QueryRow(
+		`
+			INSERT INTO incidents (title, severity, status, endpoint_keys, suite_keys, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING incident_id
+		`,
+		inc.Title,
+		inc.Severity,
+		inc.Status,
+		strings.Join(inc.EndpointKeys, arraySeparator),
+		strings.Join(inc.SuiteKeys, arraySeparator),
+		inc.CreatedAt.UTC(),
+		inc.UpdatedAt.UTC(),
+	).Scan(&id)
+	if err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.InsertIncident] Failed to insert incident: %s", err.Error())
+		return err
+	}
+	if err = s.insertIncidentUpdates(tx, id, inc.Updates); err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.InsertIncident] Failed to insert updates of incident with id=%d: %s", id, err.Error())
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	inc.ID = id
+	return nil
+}
+
+// UpdateIncident replaces an existing incident, including its timeline of updates
+func (s *Store) UpdateIncident(inc *incident.Incident) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(
+		`
+			UPDATE incidents
+			SET title = $1, severity = $2, status = $3, endpoint_keys = $4, suite_keys = $5, updated_at = $6
+			WHERE incident_id = $7
+		`,
+		inc.Title,
+		inc.Severity,
+		inc.Status,
+		strings.Join(inc.EndpointKeys, arraySeparator),
+		strings.Join(inc.SuiteKeys, arraySeparator),
+		inc.UpdatedAt.UTC(),
+		inc.ID,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.UpdateIncident] Failed to update incident with id=%d: %s", inc.ID, err.Error())
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return common.ErrIncidentNotFound
+	}
+	// The timeline is small enough that it's simpler to replace it entirely than to figure out which updates are new
+	if _, err = tx.Exec("DELETE FROM incident_updates WHERE incident_id = $1", inc.ID); err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.UpdateIncident] Failed to delete updates of incident with id=%d: %s", inc.ID, err.Error())
+		return err
+	}
+	if err = s.insertIncidentUpdates(tx, inc.ID, inc.Updates); err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.UpdateIncident] Failed to insert updates of incident with id=%d: %s", inc.ID, err.Error())
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+// getIncidents retrieves the incidents matching the where clause, without their updates
+func (s *Store) getIncidents(tx *sql.Tx, whereClause string, args ...any) ([]*incident.Incident, error) {
+	rows, err := tx.Query(
+		`
+			SELECT incident_id, title, severity, status, endpoint_keys, suite_keys, created_at, updated_at
+			FROM incidents
+		`+whereClause+`
+			ORDER BY incident_id DESC
+		`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	incidents := make([]*incident.Incident, 0)
+	for rows.Next() {
+		inc := &incident.Incident{Updates: []*incident.Update{}}
+		var joinedEndpointKeys, joinedSuiteKeys string
+		if err = rows.Scan(&inc.ID, &inc.Title, &inc.Severity, &inc.Status, &joinedEndpointKeys, &joinedSuiteKeys, &inc.CreatedAt, &inc.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if len(joinedEndpointKeys) != 0 {
+			inc.EndpointKeys = strings.Split(joinedEndpointKeys, arraySeparator)
+		}
+		if len(joinedSuiteKeys) != 0 {
+			inc.SuiteKeys = strings.Split(joinedSuiteKeys, arraySeparator)
+		}
+		incidents = append(incidents, inc)
+	}
+	return incidents, rows.Err()
+}
+
+// populateIncidentUpdates retrieves the updates of each incident passed as parameter
+func (s *Store) populateIncidentUpdates(tx *sql.Tx, incidents []*incident.Incident) error {
+	if len(incidents) == 0 {
+		return nil
+	}
+	idIncidentMap := make(map[int64]*incident.Incident, len(incidents))
+	for _, inc := range incidents {
+		idIncidentMap[inc.ID] = inc
+	}
+	var rows *sql.Rows
+	var err error
+	if len(incidents) == 1 {
+		rows, err = tx.Query("SELECT incident_id, status, message, timestamp FROM incident_updates WHERE incident_id = $1 ORDER BY incident_update_id", incidents[0].ID)
+	} else {
+		rows, err = tx.Query("SELECT incident_id, status, message, timestamp FROM incident_updates ORDER BY incident_update_id")
+	}
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		update := &incident.Update{}
+		var incidentID int64
+		if err = rows.Scan(&incidentID, &update.Status, &update.Message, &update.Timestamp); err != nil {
+			return err
+		}
+		if inc, exists := idIncidentMap[incidentID]; exists {
+			inc.Updates = append(inc.Updates, update)
+		}
+	}
+	return rows.Err()
+}
+
+// insertIncidentUpdates inserts the updates of an incident
+func (s *Store) insertIncidentUpdates(tx *sql.Tx, incidentID int64, updates []*incident.Update) error {
+	if len(updates) == 0 {
+		return errors.New("incident must have at least one update")
+	}
+	for _, update := range updates {
+		_, err := tx.Exec(
+			"INSERT INTO incident_updates (incident_id, status, message, timestamp) VALUES ($1, $2, $3, $4)",
+			incidentID,
+			update.Status,
+			update.Message,
+			update.Timestamp.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package sql
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+)
+
+// locationResult is the representation of a result reported from a location in the database, which includes the
+// fields of endpoint.Result that aren't part of its JSON representation but are needed to evaluate the health of the
+// endpoint from the results of all of its locations
+type locationResult struct {
+	*endpoint.Result
+	Connected             bool          `json:"connected"`
+	CertificateExpiration time.Duration `json:"certificateExpiration,omitempty"`
+}
+
+// GetLatestLocationResults returns the latest result reported from each location for the endpoint with the given key,
+// keyed by the name of the location
+func (s *Store) GetLatestLocationResults(key string) (map[string]*endpoint.Result, error) {
+	rows, err := s.db.Query(
+		`
+			SELECT location, result
+			FROM endpoint_location_results
+			WHERE endpoint_id = (SELECT endpoint_id FROM endpoints WHERE endpoint_key = $1)
+		`,
+		key,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	latestResults := make(map[string]*endpoint.Result)
+	for rows.Next() {
+		var location, serializedResult string
+		if err = rows.Scan(&location, &serializedResult); err != nil {
+			return nil, err
+		}
+		var stored locationResult
+		if err = json.Unmarshal([]byte(serializedResult), &stored); err != nil || stored.Result == nil {
+			logr.Errorf("[sql.GetLatestLocationResults] Ignoring invalid result from location=%s for endpoint with key=%s", location, key)
+			continue
+		}
+		stored.Result.Connected = stored.Connected
+		stored.Result.CertificateExpiration = stored.CertificateExpiration
+		latestResults[location] = stored.Result
+	}
+	return latestResults, rows.Err()
+}
+
+// UpsertLatestLocationResult replaces the latest result reported from the given location for the endpoint
+func (s *Store) UpsertLatestLocationResult(ep *endpoint.Endpoint, location string, result *endpoint.Result) error {
+	serializedResult, err := json.Marshal(&locationResult{Result: result, Connected: result.Connected, CertificateExpiration: result.CertificateExpiration})
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	endpointID, err := s.getEndpointID(tx, ep)
+	if err != nil {
+		if !errors.Is(err, common.ErrEndpointNotFound) {
+			_ = tx.Rollback()
+			return err
+		}
+		// The endpoint has no result of its own until its health has been evaluated from the results of its locations
+		if endpointID, err = s.insertEndpoint(tx, ep); err != nil {
+			_ = tx.Rollback()
+			logr.Errorf("[sql.UpsertLatestLocationResult] Failed to create endpoint with key=%s: %s", ep.Key(), err.Error())
+			return err
+		}
+	}
+	_, err = tx.Exec(
+		`
+			INSERT INTO endpoint_location_results (endpoint_id, location, result)
+			VALUES ($1, $2, $3)
+			ON CONFLICT(endpoint_id, location) DO UPDATE SET result = $3
+		`,
+		endpointID,
+		location,
+		string(serializedResult),
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+)
+
+// GetAllMaintenanceWindows returns all maintenance windows that haven't expired yet, sorted from the most recent to
+// the oldest
+func (s *Store) GetAllMaintenanceWindows() ([]*maintenance.Window, error) {
+	rows, err := s.db.Query(
+		`
+			SELECT maintenance_window_id, description, endpoint_keys, endpoint_groups, suite_keys, schedule_from, schedule_until, schedule_cron, schedule_duration, schedule_timezone, created_by, created_at
+			FROM maintenance_windows
+			WHERE expires_at IS NULL OR expires_at > $1
+			ORDER BY maintenance_window_id DESC
+		`,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	windows := make([]*maintenance.Window, 0)
+	for rows.Next() {
+		window := &maintenance.Window{}
+		var joinedEndpointKeys, joinedGroups, joinedSuiteKeys string
+		if err = rows.Scan(&window.ID, &window.Description, &joinedEndpointKeys, &joinedGroups, &joinedSuiteKeys, &window.From, &window.Until, &window.Cron, &window.Duration, &window.Timezone, &window.CreatedBy, &window.CreatedAt); err != nil {
+			return nil, err
+		}
+		if len(joinedEndpointKeys) != 0 {
+			window.EndpointKeys = strings.Split(joinedEndpointKeys, arraySeparator)
+		}
+		if len(joinedGroups) != 0 {
+			window.Groups = strings.Split(joinedGroups, arraySeparator)
+		}
+		if len(joinedSuiteKeys) != 0 {
+			window.SuiteKeys = strings.Split(joinedSuiteKeys, arraySeparator)
+		}
+		// The schedule of the maintenance window must be parsed before it can be used
+		if err = window.ValidateAndSetDefaults(); err != nil {
+			logr.Errorf("[sql.GetAllMaintenanceWindows] Skipping invalid maintenance window with id=%d: %s", window.ID, err.Error())
+			continue
+		}
+		windows = append(windows, window)
+	}
+	return windows, rows.Err()
+}
+
+// InsertMaintenanceWindow inserts a new maintenance window in the store and sets its ID
+//
+// Maintenance windows that have expired are deleted in the process
+func (s *Store) InsertMaintenanceWindow(window *maintenance.Window) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM maintenance_windows WHERE expires_at <= $1", time.Now().UTC()); err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.InsertMaintenanceWindow] Failed to delete expired maintenance windows: %s", err.Error())
+		return err
+	}
+	var expiresAt sql.NullTime
+	if !window.ExpiresAt().IsZero() {
+		expiresAt = sql.NullTime{Time: window.ExpiresAt().UTC(), Valid: true}
+	}
+	err = tx.QueryRow(
+		`
+			INSERT INTO maintenance_windows (description, endpoint_keys, endpoint_groups, suite_keys, schedule_from, schedule_until, schedule_cron, schedule_duration, schedule_timezone, created_by, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING maintenance_window_id
+		`,
+		window.Description,
+		strings.Join(window.EndpointKeys, arraySeparator),
+		strings.Join(window.Groups, arraySeparator),
+		strings.Join(window.SuiteKeys, arraySeparator),
+		window.From,
+		window.Until,
+		window.Cron,
+		window.Duration,
+		window.Timezone,
+		window.CreatedBy,
+		window.CreatedAt.UTC(),
+		expiresAt,
+	).Scan(&window.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.InsertMaintenanceWindow] Failed to insert maintenance window: %s", err.Error())
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteMaintenanceWindow deletes the maintenance window with the given ID
+func (s *Store) DeleteMaintenanceWindow(id int64) error {
+	result, err := s.db.Exec("DELETE FROM maintenance_windows WHERE maintenance_window_id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return common.ErrMaintenanceWindowNotFound
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/security/session"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+)
+
+// GetAllSessions returns all sessions that haven't expired yet, sorted from the most recent to the oldest
+func (s *Store) GetAllSessions() ([]*session.Session, error) {
+	rows, err := s.db.Query(
+		`
+			SELECT session_id, subject, user_groups, refresh_token, created_at, expires_at
+			FROM sessions
+			WHERE expires_at > $1
+			ORDER BY created_at DESC
+		`,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	sessions := make([]*session.Session, 0)
+	for rows.Next() {
+		sess, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, rows.Err()
+}
+
+// GetSessionByID returns the session with the given ID, or returns common.ErrSessionNotFound if it doesn't exist or
+// if it has expired
+func (s *Store) GetSessionByID(id string) (*session.Session, error) {
+	row := s.db.QueryRow(
+		`
+			SELECT session_id, subject, user_groups, refresh_token, created_at, expires_at
+			FROM sessions
+			WHERE session_id = $1 AND expires_at > $2
+		`,
+		id,
+		time.Now().UTC(),
+	)
+	sess, err := scanSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, common.ErrSessionNotFound
+	}
+	return sess, err
+}
+
+// UpsertSession inserts/updates a session
+//
+// Sessions that have expired are deleted in the process
+func (s *Store) UpsertSession(sess *session.Session) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM sessions WHERE expires_at <= $1", time.Now().UTC()); err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.UpsertSession] Failed to delete expired sessions: %s", err.Error())
+		return err
+	}
+	_, err = tx.Exec(
+		`
+			INSERT INTO sessions (session_id, subject, user_groups, refresh_token, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT(session_id) DO UPDATE SET user_groups = $3, refresh_token = $4, expires_at = $6
+		`,
+		sess.ID,
+		sess.Subject,
+		strings.Join(sess.Groups, arraySeparator),
+		sess.RefreshToken,
+		sess.CreatedAt.UTC(),
+		sess.ExpiresAt.UTC(),
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.UpsertSession] Failed to upsert session: %s", err.Error())
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteSession deletes the session with the given ID
+func (s *Store) DeleteSession(id string) error {
+	result, err := s.db.Exec("DELETE FROM sessions WHERE session_id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return common.ErrSessionNotFound
+	}
+	return nil
+}
+
+// scanSession scans a row of the sessions table into a session
+func scanSession(row interface{ Scan(dest ...any) error }) (*session.Session, error) {
+	sess := &session.Session{}
+	var joinedGroups string
+	if err := row.Scan(&sess.ID, &sess.Subject, &joinedGroups, &sess.RefreshToken, &sess.CreatedAt, &sess.ExpiresAt); err != nil {
+		return nil, err
+	}
+	if len(joinedGroups) != 0 {
+		sess.Groups = strings.Split(joinedGroups, arraySeparator)
+	}
+	return sess, nil
+}
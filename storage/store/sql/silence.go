@@ -0,0 +1,138 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+)
+
+// GetAllSilences returns all silences that haven't expired yet, sorted from the most recent to the oldest
+func (s *Store) GetAllSilences() ([]*silence.Silence, error) {
+	rows, err := s.db.Query(
+		`
+			SELECT silence_id, endpoint_key_pattern, endpoint_group, alert_type, comment, created_by, created_at, expires_at
+			FROM alert_silences
+			WHERE expires_at > $1
+			ORDER BY silence_id DESC
+		`,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	silences := make([]*silence.Silence, 0)
+	for rows.Next() {
+		sil := &silence.Silence{}
+		var alertType string
+		if err = rows.Scan(&sil.ID, &sil.Matcher.EndpointKey, &sil.Matcher.Group, &alertType, &sil.Comment, &sil.CreatedBy, &sil.CreatedAt, &sil.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sil.Matcher.AlertType = alert.Type(alertType)
+		silences = append(silences, sil)
+	}
+	return silences, rows.Err()
+}
+
+// InsertSilence inserts a new silence in the store and sets its ID
+//
+// Silences that have expired are deleted in the process
+func (s *Store) InsertSilence(sil *silence.Silence) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM alert_silences WHERE expires_at <= $1", time.Now().UTC()); err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.InsertSilence] Failed to delete expired silences: %s", err.Error())
+		return err
+	}
+	err = tx.QueryRow(
+		`
+			INSERT INTO alert_silences (endpoint_key_pattern, endpoint_group, alert_type, comment, created_by, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING silence_id
+		`,
+		sil.Matcher.EndpointKey,
+		sil.Matcher.Group,
+		string(sil.Matcher.AlertType),
+		sil.Comment,
+		sil.CreatedBy,
+		sil.CreatedAt.UTC(),
+		sil.ExpiresAt.UTC(),
+	).Scan(&sil.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		logr.Errorf("[sql.InsertSilence] Failed to insert silence: %s", err.Error())
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteSilence deletes the silence with the given ID
+func (s *Store) DeleteSilence(id int64) error {
+	result, err := s.db.Exec("DELETE FROM alert_silences WHERE silence_id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return common.ErrSilenceNotFound
+	}
+	return nil
+}
+
+// GetAllAlertAcknowledgements returns all acknowledgements of triggered alerts
+func (s *Store) GetAllAlertAcknowledgements() ([]*silence.Acknowledgement, error) {
+	rows, err := s.db.Query("SELECT endpoint_key, alert_checksum, comment, acknowledged_by, acknowledged_at FROM alert_acknowledgements ORDER BY acknowledged_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	acknowledgements := make([]*silence.Acknowledgement, 0)
+	for rows.Next() {
+		ack := &silence.Acknowledgement{}
+		if err = rows.Scan(&ack.EndpointKey, &ack.AlertChecksum, &ack.Comment, &ack.AcknowledgedBy, &ack.AcknowledgedAt); err != nil {
+			return nil, err
+		}
+		acknowledgements = append(acknowledgements, ack)
+	}
+	return acknowledgements, rows.Err()
+}
+
+// UpsertAlertAcknowledgement inserts/updates the acknowledgement of a triggered alert
+func (s *Store) UpsertAlertAcknowledgement(ack *silence.Acknowledgement) error {
+	_, err := s.db.Exec(
+		`
+			INSERT INTO alert_acknowledgements (endpoint_key, alert_checksum, comment, acknowledged_by, acknowledged_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT(endpoint_key, alert_checksum) DO UPDATE SET
+				comment = $3,
+				acknowledged_by = $4,
+				acknowledged_at = $5
+		`,
+		ack.EndpointKey,
+		ack.AlertChecksum,
+		ack.Comment,
+		ack.AcknowledgedBy,
+		ack.AcknowledgedAt.UTC(),
+	)
+	if err != nil {
+		logr.Errorf("[sql.UpsertAlertAcknowledgement] Failed to persist acknowledgement of alert for endpoint with key=%s: %s", ack.EndpointKey, err.Error())
+	}
+	return err
+}
+
+// DeleteAlertAcknowledgement deletes the acknowledgement of an alert
+func (s *Store) DeleteAlertAcknowledgement(endpointKey, alertChecksum string) error {
+	result, err := s.db.Exec("DELETE FROM alert_acknowledgements WHERE endpoint_key = $1 AND alert_checksum = $2", endpointKey, alertChecksum)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return common.ErrAcknowledgementNotFound
+	}
+	return nil
+}
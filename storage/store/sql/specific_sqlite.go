@@ -65,6 +65,14 @@ func (s *Store) createSQLiteSchema() error {
 			hostname               TEXT      NOT NULL,
 			ip                     TEXT      NOT NULL,
 			duration               INTEGER   NOT NULL,
+			connection_duration    INTEGER   NOT NULL DEFAULT 0,
+			query_duration         INTEGER   NOT NULL DEFAULT 0,
+			dns_lookup_duration    INTEGER   NOT NULL DEFAULT 0,
+			tcp_connect_duration   INTEGER   NOT NULL DEFAULT 0,
+			tls_handshake_duration INTEGER   NOT NULL DEFAULT 0,
+			time_to_first_byte     INTEGER   NOT NULL DEFAULT 0,
+			transfer_duration      INTEGER   NOT NULL DEFAULT 0,
+			suppressed_by          TEXT      NOT NULL DEFAULT '',
 			timestamp              TIMESTAMP NOT NULL,
 			suite_result_id        INTEGER   REFERENCES suite_results(suite_result_id) ON DELETE CASCADE
 		)
@@ -83,6 +91,19 @@ func (s *Store) createSQLiteSchema() error {
 	if err != nil {
 		return err
 	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_result_attempts (
+			endpoint_result_attempt_id  INTEGER PRIMARY KEY,
+			endpoint_result_id          INTEGER   NOT NULL REFERENCES endpoint_results(endpoint_result_id) ON DELETE CASCADE,
+			success                     INTEGER   NOT NULL,
+			errors                      TEXT      NOT NULL,
+			duration                    INTEGER   NOT NULL,
+			timestamp                   TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
 	_, err = s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoint_uptimes (
 			endpoint_uptime_id    INTEGER PRIMARY KEY,
@@ -91,6 +112,9 @@ func (s *Store) createSQLiteSchema() error {
 			total_executions      INTEGER NOT NULL,
 			successful_executions INTEGER NOT NULL,
 			total_response_time   INTEGER NOT NULL,
+			executions_within_latency_threshold INTEGER NOT NULL DEFAULT 0,
+			response_time_histogram TEXT NOT NULL DEFAULT '',
+			max_response_time INTEGER NOT NULL DEFAULT 0,
 			UNIQUE(endpoint_id, hour_unix_timestamp)
 		)
 	`)
@@ -104,12 +128,145 @@ func (s *Store) createSQLiteSchema() error {
 		    configuration_checksum        TEXT    NOT NULL,
 		    resolve_key		              TEXT    NOT NULL,
 			number_of_successes_in_a_row  INTEGER NOT NULL,
+			triggered_at                  TIMESTAMP,
 			UNIQUE(endpoint_id, configuration_checksum)
 		)
 	`)
 	if err != nil {
 		return err
 	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_alert_escalation_steps (
+			endpoint_alert_escalation_step_id  INTEGER PRIMARY KEY,
+			endpoint_alert_trigger_id          INTEGER   NOT NULL REFERENCES endpoint_alerts_triggered(endpoint_alert_trigger_id) ON DELETE CASCADE,
+			step_index                         INTEGER   NOT NULL,
+			resolve_key                        TEXT      NOT NULL,
+			UNIQUE(endpoint_alert_trigger_id, step_index)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS alert_silences (
+			silence_id            INTEGER PRIMARY KEY,
+			endpoint_key_pattern  TEXT      NOT NULL,
+			endpoint_group        TEXT      NOT NULL,
+			alert_type            TEXT      NOT NULL,
+			comment               TEXT      NOT NULL,
+			created_by            TEXT      NOT NULL,
+			created_at            TIMESTAMP NOT NULL,
+			expires_at            TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS alert_acknowledgements (
+			alert_acknowledgement_id  INTEGER PRIMARY KEY,
+			endpoint_key              TEXT      NOT NULL,
+			alert_checksum            TEXT      NOT NULL,
+			comment                   TEXT      NOT NULL,
+			acknowledged_by           TEXT      NOT NULL,
+			acknowledged_at           TIMESTAMP NOT NULL,
+			UNIQUE(endpoint_key, alert_checksum)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS maintenance_windows (
+			maintenance_window_id  INTEGER PRIMARY KEY,
+			description            TEXT      NOT NULL,
+			endpoint_keys          TEXT      NOT NULL,
+			endpoint_groups        TEXT      NOT NULL,
+			suite_keys             TEXT      NOT NULL,
+			schedule_from          TEXT      NOT NULL,
+			schedule_until         TEXT      NOT NULL,
+			schedule_cron          TEXT      NOT NULL,
+			schedule_duration      TEXT      NOT NULL,
+			schedule_timezone      TEXT      NOT NULL,
+			created_by             TEXT      NOT NULL,
+			created_at             TIMESTAMP NOT NULL,
+			expires_at             TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS incidents (
+			incident_id    INTEGER PRIMARY KEY,
+			title          TEXT      NOT NULL,
+			severity       TEXT      NOT NULL,
+			status         TEXT      NOT NULL,
+			endpoint_keys  TEXT      NOT NULL,
+			suite_keys     TEXT      NOT NULL,
+			created_at     TIMESTAMP NOT NULL,
+			updated_at     TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS incident_updates (
+			incident_update_id  INTEGER PRIMARY KEY,
+			incident_id         INTEGER   NOT NULL REFERENCES incidents(incident_id) ON DELETE CASCADE,
+			status              TEXT      NOT NULL,
+			message             TEXT      NOT NULL,
+			timestamp           TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS leases (
+			lease_name  TEXT      PRIMARY KEY,
+			holder      TEXT      NOT NULL,
+			expires_at  TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_location_results (
+			endpoint_location_result_id  INTEGER   PRIMARY KEY,
+			endpoint_id                  INTEGER   NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			location                     TEXT      NOT NULL,
+			result                       TEXT      NOT NULL,
+			UNIQUE(endpoint_id, location)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS api_tokens (
+			api_token_name  TEXT      PRIMARY KEY,
+			last_used_at    TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS sessions (
+			session_id     TEXT      PRIMARY KEY,
+			subject        TEXT      NOT NULL,
+			user_groups    TEXT      NOT NULL,
+			refresh_token  TEXT      NOT NULL,
+			created_at     TIMESTAMP NOT NULL,
+			expires_at     TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
 	// Create indices for performance reasons
 	_, err = s.db.Exec(`
 		CREATE INDEX IF NOT EXISTS endpoint_results_endpoint_id_idx ON endpoint_results (endpoint_id);
@@ -129,6 +286,12 @@ func (s *Store) createSQLiteSchema() error {
 	if err != nil {
 		return err
 	}
+	_, err = s.db.Exec(`
+		CREATE INDEX IF NOT EXISTS endpoint_result_attempts_endpoint_result_id_idx ON endpoint_result_attempts (endpoint_result_id);
+	`)
+	if err != nil {
+		return err
+	}
 	// Create index for suite_results
 	_, err = s.db.Exec(`
 		CREATE INDEX IF NOT EXISTS suite_results_suite_id_idx ON suite_results (suite_id);
@@ -136,10 +299,34 @@ func (s *Store) createSQLiteSchema() error {
 	if err != nil {
 		return err
 	}
+	_, err = s.db.Exec(`
+		CREATE INDEX IF NOT EXISTS incident_updates_incident_id_idx ON incident_updates (incident_id);
+	`)
+	if err != nil {
+		return err
+	}
 	// Silent table modifications TODO: Remove this in v6.0.0
 	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD domain_expiration INTEGER NOT NULL DEFAULT 0`)
 	// Add suite_result_id to endpoint_results table for suite endpoint linkage
 	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD suite_result_id INTEGER REFERENCES suite_results(suite_result_id) ON DELETE CASCADE`)
+	// Add connection_duration and query_duration to endpoint_results table for endpoint types that measure them separately
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD connection_duration INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD query_duration INTEGER NOT NULL DEFAULT 0`)
+	// Add the durations of the phases of HTTP requests to endpoint_results table
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD dns_lookup_duration INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD tcp_connect_duration INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD tls_handshake_duration INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD time_to_first_byte INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD transfer_duration INTEGER NOT NULL DEFAULT 0`)
+	// Add suppressed_by to endpoint_results table to mark results taken while a dependency was unhealthy
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD suppressed_by TEXT NOT NULL DEFAULT ''`)
+	// Add triggered_at to endpoint_alerts_triggered table to track the progress of alerts through their escalation policy
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_alerts_triggered ADD triggered_at TIMESTAMP`)
+	// Add executions_within_latency_threshold to endpoint_uptimes table to track the latency objective of SLOs
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_uptimes ADD executions_within_latency_threshold INTEGER NOT NULL DEFAULT 0`)
+	// Add response_time_histogram and max_response_time to endpoint_uptimes table to support response time percentiles
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_uptimes ADD response_time_histogram TEXT NOT NULL DEFAULT ''`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_uptimes ADD max_response_time INTEGER NOT NULL DEFAULT 0`)
 	// Create index for suite_result_id
 	_, _ = s.db.Exec(`CREATE INDEX IF NOT EXISTS endpoint_results_suite_result_id_idx ON endpoint_results(suite_result_id)`)
 	// Note: SQLite doesn't support DROP COLUMN in older versions, so we skip this cleanup
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/key"
 	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gocache/v2"
@@ -33,10 +35,9 @@ const (
 	eventsAboveMaximumCleanUpThreshold  = 10 // Maximum number of events above the configured maximum before triggering a cleanup
 	resultsAboveMaximumCleanUpThreshold = 10 // Maximum number of results above the configured maximum before triggering a cleanup
 
-	uptimeTotalEntriesMergeThreshold = 100                 // Maximum number of uptime entries before triggering a merge
-	uptimeAgeCleanUpThreshold        = 32 * 24 * time.Hour // Maximum uptime age before triggering a cleanup
-	uptimeRetention                  = 30 * 24 * time.Hour // Minimum duration that must be kept to operate as intended
-	uptimeHourlyBuffer               = 48 * time.Hour      // Number of hours to buffer from now when determining which hourly uptime entries can be merged into daily uptime entries
+	uptimeTotalEntriesMergeThreshold = 100                 // Maximum number of uptime entries before triggering a merge, with the default uptime retention
+	uptimeAgeCleanUpThreshold        = 32 * 24 * time.Hour // Maximum uptime age before triggering a cleanup, with the default uptime retention
+	uptimeRetention                  = 30 * 24 * time.Hour // Minimum duration that must be kept to operate as intended, with the default uptime retention
 
 	cacheTTL = 10 * time.Minute
 )
@@ -63,6 +64,8 @@ type Store struct {
 
 	maximumNumberOfResults int // maximum number of results that an endpoint can have
 	maximumNumberOfEvents  int // maximum number of events that an endpoint can have
+
+	uptimeRetention *storage.UptimeRetentionConfig // how long uptime entries are kept, and at which granularity
 }
 
 // NewStore initializes the database and creates the schema if it doesn't already exist in the path specified
@@ -78,6 +81,7 @@ func NewStore(driver, path string, caching bool, maximumNumberOfResults, maximum
 		path:                   path,
 		maximumNumberOfResults: maximumNumberOfResults,
 		maximumNumberOfEvents:  maximumNumberOfEvents,
+		uptimeRetention:        storage.GetDefaultUptimeRetentionConfig(),
 	}
 	var err error
 	if store.db, err = sql.Open(driver, path); err != nil {
@@ -104,6 +108,16 @@ func NewStore(driver, path string, caching bool, maximumNumberOfResults, maximum
 	return store, nil
 }
 
+// WithUptimeRetention sets how long uptime entries are kept, and at which granularity.
+// If nil, the default uptime retention is used.
+func (s *Store) WithUptimeRetention(uptimeRetention *storage.UptimeRetentionConfig) *Store {
+	if uptimeRetention == nil {
+		uptimeRetention = storage.GetDefaultUptimeRetentionConfig()
+	}
+	s.uptimeRetention = uptimeRetention
+	return s
+}
+
 // createSchema creates the schema required to perform all database operations.
 func (s *Store) createSchema() error {
 	if s.driver == "sqlite" {
@@ -235,6 +249,58 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 	return hourlyAverageResponseTimes, nil
 }
 
+// GetUptimeStatisticsByKey returns the sum of the uptime statistics during a time range
+func (s *Store) GetUptimeStatisticsByKey(key string, from, to time.Time) (*endpoint.HourlyUptimeStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	statistics, err := s.getEndpointUptimeStatistics(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return statistics, nil
+}
+
+// GetHourlyUptimeStatisticsByKey returns a map of hourly (key) uptime statistics (value) during a time range
+func (s *Store) GetHourlyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*endpoint.HourlyUptimeStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	hourlyStatistics, err := s.getEndpointHourlyUptimeStatistics(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return hourlyStatistics, nil
+}
+
 // InsertEndpointResult adds the observed result for the specified endpoint into the store
 func (s *Store) InsertEndpointResult(ep *endpoint.Endpoint, result *endpoint.Result) error {
 	tx, err := s.db.Begin()
@@ -332,16 +398,22 @@ func (s *Store) InsertEndpointResult(ep *endpoint.Endpoint, result *endpoint.Res
 	}
 	// Finally, we need to insert the uptime data.
 	// Because the uptime data significantly outlives the results, we can't rely on the results for determining the uptime
-	if err = s.updateEndpointUptime(tx, endpointID, result); err != nil {
+	if err = s.updateEndpointUptime(tx, endpointID, result, ep.SLO.LatencyThreshold()); err != nil {
 		logr.Errorf("[sql.InsertEndpointResult] Failed to update uptime for endpoint with key=%s: %s", ep.Key(), err.Error())
 	}
 	// Merge hourly uptime entries that can be merged into daily entries and clean up old uptime entries
-	numberOfUptimeEntries, err := s.getNumberOfUptimeEntriesByEndpointID(tx, endpointID)
+	var numberOfUptimeEntries int64
+	if s.uptimeRetention.Monthly {
+		// Monthly uptime entries are kept forever, so they must not count towards the merge threshold
+		numberOfUptimeEntries, err = s.getNumberOfUptimeEntriesNewerThanByEndpointID(tx, endpointID, time.Now().Add(-s.uptimeRetention.DailyRetention()))
+	} else {
+		numberOfUptimeEntries, err = s.getNumberOfUptimeEntriesByEndpointID(tx, endpointID)
+	}
 	if err != nil {
 		logr.Errorf("[sql.InsertEndpointResult] Failed to retrieve total number of uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
 	} else {
-		// Merge older hourly uptime entries into daily uptime entries if we have more than uptimeTotalEntriesMergeThreshold
-		if numberOfUptimeEntries >= uptimeTotalEntriesMergeThreshold {
+		// Merge older hourly uptime entries into daily uptime entries if we have more than the merge threshold
+		if numberOfUptimeEntries >= s.getUptimeEntriesMergeThreshold() {
 			logr.Infof("[sql.InsertEndpointResult] Merging hourly uptime entries for endpoint with key=%s; This is a lot of work, it shouldn't happen too often", ep.Key())
 			if err = s.mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEntries(tx, endpointID); err != nil {
 				logr.Errorf("[sql.InsertEndpointResult] Failed to merge hourly uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
@@ -350,14 +422,19 @@ func (s *Store) InsertEndpointResult(ep *endpoint.Endpoint, result *endpoint.Res
 	}
 	// Clean up outdated uptime entries
 	// In most cases, this would be handled by mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEntries,
-	// but if Gatus was temporarily shut down, we might have some old entries that need to be cleaned up
-	ageOfOldestUptimeEntry, err := s.getAgeOfOldestEndpointUptimeEntry(tx, endpointID)
-	if err != nil {
-		logr.Errorf("[sql.InsertEndpointResult] Failed to retrieve oldest endpoint uptime entry for endpoint with key=%s: %s", ep.Key(), err.Error())
-	} else {
-		if ageOfOldestUptimeEntry > uptimeAgeCleanUpThreshold {
-			if err = s.deleteOldUptimeEntries(tx, endpointID, time.Now().Add(-(uptimeRetention + time.Hour))); err != nil {
-				logr.Errorf("[sql.InsertEndpointResult] Failed to delete old uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
+	// but if Gatus was temporarily shut down, we might have some old entries that need to be cleaned up.
+	// If monthly uptime entries are kept, there's no such thing as an outdated uptime entry.
+	if !s.uptimeRetention.Monthly {
+		ageOfOldestUptimeEntry, err := s.getAgeOfOldestEndpointUptimeEntry(tx, endpointID)
+		if err != nil {
+			logr.Errorf("[sql.InsertEndpointResult] Failed to retrieve oldest endpoint uptime entry for endpoint with key=%s: %s", ep.Key(), err.Error())
+		} else {
+			// The cleanup is triggered with the same margin as with the default uptime retention
+			dailyRetention := s.uptimeRetention.DailyRetention()
+			if ageOfOldestUptimeEntry > dailyRetention+(uptimeAgeCleanUpThreshold-uptimeRetention) {
+				if err = s.deleteOldUptimeEntries(tx, endpointID, time.Now().Add(-(dailyRetention + time.Hour))); err != nil {
+					logr.Errorf("[sql.InsertEndpointResult] Failed to delete old uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
+				}
 			}
 		}
 	}
@@ -481,28 +558,71 @@ func (s *Store) UpsertTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAle
 	}
 	_, err = tx.Exec(
 		`
-			INSERT INTO endpoint_alerts_triggered (endpoint_id, configuration_checksum, resolve_key, number_of_successes_in_a_row) 
-			VALUES ($1, $2, $3, $4)
+			INSERT INTO endpoint_alerts_triggered (endpoint_id, configuration_checksum, resolve_key, number_of_successes_in_a_row, triggered_at) 
+			VALUES ($1, $2, $3, $4, $5)
 			ON CONFLICT(endpoint_id, configuration_checksum) DO UPDATE SET
 				resolve_key = $3,
-				number_of_successes_in_a_row = $4
+				number_of_successes_in_a_row = $4,
+				triggered_at = $5
 		`,
 		endpointID,
 		triggeredAlert.Checksum(),
 		triggeredAlert.ResolveKey,
 		ep.NumberOfSuccessesInARow, // We only persist NumberOfSuccessesInARow, because all alerts in this table are already triggered
+		sql.NullTime{Time: triggeredAlert.TriggeredAt.UTC(), Valid: !triggeredAlert.TriggeredAt.IsZero()},
 	)
 	if err != nil {
 		_ = tx.Rollback()
 		logr.Errorf("[sql.UpsertTriggeredEndpointAlert] Failed to persist triggered alert for endpoint with key=%s: %s", ep.Key(), err.Error())
 		return err
 	}
+	if len(triggeredAlert.EscalationPolicy) > 0 {
+		if err = s.replaceTriggeredEndpointAlertEscalationSteps(tx, endpointID, triggeredAlert); err != nil {
+			_ = tx.Rollback()
+			logr.Errorf("[sql.UpsertTriggeredEndpointAlert] Failed to persist escalation steps of triggered alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+			return err
+		}
+	}
 	if err = tx.Commit(); err != nil {
 		_ = tx.Rollback()
 	}
 	return nil
 }
 
+// GetTriggeredEndpointAlertEscalation returns the time at which the triggered alert for the specified endpoint was
+// triggered as well as the resolve keys of the escalation steps that have been reached, in order
+func (s *Store) GetTriggeredEndpointAlertEscalation(ep *endpoint.Endpoint, alert *alert.Alert) (triggeredAt time.Time, resolveKeys []string, err error) {
+	var triggeredAlertID int64
+	var nullableTriggeredAt sql.NullTime
+	err = s.db.QueryRow(
+		"SELECT endpoint_alert_trigger_id, triggered_at FROM endpoint_alerts_triggered WHERE endpoint_id = (SELECT endpoint_id FROM endpoints WHERE endpoint_key = $1 LIMIT 1) AND configuration_checksum = $2",
+		ep.Key(),
+		alert.Checksum(),
+	).Scan(&triggeredAlertID, &nullableTriggeredAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil, nil
+		}
+		return time.Time{}, nil, err
+	}
+	rows, err := s.db.Query("SELECT resolve_key FROM endpoint_alert_escalation_steps WHERE endpoint_alert_trigger_id = $1 ORDER BY step_index", triggeredAlertID)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var resolveKey string
+		if err = rows.Scan(&resolveKey); err != nil {
+			return time.Time{}, nil, err
+		}
+		resolveKeys = append(resolveKeys, resolveKey)
+	}
+	if nullableTriggeredAt.Valid {
+		triggeredAt = nullableTriggeredAt.Time
+	}
+	return triggeredAt, resolveKeys, rows.Err()
+}
+
 // DeleteTriggeredEndpointAlert deletes a triggered alert for an endpoint
 func (s *Store) DeleteTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
 	//logr.Debugf("[sql.DeleteTriggeredEndpointAlert] Deleting triggered alert with checksum=%s for endpoint with key=%s", triggeredAlert.Checksum(), ep.Key())
@@ -561,9 +681,68 @@ func (s *Store) HasEndpointStatusNewerThan(key string, timestamp time.Time) (boo
 	return count > 0, nil
 }
 
+// AcquireLease acquires the lease with the given name for the given holder if it isn't held by anybody else, or renews
+// it if it's already held by the given holder
+//
+// Returns whether the given holder holds the lease
+func (s *Store) AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	// The expiration of the lease is computed and compared using the clock of the database rather than the clock of
+	// each instance, since instances whose clocks drifted apart could otherwise both believe that they hold the lease
+	now, expiresAt := "(CURRENT_TIMESTAMP AT TIME ZONE 'UTC')", "(CURRENT_TIMESTAMP AT TIME ZONE 'UTC') + CAST($3 AS DOUBLE PRECISION) * INTERVAL '1 second'"
+	if s.driver == "sqlite" {
+		now, expiresAt = "STRFTIME('%Y-%m-%d %H:%M:%f', 'now')", "STRFTIME('%Y-%m-%d %H:%M:%f', 'now', '+' || $3 || ' seconds')"
+	}
+	// The lease is only taken over if it's held by the same holder or if it has expired, and since the upsert is
+	// atomic, only one of the instances trying to acquire an expired lease at the same time can succeed
+	_, err = tx.ExecContext(
+		ctx,
+		`
+			INSERT INTO leases (lease_name, holder, expires_at)
+			VALUES ($1, $2, `+expiresAt+`)
+			ON CONFLICT(lease_name) DO UPDATE SET
+				holder = $2,
+				expires_at = `+expiresAt+`
+			WHERE leases.holder = $2 OR leases.expires_at < `+now,
+		name,
+		holder,
+		duration.Seconds(),
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	var currentHolder string
+	if err = tx.QueryRowContext(ctx, "SELECT holder FROM leases WHERE lease_name = $1", name).Scan(&currentHolder); err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	return currentHolder == holder, nil
+}
+
+// ReleaseLease releases the lease with the given name if it's held by the given holder
+func (s *Store) ReleaseLease(name, holder string) error {
+	_, err := s.db.Exec("DELETE FROM leases WHERE lease_name = $1 AND holder = $2", name, holder)
+	return err
+}
+
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	_, _ = s.db.Exec("DELETE FROM endpoints")
+	_, _ = s.db.Exec("DELETE FROM incidents")
+	_, _ = s.db.Exec("DELETE FROM alert_silences")
+	_, _ = s.db.Exec("DELETE FROM alert_acknowledgements")
+	_, _ = s.db.Exec("DELETE FROM maintenance_windows")
+	_, _ = s.db.Exec("DELETE FROM leases")
+	_, _ = s.db.Exec("DELETE FROM api_tokens")
+	_, _ = s.db.Exec("DELETE FROM sessions")
 	if s.writeThroughCache != nil {
 		_ = s.writeThroughCache.DeleteKeysByPattern("*")
 	}
@@ -623,8 +802,8 @@ func (s *Store) insertEndpointResultWithSuiteID(tx *sql.Tx, endpointID int64, re
 	var endpointResultID int64
 	err := tx.QueryRow(
 		`
-			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp, suite_result_id)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, connection_duration, query_duration, dns_lookup_duration, tcp_connect_duration, tls_handshake_duration, time_to_first_byte, transfer_duration, suppressed_by, timestamp, suite_result_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 			RETURNING endpoint_result_id
 		`,
 		endpointID,
@@ -638,13 +817,24 @@ func (s *Store) insertEndpointResultWithSuiteID(tx *sql.Tx, endpointID int64, re
 		result.Hostname,
 		result.IP,
 		result.Duration,
+		result.ConnectionDuration,
+		result.QueryDuration,
+		result.DNSLookupDuration,
+		result.TCPConnectDuration,
+		result.TLSHandshakeDuration,
+		result.TimeToFirstByte,
+		result.TransferDuration,
+		result.SuppressedBy,
 		result.Timestamp.UTC(),
 		suiteResultID,
 	).Scan(&endpointResultID)
 	if err != nil {
 		return err
 	}
-	return s.insertConditionResults(tx, endpointResultID, result.ConditionResults)
+	if err = s.insertConditionResults(tx, endpointResultID, result.ConditionResults); err != nil {
+		return err
+	}
+	return s.insertAttempts(tx, endpointResultID, result.Attempts)
 }
 
 func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, conditionResults []*endpoint.ConditionResult) error {
@@ -662,26 +852,69 @@ func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, condi
 	return nil
 }
 
-func (s *Store) updateEndpointUptime(tx *sql.Tx, endpointID int64, result *endpoint.Result) error {
+func (s *Store) insertAttempts(tx *sql.Tx, endpointResultID int64, attempts []*endpoint.Attempt) error {
+	var err error
+	for _, attempt := range attempts {
+		_, err = tx.Exec("INSERT INTO endpoint_result_attempts (endpoint_result_id, success, errors, duration, timestamp) VALUES ($1, $2, $3, $4, $5)",
+			endpointResultID,
+			attempt.Success,
+			strings.Join(attempt.Errors, arraySeparator),
+			attempt.Duration,
+			attempt.Timestamp.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateEndpointUptime adds the result to the uptime entry of the hour it was taken in.
+//
+// If latencyThreshold is 0, the endpoint has no latency objective, so executions within the latency threshold are not
+// counted.
+func (s *Store) updateEndpointUptime(tx *sql.Tx, endpointID int64, result *endpoint.Result, latencyThreshold time.Duration) error {
 	unixTimestampFlooredAtHour := result.Timestamp.Truncate(time.Hour).Unix()
-	var successfulExecutions int
+	var successfulExecutions, executionsWithinLatencyThreshold int
 	if result.Success {
 		successfulExecutions = 1
+		if latencyThreshold > 0 && result.Duration <= latencyThreshold {
+			executionsWithinLatencyThreshold = 1
+		}
 	}
-	_, err := tx.Exec(
+	// The response time histogram can't be updated in SQL, so it's retrieved, updated and then replaced
+	var encodedResponseTimeHistogram string
+	responseTimes := &endpoint.HourlyUptimeStatistics{}
+	err := tx.QueryRow(
+		"SELECT response_time_histogram, max_response_time FROM endpoint_uptimes WHERE endpoint_id = $1 AND hour_unix_timestamp = $2",
+		endpointID,
+		unixTimestampFlooredAtHour,
+	).Scan(&encodedResponseTimeHistogram, &responseTimes.MaximumResponseTime)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	responseTimes.ResponseTimeHistogram = decodeResponseTimeHistogram(encodedResponseTimeHistogram)
+	responseTimes.AddResponseTime(result.Duration)
+	_, err = tx.Exec(
 		`
-			INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time) 
-			VALUES ($1, $2, $3, $4, $5)
+			INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time, executions_within_latency_threshold, response_time_histogram, max_response_time) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 			ON CONFLICT(endpoint_id, hour_unix_timestamp) DO UPDATE SET
 				total_executions = excluded.total_executions + endpoint_uptimes.total_executions,
 				successful_executions = excluded.successful_executions + endpoint_uptimes.successful_executions,
-				total_response_time = excluded.total_response_time + endpoint_uptimes.total_response_time
+				total_response_time = excluded.total_response_time + endpoint_uptimes.total_response_time,
+				executions_within_latency_threshold = excluded.executions_within_latency_threshold + endpoint_uptimes.executions_within_latency_threshold,
+				response_time_histogram = excluded.response_time_histogram,
+				max_response_time = excluded.max_response_time
 		`,
 		endpointID,
 		unixTimestampFlooredAtHour,
 		1,
 		successfulExecutions,
 		result.Duration.Milliseconds(),
+		executionsWithinLatencyThreshold,
+		encodeResponseTimeHistogram(responseTimes.ResponseTimeHistogram),
+		responseTimes.MaximumResponseTime,
 	)
 	return err
 }
@@ -791,7 +1024,7 @@ func (s *Store) getEndpointEventsByEndpointID(tx *sql.Tx, endpointID int64, page
 func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, page, pageSize int) (results []*endpoint.Result, err error) {
 	rows, err := tx.Query(
 		`
-			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp
+			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, connection_duration, query_duration, dns_lookup_duration, tcp_connect_duration, tls_handshake_duration, time_to_first_byte, transfer_duration, suppressed_by, timestamp
 			FROM endpoint_results
 			WHERE endpoint_id = $1
 			ORDER BY endpoint_result_id DESC -- Normally, we'd sort by timestamp, but sorting by endpoint_result_id is faster
@@ -809,7 +1042,7 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		result := &endpoint.Result{}
 		var id int64
 		var joinedErrors string
-		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp)
+		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.ConnectionDuration, &result.QueryDuration, &result.DNSLookupDuration, &result.TCPConnectDuration, &result.TLSHandshakeDuration, &result.TimeToFirstByte, &result.TransferDuration, &result.SuppressedBy, &result.Timestamp)
 		if err != nil {
 			logr.Errorf("[sql.getEndpointResultsByEndpointID] Silently failed to retrieve endpoint result for endpointID=%d: %s", endpointID, err.Error())
 			err = nil
@@ -825,6 +1058,15 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		// If there's no result, we'll just return an empty/nil slice
 		return
 	}
+	if err = s.populateConditionResultsAndAttempts(tx, idResultMap); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// populateConditionResultsAndAttempts retrieves the condition results and the attempts of each endpoint result in
+// idResultMap and adds them to the corresponding endpoint result
+func (s *Store) populateConditionResultsAndAttempts(tx *sql.Tx, idResultMap map[int64]*endpoint.Result) error {
 	// Get condition results
 	args := make([]interface{}, 0, len(idResultMap))
 	query := `SELECT endpoint_result_id, condition, success
@@ -837,20 +1079,45 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		index++
 	}
 	query = query[:len(query)-1] + ")"
-	rows, err = tx.Query(query, args...)
+	rows, err := tx.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close() // explicitly defer the close in case an error happens during the scan
 	for rows.Next() {
 		conditionResult := &endpoint.ConditionResult{}
 		var endpointResultID int64
 		if err = rows.Scan(&endpointResultID, &conditionResult.Condition, &conditionResult.Success); err != nil {
-			return
+			return err
 		}
 		idResultMap[endpointResultID].ConditionResults = append(idResultMap[endpointResultID].ConditionResults, conditionResult)
 	}
-	return
+	// Get attempts, which only exist for endpoints with retries configured
+	query = `SELECT endpoint_result_id, success, errors, duration, timestamp
+				FROM endpoint_result_attempts
+				WHERE endpoint_result_id IN (`
+	for index = 1; index <= len(args); index++ {
+		query += "$" + strconv.Itoa(index) + ","
+	}
+	query = query[:len(query)-1] + ") ORDER BY endpoint_result_attempt_id"
+	rows, err = tx.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		attempt := &endpoint.Attempt{}
+		var endpointResultID int64
+		var joinedErrors string
+		if err = rows.Scan(&endpointResultID, &attempt.Success, &joinedErrors, &attempt.Duration, &attempt.Timestamp); err != nil {
+			return err
+		}
+		if len(joinedErrors) != 0 {
+			attempt.Errors = strings.Split(joinedErrors, arraySeparator)
+		}
+		idResultMap[endpointResultID].Attempts = append(idResultMap[endpointResultID].Attempts, attempt)
+	}
+	return nil
 }
 
 func (s *Store) getEndpointUptime(tx *sql.Tx, endpointID int64, from, to time.Time) (uptime float64, avgResponseTime time.Duration, err error) {
@@ -880,6 +1147,53 @@ func (s *Store) getEndpointUptime(tx *sql.Tx, endpointID int64, from, to time.Ti
 	return
 }
 
+// getEndpointUptimeStatistics returns the sum of the uptime statistics within the given time range.
+//
+// Like the memory store, hourly statistics are included if the hour they cover overlaps with the time range, so that
+// time ranges shorter than an hour include the statistics of the current hour.
+func (s *Store) getEndpointUptimeStatistics(tx *sql.Tx, endpointID int64, from, to time.Time) (*endpoint.HourlyUptimeStatistics, error) {
+	hourlyStatistics, err := s.getEndpointHourlyUptimeStatistics(tx, endpointID, from.Truncate(time.Hour), to)
+	if err != nil {
+		return nil, err
+	}
+	totalStatistics := &endpoint.HourlyUptimeStatistics{}
+	for _, statistics := range hourlyStatistics {
+		totalStatistics.Add(statistics)
+	}
+	return totalStatistics, nil
+}
+
+func (s *Store) getEndpointHourlyUptimeStatistics(tx *sql.Tx, endpointID int64, from, to time.Time) (map[int64]*endpoint.HourlyUptimeStatistics, error) {
+	rows, err := tx.Query(
+		`
+			SELECT hour_unix_timestamp, total_executions, successful_executions, total_response_time, executions_within_latency_threshold, response_time_histogram, max_response_time
+			FROM endpoint_uptimes
+			WHERE endpoint_id = $1
+				AND hour_unix_timestamp >= $2
+				AND hour_unix_timestamp <= $3
+		`,
+		endpointID,
+		from.Unix(),
+		to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	hourlyStatistics := make(map[int64]*endpoint.HourlyUptimeStatistics)
+	for rows.Next() {
+		var unixTimestamp int64
+		var encodedResponseTimeHistogram string
+		statistics := &endpoint.HourlyUptimeStatistics{}
+		if err = rows.Scan(&unixTimestamp, &statistics.TotalExecutions, &statistics.SuccessfulExecutions, &statistics.TotalExecutionsResponseTime, &statistics.ExecutionsWithinLatencyThreshold, &encodedResponseTimeHistogram, &statistics.MaximumResponseTime); err != nil {
+			return nil, err
+		}
+		statistics.ResponseTimeHistogram = decodeResponseTimeHistogram(encodedResponseTimeHistogram)
+		hourlyStatistics[unixTimestamp] = statistics
+	}
+	return hourlyStatistics, rows.Err()
+}
+
 func (s *Store) getEndpointAverageResponseTime(tx *sql.Tx, endpointID int64, from, to time.Time) (int, error) {
 	rows, err := tx.Query(
 		`
@@ -934,6 +1248,35 @@ func (s *Store) getEndpointHourlyAverageResponseTimes(tx *sql.Tx, endpointID int
 	return hourlyAverageResponseTimes, nil
 }
 
+// replaceTriggeredEndpointAlertEscalationSteps replaces the persisted escalation steps of a triggered alert by the
+// escalation steps the alert has reached
+func (s *Store) replaceTriggeredEndpointAlertEscalationSteps(tx *sql.Tx, endpointID int64, triggeredAlert *alert.Alert) error {
+	var triggeredAlertID int64
+	err := tx.QueryRow(
+		"SELECT endpoint_alert_trigger_id FROM endpoint_alerts_triggered WHERE endpoint_id = $1 AND configuration_checksum = $2",
+		endpointID,
+		triggeredAlert.Checksum(),
+	).Scan(&triggeredAlertID)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM endpoint_alert_escalation_steps WHERE endpoint_alert_trigger_id = $1", triggeredAlertID); err != nil {
+		return err
+	}
+	for stepIndex, resolveKey := range triggeredAlert.EscalationResolveKeys {
+		_, err = tx.Exec(
+			"INSERT INTO endpoint_alert_escalation_steps (endpoint_alert_trigger_id, step_index, resolve_key) VALUES ($1, $2, $3)",
+			triggeredAlertID,
+			stepIndex,
+			resolveKey,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Store) getEndpointID(tx *sql.Tx, ep *endpoint.Endpoint) (int64, error) {
 	var id int64
 	err := tx.QueryRow("SELECT endpoint_id FROM endpoints WHERE endpoint_key = $1", ep.Key()).Scan(&id)
@@ -964,6 +1307,22 @@ func (s *Store) getNumberOfUptimeEntriesByEndpointID(tx *sql.Tx, endpointID int6
 	return numberOfUptimeEntries, err
 }
 
+func (s *Store) getNumberOfUptimeEntriesNewerThanByEndpointID(tx *sql.Tx, endpointID int64, since time.Time) (int64, error) {
+	var numberOfUptimeEntries int64
+	err := tx.QueryRow("SELECT COUNT(1) FROM endpoint_uptimes WHERE endpoint_id = $1 AND hour_unix_timestamp >= $2", endpointID, since.Unix()).Scan(&numberOfUptimeEntries)
+	return numberOfUptimeEntries, err
+}
+
+// getUptimeEntriesMergeThreshold returns the number of uptime entries that an endpoint can have before triggering a
+// merge. The threshold grows with the uptime retention, so that merges don't happen more often than they would with
+// the default uptime retention.
+func (s *Store) getUptimeEntriesMergeThreshold() int64 {
+	defaultUptimeRetention := storage.GetDefaultUptimeRetentionConfig()
+	numberOfExtraHourlyEntries := (s.uptimeRetention.HourlyDays - defaultUptimeRetention.HourlyDays) * 24
+	numberOfExtraDailyEntries := s.uptimeRetention.DailyDays - defaultUptimeRetention.DailyDays
+	return uptimeTotalEntriesMergeThreshold + int64(numberOfExtraHourlyEntries+numberOfExtraDailyEntries)
+}
+
 func (s *Store) getAgeOfOldestEndpointUptimeEntry(tx *sql.Tx, endpointID int64) (time.Duration, error) {
 	rows, err := tx.Query(
 		`
@@ -1048,12 +1407,14 @@ func (s *Store) deleteOldUptimeEntries(tx *sql.Tx, endpointID int64, maxAge time
 }
 
 // mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEntries merges all hourly uptime entries older than
-// uptimeHourlyMergeThreshold from now into daily uptime entries by summing all hourly entries of the same day into a
-// single entry.
+// the hourly uptime retention from now into daily uptime entries by summing all hourly entries of the same day into a
+// single entry. If monthly uptime entries are kept, entries older than the daily uptime retention are also merged into
+// monthly uptime entries, which are timestamped at the first day of the month.
 //
-// This effectively limits the number of uptime entries to (48+(n-2)) where 48 is for the first 48 entries with hourly
-// entries (defined by uptimeHourlyBuffer) and n is the number of days for all entries older than 48 hours.
-// Supporting 30d of entries would then result in far less than 24*30=720 entries.
+// With the default uptime retention, this effectively limits the number of uptime entries to (48+(n-2)) where 48 is
+// for the first 48 entries with hourly entries (defined by storage.DefaultUptimeHourlyRetentionDays) and n is the
+// number of days for all entries older than 48 hours. Supporting 30d of entries would then result in far less than
+// 24*30=720 entries.
 func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEntries(tx *sql.Tx, endpointID int64) error {
 	// Calculate timestamp of the first full day of uptime entries that would not impact the uptime calculation for 24h badges
 	// The logic is that once at least 48 hours passed, we:
@@ -1062,13 +1423,18 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 	// which implies that no matter at what hour of the day we are, any timestamp + 48h floored to the current day
 	// will never impact the 24h uptime badge calculation
 	now := time.Now()
-	minThreshold := now.Add(-uptimeHourlyBuffer)
+	minThreshold := now.Add(-s.uptimeRetention.HourlyRetention())
 	minThreshold = time.Date(minThreshold.Year(), minThreshold.Month(), minThreshold.Day(), 0, 0, 0, 0, minThreshold.Location())
-	maxThreshold := now.Add(-uptimeRetention)
-	// Get all uptime entries older than uptimeHourlyMergeThreshold
+	maxThreshold := now.Add(-s.uptimeRetention.DailyRetention())
+	// Entries older than maxThreshold are merged into monthly entries if those are kept, and deleted otherwise
+	oldestEntryToMerge := maxThreshold
+	if s.uptimeRetention.Monthly {
+		oldestEntryToMerge = time.Unix(0, 0)
+	}
+	// Get all uptime entries older than the hourly uptime retention
 	rows, err := tx.Query(
 		`
-			SELECT hour_unix_timestamp, total_executions, successful_executions, total_response_time
+			SELECT hour_unix_timestamp, total_executions, successful_executions, total_response_time, executions_within_latency_threshold, response_time_histogram, max_response_time
 			FROM endpoint_uptimes
 			WHERE endpoint_id = $1
 				AND hour_unix_timestamp < $2
@@ -1076,31 +1442,32 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 		`,
 		endpointID,
 		minThreshold.Unix(),
-		maxThreshold.Unix(),
+		oldestEntryToMerge.Unix(),
 	)
 	if err != nil {
 		return err
 	}
-	type Entry struct {
-		totalExecutions      int
-		successfulExecutions int
-		totalResponseTime    int
-	}
-	dailyEntries := make(map[int64]*Entry)
+	dailyEntries := make(map[int64]*endpoint.HourlyUptimeStatistics)
 	for rows.Next() {
 		var unixTimestamp int64
-		entry := Entry{}
-		if err = rows.Scan(&unixTimestamp, &entry.totalExecutions, &entry.successfulExecutions, &entry.totalResponseTime); err != nil {
+		var encodedResponseTimeHistogram string
+		entry := endpoint.HourlyUptimeStatistics{}
+		if err = rows.Scan(&unixTimestamp, &entry.TotalExecutions, &entry.SuccessfulExecutions, &entry.TotalExecutionsResponseTime, &entry.ExecutionsWithinLatencyThreshold, &encodedResponseTimeHistogram, &entry.MaximumResponseTime); err != nil {
 			return err
 		}
+		entry.ResponseTimeHistogram = decodeResponseTimeHistogram(encodedResponseTimeHistogram)
 		timestamp := time.Unix(unixTimestamp, 0)
-		unixTimestampFlooredAtDay := time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), 0, 0, 0, 0, timestamp.Location()).Unix()
+		var unixTimestampFlooredAtDay int64
+		if timestamp.Before(maxThreshold) {
+			// Only possible if monthly uptime entries are kept, in which case the entry is merged into a monthly entry
+			unixTimestampFlooredAtDay = time.Date(timestamp.Year(), timestamp.Month(), 1, 0, 0, 0, 0, timestamp.Location()).Unix()
+		} else {
+			unixTimestampFlooredAtDay = time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), 0, 0, 0, 0, timestamp.Location()).Unix()
+		}
 		if dailyEntry := dailyEntries[unixTimestampFlooredAtDay]; dailyEntry == nil {
 			dailyEntries[unixTimestampFlooredAtDay] = &entry
 		} else {
-			dailyEntries[unixTimestampFlooredAtDay].totalExecutions += entry.totalExecutions
-			dailyEntries[unixTimestampFlooredAtDay].successfulExecutions += entry.successfulExecutions
-			dailyEntries[unixTimestampFlooredAtDay].totalResponseTime += entry.totalResponseTime
+			dailyEntry.Add(&entry)
 		}
 	}
 	// Delete older hourly uptime entries
@@ -1112,18 +1479,24 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 	for unixTimestamp, entry := range dailyEntries {
 		_, err = tx.Exec(
 			`
-					INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time)
-					VALUES ($1, $2, $3, $4, $5)
+					INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time, executions_within_latency_threshold, response_time_histogram, max_response_time)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 					ON CONFLICT(endpoint_id, hour_unix_timestamp) DO UPDATE SET
 						total_executions = $3,
 						successful_executions = $4,
-						total_response_time = $5
+						total_response_time = $5,
+						executions_within_latency_threshold = $6,
+						response_time_histogram = $7,
+						max_response_time = $8
 				`,
 			endpointID,
 			unixTimestamp,
-			entry.totalExecutions,
-			entry.successfulExecutions,
-			entry.totalResponseTime,
+			entry.TotalExecutions,
+			entry.SuccessfulExecutions,
+			entry.TotalExecutionsResponseTime,
+			entry.ExecutionsWithinLatencyThreshold,
+			encodeResponseTimeHistogram(entry.ResponseTimeHistogram),
+			entry.MaximumResponseTime,
 		)
 		if err != nil {
 			return err
@@ -1133,6 +1506,35 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 	return nil
 }
 
+// encodeResponseTimeHistogram encodes a response time histogram as a comma-separated list of the number of executions
+// of each bucket, omitting trailing empty buckets
+func encodeResponseTimeHistogram(histogram []uint64) string {
+	var builder strings.Builder
+	for len(histogram) > 0 && histogram[len(histogram)-1] == 0 {
+		histogram = histogram[:len(histogram)-1]
+	}
+	for bucket, executions := range histogram {
+		if bucket > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(strconv.FormatUint(executions, 10))
+	}
+	return builder.String()
+}
+
+// decodeResponseTimeHistogram decodes a response time histogram encoded by encodeResponseTimeHistogram
+func decodeResponseTimeHistogram(encodedHistogram string) []uint64 {
+	if len(encodedHistogram) == 0 {
+		return nil
+	}
+	buckets := strings.Split(encodedHistogram, ",")
+	histogram := make([]uint64, len(buckets))
+	for bucket, executions := range buckets {
+		histogram[bucket], _ = strconv.ParseUint(executions, 10, 64)
+	}
+	return histogram
+}
+
 func generateCacheKey(endpointKey string, p *paging.EndpointStatusParams) string {
 	return fmt.Sprintf("%s-%d-%d-%d-%d", endpointKey, p.EventsPage, p.EventsPageSize, p.ResultsPage, p.ResultsPageSize)
 }
@@ -5,8 +5,12 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/silence"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/incident"
+	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/gatus/v5/security/session"
 	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gatus/v5/storage/store/memory"
@@ -14,6 +18,12 @@ import (
 	"github.com/TwiN/logr"
 )
 
+const (
+	// memoryStoreSnapshotInterval is the interval at which a snapshot of the memory store is written, if it has a path.
+	// In between snapshots, mutations are persisted through the write-ahead log.
+	memoryStoreSnapshotInterval = 5 * time.Minute
+)
+
 // Store is the interface that each store should implement
 type Store interface {
 	// GetAllEndpointStatuses returns the JSON encoding of all monitored endpoint.Status
@@ -41,6 +51,20 @@ type Store interface {
 	// GetHourlyAverageResponseTimeByKey returns a map of hourly (key) average response time in milliseconds (value) during a time range
 	GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (map[int64]int, error)
 
+	// GetUptimeStatisticsByKey returns the sum of the uptime statistics during a time range
+	GetUptimeStatisticsByKey(key string, from, to time.Time) (*endpoint.HourlyUptimeStatistics, error)
+
+	// GetHourlyUptimeStatisticsByKey returns a map of hourly (key) uptime statistics (value) during a time range
+	GetHourlyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*endpoint.HourlyUptimeStatistics, error)
+
+	// GetEndpointResultsByKey returns a page of the results of an endpoint, from the oldest to the most recent, as well
+	// as the cursor to pass to retrieve the next page, which is empty if there are no more results
+	GetEndpointResultsByKey(key string, params *paging.EndpointHistoryParams) (results []*endpoint.Result, nextCursor string, err error)
+
+	// GetEndpointEventsByKey returns a page of the events of an endpoint, from the oldest to the most recent, as well
+	// as the cursor to pass to retrieve the next page, which is empty if there are no more events
+	GetEndpointEventsByKey(key string, params *paging.EndpointHistoryParams) (events []*endpoint.Event, nextCursor string, err error)
+
 	// InsertEndpointResult adds the observed result for the specified endpoint into the store
 	InsertEndpointResult(ep *endpoint.Endpoint, result *endpoint.Result) error
 
@@ -62,6 +86,10 @@ type Store interface {
 	// Used for persistence of triggered alerts across application restarts
 	UpsertTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error
 
+	// GetTriggeredEndpointAlertEscalation returns the time at which the triggered alert for the specified endpoint was
+	// triggered as well as the resolve keys of the escalation steps that have been reached, in order
+	GetTriggeredEndpointAlertEscalation(ep *endpoint.Endpoint, alert *alert.Alert) (triggeredAt time.Time, resolveKeys []string, err error)
+
 	// DeleteTriggeredEndpointAlert deletes a triggered alert for an endpoint
 	DeleteTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error
 
@@ -73,6 +101,85 @@ type Store interface {
 	// HasEndpointStatusNewerThan checks whether an endpoint has a status newer than the provided timestamp
 	HasEndpointStatusNewerThan(key string, timestamp time.Time) (bool, error)
 
+	// GetAllIncidents returns all incidents, sorted from the most recent to the oldest
+	GetAllIncidents() ([]*incident.Incident, error)
+
+	// GetIncidentByID returns the incident with the given ID, or common.ErrIncidentNotFound if it doesn't exist
+	GetIncidentByID(id int64) (*incident.Incident, error)
+
+	// InsertIncident inserts a new incident in the store and sets its ID
+	InsertIncident(inc *incident.Incident) error
+
+	// UpdateIncident replaces an existing incident, including its timeline of updates
+	UpdateIncident(inc *incident.Incident) error
+
+	// GetAllSilences returns all silences that haven't expired yet, sorted from the most recent to the oldest
+	GetAllSilences() ([]*silence.Silence, error)
+
+	// InsertSilence inserts a new silence in the store and sets its ID
+	InsertSilence(s *silence.Silence) error
+
+	// DeleteSilence deletes the silence with the given ID, or returns common.ErrSilenceNotFound if it doesn't exist
+	DeleteSilence(id int64) error
+
+	// GetAllAlertAcknowledgements returns all acknowledgements of triggered alerts
+	GetAllAlertAcknowledgements() ([]*silence.Acknowledgement, error)
+
+	// UpsertAlertAcknowledgement inserts/updates the acknowledgement of a triggered alert
+	UpsertAlertAcknowledgement(ack *silence.Acknowledgement) error
+
+	// DeleteAlertAcknowledgement deletes the acknowledgement of an alert, or returns common.ErrAcknowledgementNotFound
+	// if the alert hasn't been acknowledged
+	DeleteAlertAcknowledgement(endpointKey, alertChecksum string) error
+
+	// GetAllMaintenanceWindows returns all maintenance windows that haven't expired yet, sorted from the most recent
+	// to the oldest
+	GetAllMaintenanceWindows() ([]*maintenance.Window, error)
+
+	// InsertMaintenanceWindow inserts a new maintenance window in the store and sets its ID
+	InsertMaintenanceWindow(w *maintenance.Window) error
+
+	// DeleteMaintenanceWindow deletes the maintenance window with the given ID, or returns
+	// common.ErrMaintenanceWindowNotFound if it doesn't exist
+	DeleteMaintenanceWindow(id int64) error
+
+	// AcquireLease acquires the lease with the given name for the given holder until the given duration has elapsed if
+	// it isn't held by anybody else, or renews it if it's already held by the given holder.
+	// Used to elect a leader amongst multiple instances sharing the same store.
+	//
+	// Returns whether the given holder holds the lease, or an error if the lease couldn't be acquired before the given
+	// context was canceled
+	AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error)
+
+	// ReleaseLease releases the lease with the given name if it's held by the given holder
+	ReleaseLease(name, holder string) error
+
+	// GetLatestLocationResults returns the latest result reported from each location for the endpoint with the given
+	// key, keyed by the name of the location
+	GetLatestLocationResults(key string) (map[string]*endpoint.Result, error)
+
+	// UpsertLatestLocationResult replaces the latest result reported from the given location for the endpoint
+	UpsertLatestLocationResult(ep *endpoint.Endpoint, location string, result *endpoint.Result) error
+
+	// GetAllAPITokenLastUsedTimes returns the last time each API token was used, keyed by the name of the token
+	GetAllAPITokenLastUsedTimes() (map[string]time.Time, error)
+
+	// UpdateAPITokenLastUsedTime records the last time the API token with the given name was used
+	UpdateAPITokenLastUsedTime(name string, lastUsedAt time.Time) error
+
+	// GetAllSessions returns all sessions that haven't expired yet, sorted from the most recent to the oldest
+	GetAllSessions() ([]*session.Session, error)
+
+	// GetSessionByID returns the session with the given ID, or returns common.ErrSessionNotFound if it doesn't exist
+	// or if it has expired
+	GetSessionByID(id string) (*session.Session, error)
+
+	// UpsertSession inserts/updates a session
+	UpsertSession(s *session.Session) error
+
+	// DeleteSession deletes the session with the given ID, or returns common.ErrSessionNotFound if it doesn't exist
+	DeleteSession(id string) error
+
 	// Clear deletes everything from the store
 	Clear()
 
@@ -119,7 +226,6 @@ func Get() Store {
 // Initialize instantiates the storage provider based on the Config provider
 func Initialize(cfg *storage.Config) error {
 	initialized = true
-	var err error
 	if cancelFunc != nil {
 		// Stop the active autoSave task, if there's already one
 		cancelFunc()
@@ -138,14 +244,22 @@ func Initialize(cfg *storage.Config) error {
 	ctx, cancelFunc = context.WithCancel(context.Background())
 	switch cfg.Type {
 	case storage.TypeSQLite, storage.TypePostgres:
-		store, err = sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching, cfg.MaximumNumberOfResults, cfg.MaximumNumberOfEvents)
+		sqlStore, err := sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching, cfg.MaximumNumberOfResults, cfg.MaximumNumberOfEvents)
 		if err != nil {
 			return err
 		}
+		store = sqlStore.WithUptimeRetention(cfg.UptimeRetention)
 	case storage.TypeMemory:
 		fallthrough
 	default:
-		store, _ = memory.NewStore(cfg.MaximumNumberOfResults, cfg.MaximumNumberOfEvents)
+		memoryStore, err := memory.NewStore(cfg.Path, cfg.MaximumNumberOfResults, cfg.MaximumNumberOfEvents)
+		if err != nil {
+			return err
+		}
+		store = memoryStore.WithUptimeRetention(cfg.UptimeRetention)
+		if len(cfg.Path) > 0 {
+			go autoSave(ctx, store, memoryStoreSnapshotInterval)
+		}
 	}
 	return nil
 }
@@ -91,7 +91,7 @@ type Scenario struct {
 }
 
 func initStoresAndBaseScenarios(t *testing.T, testName string) []*Scenario {
-	memoryStore, err := memory.NewStore(storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
+	memoryStore, err := memory.NewStore("", storage.DefaultMaximumNumberOfResults, storage.DefaultMaximumNumberOfEvents)
 	if err != nil {
 		t.Fatal("failed to create store:", err.Error())
 	}
@@ -600,6 +600,11 @@ func TestInitialize(t *testing.T) {
 			Cfg:         &storage.Config{Type: storage.TypeMemory},
 			ExpectedErr: nil,
 		},
+		{
+			Name:        "memory-with-path",
+			Cfg:         &storage.Config{Type: storage.TypeMemory, Path: filepath.Join(dir, "TestInitialize_memory-with-path.gob")},
+			ExpectedErr: nil,
+		},
 		{
 			Name:        "sqlite-no-path",
 			Cfg:         &storage.Config{Type: storage.TypeSQLite},
@@ -0,0 +1,93 @@
+package watchdog
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/logr"
+)
+
+// assignedEndpoints are the endpoints assigned to the location of this instance by the central instance, if this
+// instance is an agent. Protected by monitorsMutex.
+var assignedEndpoints []*endpoint.Endpoint
+
+// synchronizeAssignedEndpoints periodically retrieves the endpoints assigned to the location of the agent from the
+// central instance, and starts or stops monitoring them accordingly until the context is canceled
+func synchronizeAssignedEndpoints(agentConfig *agent.Config, ctx context.Context) {
+	updateAssignedEndpoints(agentConfig)
+	ticker := time.NewTicker(agentConfig.SynchronizationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			updateAssignedEndpoints(agentConfig)
+		}
+	}
+}
+
+// updateAssignedEndpoints retrieves the endpoints assigned to the location of the agent from the central instance, and
+// replaces the assigned endpoints by them.
+//
+// Endpoints whose configuration hasn't changed keep being monitored without interruption.
+func updateAssignedEndpoints(agentConfig *agent.Config) {
+	assignment, err := agentConfig.FetchAssignment()
+	if err != nil {
+		logr.Errorf("[watchdog.updateAssignedEndpoints] Failed to retrieve assigned endpoints from central instance: %s", err.Error())
+		return
+	}
+	updated := &config.Config{Endpoints: assignment.Endpoints}
+	monitorsMutex.Lock()
+	updated.ReuseUnchangedMonitors(&config.Config{Endpoints: assignedEndpoints})
+	numberOfMonitorsStopped := 0
+	for _, ep := range assignedEndpoints {
+		if slices.Contains(updated.Endpoints, ep) {
+			continue
+		}
+		if stopMonitoring, exists := monitors[ep]; exists {
+			stopMonitoring()
+			delete(monitors, ep)
+		}
+		ep.Close()
+		numberOfMonitorsStopped++
+	}
+	assignedEndpoints = updated.Endpoints
+	monitorsMutex.Unlock()
+	numberOfMonitorsStarted := 0
+	for _, ep := range updated.Endpoints {
+		if !isMonitored(ep) {
+			go monitorEndpoint(ep, newMonitorContext(ep))
+			numberOfMonitorsStarted++
+		}
+	}
+	if numberOfMonitorsStopped > 0 || numberOfMonitorsStarted > 0 {
+		logr.Infof("[watchdog.updateAssignedEndpoints] Location=%s has %d assigned endpoint(s); stopped %d monitor(s) and started %d monitor(s)", assignment.Location, len(updated.Endpoints), numberOfMonitorsStopped, numberOfMonitorsStarted)
+	}
+}
+
+// isAssignedEndpoint returns whether the endpoint has been assigned to the location of this instance by the central
+// instance
+func isAssignedEndpoint(ep *endpoint.Endpoint) bool {
+	monitorsMutex.RLock()
+	defer monitorsMutex.RUnlock()
+	return slices.Contains(assignedEndpoints, ep)
+}
+
+// getAssignedEndpoints returns the endpoints assigned to the location of this instance by the central instance
+func getAssignedEndpoints() []*endpoint.Endpoint {
+	monitorsMutex.RLock()
+	defer monitorsMutex.RUnlock()
+	return assignedEndpoints
+}
+
+// pushResultToCentralInstance reports the result of an assigned endpoint to the central instance
+func pushResultToCentralInstance(agentConfig *agent.Config, ep *endpoint.Endpoint, result *endpoint.Result) {
+	if err := agentConfig.PushResult(ep, result); err != nil {
+		logr.Errorf("[watchdog.pushResultToCentralInstance] Failed to push result for endpoint with key=%s to central instance: %s", ep.Key(), err.Error())
+	}
+}
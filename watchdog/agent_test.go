@@ -0,0 +1,89 @@
+package watchdog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestAgent(t *testing.T) {
+	defer store.Get().Clear()
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+	var mutex sync.Mutex
+	var endpointsAssignedByCentral []*endpoint.Endpoint
+	pushedResults := make(map[string]int)
+	central := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if r.Method == http.MethodPost {
+			pushedResults[r.URL.Path]++
+			return
+		}
+		body, _ := agent.MarshalAssignment("eu-west", endpointsAssignedByCentral)
+		_, _ = w.Write(body)
+	}))
+	defer central.Close()
+	website := &endpoint.Endpoint{Name: "website", Group: "core", URL: target.URL, Conditions: []endpoint.Condition{"[STATUS] == 200"}, Locations: []string{"eu-west"}}
+	api := &endpoint.Endpoint{Name: "api", Group: "core", URL: target.URL, Conditions: []endpoint.Condition{"[STATUS] == 200"}, Locations: []string{"eu-west"}}
+	for _, ep := range []*endpoint.Endpoint{website, api} {
+		if err := ep.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	endpointsAssignedByCentral = []*endpoint.Endpoint{website, api}
+	cfg := &config.Config{
+		Agent:       &agent.Config{URL: central.URL, Token: "potato", SynchronizationInterval: time.Hour},
+		Maintenance: maintenance.GetDefaultConfig(),
+	}
+	if err := cfg.Agent.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	Monitor(cfg)
+	defer Shutdown(cfg)
+	waitFor(t, "results of both assigned endpoints to be pushed to the central instance", func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return pushedResults["/api/v1/agents/endpoints/core_website/results"] > 0 && pushedResults["/api/v1/agents/endpoints/core_api/results"] > 0
+	})
+	if len(getAssignedEndpoints()) != 2 {
+		t.Fatalf("expected 2 assigned endpoints, got %d", len(getAssignedEndpoints()))
+	}
+	assignedWebsite := getAssignedEndpoints()[0]
+	// Reloading the configuration must not stop the monitoring of the assigned endpoints
+	Reload(&config.Config{Agent: cfg.Agent, Maintenance: cfg.Maintenance})
+	if !isMonitored(assignedWebsite) {
+		t.Error("expected assigned endpoint to still be monitored after reload")
+	}
+	mutex.Lock()
+	endpointsAssignedByCentral = []*endpoint.Endpoint{website}
+	mutex.Unlock()
+	updateAssignedEndpoints(cfg.Agent)
+	if len(getAssignedEndpoints()) != 1 || getAssignedEndpoints()[0] != assignedWebsite {
+		t.Error("expected the unchanged assigned endpoint to be kept as is")
+	}
+	if len(monitors) != 1 || !isMonitored(assignedWebsite) {
+		t.Errorf("expected only the endpoint that is still assigned to be monitored, got %d monitors", len(monitors))
+	}
+}
+
+// waitFor waits until the condition is met, and fails the test if it isn't met within a few seconds
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if condition() {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", description)
+}
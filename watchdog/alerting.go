@@ -12,16 +12,58 @@ import (
 	"github.com/TwiN/logr"
 )
 
-// HandleAlerting takes care of alerts to resolve and alerts to trigger based on result success or failure
+// HandleAlerting takes care of alerts to resolve and alerts to trigger based on result success or failure, as well as
+// burn-rate alerts based on the rate at which the error budget of the endpoint's SLO is consumed.
+//
+// If high availability is enabled, alerting is only handled by the leader.
+//
+// The alerting state of the endpoint is locked for the duration of the call. See LockEndpoint.
 func HandleAlerting(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config) {
 	if alertingConfig == nil {
 		return
 	}
+	if !IsLeader() {
+		logr.Debugf("[watchdog.HandleAlerting] Not the leader; not handling alerting for endpoint with key=%s", ep.Key())
+		return
+	}
+	defer LockEndpoint(ep.Key())()
+	handleAlerting(ep, result, alertingConfig)
+}
+
+// HandleExternalEndpointAlerting takes care of the alerts of an external endpoint like HandleAlerting does, and updates
+// the number of failures and successes in a row of the external endpoint accordingly.
+//
+// The alerting state of the external endpoint is locked for the duration of the call. See LockEndpoint.
+func HandleExternalEndpointAlerting(ee *endpoint.ExternalEndpoint, result *endpoint.Result, alertingConfig *alerting.Config) {
+	if alertingConfig == nil {
+		return
+	}
+	if !IsLeader() {
+		logr.Debugf("[watchdog.HandleExternalEndpointAlerting] Not the leader; not handling alerting for external endpoint with key=%s", ee.Key())
+		return
+	}
+	defer LockEndpoint(ee.Key())()
+	convertedEndpoint := ee.ToEndpoint()
+	handleAlerting(convertedEndpoint, result, alertingConfig)
+	// Sync the failure/success counters back to the external endpoint
+	ee.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
+	ee.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
+}
+
+// handleAlerting handles the alerts of an endpoint whose alerting state is locked
+func handleAlerting(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config) {
 	if result.Success {
 		handleAlertsToResolve(ep, result, alertingConfig)
+	} else if len(result.SuppressedBy) > 0 {
+		// The failure still counts towards the failure threshold of the alerts, but no alert is sent while a dependency
+		// of the endpoint is unhealthy
+		ep.NumberOfSuccessesInARow = 0
+		ep.NumberOfFailuresInARow++
+		logr.Infof("[watchdog.HandleAlerting] Not handling alerts to trigger for endpoint with key=%s, because the endpoint it depends on with key=%s is unhealthy", ep.Key(), result.SuppressedBy)
 	} else {
 		handleAlertsToTrigger(ep, result, alertingConfig)
 	}
+	handleBurnRateAlerts(ep, result, alertingConfig)
 }
 
 func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config) {
@@ -34,7 +76,16 @@ func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alert
 	lastReminderSent := ep.LastReminderSent
 	for _, endpointAlert := range ep.Alerts {
 		// If the alert hasn't been triggered, move to the next one
-		if !endpointAlert.IsEnabled() || endpointAlert.FailureThreshold > ep.NumberOfFailuresInARow {
+		// Burn-rate alerts don't depend on the number of failures in a row, so they're handled separately
+		if !endpointAlert.IsEnabled() || endpointAlert.BurnRate != nil || endpointAlert.FailureThreshold > ep.NumberOfFailuresInARow {
+			continue
+		}
+		if len(endpointAlert.EscalationPolicy) > 0 {
+			if reason := getAlertMuteReason(ep, endpointAlert); len(reason) > 0 {
+				logr.Infof("[watchdog.handleAlertsToTrigger] Not escalating alert for endpoint with key=%s with description='%s', because %s", ep.Key(), endpointAlert.GetDescription(), reason)
+				continue
+			}
+			handleEscalationPolicyAlertToTrigger(ep, endpointAlert, result, alertingConfig, lastReminderSent)
 			continue
 		}
 		// Determine if an initial alert should be sent
@@ -46,6 +97,10 @@ func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alert
 			logr.Debugf("[watchdog.handleAlertsToTrigger] Alert for endpoint=%s with description='%s' is not due for triggering or reminding, skipping", ep.Name, endpointAlert.GetDescription())
 			continue
 		}
+		if reason := getAlertMuteReason(ep, endpointAlert); len(reason) > 0 {
+			logr.Infof("[watchdog.handleAlertsToTrigger] Not sending alert for endpoint with key=%s with description='%s', because %s", ep.Key(), endpointAlert.GetDescription(), reason)
+			continue
+		}
 		alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
 		if alertProvider != nil {
 			logr.Infof("[watchdog.handleAlertsToTrigger] Sending %s alert because alert for endpoint with key=%s with description='%s' has been TRIGGERED", endpointAlert.Type, ep.Key(), endpointAlert.GetDescription())
@@ -59,6 +114,11 @@ func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alert
 				if os.Getenv("MOCK_ALERT_PROVIDER_ERROR") == "true" {
 					err = errors.New("error")
 				}
+			} else if alertingConfig.Grouping != nil {
+				// The alert will be sent as part of a batch once the grouping window has elapsed, and will only be marked
+				// as triggered once the batch has been sent successfully
+				alertGroups.add(alertingConfig.Grouping, alertProvider, ep, endpointAlert, result, false)
+				continue
 			} else {
 				err = alertProvider.Send(ep, endpointAlert, result, false)
 			}
@@ -83,6 +143,10 @@ func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alert
 func handleAlertsToResolve(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config) {
 	ep.NumberOfSuccessesInARow++
 	for _, endpointAlert := range ep.Alerts {
+		if endpointAlert.BurnRate != nil {
+			// Burn-rate alerts don't depend on the number of successes in a row, so they're handled separately
+			continue
+		}
 		isStillBelowSuccessThreshold := endpointAlert.SuccessThreshold > ep.NumberOfSuccessesInARow
 		if isStillBelowSuccessThreshold && endpointAlert.IsEnabled() && endpointAlert.Triggered {
 			// Persist NumberOfSuccessesInARow
@@ -90,7 +154,13 @@ func handleAlertsToResolve(ep *endpoint.Endpoint, result *endpoint.Result, alert
 				logr.Errorf("[watchdog.handleAlertsToResolve] Failed to update triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
 			}
 		}
-		if !endpointAlert.IsEnabled() || !endpointAlert.Triggered || isStillBelowSuccessThreshold {
+		if !endpointAlert.IsEnabled() || isStillBelowSuccessThreshold {
+			continue
+		}
+		if !endpointAlert.Triggered {
+			if alertingConfig.Grouping != nil && alertGroups.cancel(alertingConfig.Grouping, ep, endpointAlert) {
+				logr.Infof("[watchdog.handleAlertsToResolve] Not sending alert of type=%s for endpoint with key=%s despite being RESOLVED, because it was resolved before the triggered alert was sent", endpointAlert.Type, ep.Key())
+			}
 			continue
 		}
 		// Even if the alert provider returns an error, we still set the alert's Triggered variable to false.
@@ -99,12 +169,26 @@ func handleAlertsToResolve(ep *endpoint.Endpoint, result *endpoint.Result, alert
 		if err := store.Get().DeleteTriggeredEndpointAlert(ep, endpointAlert); err != nil {
 			logr.Errorf("[watchdog.handleAlertsToResolve] Failed to delete persisted triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
 		}
+		deleteAlertAcknowledgement(ep, endpointAlert)
+		if len(endpointAlert.EscalationPolicy) > 0 {
+			// Every step that was reached is notified, regardless of send-on-resolved
+			handleEscalationPolicyAlertToResolve(ep, endpointAlert, result, alertingConfig)
+			continue
+		}
+		if alertingConfig.Grouping != nil {
+			// A reminder that hasn't been sent yet is no longer relevant
+			alertGroups.cancel(alertingConfig.Grouping, ep, endpointAlert)
+		}
 		if !endpointAlert.IsSendingOnResolved() {
 			logr.Debugf("[watchdog.handleAlertsToResolve] Not sending request to provider of alert with type=%s for endpoint with key=%s despite being RESOLVED, because send-on-resolved is set to false", endpointAlert.Type, ep.Key())
 			continue
 		}
 		alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
 		if alertProvider != nil {
+			if alertingConfig.Grouping != nil {
+				alertGroups.add(alertingConfig.Grouping, alertProvider, ep, endpointAlert, result, true)
+				continue
+			}
 			logr.Infof("[watchdog.handleAlertsToResolve] Sending %s alert because alert for endpoint with key=%s with description='%s' has been RESOLVED", endpointAlert.Type, ep.Key(), endpointAlert.GetDescription())
 			err := alertProvider.Send(ep, endpointAlert, result, true)
 			if err != nil {
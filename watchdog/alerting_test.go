@@ -80,6 +80,41 @@ func TestHandleAlerting(t *testing.T) {
 	verify(t, ep, 0, 4, false, "The alert should no longer be triggered")
 }
 
+func TestHandleExternalEndpointAlerting(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				DefaultConfig: custom.Config{
+					URL:    "https://twin.sh/health",
+					Method: "GET",
+				},
+			},
+		},
+	}
+	enabled := true
+	ee := &endpoint.ExternalEndpoint{
+		Name: "backup",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				FailureThreshold: 2,
+				SuccessThreshold: 1,
+				SendOnResolved:   &enabled,
+			},
+		},
+	}
+	HandleExternalEndpointAlerting(ee, &endpoint.Result{Success: false}, cfg.Alerting)
+	verify(t, ee.ToEndpoint(), 1, 0, false, "The alert shouldn't have triggered")
+	HandleExternalEndpointAlerting(ee, &endpoint.Result{Success: false}, cfg.Alerting)
+	verify(t, ee.ToEndpoint(), 2, 0, true, "The alert should've triggered")
+	HandleExternalEndpointAlerting(ee, &endpoint.Result{Success: true}, cfg.Alerting)
+	verify(t, ee.ToEndpoint(), 0, 1, false, "The alert should've been resolved")
+}
+
 func TestHandleAlertingWhenAlertingConfigIsNil(t *testing.T) {
 	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
 	defer os.Clearenv()
@@ -665,6 +700,8 @@ func TestHandleAlertingWithMinimumReminderInterval(t *testing.T) {
 }
 
 func verify(t *testing.T, ep *endpoint.Endpoint, expectedNumberOfFailuresInARow, expectedNumberOfSuccessInARow int, expectedTriggered bool, expectedTriggeredReason string) {
+	// Grouped alerts are sent from their own goroutine, which modifies the alerting state of the endpoint
+	defer LockEndpoint(ep.Key())()
 	if ep.NumberOfFailuresInARow != expectedNumberOfFailuresInARow {
 		t.Errorf("endpoint.NumberOfFailuresInARow should've been %d, got %d", expectedNumberOfFailuresInARow, ep.NumberOfFailuresInARow)
 	}
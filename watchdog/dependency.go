@@ -0,0 +1,36 @@
+package watchdog
+
+import (
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/logr"
+)
+
+// markResultIfSuppressedByDependency sets the result's SuppressedBy field to the key of the first endpoint the
+// endpoint depends on whose most recent result stored is unsuccessful, if the result is unsuccessful.
+func markResultIfSuppressedByDependency(ep *endpoint.Endpoint, result *endpoint.Result) {
+	if result.Success || len(ep.DependsOn) == 0 {
+		return
+	}
+	for _, key := range ep.DependsOn {
+		if isEndpointUnhealthy(key) {
+			result.SuppressedBy = key
+			return
+		}
+	}
+}
+
+// isEndpointUnhealthy returns whether the most recent result of the endpoint with the given key is unsuccessful.
+// If the endpoint has no results yet, it is not considered unhealthy.
+func isEndpointUnhealthy(key string) bool {
+	status, err := store.Get().GetEndpointStatusByKey(key, paging.NewEndpointStatusParams().WithResults(1, 1))
+	if err != nil {
+		logr.Debugf("[watchdog.isEndpointUnhealthy] Failed to retrieve status of endpoint with key=%s: %s", key, err.Error())
+		return false
+	}
+	if len(status.Results) == 0 {
+		return false
+	}
+	return !status.Results[len(status.Results)-1].Success
+}
@@ -0,0 +1,69 @@
+package watchdog
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestHandleAlertingWithUnhealthyDependency(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	defer store.Get().Clear()
+
+	alertingConfig := &alerting.Config{
+		Custom: &custom.AlertProvider{
+			DefaultConfig: custom.Config{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	gateway := &endpoint.Endpoint{Name: "gateway", Group: "core", URL: "https://example.com"}
+	ep := &endpoint.Endpoint{
+		Name:      "api",
+		Group:     "core",
+		URL:       "https://example.com",
+		DependsOn: []string{"core_gateway"},
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1},
+		},
+	}
+	// The gateway has no results yet, so it shouldn't be considered unhealthy
+	result := &endpoint.Result{Success: false, Timestamp: time.Now()}
+	markResultIfSuppressedByDependency(ep, result)
+	if len(result.SuppressedBy) != 0 {
+		t.Errorf("expected result to not be suppressed, got suppressed by %s", result.SuppressedBy)
+	}
+	// The gateway is now unhealthy, so the alerts of the endpoint should be suppressed
+	UpdateEndpointStatus(gateway, &endpoint.Result{Success: false, Timestamp: time.Now()})
+	result = &endpoint.Result{Success: false, Timestamp: time.Now()}
+	markResultIfSuppressedByDependency(ep, result)
+	if result.SuppressedBy != "core_gateway" {
+		t.Fatalf("expected result to be suppressed by core_gateway, got %s", result.SuppressedBy)
+	}
+	HandleAlerting(ep, result, alertingConfig)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have been triggered, because the gateway is unhealthy")
+	// Successful results should never be marked as suppressed
+	result = &endpoint.Result{Success: true, Timestamp: time.Now()}
+	markResultIfSuppressedByDependency(ep, result)
+	if len(result.SuppressedBy) != 0 {
+		t.Errorf("expected successful result to not be suppressed, got suppressed by %s", result.SuppressedBy)
+	}
+	// The gateway is healthy again, so the alert should be triggered
+	UpdateEndpointStatus(gateway, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	result = &endpoint.Result{Success: false, Timestamp: time.Now()}
+	markResultIfSuppressedByDependency(ep, result)
+	if len(result.SuppressedBy) != 0 {
+		t.Fatalf("expected result to not be suppressed, got suppressed by %s", result.SuppressedBy)
+	}
+	HandleAlerting(ep, result, alertingConfig)
+	verify(t, ep, 2, 0, true, "The alert should've been triggered, because the gateway is healthy")
+}
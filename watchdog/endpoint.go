@@ -11,9 +11,10 @@ import (
 	"github.com/TwiN/logr"
 )
 
-// monitorEndpoint a single endpoint in a loop
-func monitorEndpoint(ep *endpoint.Endpoint, cfg *config.Config, extraLabels []string, ctx context.Context) {
+// monitorEndpoint a single endpoint in a loop until the context is canceled
+func monitorEndpoint(ep *endpoint.Endpoint, ctx context.Context) {
 	// Run it immediately on start
+	cfg, extraLabels := getCurrentConfig()
 	executeEndpoint(ep, cfg, extraLabels)
 	// Loop for the next executions
 	ticker := time.NewTicker(ep.Interval)
@@ -24,6 +25,7 @@ func monitorEndpoint(ep *endpoint.Endpoint, cfg *config.Config, extraLabels []st
 			logr.Warnf("[watchdog.monitorEndpoint] Canceling current execution of group=%s; endpoint=%s; key=%s", ep.Group, ep.Name, ep.Key())
 			return
 		case <-ticker.C:
+			cfg, extraLabels := getCurrentConfig()
 			executeEndpoint(ep, cfg, extraLabels)
 		}
 	}
@@ -33,6 +35,10 @@ func monitorEndpoint(ep *endpoint.Endpoint, cfg *config.Config, extraLabels []st
 }
 
 func executeEndpoint(ep *endpoint.Endpoint, cfg *config.Config, extraLabels []string) {
+	if !IsLeader() {
+		logr.Debugf("[watchdog.executeEndpoint] Not the leader; skipping execution of group=%s; endpoint=%s; key=%s", ep.Group, ep.Name, ep.Key())
+		return
+	}
 	// Acquire semaphore to limit concurrent endpoint monitoring
 	if err := monitoringSemaphore.Acquire(ctx, 1); err != nil {
 		// Only fails if context is cancelled (during shutdown)
@@ -40,17 +46,33 @@ func executeEndpoint(ep *endpoint.Endpoint, cfg *config.Config, extraLabels []st
 		return
 	}
 	defer monitoringSemaphore.Release(1)
-	// If there's a connectivity checker configured, check if Gatus has internet connectivity
-	if cfg.Connectivity != nil && cfg.Connectivity.Checker != nil && !cfg.Connectivity.Checker.IsConnected() {
-		logr.Infof("[watchdog.executeEndpoint] No connectivity; skipping execution")
-		return
+	var result *endpoint.Result
+	if ep.IsMonitoredFromLocations() {
+		// The endpoint is monitored by the agents of its locations, so its health is determined by their results
+		if result = evaluateHealthFromLocations(ep); result == nil {
+			logr.Warnf("[watchdog.executeEndpoint] No recent result from any location for group=%s; endpoint=%s; key=%s; skipping execution", ep.Group, ep.Name, ep.Key())
+			return
+		}
+	} else {
+		// If there's a connectivity checker configured, check if Gatus has internet connectivity
+		if cfg.Connectivity != nil && cfg.Connectivity.Checker != nil && !cfg.Connectivity.Checker.IsConnected() {
+			logr.Infof("[watchdog.executeEndpoint] No connectivity; skipping execution")
+			return
+		}
+		logr.Debugf("[watchdog.executeEndpoint] Monitoring group=%s; endpoint=%s; key=%s", ep.Group, ep.Name, ep.Key())
+		result = ep.EvaluateHealthWithCancellation(ctx)
 	}
-	logr.Debugf("[watchdog.executeEndpoint] Monitoring group=%s; endpoint=%s; key=%s", ep.Group, ep.Name, ep.Key())
-	result := ep.EvaluateHealth()
+	markResultIfSuppressedByDependency(ep, result)
 	if cfg.Metrics {
 		metrics.PublishMetricsForEndpoint(ep, result, extraLabels)
 	}
 	UpdateEndpointStatus(ep, result)
+	if cfg.Agent != nil && isAssignedEndpoint(ep) {
+		pushResultToCentralInstance(cfg.Agent, ep, result)
+	}
+	if cfg.Metrics && ep.SLO != nil {
+		publishSLOMetricsForEndpoint(ep, extraLabels)
+	}
 	if logr.GetThreshold() == logr.LevelDebug && !result.Success {
 		logr.Debugf("[watchdog.executeEndpoint] Monitored group=%s; endpoint=%s; key=%s; success=%v; errors=%d; duration=%s; body=%s", ep.Group, ep.Name, ep.Key(), result.Success, len(result.Errors), result.Duration.Round(time.Millisecond), result.Body)
 	} else {
@@ -63,7 +85,7 @@ func executeEndpoint(ep *endpoint.Endpoint, cfg *config.Config, extraLabels []st
 			inEndpointMaintenanceWindow = true
 		}
 	}
-	if !cfg.Maintenance.IsUnderMaintenance() && !inEndpointMaintenanceWindow {
+	if !cfg.Maintenance.IsUnderMaintenance() && !inEndpointMaintenanceWindow && !IsUnderRuntimeMaintenance(ep.Key(), ep.Group, "") {
 		HandleAlerting(ep, result, cfg.Alerting)
 	} else {
 		logr.Debug("[watchdog.executeEndpoint] Not handling alerting because currently in the maintenance window")
@@ -0,0 +1,92 @@
+package watchdog
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/logr"
+)
+
+// handleEscalationPolicyAlertToTrigger notifies every step of the alert's escalation policy that has been reached
+// since the alert was triggered and hasn't been notified yet, as well as the steps already notified if a reminder is due
+func handleEscalationPolicyAlertToTrigger(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, alertingConfig *alerting.Config, lastReminderSent time.Time) {
+	policy := alertingConfig.EscalationPolicies[endpointAlert.EscalationPolicy]
+	if policy == nil {
+		logr.Warnf("[watchdog.handleEscalationPolicyAlertToTrigger] Not sending alert for endpoint with key=%s despite being TRIGGERED, because escalation policy %s doesn't exist", ep.Key(), endpointAlert.EscalationPolicy)
+		return
+	}
+	modified := false
+	if !endpointAlert.Triggered {
+		endpointAlert.Triggered, endpointAlert.TriggeredAt, endpointAlert.EscalationResolveKeys = true, time.Now(), nil
+		modified = true
+	}
+	numberOfStepsReached := len(endpointAlert.EscalationResolveKeys)
+	if numberOfStepsReached > 0 && endpointAlert.MinimumReminderInterval > 0 && time.Since(lastReminderSent) >= endpointAlert.MinimumReminderInterval {
+		for i := 0; i < numberOfStepsReached && i < len(policy.Steps); i++ {
+			logr.Infof("[watchdog.handleEscalationPolicyAlertToTrigger] Sending reminder for step %d of escalation policy %s because alert for endpoint with key=%s with description='%s' is still TRIGGERED", i+1, endpointAlert.EscalationPolicy, ep.Key(), endpointAlert.GetDescription())
+			if err := sendAlert(alertingConfig, ep, policy.Steps[i].ToAlert(endpointAlert, endpointAlert.EscalationResolveKeys[i]), result, false); err != nil {
+				logr.Errorf("[watchdog.handleEscalationPolicyAlertToTrigger] Failed to send reminder for step %d of escalation policy %s for endpoint with key=%s: %s", i+1, endpointAlert.EscalationPolicy, ep.Key(), err.Error())
+			}
+		}
+		ep.LastReminderSent = time.Now()
+	}
+	for i := numberOfStepsReached; i < policy.NumberOfStepsDue(endpointAlert.TriggeredAt); i++ {
+		logr.Infof("[watchdog.handleEscalationPolicyAlertToTrigger] Sending alert for step %d of escalation policy %s because alert for endpoint with key=%s with description='%s' has been TRIGGERED", i+1, endpointAlert.EscalationPolicy, ep.Key(), endpointAlert.GetDescription())
+		stepAlert := policy.Steps[i].ToAlert(endpointAlert, "")
+		if err := sendAlert(alertingConfig, ep, stepAlert, result, false); err != nil {
+			// Steps must be reached in order, so the remaining steps will be retried on the next evaluation
+			logr.Errorf("[watchdog.handleEscalationPolicyAlertToTrigger] Failed to send alert for step %d of escalation policy %s for endpoint with key=%s: %s", i+1, endpointAlert.EscalationPolicy, ep.Key(), err.Error())
+			break
+		}
+		endpointAlert.EscalationResolveKeys = append(endpointAlert.EscalationResolveKeys, stepAlert.ResolveKey)
+		ep.LastReminderSent = time.Now()
+		modified = true
+	}
+	if modified {
+		if err := store.Get().UpsertTriggeredEndpointAlert(ep, endpointAlert); err != nil {
+			logr.Errorf("[watchdog.handleEscalationPolicyAlertToTrigger] Failed to persist triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
+	}
+}
+
+// handleEscalationPolicyAlertToResolve notifies every step of the alert's escalation policy that was reached while
+// the alert was triggered
+func handleEscalationPolicyAlertToResolve(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, alertingConfig *alerting.Config) {
+	policy := alertingConfig.EscalationPolicies[endpointAlert.EscalationPolicy]
+	if policy != nil {
+		for i, resolveKey := range endpointAlert.EscalationResolveKeys {
+			if i >= len(policy.Steps) {
+				break
+			}
+			logr.Infof("[watchdog.handleEscalationPolicyAlertToResolve] Sending alert for step %d of escalation policy %s because alert for endpoint with key=%s with description='%s' has been RESOLVED", i+1, endpointAlert.EscalationPolicy, ep.Key(), endpointAlert.GetDescription())
+			if err := sendAlert(alertingConfig, ep, policy.Steps[i].ToAlert(endpointAlert, resolveKey), result, true); err != nil {
+				logr.Errorf("[watchdog.handleEscalationPolicyAlertToResolve] Failed to send alert for step %d of escalation policy %s for endpoint with key=%s: %s", i+1, endpointAlert.EscalationPolicy, ep.Key(), err.Error())
+			}
+		}
+	}
+	endpointAlert.TriggeredAt, endpointAlert.EscalationResolveKeys = time.Time{}, nil
+}
+
+// sendAlert sends an alert to its provider, bypassing alert grouping.
+//
+// Used for the alerts of escalation steps, as returned by escalation.Step.ToAlert, and for burn-rate alerts.
+func sendAlert(alertingConfig *alerting.Config, ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
+	if alertProvider == nil {
+		// There's no point in retrying an alert whose provider isn't configured, so it's considered as sent
+		logr.Warnf("[watchdog.sendAlert] Not sending alert of type=%s for endpoint with key=%s, because the provider wasn't configured properly", endpointAlert.Type, ep.Key())
+		return nil
+	}
+	if os.Getenv("MOCK_ALERT_PROVIDER") == "true" {
+		if os.Getenv("MOCK_ALERT_PROVIDER_ERROR") == "true" {
+			return errors.New("error")
+		}
+		return nil
+	}
+	return alertProvider.Send(ep, endpointAlert, result, resolved)
+}
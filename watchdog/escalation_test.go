@@ -0,0 +1,93 @@
+package watchdog
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/escalation"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestHandleAlertingWithEscalationPolicy(t *testing.T) {
+	var mutex sync.Mutex
+	var receivedBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		receivedBodies = append(receivedBodies, string(body))
+		mutex.Unlock()
+	}))
+	defer server.Close()
+	getReceivedBodiesAndReset := func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		bodies := receivedBodies
+		receivedBodies = nil
+		return bodies
+	}
+	alertingConfig := &alerting.Config{
+		Custom: &custom.AlertProvider{
+			DefaultConfig: custom.Config{
+				URL:    server.URL,
+				Method: "POST",
+				Body:   "[ALERT_TRIGGERED_OR_RESOLVED] on-call",
+			},
+		},
+		EscalationPolicies: map[string]*escalation.Policy{
+			"critical": {
+				Steps: []*escalation.Step{
+					{Type: alert.TypeCustom},
+					{Type: alert.TypeCustom, After: 15 * time.Minute, ProviderOverride: map[string]any{"body": "[ALERT_TRIGGERED_OR_RESOLVED] management"}},
+				},
+			},
+		},
+	}
+	ep := &endpoint.Endpoint{
+		Name: "api",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{EscalationPolicy: "critical", FailureThreshold: 2, SuccessThreshold: 1},
+		},
+	}
+	endpointAlert := ep.Alerts[0]
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have been triggered, because the failure threshold hasn't been reached")
+	if bodies := getReceivedBodiesAndReset(); len(bodies) != 0 {
+		t.Fatalf("expected no notification, got %v", bodies)
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig)
+	verify(t, ep, 2, 0, true, "The alert should've been triggered")
+	if bodies := getReceivedBodiesAndReset(); len(bodies) != 1 || bodies[0] != "TRIGGERED on-call" {
+		t.Fatalf("expected only the first step to have been notified, got %v", bodies)
+	}
+	// The second step isn't due yet, so nothing should be sent
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig)
+	if bodies := getReceivedBodiesAndReset(); len(bodies) != 0 {
+		t.Fatalf("expected no notification, got %v", bodies)
+	}
+	// Simulate the alert having been triggered 20 minutes ago, which means that the second step is now due
+	endpointAlert.TriggeredAt = endpointAlert.TriggeredAt.Add(-20 * time.Minute)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig)
+	if bodies := getReceivedBodiesAndReset(); len(bodies) != 1 || bodies[0] != "TRIGGERED management" {
+		t.Fatalf("expected only the second step to have been notified, got %v", bodies)
+	}
+	if len(endpointAlert.EscalationResolveKeys) != 2 {
+		t.Fatalf("expected 2 steps to have been reached, got %d", len(endpointAlert.EscalationResolveKeys))
+	}
+	// Every step that was reached should be notified when the alert is resolved, even though send-on-resolved is false
+	HandleAlerting(ep, &endpoint.Result{Success: true}, alertingConfig)
+	verify(t, ep, 0, 1, false, "The alert should've been resolved")
+	if bodies := getReceivedBodiesAndReset(); len(bodies) != 2 || bodies[0] != "RESOLVED on-call" || bodies[1] != "RESOLVED management" {
+		t.Fatalf("expected both steps to have been notified of the resolution, got %v", bodies)
+	}
+	if !endpointAlert.TriggeredAt.IsZero() || len(endpointAlert.EscalationResolveKeys) != 0 {
+		t.Error("expected the escalation progress to have been reset")
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/TwiN/logr"
 )
 
-func monitorExternalEndpointHeartbeat(ee *endpoint.ExternalEndpoint, cfg *config.Config, extraLabels []string, ctx context.Context) {
+func monitorExternalEndpointHeartbeat(ee *endpoint.ExternalEndpoint, ctx context.Context) {
 	ticker := time.NewTicker(ee.Heartbeat.Interval)
 	defer ticker.Stop()
 	for {
@@ -20,12 +20,17 @@ func monitorExternalEndpointHeartbeat(ee *endpoint.ExternalEndpoint, cfg *config
 			logr.Warnf("[watchdog.monitorExternalEndpointHeartbeat] Canceling current execution of group=%s; endpoint=%s; key=%s", ee.Group, ee.Name, ee.Key())
 			return
 		case <-ticker.C:
+			cfg, extraLabels := getCurrentConfig()
 			executeExternalEndpointHeartbeat(ee, cfg, extraLabels)
 		}
 	}
 }
 
 func executeExternalEndpointHeartbeat(ee *endpoint.ExternalEndpoint, cfg *config.Config, extraLabels []string) {
+	if !IsLeader() {
+		logr.Debugf("[watchdog.monitorExternalEndpointHeartbeat] Not the leader; skipping heartbeat check of group=%s; endpoint=%s; key=%s", ee.Group, ee.Name, ee.Key())
+		return
+	}
 	// Acquire semaphore to limit concurrent external endpoint monitoring
 	if err := monitoringSemaphore.Acquire(ctx, 1); err != nil {
 		// Only fails if context is cancelled (during shutdown)
@@ -71,11 +76,8 @@ func executeExternalEndpointHeartbeat(ee *endpoint.ExternalEndpoint, cfg *config
 			inEndpointMaintenanceWindow = true
 		}
 	}
-	if !cfg.Maintenance.IsUnderMaintenance() && !inEndpointMaintenanceWindow {
-		HandleAlerting(convertedEndpoint, result, cfg.Alerting)
-		// Sync the failure/success counters back to the external endpoint
-		ee.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
-		ee.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
+	if !cfg.Maintenance.IsUnderMaintenance() && !inEndpointMaintenanceWindow && !IsUnderRuntimeMaintenance(ee.Key(), ee.Group, "") {
+		HandleExternalEndpointAlerting(ee, result, cfg.Alerting)
 	} else {
 		logr.Debug("[watchdog.monitorExternalEndpointHeartbeat] Not handling alerting because currently in the maintenance window")
 	}
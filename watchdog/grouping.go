@@ -0,0 +1,166 @@
+package watchdog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/grouping"
+	"github.com/TwiN/gatus/v5/alerting/provider"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/logr"
+)
+
+// alertGroups buffers the alerts to send when alert grouping is enabled
+var alertGroups = &alertGrouper{pending: make(map[string]*pendingAlertBatch)}
+
+// alertGrouper buffers triggered and resolved alerts for the duration of the grouping window, so that alerts of the
+// same type affecting multiple endpoints can be sent as a single notification
+type alertGrouper struct {
+	pending map[string]*pendingAlertBatch
+	sync.Mutex
+}
+
+type pendingAlertBatch struct {
+	provider provider.AlertProvider
+	batch    *grouping.Batch
+	window   time.Duration
+	resolved bool
+}
+
+// add buffers an alert until the grouping window of the batch it belongs to has elapsed.
+// If the batch already has an entry for the same endpoint and alert, the entry is replaced instead.
+func (g *alertGrouper) add(cfg *grouping.Config, alertProvider provider.AlertProvider, ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, resolved bool) {
+	key := alertBatchKey(cfg, ep, endpointAlert, resolved)
+	entry := &grouping.Entry{Endpoint: ep, Alert: endpointAlert, Result: result}
+	g.Lock()
+	defer g.Unlock()
+	pending, exists := g.pending[key]
+	if !exists {
+		pending = &pendingAlertBatch{provider: alertProvider, batch: &grouping.Batch{Key: cfg.GetKey(ep)}, window: cfg.Window, resolved: resolved}
+		g.schedule(key, pending)
+	}
+	pending.addEntry(entry)
+}
+
+// schedule registers a pending batch and flushes it once its grouping window has elapsed.
+// Must be called while holding the lock.
+func (g *alertGrouper) schedule(key string, pending *pendingAlertBatch) {
+	g.pending[key] = pending
+	time.AfterFunc(pending.window, func() {
+		g.flush(key, pending)
+	})
+}
+
+// retry puts the entries of a batch that failed to be sent back into the pending batch with the same key, so that
+// they're sent again once the grouping window has elapsed.
+// Entries that have been added again in the meantime are kept as is, since they're more recent.
+func (g *alertGrouper) retry(key string, failed *pendingAlertBatch) {
+	g.Lock()
+	defer g.Unlock()
+	pending, exists := g.pending[key]
+	if !exists {
+		g.schedule(key, failed)
+		return
+	}
+	for _, entry := range failed.batch.Entries {
+		if !pending.hasEntry(entry.Alert) {
+			pending.batch.Entries = append(pending.batch.Entries, entry)
+		}
+	}
+}
+
+// addEntry adds an entry to the batch, replacing the existing entry for the same alert if there is one
+func (p *pendingAlertBatch) addEntry(entry *grouping.Entry) {
+	for i, existingEntry := range p.batch.Entries {
+		if existingEntry.Alert == entry.Alert {
+			p.batch.Entries[i] = entry
+			return
+		}
+	}
+	p.batch.Entries = append(p.batch.Entries, entry)
+}
+
+// hasEntry returns whether the batch has an entry for the given alert
+func (p *pendingAlertBatch) hasEntry(endpointAlert *alert.Alert) bool {
+	for _, entry := range p.batch.Entries {
+		if entry.Alert == endpointAlert {
+			return true
+		}
+	}
+	return false
+}
+
+// cancel removes the triggered alert of an endpoint from its batch, if it hasn't been sent yet.
+// Returns whether the triggered alert was removed.
+func (g *alertGrouper) cancel(cfg *grouping.Config, ep *endpoint.Endpoint, endpointAlert *alert.Alert) bool {
+	key := alertBatchKey(cfg, ep, endpointAlert, false)
+	g.Lock()
+	defer g.Unlock()
+	pending, exists := g.pending[key]
+	if !exists {
+		return false
+	}
+	for i, entry := range pending.batch.Entries {
+		if entry.Alert == endpointAlert {
+			pending.batch.Entries = append(pending.batch.Entries[:i], pending.batch.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// flush sends the pending batch, unless it has already been sent.
+//
+// Like alerts that aren't grouped, triggered alerts are only marked as triggered once they've been sent successfully.
+// If the batch fails to be sent, its triggered alerts are retried once the grouping window has elapsed again, unless
+// they're resolved in the meantime.
+func (g *alertGrouper) flush(key string, pending *pendingAlertBatch) {
+	g.Lock()
+	if g.pending[key] != pending {
+		g.Unlock()
+		return
+	}
+	delete(g.pending, key)
+	g.Unlock()
+	if len(pending.batch.Entries) == 0 {
+		return
+	}
+	state := "TRIGGERED"
+	if pending.resolved {
+		state = "RESOLVED"
+	}
+	logr.Infof("[watchdog.flush] Sending %s alert for %d endpoint(s) with grouping key='%s' that have been %s", pending.batch.Entries[0].Alert.Type, len(pending.batch.Entries), pending.batch.Key, state)
+	if err := provider.SendBatch(pending.provider, pending.batch, pending.resolved); err != nil {
+		logr.Errorf("[watchdog.flush] Failed to send %s alert for %d endpoint(s) with grouping key='%s': %s", pending.batch.Entries[0].Alert.Type, len(pending.batch.Entries), pending.batch.Key, err.Error())
+		if !pending.resolved {
+			g.retry(key, pending)
+		}
+		return
+	}
+	if pending.resolved {
+		return
+	}
+	now := time.Now()
+	for _, entry := range pending.batch.Entries {
+		// The batch is sent from its own goroutine, so the alerting state of the endpoint must be locked to avoid
+		// racing with the execution of the endpoint
+		unlock := LockEndpoint(entry.Endpoint.Key())
+		// Mark initial alert as triggered and update last reminder time
+		entry.Alert.Triggered = true
+		entry.Endpoint.LastReminderSent = now
+		if err := store.Get().UpsertTriggeredEndpointAlert(entry.Endpoint, entry.Alert); err != nil {
+			logr.Errorf("[watchdog.flush] Failed to persist triggered endpoint alert for endpoint with key=%s: %s", entry.Endpoint.Key(), err.Error())
+		}
+		unlock()
+	}
+}
+
+// alertBatchKey returns the key of the batch an alert belongs to.
+// Besides the grouping key, alerts are only grouped together if they are of the same type and would be sent with the
+// same provider configuration, which depends on the endpoint's group and on the alert's provider override.
+func alertBatchKey(cfg *grouping.Config, ep *endpoint.Endpoint, endpointAlert *alert.Alert, resolved bool) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%t", endpointAlert.Type, cfg.GetKey(ep), ep.Group, endpointAlert.ProviderOverrideAsBytes(), resolved)
+}
@@ -0,0 +1,117 @@
+package watchdog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/grouping"
+	"github.com/TwiN/gatus/v5/alerting/provider/slack"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestHandleAlertingWithGrouping(t *testing.T) {
+	var mutex sync.Mutex
+	var receivedMessages []string
+	var failing bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body slack.Body
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mutex.Lock()
+		defer mutex.Unlock()
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		receivedMessages = append(receivedMessages, body.Attachments[0].Text)
+	}))
+	defer server.Close()
+	setFailing := func(value bool) {
+		mutex.Lock()
+		failing = value
+		mutex.Unlock()
+	}
+	getReceivedMessagesAndReset := func() []string {
+		// Wait for the grouping window to elapse
+		time.Sleep(150 * time.Millisecond)
+		mutex.Lock()
+		defer mutex.Unlock()
+		messages := receivedMessages
+		receivedMessages = nil
+		return messages
+	}
+	alertingConfig := &alerting.Config{
+		Grouping: &grouping.Config{Window: 50 * time.Millisecond},
+		Slack:    &slack.AlertProvider{DefaultConfig: slack.Config{WebhookURL: server.URL}},
+	}
+	if err := alertingConfig.Grouping.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	enabled := true
+	newEndpoint := func(name string) *endpoint.Endpoint {
+		return &endpoint.Endpoint{
+			Name:  name,
+			Group: "core",
+			URL:   "https://example.com",
+			Alerts: []*alert.Alert{
+				{Type: alert.TypeSlack, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1, SendOnResolved: &enabled},
+			},
+		}
+	}
+	frontend, backend, database := newEndpoint("frontend"), newEndpoint("backend"), newEndpoint("database")
+	// Trigger the alerts of all endpoints, which should result in a single notification
+	for _, ep := range []*endpoint.Endpoint{frontend, backend, database} {
+		HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig)
+		verify(t, ep, 1, 0, false, "The alert shouldn't have been triggered before the batch was sent")
+	}
+	if messages := getReceivedMessagesAndReset(); len(messages) != 1 {
+		t.Fatalf("expected 1 grouped notification, got %d", len(messages))
+	} else if messages[0] != "Alerts for *3 endpoints* have been triggered in *core*" {
+		t.Errorf("unexpected notification: %s", messages[0])
+	}
+	for _, ep := range []*endpoint.Endpoint{frontend, backend, database} {
+		verify(t, ep, 1, 0, true, "The alert should've been triggered once the batch was sent")
+	}
+	// Resolve the alerts of all endpoints, which should also result in a single notification
+	for _, ep := range []*endpoint.Endpoint{frontend, backend, database} {
+		HandleAlerting(ep, &endpoint.Result{Success: true}, alertingConfig)
+		verify(t, ep, 0, 1, false, "The alert should've been resolved")
+	}
+	if messages := getReceivedMessagesAndReset(); len(messages) != 1 {
+		t.Fatalf("expected 1 grouped notification, got %d", len(messages))
+	} else if messages[0] != "Alerts for *3 endpoints* have been resolved in *core*" {
+		t.Errorf("unexpected notification: %s", messages[0])
+	}
+	// An alert that is triggered and resolved within the same window should not result in any notification
+	HandleAlerting(frontend, &endpoint.Result{Success: false}, alertingConfig)
+	HandleAlerting(frontend, &endpoint.Result{Success: true}, alertingConfig)
+	verify(t, frontend, 0, 1, false, "The alert should've been resolved")
+	if messages := getReceivedMessagesAndReset(); len(messages) != 0 {
+		t.Errorf("expected no notification, got %d", len(messages))
+	}
+	// A single alert should be sent as is
+	HandleAlerting(backend, &endpoint.Result{Success: false}, alertingConfig)
+	if messages := getReceivedMessagesAndReset(); len(messages) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(messages))
+	} else if messages[0] != "An alert for *core/backend* has been triggered due to having failed 1 time(s) in a row" {
+		t.Errorf("unexpected notification: %s", messages[0])
+	}
+	verify(t, backend, 1, 0, true, "The alert should've been triggered once the batch was sent")
+	// A batch that fails to be sent should be retried, and its alerts should only be triggered once it has been sent
+	setFailing(true)
+	HandleAlerting(database, &endpoint.Result{Success: false}, alertingConfig)
+	if messages := getReceivedMessagesAndReset(); len(messages) != 0 {
+		t.Fatalf("expected no notification, got %d", len(messages))
+	}
+	verify(t, database, 1, 0, false, "The alert shouldn't have been triggered, because the batch failed to be sent")
+	setFailing(false)
+	if messages := getReceivedMessagesAndReset(); len(messages) != 1 {
+		t.Fatalf("expected the batch to have been retried, got %d notifications", len(messages))
+	}
+	verify(t, database, 1, 0, true, "The alert should've been triggered once the batch was retried")
+}
@@ -0,0 +1,130 @@
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/ha"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/logr"
+)
+
+const (
+	// leaderLeaseName is the name of the lease held by the leader in the storage shared by all instances
+	leaderLeaseName = "leader"
+)
+
+var (
+	// isLeader is whether this instance currently holds the leadership. Only relevant if high availability is enabled.
+	isLeader atomic.Bool
+
+	// leaseRenewedAt is the time, in nanoseconds since the Unix epoch, at which the last successful attempt to acquire or
+	// renew the leadership started
+	leaseRenewedAt atomic.Int64
+
+	// leadershipAcquiredHandler is called with the current configuration every time this instance becomes the leader
+	leadershipAcquiredHandler func(cfg *config.Config)
+
+	// leadershipMutex prevents the leadership from being renewed while it's being released, and vice versa
+	leadershipMutex sync.Mutex
+)
+
+// OnLeadershipAcquired registers the function to call every time this instance becomes the leader while running in
+// high-availability mode, right before it starts monitoring endpoints and handling alerting.
+//
+// This is meant to load the state persisted by the previous leader, such as the triggered alerts.
+func OnLeadershipAcquired(handler func(cfg *config.Config)) {
+	leadershipAcquiredHandler = handler
+}
+
+// IsLeader returns whether this instance is responsible for monitoring endpoints and handling alerting, which is
+// always the case unless high availability is enabled, in which case only the instance elected as the leader is.
+func IsLeader() bool {
+	cfg, _ := getCurrentConfig()
+	if cfg == nil || cfg.HighAvailability == nil {
+		return true
+	}
+	if !isLeader.Load() {
+		return false
+	}
+	// If the lease couldn't be renewed in time, e.g. because the storage is unresponsive, another instance may already
+	// have taken over, so the leadership is given up without waiting for the renewal to fail
+	if time.Since(time.Unix(0, leaseRenewedAt.Load())) > cfg.HighAvailability.LeaseDuration {
+		if isLeader.CompareAndSwap(true, false) {
+			logr.Warnf("[watchdog.IsLeader] Instance with id=%s is no longer the leader, because its lease expired before it could be renewed", cfg.HighAvailability.InstanceID)
+		}
+		return false
+	}
+	return true
+}
+
+// maintainLeadership periodically tries to acquire the leadership, or to renew it if this instance is already the
+// leader, until the context is canceled
+func maintainLeadership(haConfig *ha.Config, ctx context.Context) {
+	ticker := time.NewTicker(haConfig.RenewalInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			renewLeadership(haConfig, ctx)
+		}
+	}
+}
+
+// renewLeadership tries to acquire the leadership, or to renew it if this instance is already the leader
+func renewLeadership(haConfig *ha.Config, ctx context.Context) {
+	leadershipMutex.Lock()
+	defer leadershipMutex.Unlock()
+	if ctx.Err() != nil {
+		// The leadership may have been released already, so it must not be acquired again
+		return
+	}
+	// The lease is considered to have been renewed when the attempt started rather than when it completed, since the
+	// time it takes for the storage to respond is time during which another instance could've taken over
+	startedAt := time.Now()
+	// An attempt taking longer than the renewal interval is abandoned so that renewals don't pile up, and so that the
+	// outcome of the next attempt can be known before the lease expires
+	renewalCtx, cancel := context.WithTimeout(ctx, haConfig.RenewalInterval())
+	acquired, err := store.Get().AcquireLease(renewalCtx, leaderLeaseName, haConfig.InstanceID, haConfig.LeaseDuration)
+	cancel()
+	if err != nil {
+		// Without renewing the lease, there's no telling whether another instance is about to take over, so to avoid
+		// having two leaders at once, the leadership is given up until the lease can be acquired again
+		logr.Errorf("[watchdog.renewLeadership] Failed to acquire leader lease for instance with id=%s: %s", haConfig.InstanceID, err.Error())
+	}
+	if acquired {
+		leaseRenewedAt.Store(startedAt.UnixNano())
+	}
+	wasLeader := isLeader.Load()
+	if acquired && !wasLeader {
+		logr.Infof("[watchdog.renewLeadership] Instance with id=%s is now the leader", haConfig.InstanceID)
+		if leadershipAcquiredHandler != nil {
+			cfg, _ := getCurrentConfig()
+			leadershipAcquiredHandler(cfg)
+		}
+		isLeader.Store(true)
+	} else if !acquired && wasLeader {
+		logr.Warnf("[watchdog.renewLeadership] Instance with id=%s is no longer the leader", haConfig.InstanceID)
+		isLeader.Store(false)
+	}
+}
+
+// releaseLeadership releases the leadership if this instance is the leader, so that another instance can take over
+// without waiting for the lease to expire
+func releaseLeadership(haConfig *ha.Config) {
+	leadershipMutex.Lock()
+	defer leadershipMutex.Unlock()
+	if !isLeader.Swap(false) {
+		return
+	}
+	if err := store.Get().ReleaseLease(leaderLeaseName, haConfig.InstanceID); err != nil {
+		logr.Errorf("[watchdog.releaseLeadership] Failed to release leader lease for instance with id=%s: %s", haConfig.InstanceID, err.Error())
+		return
+	}
+	logr.Infof("[watchdog.releaseLeadership] Instance with id=%s released the leadership", haConfig.InstanceID)
+}
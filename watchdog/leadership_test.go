@@ -0,0 +1,111 @@
+package watchdog
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/ha"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestIsLeader(t *testing.T) {
+	defer resetLeadership()
+	if !IsLeader() {
+		t.Error("expected instance to be the leader when there's no configuration")
+	}
+	currentConfig = &config.Config{}
+	if !IsLeader() {
+		t.Error("expected instance to be the leader when high availability isn't enabled")
+	}
+	currentConfig = &config.Config{HighAvailability: &ha.Config{InstanceID: "gatus-1", LeaseDuration: time.Minute}}
+	if IsLeader() {
+		t.Error("expected instance to not be the leader before acquiring the leadership")
+	}
+	isLeader.Store(true)
+	leaseRenewedAt.Store(time.Now().UnixNano())
+	if !IsLeader() {
+		t.Error("expected instance to be the leader after acquiring the leadership")
+	}
+	leaseRenewedAt.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	if IsLeader() {
+		t.Error("expected instance to step down once its lease expired without being renewed")
+	}
+	if isLeader.Load() {
+		t.Error("expected instance to have given up the leadership")
+	}
+}
+
+func TestLeadership(t *testing.T) {
+	defer store.Get().Clear()
+	defer resetLeadership()
+	// Another instance holds the leadership when this instance starts
+	if acquired, _ := store.Get().AcquireLease(context.Background(), leaderLeaseName, "gatus-2", time.Second); !acquired {
+		t.Fatal("expected gatus-2 to acquire the leadership")
+	}
+	var numberOfTimesLeadershipAcquired atomic.Int32
+	OnLeadershipAcquired(func(cfg *config.Config) {
+		if cfg.HighAvailability.InstanceID != "gatus-1" {
+			t.Error("expected the handler to be called with the current configuration")
+		}
+		numberOfTimesLeadershipAcquired.Add(1)
+	})
+	cfg := &config.Config{
+		HighAvailability: &ha.Config{InstanceID: "gatus-1", LeaseDuration: 300 * time.Millisecond},
+		Maintenance:      maintenance.GetDefaultConfig(),
+	}
+	Monitor(cfg)
+	defer Shutdown(cfg)
+	if IsLeader() {
+		t.Fatal("expected gatus-1 to not be the leader while gatus-2 holds the leadership")
+	}
+	// Followers must not handle alerting
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	enabled := true
+	ep := &endpoint.Endpoint{Name: "website", Group: "core", Alerts: []*alert.Alert{{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 1}}}
+	alertingConfig := &alerting.Config{Custom: &custom.AlertProvider{DefaultConfig: custom.Config{URL: "https://twin.sh/health"}}}
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig)
+	if ep.NumberOfFailuresInARow != 0 || ep.Alerts[0].Triggered {
+		t.Error("expected alerting to not be handled by a follower")
+	}
+	// Once the lease of gatus-2 expires, gatus-1 takes over
+	waitFor(t, "gatus-1 to take over the leadership", IsLeader)
+	if numberOfTimesLeadershipAcquired.Load() != 1 {
+		t.Errorf("expected the handler to be called once, got %d", numberOfTimesLeadershipAcquired.Load())
+	}
+	if acquired, _ := store.Get().AcquireLease(context.Background(), leaderLeaseName, "gatus-2", time.Second); acquired {
+		t.Error("expected gatus-2 to not be able to acquire the leadership while gatus-1 renews it")
+	}
+	// Renewing the leadership must not call the handler again
+	time.Sleep(3 * cfg.HighAvailability.RenewalInterval())
+	if !IsLeader() || numberOfTimesLeadershipAcquired.Load() != 1 {
+		t.Errorf("expected gatus-1 to still be the leader and the handler to have been called once, got %d", numberOfTimesLeadershipAcquired.Load())
+	}
+	// Shutting down releases the leadership, so that another instance can take over right away
+	Shutdown(cfg)
+	if IsLeader() {
+		t.Error("expected gatus-1 to no longer be the leader after shutting down")
+	}
+	if acquired, _ := store.Get().AcquireLease(context.Background(), leaderLeaseName, "gatus-2", time.Second); !acquired {
+		t.Error("expected gatus-2 to acquire the leadership released by gatus-1")
+	}
+}
+
+// resetLeadership resets the state of the leadership, so that other tests aren't affected by high availability
+func resetLeadership() {
+	monitorsMutex.Lock()
+	currentConfig = nil
+	monitorsMutex.Unlock()
+	isLeader.Store(false)
+	leaseRenewedAt.Store(0)
+	OnLeadershipAcquired(nil)
+}
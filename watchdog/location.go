@@ -0,0 +1,94 @@
+package watchdog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/logr"
+)
+
+const (
+	// maximumLocationResultAgeInIntervals is the number of intervals of an endpoint after which the latest result
+	// reported from a location is considered unknown rather than healthy, e.g. because the agent of that location is down
+	maximumLocationResultAgeInIntervals = 2
+)
+
+// HandleLocationResult persists the result of an endpoint reported by the agent of a location, so that it is taken
+// into account the next time the health of the endpoint is evaluated.
+//
+// Only the latest result of each location is stored with the endpoint, since the results of the endpoint itself are
+// determined by the results of all of its locations. Because the latest result of each location is retrieved from the
+// storage, the instance receiving the result doesn't have to be the one evaluating the health of the endpoint, e.g. if
+// high availability is enabled.
+func HandleLocationResult(ep *endpoint.Endpoint, location string, result *endpoint.Result) {
+	if err := store.Get().UpsertLatestLocationResult(ep, location, result); err != nil {
+		logr.Errorf("[watchdog.HandleLocationResult] Failed to store result from location=%s for endpoint with key=%s: %s", location, ep.Key(), err.Error())
+	}
+}
+
+// evaluateHealthFromLocations determines the health of an endpoint monitored from locations based on the latest result
+// reported from each of its locations. The endpoint is considered unhealthy if it is failing from, or hasn't recently
+// received a result from, at least as many locations as its location quorum.
+//
+// Returns nil if no location has ever reported a result.
+func evaluateHealthFromLocations(ep *endpoint.Endpoint) *endpoint.Result {
+	latestResults, err := store.Get().GetLatestLocationResults(ep.Key())
+	if err != nil {
+		logr.Errorf("[watchdog.evaluateHealthFromLocations] Failed to retrieve latest results from locations for endpoint with key=%s: %s", ep.Key(), err.Error())
+		return nil
+	}
+	if len(latestResults) == 0 {
+		return nil
+	}
+	var reportingLocations, failingLocations, unknownLocations []string
+	var representativeResult *endpoint.Result
+	var totalDuration time.Duration
+	result := &endpoint.Result{Timestamp: time.Now()}
+	for _, location := range ep.Locations {
+		latest, exists := latestResults[location]
+		if !exists || time.Since(latest.Timestamp) > maximumLocationResultAgeInIntervals*ep.Interval {
+			// A location that hasn't reported anything recently, e.g. because its agent is down, can't vouch for the
+			// health of the endpoint
+			unknownLocations = append(unknownLocations, location)
+			continue
+		}
+		reportingLocations = append(reportingLocations, location)
+		totalDuration += latest.Duration
+		result.Connected = result.Connected || latest.Connected
+		if representativeResult == nil {
+			representativeResult = latest
+		}
+		if !latest.Success {
+			if len(failingLocations) == 0 {
+				// The conditions of a failing location are more relevant than those of a healthy location
+				representativeResult = latest
+			}
+			failingLocations = append(failingLocations, location)
+		}
+	}
+	if representativeResult != nil {
+		result.Duration = totalDuration / time.Duration(len(reportingLocations))
+		result.HTTPStatus = representativeResult.HTTPStatus
+		result.Hostname = representativeResult.Hostname
+		result.ConditionResults = representativeResult.ConditionResults
+		result.CertificateExpiration = representativeResult.CertificateExpiration
+	}
+	result.Success = len(failingLocations)+len(unknownLocations) < ep.LocationQuorum
+	if !result.Success {
+		if len(failingLocations) > 0 {
+			result.AddError(fmt.Sprintf("failing from %d of %d locations: %s", len(failingLocations), len(ep.Locations), strings.Join(failingLocations, ", ")))
+		}
+		if len(unknownLocations) > 0 {
+			result.AddError(fmt.Sprintf("no recent result from %d of %d locations: %s", len(unknownLocations), len(ep.Locations), strings.Join(unknownLocations, ", ")))
+		}
+		for _, location := range failingLocations {
+			for _, locationError := range latestResults[location].Errors {
+				result.AddError(location + ": " + locationError)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package watchdog
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestHandleLocationResult(t *testing.T) {
+	defer store.Get().Clear()
+	ep := &endpoint.Endpoint{Name: "website", Group: "core", Interval: time.Minute, Locations: []string{"eu-west", "us-east"}, LocationQuorum: 2}
+	HandleLocationResult(ep, "eu-west", &endpoint.Result{Success: false, Errors: []string{"timeout"}, Timestamp: time.Now()})
+	latestResults, err := store.Get().GetLatestLocationResults(ep.Key())
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(latestResults) != 1 || latestResults["eu-west"] == nil || latestResults["eu-west"].Success {
+		t.Errorf("expected 1 unsuccessful result from eu-west, got %v", latestResults)
+	}
+	HandleLocationResult(ep, "eu-west", &endpoint.Result{Success: true, Timestamp: time.Now()})
+	if latestResults, _ = store.Get().GetLatestLocationResults(ep.Key()); len(latestResults) != 1 || !latestResults["eu-west"].Success {
+		t.Errorf("expected the latest result from eu-west to have replaced the previous one, got %v", latestResults)
+	}
+	statuses, _ := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 10))
+	for _, status := range statuses {
+		if status.Key != ep.Key() {
+			t.Errorf("expected no endpoint other than %s to be stored, got %s", ep.Key(), status.Key)
+		} else if len(status.Results) != 0 {
+			t.Error("expected no result to be stored for the endpoint itself")
+		}
+	}
+}
+
+func TestEvaluateHealthFromLocations(t *testing.T) {
+	defer store.Get().Clear()
+	ep := &endpoint.Endpoint{Name: "website", Group: "core", Interval: time.Minute, Locations: []string{"eu-west", "us-east", "ap-south"}, LocationQuorum: 2}
+	successfulResult := endpoint.Result{Success: true, Connected: true, HTTPStatus: 200, Duration: 100 * time.Millisecond}
+	failedResult := endpoint.Result{Success: false, Connected: true, HTTPStatus: 500, Duration: 300 * time.Millisecond, Errors: []string{"error"}}
+	scenarios := []struct {
+		name               string
+		results            map[string]endpoint.Result
+		staleLocations     []string
+		expectedNil        bool
+		expectedSuccess    bool
+		expectedDuration   time.Duration
+		expectedHTTPStatus int
+		expectedErrors     []string
+	}{
+		{
+			name:        "no-results",
+			results:     nil,
+			expectedNil: true,
+		},
+		{
+			name:           "only-stale-results",
+			results:        map[string]endpoint.Result{"eu-west": failedResult, "us-east": failedResult},
+			staleLocations: []string{"eu-west", "us-east"},
+			expectedErrors: []string{"no recent result from 3 of 3 locations: eu-west, us-east, ap-south"},
+		},
+		{
+			name:               "all-successful",
+			results:            map[string]endpoint.Result{"eu-west": successfulResult, "us-east": successfulResult, "ap-south": successfulResult},
+			expectedSuccess:    true,
+			expectedDuration:   100 * time.Millisecond,
+			expectedHTTPStatus: 200,
+		},
+		{
+			name:               "failing-from-fewer-locations-than-quorum",
+			results:            map[string]endpoint.Result{"eu-west": successfulResult, "us-east": failedResult, "ap-south": successfulResult},
+			expectedSuccess:    true,
+			expectedDuration:   166666666 * time.Nanosecond,
+			expectedHTTPStatus: 500,
+		},
+		{
+			name:               "failing-from-quorum",
+			results:            map[string]endpoint.Result{"eu-west": failedResult, "us-east": successfulResult, "ap-south": failedResult},
+			expectedSuccess:    false,
+			expectedDuration:   233333333 * time.Nanosecond,
+			expectedHTTPStatus: 500,
+			expectedErrors:     []string{"failing from 2 of 3 locations: eu-west, ap-south", "eu-west: error", "ap-south: error"},
+		},
+		{
+			name:               "failing-from-quorum-with-location-not-reporting",
+			results:            map[string]endpoint.Result{"eu-west": failedResult, "us-east": failedResult},
+			expectedSuccess:    false,
+			expectedDuration:   300 * time.Millisecond,
+			expectedHTTPStatus: 500,
+			expectedErrors:     []string{"failing from 2 of 3 locations: eu-west, us-east", "no recent result from 1 of 3 locations: ap-south", "eu-west: error", "us-east: error"},
+		},
+		{
+			name:               "failing-from-quorum-with-stale-result",
+			results:            map[string]endpoint.Result{"eu-west": failedResult, "us-east": failedResult, "ap-south": successfulResult},
+			staleLocations:     []string{"us-east"},
+			expectedSuccess:    false,
+			expectedDuration:   200 * time.Millisecond,
+			expectedHTTPStatus: 500,
+			expectedErrors:     []string{"failing from 1 of 3 locations: eu-west", "no recent result from 1 of 3 locations: us-east", "eu-west: error"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			store.Get().Clear()
+			for location, result := range scenario.results {
+				result.Timestamp = time.Now()
+				if slices.Contains(scenario.staleLocations, location) {
+					result.Timestamp = time.Now().Add(-3 * ep.Interval)
+				}
+				HandleLocationResult(ep, location, &result)
+			}
+			result := evaluateHealthFromLocations(ep)
+			if scenario.expectedNil {
+				if result != nil {
+					t.Errorf("expected no result, got %+v", result)
+				}
+				return
+			}
+			if result == nil {
+				t.Fatal("expected a result, got nil")
+			}
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v", scenario.expectedSuccess, result.Success)
+			}
+			if result.Duration != scenario.expectedDuration {
+				t.Errorf("expected duration to be %s, got %s", scenario.expectedDuration, result.Duration)
+			}
+			if result.HTTPStatus != scenario.expectedHTTPStatus {
+				t.Errorf("expected status to be %d, got %d", scenario.expectedHTTPStatus, result.HTTPStatus)
+			}
+			if expectedConnected := len(scenario.results) > len(scenario.staleLocations); result.Connected != expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", expectedConnected, result.Connected)
+			}
+			if len(result.Errors) != len(scenario.expectedErrors) {
+				t.Fatalf("expected errors %v, got %v", scenario.expectedErrors, result.Errors)
+			}
+			for i := range result.Errors {
+				if result.Errors[i] != scenario.expectedErrors[i] {
+					t.Errorf("expected error %q, got %q", scenario.expectedErrors[i], result.Errors[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package watchdog
+
+import "sync"
+
+var (
+	// endpointLocks are the locks guarding the alerting state of each endpoint, keyed by the key of the endpoint
+	endpointLocks      = make(map[string]*endpointLock)
+	endpointLocksMutex sync.Mutex
+)
+
+// endpointLock is the lock held while the alerting state of an endpoint is being read or modified
+type endpointLock struct {
+	sync.Mutex
+	references int // Number of goroutines holding or waiting for the lock. Guarded by endpointLocksMutex.
+}
+
+// LockEndpoint locks the alerting state of the endpoint with the given key, and returns the function unlocking it.
+//
+// The alerting state of an endpoint, which consists of its number of failures and successes in a row, the time at
+// which the last reminder was sent as well as whether each of its alerts is triggered, is modified by HandleAlerting,
+// but also by grouped alerts being sent once their grouping window has elapsed and by the leader election. Since
+// endpoints are converted to a new endpoint.Endpoint for each execution in some cases (e.g. external endpoints), the
+// lock is keyed by the key of the endpoint rather than by the endpoint itself.
+//
+// The lock is not reentrant: HandleAlerting must not be called while holding it.
+func LockEndpoint(key string) func() {
+	endpointLocksMutex.Lock()
+	lock, exists := endpointLocks[key]
+	if !exists {
+		lock = &endpointLock{}
+		endpointLocks[key] = lock
+	}
+	lock.references++
+	endpointLocksMutex.Unlock()
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		endpointLocksMutex.Lock()
+		if lock.references--; lock.references == 0 {
+			delete(endpointLocks, key)
+		}
+		endpointLocksMutex.Unlock()
+	}
+}
@@ -0,0 +1,23 @@
+package watchdog
+
+import (
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/logr"
+)
+
+// IsUnderRuntimeMaintenance returns whether a maintenance window managed through the API is ongoing for the endpoint
+// with the given key and group, which may be part of the suite with the given key
+func IsUnderRuntimeMaintenance(endpointKey, group, suiteKey string) bool {
+	windows, err := store.Get().GetAllMaintenanceWindows()
+	if err != nil {
+		logr.Errorf("[watchdog.IsUnderRuntimeMaintenance] Failed to retrieve maintenance windows: %s", err.Error())
+		return false
+	}
+	for _, window := range windows {
+		if window.AppliesTo(endpointKey, group, suiteKey) && window.IsActive() {
+			logr.Debugf("[watchdog.IsUnderRuntimeMaintenance] Endpoint with key=%s is under maintenance window with id=%d", endpointKey, window.ID)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestIsUnderRuntimeMaintenance(t *testing.T) {
+	defer store.Get().Clear()
+	now := time.Now()
+	activeWindow := &maintenance.Window{
+		Description:  "Database migration",
+		EndpointKeys: []string{"core_frontend"},
+		SuiteKeys:    []string{"flows_checkout"},
+		From:         now.Add(-time.Hour).Format(time.RFC3339),
+		Until:        now.Add(time.Hour).Format(time.RFC3339),
+	}
+	upcomingWindow := &maintenance.Window{
+		Description: "Network upgrade",
+		Groups:      []string{"internal"},
+		From:        now.Add(time.Hour).Format(time.RFC3339),
+		Until:       now.Add(2 * time.Hour).Format(time.RFC3339),
+	}
+	for _, window := range []*maintenance.Window{activeWindow, upcomingWindow} {
+		if err := window.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if err := store.Get().InsertMaintenanceWindow(window); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	scenarios := []struct {
+		name        string
+		endpointKey string
+		group       string
+		suiteKey    string
+		expected    bool
+	}{
+		{name: "endpoint-in-active-window", endpointKey: "core_frontend", group: "core", expected: true},
+		{name: "endpoint-not-in-any-window", endpointKey: "core_backend", group: "core", expected: false},
+		{name: "suite-endpoint-in-active-window", endpointKey: "flows_login", group: "flows", suiteKey: "flows_checkout", expected: true},
+		{name: "group-in-upcoming-window", endpointKey: "internal_backend", group: "internal", expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := IsUnderRuntimeMaintenance(scenario.endpointKey, scenario.group, scenario.suiteKey); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
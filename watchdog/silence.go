@@ -0,0 +1,46 @@
+package watchdog
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/logr"
+)
+
+// getAlertMuteReason returns why the alert of an endpoint must not be sent, or an empty string if it may be sent.
+// An alert is muted if it has been acknowledged or if it's matched by a silence that hasn't expired yet.
+func getAlertMuteReason(ep *endpoint.Endpoint, endpointAlert *alert.Alert) string {
+	if endpointAlert.Triggered {
+		acknowledgements, err := store.Get().GetAllAlertAcknowledgements()
+		if err != nil {
+			logr.Errorf("[watchdog.getAlertMuteReason] Failed to retrieve alert acknowledgements: %s", err.Error())
+		}
+		checksum := endpointAlert.Checksum()
+		for _, ack := range acknowledgements {
+			if ack.EndpointKey == ep.Key() && ack.AlertChecksum == checksum {
+				return fmt.Sprintf("it has been acknowledged by '%s'", ack.AcknowledgedBy)
+			}
+		}
+	}
+	silences, err := store.Get().GetAllSilences()
+	if err != nil {
+		logr.Errorf("[watchdog.getAlertMuteReason] Failed to retrieve silences: %s", err.Error())
+	}
+	for _, sil := range silences {
+		if sil.IsActive() && sil.Matcher.Matches(ep.Key(), ep.Group, endpointAlert.Type) {
+			return fmt.Sprintf("it is silenced by silence with id=%d until %s", sil.ID, sil.ExpiresAt.Format("2006-01-02 15:04:05"))
+		}
+	}
+	return ""
+}
+
+// deleteAlertAcknowledgement deletes the acknowledgement of an alert that has been resolved, if it was acknowledged
+func deleteAlertAcknowledgement(ep *endpoint.Endpoint, endpointAlert *alert.Alert) {
+	if err := store.Get().DeleteAlertAcknowledgement(ep.Key(), endpointAlert.Checksum()); err != nil && !errors.Is(err, common.ErrAcknowledgementNotFound) {
+		logr.Errorf("[watchdog.deleteAlertAcknowledgement] Failed to delete acknowledgement of alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+}
@@ -0,0 +1,95 @@
+package watchdog
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestHandleAlertingWithSilence(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	defer store.Get().Clear()
+
+	alertingConfig := &alerting.Config{
+		Custom: &custom.AlertProvider{
+			DefaultConfig: custom.Config{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name:  "api",
+		Group: "core",
+		URL:   "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1},
+		},
+	}
+	// A silence for another alert type should not prevent the alert from being sent
+	otherSilence, _ := silence.NewSilence(silence.Matcher{EndpointKey: "core_*", AlertType: alert.TypeSlack}, time.Hour, "Maintenance", "")
+	_ = store.Get().InsertSilence(otherSilence)
+	// A silence matching the alert should prevent it from being sent
+	matchingSilence, _ := silence.NewSilence(silence.Matcher{Group: "core"}, time.Hour, "Maintenance", "")
+	_ = store.Get().InsertSilence(matchingSilence)
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: time.Now()}, alertingConfig)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have been triggered, because it's silenced")
+	// Once the silence is deleted, the alert should be sent, since the endpoint is still failing
+	_ = store.Get().DeleteSilence(matchingSilence.ID)
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: time.Now()}, alertingConfig)
+	verify(t, ep, 2, 0, true, "The alert should've been triggered, because the silence was deleted")
+}
+
+func TestHandleAlertingWithAcknowledgement(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	defer store.Get().Clear()
+
+	alertingConfig := &alerting.Config{
+		Custom: &custom.AlertProvider{
+			DefaultConfig: custom.Config{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name:  "api",
+		Group: "core",
+		URL:   "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1, MinimumReminderInterval: time.Nanosecond},
+		},
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: time.Now()}, alertingConfig)
+	verify(t, ep, 1, 0, true, "The alert should've been triggered")
+	_ = store.Get().UpsertAlertAcknowledgement(&silence.Acknowledgement{
+		EndpointKey:    ep.Key(),
+		AlertChecksum:  ep.Alerts[0].Checksum(),
+		AcknowledgedBy: "john.doe",
+		AcknowledgedAt: time.Now(),
+	})
+	// Reminders should not be sent while the alert is acknowledged
+	lastReminderSent := ep.LastReminderSent
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: time.Now()}, alertingConfig)
+	verify(t, ep, 2, 0, true, "The alert should still be triggered")
+	if ep.LastReminderSent != lastReminderSent {
+		t.Error("expected no reminder to have been sent, because the alert is acknowledged")
+	}
+	// The acknowledgement should be deleted once the alert is resolved
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: time.Now()}, alertingConfig)
+	verify(t, ep, 0, 1, false, "The alert should've been resolved")
+	if acknowledgements, _ := store.Get().GetAllAlertAcknowledgements(); len(acknowledgements) != 0 {
+		t.Errorf("expected the acknowledgement to have been deleted, got %d acknowledgements", len(acknowledgements))
+	}
+}
@@ -0,0 +1,118 @@
+package watchdog
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/logr"
+)
+
+// GetSLOStatus returns the state of the service level objectives of an endpoint over their window, computed from the
+// uptime statistics of the endpoint
+func GetSLOStatus(ep *endpoint.Endpoint) (*slo.Status, error) {
+	now := time.Now()
+	statistics, err := store.Get().GetUptimeStatisticsByKey(ep.Key(), now.Add(-ep.SLO.WindowDuration()), now)
+	if err != nil {
+		return nil, err
+	}
+	return ep.SLO.NewStatus(statistics.TotalExecutions, statistics.SuccessfulExecutions, statistics.ExecutionsWithinLatencyThreshold), nil
+}
+
+// publishSLOMetricsForEndpoint publishes the metrics of the service level objectives of an endpoint
+func publishSLOMetricsForEndpoint(ep *endpoint.Endpoint, extraLabels []string) {
+	status, err := GetSLOStatus(ep)
+	if err != nil {
+		logr.Errorf("[watchdog.publishSLOMetricsForEndpoint] Failed to compute SLO status for endpoint with key=%s: %s", ep.Key(), err.Error())
+		return
+	}
+	metrics.PublishSLOMetricsForEndpoint(ep, status, extraLabels)
+}
+
+// handleBurnRateAlerts triggers the burn-rate alerts of an endpoint whose error budget is being consumed faster than
+// their threshold over both of their windows, and resolves those for which that is no longer the case.
+//
+// Unlike other alerts, burn-rate alerts don't depend on the number of failures or successes in a row.
+func handleBurnRateAlerts(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config) {
+	if ep.SLO == nil {
+		return
+	}
+	for _, endpointAlert := range ep.Alerts {
+		if endpointAlert.BurnRate == nil || !endpointAlert.IsEnabled() {
+			continue
+		}
+		exceeded, err := isBurnRateThresholdExceeded(ep, endpointAlert.BurnRate)
+		if err != nil {
+			logr.Errorf("[watchdog.handleBurnRateAlerts] Failed to compute burn rate for endpoint with key=%s: %s", ep.Key(), err.Error())
+			continue
+		}
+		if exceeded {
+			if len(result.SuppressedBy) > 0 {
+				logr.Infof("[watchdog.handleBurnRateAlerts] Not handling burn-rate alert for endpoint with key=%s, because the endpoint it depends on with key=%s is unhealthy", ep.Key(), result.SuppressedBy)
+				continue
+			}
+			handleBurnRateAlertToTrigger(ep, endpointAlert, result, alertingConfig)
+		} else if endpointAlert.Triggered {
+			handleBurnRateAlertToResolve(ep, endpointAlert, result, alertingConfig)
+		}
+	}
+}
+
+func handleBurnRateAlertToTrigger(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, alertingConfig *alerting.Config) {
+	sendInitialAlert := !endpointAlert.Triggered
+	sendReminder := endpointAlert.Triggered && endpointAlert.MinimumReminderInterval > 0 && time.Since(ep.LastReminderSent) >= endpointAlert.MinimumReminderInterval
+	if !sendInitialAlert && !sendReminder {
+		return
+	}
+	if reason := getAlertMuteReason(ep, endpointAlert); len(reason) > 0 {
+		logr.Infof("[watchdog.handleBurnRateAlertToTrigger] Not sending alert for endpoint with key=%s with description='%s', because %s", ep.Key(), endpointAlert.GetDescription(), reason)
+		return
+	}
+	logr.Infof("[watchdog.handleBurnRateAlertToTrigger] Sending %s alert because burn-rate alert for endpoint with key=%s with description='%s' has been TRIGGERED", endpointAlert.Type, ep.Key(), endpointAlert.GetDescription())
+	if err := sendAlert(alertingConfig, ep, endpointAlert, result, false); err != nil {
+		logr.Errorf("[watchdog.handleBurnRateAlertToTrigger] Failed to send an alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+		return
+	}
+	endpointAlert.Triggered = true
+	ep.LastReminderSent = time.Now()
+	if err := store.Get().UpsertTriggeredEndpointAlert(ep, endpointAlert); err != nil {
+		logr.Errorf("[watchdog.handleBurnRateAlertToTrigger] Failed to persist triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+}
+
+func handleBurnRateAlertToResolve(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, alertingConfig *alerting.Config) {
+	// Even if the alert provider returns an error, we still set the alert's Triggered variable to false.
+	// Further explanation can be found on Alert's Triggered field.
+	endpointAlert.Triggered = false
+	if err := store.Get().DeleteTriggeredEndpointAlert(ep, endpointAlert); err != nil {
+		logr.Errorf("[watchdog.handleBurnRateAlertToResolve] Failed to delete persisted triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+	deleteAlertAcknowledgement(ep, endpointAlert)
+	if !endpointAlert.IsSendingOnResolved() {
+		return
+	}
+	logr.Infof("[watchdog.handleBurnRateAlertToResolve] Sending %s alert because burn-rate alert for endpoint with key=%s with description='%s' has been RESOLVED", endpointAlert.Type, ep.Key(), endpointAlert.GetDescription())
+	if err := sendAlert(alertingConfig, ep, endpointAlert, result, true); err != nil {
+		logr.Errorf("[watchdog.handleBurnRateAlertToResolve] Failed to send an alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+}
+
+// isBurnRateThresholdExceeded returns whether the error budget of the endpoint's SLO is being consumed at a rate at
+// least as high as the threshold over both the long window and the short window of the burn-rate alert
+func isBurnRateThresholdExceeded(ep *endpoint.Endpoint, burnRate *alert.BurnRateConfig) (bool, error) {
+	now := time.Now()
+	for _, window := range []time.Duration{burnRate.ShortWindow, burnRate.LongWindow} {
+		statistics, err := store.Get().GetUptimeStatisticsByKey(ep.Key(), now.Add(-window), now)
+		if err != nil {
+			return false, err
+		}
+		if ep.SLO.BurnRate(statistics.TotalExecutions, statistics.SuccessfulExecutions) < burnRate.Threshold {
+			return false, nil
+		}
+	}
+	return true, nil
+}
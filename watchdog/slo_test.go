@@ -0,0 +1,99 @@
+package watchdog
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestGetSLOStatus(t *testing.T) {
+	defer store.Get().Clear()
+	ep := &endpoint.Endpoint{
+		Name:  "api",
+		Group: "core",
+		URL:   "https://example.com",
+		SLO:   &slo.Config{Target: 90, Latency: &slo.LatencyConfig{Threshold: 100 * time.Millisecond, Target: 50}},
+	}
+	if err := ep.SLO.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if _, err := GetSLOStatus(ep); err == nil {
+		t.Error("expected an error, because the endpoint hasn't been monitored yet")
+	}
+	for i := 0; i < 10; i++ {
+		UpdateEndpointStatus(ep, &endpoint.Result{Success: i != 0, Duration: time.Duration(i*20) * time.Millisecond, Timestamp: time.Now()})
+	}
+	status, err := GetSLOStatus(ep)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if status.TotalExecutions != 10 || status.SuccessfulExecutions != 9 {
+		t.Errorf("expected 10 executions of which 9 successful, got %d executions of which %d successful", status.TotalExecutions, status.SuccessfulExecutions)
+	}
+	if status.ErrorBudgetRemaining > 1e-9 || status.ErrorBudgetRemaining < -1e-9 {
+		t.Errorf("expected the error budget to be exhausted, got %f", status.ErrorBudgetRemaining)
+	}
+	// Only the successful executions with a duration of 20ms to 100ms are within the latency threshold
+	if status.Latency == nil || status.Latency.ExecutionsWithinThreshold != 5 {
+		t.Errorf("expected 5 executions within the latency threshold, got %v", status.Latency)
+	}
+}
+
+func TestHandleAlertingWithBurnRate(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	defer store.Get().Clear()
+
+	alertingConfig := &alerting.Config{
+		Custom: &custom.AlertProvider{
+			DefaultConfig: custom.Config{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		Name:  "api",
+		Group: "core",
+		URL:   "https://example.com",
+		SLO:   &slo.Config{Target: 99},
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, Enabled: &enabled, SendOnResolved: &enabled, BurnRate: &alert.BurnRateConfig{Threshold: 14.4}},
+		},
+	}
+	if err := ep.SLO.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err := ep.Alerts[0].ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	evaluate := func(success bool) {
+		result := &endpoint.Result{Success: success, Timestamp: time.Now()}
+		UpdateEndpointStatus(ep, result)
+		HandleAlerting(ep, result, alertingConfig)
+	}
+	for i := 0; i < 9; i++ {
+		evaluate(true)
+	}
+	// With a target of 99%, an error rate of 10% is a burn rate of 10, which is below the threshold
+	evaluate(false)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have been triggered, because the burn rate is below the threshold")
+	// An error rate of 2/11 is a burn rate of ~18.2, which exceeds the threshold
+	evaluate(false)
+	verify(t, ep, 2, 0, true, "The alert should've been triggered, because the burn rate exceeds the threshold")
+	// The success threshold doesn't apply to burn-rate alerts, so the alert remains triggered until the burn rate drops
+	evaluate(true)
+	evaluate(true)
+	verify(t, ep, 0, 2, true, "The alert should still be triggered, because the burn rate of 2/13 still exceeds the threshold")
+	// An error rate of 2/14 is a burn rate of ~14.3, which is below the threshold
+	evaluate(true)
+	verify(t, ep, 0, 3, false, "The alert should've been resolved, because the burn rate dropped below the threshold")
+}
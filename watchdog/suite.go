@@ -11,9 +11,10 @@ import (
 	"github.com/TwiN/logr"
 )
 
-// monitorSuite monitors a suite by executing it at regular intervals
-func monitorSuite(s *suite.Suite, cfg *config.Config, extraLabels []string, ctx context.Context) {
+// monitorSuite monitors a suite by executing it at regular intervals until the context is canceled
+func monitorSuite(s *suite.Suite, ctx context.Context) {
 	// Execute immediately on start
+	cfg, extraLabels := getCurrentConfig()
 	executeSuite(s, cfg, extraLabels)
 	// Set up ticker for periodic execution
 	ticker := time.NewTicker(s.Interval)
@@ -24,6 +25,7 @@ func monitorSuite(s *suite.Suite, cfg *config.Config, extraLabels []string, ctx
 			logr.Warnf("[watchdog.monitorSuite] Canceling monitoring for suite=%s", s.Name)
 			return
 		case <-ticker.C:
+			cfg, extraLabels := getCurrentConfig()
 			executeSuite(s, cfg, extraLabels)
 		}
 	}
@@ -31,6 +33,10 @@ func monitorSuite(s *suite.Suite, cfg *config.Config, extraLabels []string, ctx
 
 // executeSuite executes a suite with proper concurrency control
 func executeSuite(s *suite.Suite, cfg *config.Config, extraLabels []string) {
+	if !IsLeader() {
+		logr.Debugf("[watchdog.executeSuite] Not the leader; skipping suite=%s", s.Name)
+		return
+	}
 	// Acquire semaphore to limit concurrent suite monitoring
 	if err := monitoringSemaphore.Acquire(ctx, 1); err != nil {
 		// Only fails if context is cancelled (during shutdown)
@@ -67,7 +73,7 @@ func executeSuite(s *suite.Suite, cfg *config.Config, extraLabels []string) {
 						break
 					}
 				}
-				if !inEndpointMaintenanceWindow {
+				if !inEndpointMaintenanceWindow && !IsUnderRuntimeMaintenance(ep.Key(), ep.Group, s.Key()) {
 					HandleAlerting(ep, epResult, cfg.Alerting)
 				}
 			}
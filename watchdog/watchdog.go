@@ -2,9 +2,13 @@ package watchdog
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/suite"
+	"github.com/TwiN/logr"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -21,6 +25,18 @@ var (
 
 	ctx        context.Context
 	cancelFunc context.CancelFunc
+
+	// monitors contains the function to stop monitoring each endpoint, external endpoint and suite currently being
+	// monitored, keyed by the *endpoint.Endpoint, *endpoint.ExternalEndpoint or *suite.Suite in question
+	monitors = make(map[any]context.CancelFunc)
+
+	// currentConfig is the configuration used by the monitors, which may be replaced by Reload
+	currentConfig *config.Config
+
+	// currentExtraLabels are the unique extra metric labels of currentConfig
+	currentExtraLabels []string
+
+	monitorsMutex sync.RWMutex
 )
 
 // Monitor loops over each endpoint and starts a goroutine to monitor each endpoint separately
@@ -34,30 +50,133 @@ func Monitor(cfg *config.Config) {
 		// Limited concurrency based on configuration
 		monitoringSemaphore = semaphore.NewWeighted(int64(cfg.Concurrency))
 	}
-	extraLabels := cfg.GetUniqueExtraMetricLabels()
-	for _, endpoint := range cfg.Endpoints {
-		if endpoint.IsEnabled() {
+	monitorsMutex.Lock()
+	monitors = make(map[any]context.CancelFunc)
+	currentConfig, currentExtraLabels = cfg, cfg.GetUniqueExtraMetricLabels()
+	assignedEndpoints = nil
+	monitorsMutex.Unlock()
+	if cfg.Agent != nil {
+		go synchronizeAssignedEndpoints(cfg.Agent, ctx)
+	}
+	if cfg.HighAvailability != nil {
+		// The leadership is determined before starting the monitors so that the leader doesn't skip their first execution
+		renewLeadership(cfg.HighAvailability, ctx)
+		go maintainLeadership(cfg.HighAvailability, ctx)
+	}
+	startMonitors(cfg)
+}
+
+// Reload replaces the configuration used by the watchdog without interrupting the monitoring of the endpoints,
+// external endpoints and suites that are part of both the current and the updated configuration.
+//
+// Endpoints, external endpoints and suites are only considered to be part of both configurations if they are the same
+// instance (see config.Config.ReuseUnchangedMonitors). Those that are only part of the current configuration stop being
+// monitored, while those that are only part of the updated configuration start being monitored.
+//
+// The updated configuration must have the same concurrency as the current configuration.
+func Reload(updatedCfg *config.Config) {
+	isPartOfUpdatedConfig := make(map[any]bool)
+	for _, ep := range updatedCfg.Endpoints {
+		isPartOfUpdatedConfig[ep] = true
+	}
+	for _, ee := range updatedCfg.ExternalEndpoints {
+		isPartOfUpdatedConfig[ee] = true
+	}
+	for _, s := range updatedCfg.Suites {
+		isPartOfUpdatedConfig[s] = true
+	}
+	// The endpoints assigned by the central instance aren't part of the configuration, but must keep being monitored
+	for _, ep := range getAssignedEndpoints() {
+		isPartOfUpdatedConfig[ep] = true
+	}
+	monitorsMutex.Lock()
+	numberOfMonitorsStopped := 0
+	for monitored, stopMonitoring := range monitors {
+		if isPartOfUpdatedConfig[monitored] {
+			continue
+		}
+		stopMonitoring()
+		closeMonitored(monitored)
+		delete(monitors, monitored)
+		numberOfMonitorsStopped++
+	}
+	numberOfMonitorsKept := len(monitors)
+	currentConfig, currentExtraLabels = updatedCfg, updatedCfg.GetUniqueExtraMetricLabels()
+	monitorsMutex.Unlock()
+	numberOfMonitorsStarted := startMonitors(updatedCfg)
+	logr.Infof("[watchdog.Reload] Stopped %d monitor(s), started %d monitor(s) and kept %d monitor(s) running", numberOfMonitorsStopped, numberOfMonitorsStarted, numberOfMonitorsKept)
+}
+
+// startMonitors starts a goroutine for each endpoint, external endpoint and suite of the configuration that needs to
+// be monitored and isn't monitored already.
+//
+// Returns the number of monitors started.
+func startMonitors(cfg *config.Config) int {
+	numberOfMonitorsStarted := 0
+	for _, ep := range cfg.Endpoints {
+		if ep.IsEnabled() && !isMonitored(ep) {
 			// To prevent multiple requests from running at the same time, we'll wait for a little before each iteration
 			time.Sleep(222 * time.Millisecond)
-			go monitorEndpoint(endpoint, cfg, extraLabels, ctx)
+			go monitorEndpoint(ep, newMonitorContext(ep))
+			numberOfMonitorsStarted++
 		}
 	}
 	for _, externalEndpoint := range cfg.ExternalEndpoints {
 		// Check if the external endpoint is enabled and is using heartbeat
 		// If the external endpoint does not use heartbeat, then it does not need to be monitored periodically, because
 		// alerting is checked every time an external endpoint is pushed to Gatus, unlike normal endpoints.
-		if externalEndpoint.IsEnabled() && externalEndpoint.Heartbeat.Interval > 0 {
-			go monitorExternalEndpointHeartbeat(externalEndpoint, cfg, extraLabels, ctx)
+		if externalEndpoint.IsEnabled() && externalEndpoint.Heartbeat.Interval > 0 && !isMonitored(externalEndpoint) {
+			go monitorExternalEndpointHeartbeat(externalEndpoint, newMonitorContext(externalEndpoint))
+			numberOfMonitorsStarted++
 		}
 	}
-	for _, suite := range cfg.Suites {
-		if suite.IsEnabled() {
+	for _, s := range cfg.Suites {
+		if s.IsEnabled() && !isMonitored(s) {
 			time.Sleep(222 * time.Millisecond)
-			go monitorSuite(suite, cfg, extraLabels, ctx)
+			go monitorSuite(s, newMonitorContext(s))
+			numberOfMonitorsStarted++
+		}
+	}
+	return numberOfMonitorsStarted
+}
+
+// isMonitored returns whether the given endpoint, external endpoint or suite is already being monitored
+func isMonitored(monitored any) bool {
+	monitorsMutex.RLock()
+	defer monitorsMutex.RUnlock()
+	_, exists := monitors[monitored]
+	return exists
+}
+
+// newMonitorContext creates the context for monitoring the given endpoint, external endpoint or suite, which is
+// canceled either when the watchdog shuts down or when the monitored object is no longer part of the configuration
+func newMonitorContext(monitored any) context.Context {
+	monitorCtx, monitorCancelFunc := context.WithCancel(ctx)
+	monitorsMutex.Lock()
+	monitors[monitored] = monitorCancelFunc
+	monitorsMutex.Unlock()
+	return monitorCtx
+}
+
+// closeMonitored stops the in-flight connections of an endpoint, or of the endpoints of a suite, that is no longer monitored
+func closeMonitored(monitored any) {
+	switch m := monitored.(type) {
+	case *endpoint.Endpoint:
+		m.Close()
+	case *suite.Suite:
+		for _, ep := range m.Endpoints {
+			ep.Close()
 		}
 	}
 }
 
+// getCurrentConfig returns the configuration used by the monitors as well as its unique extra metric labels
+func getCurrentConfig() (*config.Config, []string) {
+	monitorsMutex.RLock()
+	defer monitorsMutex.RUnlock()
+	return currentConfig, currentExtraLabels
+}
+
 // Shutdown stops monitoring all endpoints
 func Shutdown(cfg *config.Config) {
 	// Stop in-flight HTTP connections
@@ -69,5 +188,15 @@ func Shutdown(cfg *config.Config) {
 			ep.Close()
 		}
 	}
+	for _, ep := range getAssignedEndpoints() {
+		ep.Close()
+	}
 	cancelFunc()
+	if cfg.HighAvailability != nil {
+		releaseLeadership(cfg.HighAvailability)
+	}
+	monitorsMutex.Lock()
+	monitors = make(map[any]context.CancelFunc)
+	assignedEndpoints = nil
+	monitorsMutex.Unlock()
 }
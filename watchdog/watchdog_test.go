@@ -0,0 +1,36 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/heartbeat"
+)
+
+func TestReload(t *testing.T) {
+	unchanged := &endpoint.ExternalEndpoint{Name: "unchanged", Heartbeat: heartbeat.Config{Interval: time.Hour}}
+	removed := &endpoint.ExternalEndpoint{Name: "removed", Heartbeat: heartbeat.Config{Interval: time.Hour}}
+	added := &endpoint.ExternalEndpoint{Name: "added", Heartbeat: heartbeat.Config{Interval: time.Hour}}
+	cfg := &config.Config{ExternalEndpoints: []*endpoint.ExternalEndpoint{unchanged, removed}}
+	Monitor(cfg)
+	defer Shutdown(cfg)
+	if !isMonitored(unchanged) || !isMonitored(removed) {
+		t.Fatal("expected all external endpoints to be monitored")
+	}
+	updatedCfg := &config.Config{ExternalEndpoints: []*endpoint.ExternalEndpoint{unchanged, added}}
+	Reload(updatedCfg)
+	if !isMonitored(unchanged) || !isMonitored(added) {
+		t.Error("expected external endpoints of the updated configuration to be monitored")
+	}
+	if isMonitored(removed) {
+		t.Error("expected external endpoint that was removed to no longer be monitored")
+	}
+	if cfg, _ := getCurrentConfig(); cfg != updatedCfg {
+		t.Error("expected the updated configuration to be used by the monitors")
+	}
+	if len(monitors) != 2 {
+		t.Errorf("expected 2 monitors, got %d", len(monitors))
+	}
+}